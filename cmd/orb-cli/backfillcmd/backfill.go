@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfillcmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	urlFlagName  = "url"
+	urlFlagUsage = "The URL of the backfill REST endpoint." +
+		" Alternatively, this can be set with the following environment variable: " + urlEnvKey
+	urlEnvKey = "ORB_CLI_URL"
+
+	idFlagName  = "id"
+	idFlagUsage = "The ID of a backfill job. This flag may be repeated." +
+		" Alternatively, this can be set with the following environment variable: " + idEnvKey
+	idEnvKey = "ORB_CLI_BACKFILL_ID"
+
+	serviceIRIFlagName  = "service-iri"
+	serviceIRIFlagUsage = "The IRI of the peer service from which activities are backfilled." +
+		" Alternatively, this can be set with the following environment variable: " + serviceIRIEnvKey
+	serviceIRIEnvKey = "ORB_CLI_BACKFILL_SERVICE_IRI"
+
+	sinceFlagName  = "since"
+	sinceFlagUsage = "Only activities published at or after the given time (RFC 3339) are backfilled." +
+		" Alternatively, this can be set with the following environment variable: " + sinceEnvKey
+	sinceEnvKey = "ORB_CLI_BACKFILL_SINCE"
+
+	untilFlagName  = "until"
+	untilFlagUsage = "Only activities published before the given time (RFC 3339) are backfilled." +
+		" Alternatively, this can be set with the following environment variable: " + untilEnvKey
+	untilEnvKey = "ORB_CLI_BACKFILL_UNTIL"
+
+	fromActivityFlagName  = "from-activity"
+	fromActivityFlagUsage = "The ID of the first activity (inclusive) to backfill." +
+		" Alternatively, this can be set with the following environment variable: " + fromActivityEnvKey
+	fromActivityEnvKey = "ORB_CLI_BACKFILL_FROM_ACTIVITY"
+
+	toActivityFlagName  = "to-activity"
+	toActivityFlagUsage = "The ID of the last activity (inclusive) to backfill." +
+		" Alternatively, this can be set with the following environment variable: " + toActivityEnvKey
+	toActivityEnvKey = "ORB_CLI_BACKFILL_TO_ACTIVITY"
+)
+
+// GetCmd returns the Cobra backfill command.
+func GetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Manages historical backfill jobs.",
+		Long: "Starts and monitors jobs that backfill anchor events from the outbox of a peer Orb server " +
+			"for a given time or activity range.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("expecting subcommand start or get")
+		},
+	}
+
+	cmd.AddCommand(
+		newStartCmd(),
+		newGetCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfillcmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+func newGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Retrieves backfill jobs.",
+		Long: "Retrieves the status of the backfill job with the given ID or, if no ID is specified, " +
+			"all backfill jobs.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeGet(cmd)
+		},
+	}
+
+	common.AddCommonFlags(cmd)
+
+	cmd.Flags().StringP(urlFlagName, "", "", urlFlagUsage)
+	cmd.Flags().StringArrayP(idFlagName, "", nil, idFlagUsage)
+
+	return cmd
+}
+
+func executeGet(cmd *cobra.Command) error {
+	u, err := getURL(cmd)
+	if err != nil {
+		return err
+	}
+
+	ids, err := cmdutils.GetUserSetVarFromArrayString(cmd, idFlagName, idEnvKey, true)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		resp, e := common.SendHTTPRequest(cmd, nil, http.MethodGet, u)
+		if e != nil {
+			return e
+		}
+
+		fmt.Println(string(resp))
+
+		return nil
+	}
+
+	for _, id := range ids {
+		resp, e := common.SendHTTPRequest(cmd, nil, http.MethodGet,
+			fmt.Sprintf("%s?id=%s", u, url.QueryEscape(id)))
+		if e != nil {
+			return e
+		}
+
+		fmt.Println(string(resp))
+	}
+
+	return nil
+}
+
+func getURL(cmd *cobra.Command) (string, error) {
+	u, err := cmdutils.GetUserSetVarFromString(cmd, urlFlagName, urlEnvKey, false)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = url.Parse(u)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %s: %w", u, err)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfillcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+func newStartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Starts a backfill job.",
+		Long: "Starts a resumable job that reads activities from the outbox of the given peer service and " +
+			"processes any anchor events that are missing on this server.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeStart(cmd)
+		},
+	}
+
+	common.AddCommonFlags(cmd)
+
+	cmd.Flags().StringP(urlFlagName, "", "", urlFlagUsage)
+	cmd.Flags().StringP(serviceIRIFlagName, "", "", serviceIRIFlagUsage)
+	cmd.Flags().StringP(sinceFlagName, "", "", sinceFlagUsage)
+	cmd.Flags().StringP(untilFlagName, "", "", untilFlagUsage)
+	cmd.Flags().StringP(fromActivityFlagName, "", "", fromActivityFlagUsage)
+	cmd.Flags().StringP(toActivityFlagName, "", "", toActivityFlagUsage)
+
+	return cmd
+}
+
+func executeStart(cmd *cobra.Command) error {
+	u, err := getURL(cmd)
+	if err != nil {
+		return err
+	}
+
+	req, err := getStartRequest(cmd)
+	if err != nil {
+		return err
+	}
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := common.SendHTTPRequest(cmd, reqBytes, http.MethodPost, u)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(resp))
+
+	return nil
+}
+
+func getStartRequest(cmd *cobra.Command) (*startRequest, error) {
+	serviceIRI, err := cmdutils.GetUserSetVarFromString(cmd, serviceIRIFlagName, serviceIRIEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = url.Parse(serviceIRI); err != nil {
+		return nil, fmt.Errorf("invalid service IRI %s: %w", serviceIRI, err)
+	}
+
+	since, err := getTime(cmd, sinceFlagName, sinceEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := getTime(cmd, untilFlagName, untilEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fromActivity, err := cmdutils.GetUserSetVarFromString(cmd, fromActivityFlagName, fromActivityEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	toActivity, err := cmdutils.GetUserSetVarFromString(cmd, toActivityFlagName, toActivityEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &startRequest{
+		ServiceIRI:   serviceIRI,
+		Since:        since,
+		Until:        until,
+		FromActivity: fromActivity,
+		ToActivity:   toActivity,
+	}, nil
+}
+
+func getTime(cmd *cobra.Command, flagName, envKey string) (*time.Time, error) {
+	value, err := cmdutils.GetUserSetVarFromString(cmd, flagName, envKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s [%s]: %w", flagName, value, err)
+	}
+
+	return &t, nil
+}
+
+type startRequest struct {
+	ServiceIRI   string     `json:"serviceIRI"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+	FromActivity string     `json:"fromActivity,omitempty"`
+	ToActivity   string     `json:"toActivity,omitempty"`
+}
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfillcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+const (
+	flag = "--"
+
+	serviceIRI = "https://orb.domain2.com/services/orb"
+)
+
+func TestStartCmd(t *testing.T) {
+	t.Run("test missing url arg", func(t *testing.T) {
+		cmd := GetCmd()
+		cmd.SetArgs([]string{"start"})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither url (command line flag) nor ORB_CLI_URL (environment variable) have been set.",
+			err.Error())
+	})
+
+	t.Run("test missing service IRI arg", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"start"}
+		args = append(args, urlArg("https://localhost:8080")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither service-iri (command line flag) nor ORB_CLI_BACKFILL_SERVICE_IRI (environment variable) "+
+				"have been set.",
+			err.Error())
+	})
+
+	t.Run("test invalid since arg", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"start"}
+		args = append(args, urlArg("https://localhost:8080")...)
+		args = append(args, serviceIRIArg(serviceIRI)...)
+		args = append(args, sinceArg("yesterday")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid value for since")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+
+			reqBytes, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			req := &startRequest{}
+			require.NoError(t, json.Unmarshal(reqBytes, req))
+			require.Equal(t, serviceIRI, req.ServiceIRI)
+			require.NotNil(t, req.Since)
+			require.NotNil(t, req.Until)
+			require.Equal(t, "https://orb.domain2.com/services/orb/activities/1", req.FromActivity)
+			require.Empty(t, req.ToActivity)
+
+			_, err = fmt.Fprint(w, `{"id":"1234","status":"pending"}`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"start"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, serviceIRIArg(serviceIRI)...)
+		args = append(args, sinceArg("2021-11-01T00:00:00Z")...)
+		args = append(args, untilArg("2021-12-01T00:00:00Z")...)
+		args = append(args, fromActivityArg("https://orb.domain2.com/services/orb/activities/1")...)
+		args = append(args, authTokenArg("ADMIN_TOKEN")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"start"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, serviceIRIArg(serviceIRI)...)
+		cmd.SetArgs(args)
+
+		require.Error(t, cmd.Execute())
+	})
+}
+
+func urlArg(value string) []string {
+	return []string{flag + urlFlagName, value}
+}
+
+func idArg(value string) []string {
+	return []string{flag + idFlagName, value}
+}
+
+func serviceIRIArg(value string) []string {
+	return []string{flag + serviceIRIFlagName, value}
+}
+
+func sinceArg(value string) []string {
+	return []string{flag + sinceFlagName, value}
+}
+
+func untilArg(value string) []string {
+	return []string{flag + untilFlagName, value}
+}
+
+func fromActivityArg(value string) []string {
+	return []string{flag + fromActivityFlagName, value}
+}
+
+func authTokenArg(value string) []string {
+	return []string{flag + common.AuthTokenFlagName, value}
+}
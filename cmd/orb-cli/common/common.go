@@ -19,6 +19,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -31,6 +32,9 @@ import (
 	"github.com/trustbloc/edge-core/pkg/log"
 	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
 	tlsutils "github.com/trustbloc/edge-core/pkg/utils/tls"
+
+	"github.com/trustbloc/orb/pkg/webfinger/client"
+	"github.com/trustbloc/orb/pkg/webfinger/model"
 )
 
 var logger = log.New("orb-cli")
@@ -315,6 +319,16 @@ func SendRequest(httpClient *http.Client, req []byte, headers map[string]string,
 	return responseBytes, nil
 }
 
+// ResolveActorIRI returns the IRI of the given actor. The actor may either be an IRI or an 'acct' URI
+// (e.g. acct:orb@orb.domain1.com), in which case the IRI is resolved using WebFinger.
+func ResolveActorIRI(httpClient *http.Client, actor string) (*url.URL, error) {
+	if model.IsAcct(actor) {
+		return client.New(client.WithHTTPClient(httpClient)).ResolveActor(actor)
+	}
+
+	return url.Parse(actor)
+}
+
 // SendHTTPRequest sends the given HTTP request using the options provided on the command-line.
 func SendHTTPRequest(cmd *cobra.Command, reqBytes []byte, method, endpointURL string) ([]byte, error) {
 	client, err := newHTTPClient(cmd)
@@ -15,10 +15,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/webfinger/model"
 )
 
 const (
@@ -328,6 +331,47 @@ func TestSendHTTPRequest(t *testing.T) {
 	})
 }
 
+func TestResolveActorIRI(t *testing.T) {
+	serv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("resource") != "acct:orb@"+r.Host {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{"subject":"acct:orb@%s","links":[{"rel":"self",`+
+			`"type":"application/activity+json","href":"https://%s/services/orb"}]}`, r.Host, r.Host)))
+		require.NoError(t, err)
+	}))
+	defer serv.Close()
+
+	host := strings.TrimPrefix(serv.URL, "https://")
+
+	t.Run("IRI", func(t *testing.T) {
+		actorIRI, err := ResolveActorIRI(serv.Client(), "https://orb.domain1.com/services/orb")
+		require.NoError(t, err)
+		require.Equal(t, "https://orb.domain1.com/services/orb", actorIRI.String())
+	})
+
+	t.Run("acct URI", func(t *testing.T) {
+		actorIRI, err := ResolveActorIRI(serv.Client(), "acct:orb@"+host)
+		require.NoError(t, err)
+		require.Equal(t, serv.URL+"/services/orb", actorIRI.String())
+	})
+
+	t.Run("acct URI not found", func(t *testing.T) {
+		_, err := ResolveActorIRI(serv.Client(), "acct:alice@"+host)
+		require.Error(t, err)
+		require.ErrorIs(t, err, model.ErrResourceNotFound)
+	})
+
+	t.Run("invalid IRI", func(t *testing.T) {
+		_, err := ResolveActorIRI(serv.Client(), ":invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing protocol scheme")
+	})
+}
+
 func TestGetVDRPublicKeys(t *testing.T) {
 	t.Run("test public key invalid path", func(t *testing.T) {
 		_, err := GetVDRPublicKeysFromFile("./wrongfile")
@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadlettercmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	urlFlagName  = "url"
+	urlFlagUsage = "The URL of the dead-letter REST endpoint." +
+		" Alternatively, this can be set with the following environment variable: " + urlEnvKey
+	urlEnvKey = "ORB_CLI_URL"
+
+	idFlagName  = "id"
+	idFlagUsage = "The ID of an undeliverable activity. This flag may be repeated." +
+		" Alternatively, this can be set with the following environment variable: " + idEnvKey
+	idEnvKey = "ORB_CLI_DEAD_LETTER_ID"
+
+	allFlagName  = "all"
+	allFlagUsage = "Applies to all undeliverable activities. Possible values [true] [false]. Defaults to false." +
+		" Alternatively, this can be set with the following environment variable: " + allEnvKey
+	allEnvKey = "ORB_CLI_DEAD_LETTER_ALL"
+)
+
+// GetCmd returns the Cobra deadletter command.
+func GetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "deadletter",
+		Short:        "Manages undeliverable activities.",
+		Long:         "Lists, inspects, replays or purges activities that could not be delivered to their target inbox.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("expecting subcommand get, replay, or purge")
+		},
+	}
+
+	cmd.AddCommand(
+		newGetCmd(),
+		newReplayCmd(),
+		newPurgeCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadlettercmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterCmd(t *testing.T) {
+	t.Run("test missing subcommand", func(t *testing.T) {
+		err := GetCmd().Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expecting subcommand get, replay, or purge")
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadlettercmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCmd(t *testing.T) {
+	t.Run("test missing url arg", func(t *testing.T) {
+		cmd := GetCmd()
+		cmd.SetArgs([]string{"get"})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither url (command line flag) nor ORB_CLI_URL (environment variable) have been set.",
+			err.Error())
+	})
+
+	t.Run("test invalid url arg", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"get"}
+		args = append(args, urlArg(":invalid")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid URL")
+	})
+
+	t.Run("get all -> success", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Empty(t, r.URL.Query().Get("id"))
+
+			_, err := fmt.Fprint(w, `[{"id":"1234"}]`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"get"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, authTokenArg("ADMIN_TOKEN")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("get by ID -> success", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "1234", r.URL.Query().Get("id"))
+
+			_, err := fmt.Fprint(w, `{"id":"1234"}`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"get"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, idArg("1234")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("get by ID -> not found", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"get"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, idArg("1234")...)
+		cmd.SetArgs(args)
+
+		require.Error(t, cmd.Execute())
+	})
+}
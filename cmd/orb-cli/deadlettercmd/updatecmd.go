@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadlettercmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+func newReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "replay",
+		Short:        "Replays undeliverable activities.",
+		Long:         "Attempts to deliver undeliverable activities to their target inbox.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeUpdate(cmd, true)
+		},
+	}
+
+	addUpdateFlags(cmd)
+
+	return cmd
+}
+
+func newPurgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "purge",
+		Short:        "Purges undeliverable activities.",
+		Long:         "Removes undeliverable activities from the dead-letter store.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeUpdate(cmd, false)
+		},
+	}
+
+	addUpdateFlags(cmd)
+
+	return cmd
+}
+
+func executeUpdate(cmd *cobra.Command, isReplay bool) error {
+	u, ids, err := getUpdateArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No undeliverable activities found.")
+
+		return nil
+	}
+
+	req := deadLetterRequest{}
+
+	if isReplay {
+		req.Replay = ids
+	} else {
+		req.Purge = ids
+	}
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = common.SendHTTPRequest(cmd, reqBytes, http.MethodPost, u)
+	if err != nil {
+		return err
+	}
+
+	if isReplay {
+		fmt.Printf("Successfully replayed %d undeliverable activities.\n", len(ids))
+	} else {
+		fmt.Printf("Successfully purged %d undeliverable activities.\n", len(ids))
+	}
+
+	return nil
+}
+
+func addUpdateFlags(cmd *cobra.Command) {
+	common.AddCommonFlags(cmd)
+
+	cmd.Flags().StringP(urlFlagName, "", "", urlFlagUsage)
+	cmd.Flags().StringArrayP(idFlagName, "", nil, idFlagUsage)
+	cmd.Flags().StringP(allFlagName, "", "", allFlagUsage)
+}
+
+func getUpdateArgs(cmd *cobra.Command) (string, []string, error) {
+	u, err := getURL(cmd)
+	if err != nil {
+		return "", nil, err
+	}
+
+	all, err := getAll(cmd)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ids, err := cmdutils.GetUserSetVarFromArrayString(cmd, idFlagName, idEnvKey, true)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if all {
+		if len(ids) > 0 {
+			return "", nil, fmt.Errorf("only one of --%s or --%s may be specified", idFlagName, allFlagName)
+		}
+
+		ids, err = getAllIDs(cmd, u)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return u, ids, nil
+	}
+
+	if len(ids) == 0 {
+		return "", nil, fmt.Errorf("either --%s or --%s must be specified", idFlagName, allFlagName)
+	}
+
+	return u, ids, nil
+}
+
+func getAll(cmd *cobra.Command) (bool, error) {
+	allStr, err := cmdutils.GetUserSetVarFromString(cmd, allFlagName, allEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if allStr == "" {
+		return false, nil
+	}
+
+	all, err := strconv.ParseBool(allStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s [%s]: %w", allFlagName, allStr, err)
+	}
+
+	return all, nil
+}
+
+func getAllIDs(cmd *cobra.Command, u string) ([]string, error) {
+	resp, err := common.SendHTTPRequest(cmd, nil, http.MethodGet, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []deadLetter
+
+	err = json.Unmarshal(resp, &activities)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal undeliverable activities: %w", err)
+	}
+
+	ids := make([]string, len(activities))
+
+	for i, a := range activities {
+		if a.ID == "" {
+			return nil, errors.New("undeliverable activity is missing an ID")
+		}
+
+		ids[i] = a.ID
+	}
+
+	return ids, nil
+}
+
+type deadLetter struct {
+	ID string `json:"id"`
+}
+
+type deadLetterRequest struct {
+	Replay []string `json:"replay,omitempty"`
+	Purge  []string `json:"purge,omitempty"`
+}
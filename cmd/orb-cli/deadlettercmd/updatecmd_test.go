@@ -0,0 +1,187 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadlettercmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+const (
+	flag = "--"
+)
+
+func TestUpdateCmd(t *testing.T) {
+	t.Run("test missing url arg", func(t *testing.T) {
+		cmd := GetCmd()
+		cmd.SetArgs([]string{"replay"})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither url (command line flag) nor ORB_CLI_URL (environment variable) have been set.",
+			err.Error())
+	})
+
+	t.Run("test missing id and all args", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"replay"}
+		args = append(args, urlArg("localhost:8080")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "either --id or --all must be specified")
+	})
+
+	t.Run("test both id and all args", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"purge"}
+		args = append(args, urlArg("localhost:8080")...)
+		args = append(args, idArg("1234")...)
+		args = append(args, allArg("true")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "only one of --id or --all may be specified")
+	})
+
+	t.Run("test invalid all arg", func(t *testing.T) {
+		cmd := GetCmd()
+
+		args := []string{"purge"}
+		args = append(args, urlArg("localhost:8080")...)
+		args = append(args, allArg("xxx")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid value for all")
+	})
+
+	t.Run("replay -> success", func(t *testing.T) {
+		var req deadLetterRequest
+
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqBytes, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(reqBytes, &req))
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"replay"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, idArg("1234")...)
+		args = append(args, idArg("5678")...)
+		args = append(args, authTokenArg("ADMIN_TOKEN")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, []string{"1234", "5678"}, req.Replay)
+		require.Empty(t, req.Purge)
+	})
+
+	t.Run("purge all -> success", func(t *testing.T) {
+		var req deadLetterRequest
+
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				_, err := fmt.Fprint(w, `[{"id":"1234"},{"id":"5678"}]`)
+				require.NoError(t, err)
+
+				return
+			}
+
+			reqBytes, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(reqBytes, &req))
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"purge"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, allArg("true")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, []string{"1234", "5678"}, req.Purge)
+		require.Empty(t, req.Replay)
+	})
+
+	t.Run("purge all -> none found", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+
+			_, err := fmt.Fprint(w, `[]`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"purge"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, allArg("true")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("replay all -> invalid response", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := fmt.Fprint(w, `invalid`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetCmd()
+
+		args := []string{"replay"}
+		args = append(args, urlArg(serv.URL)...)
+		args = append(args, allArg("true")...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal undeliverable activities")
+	})
+}
+
+func urlArg(value string) []string {
+	return []string{flag + urlFlagName, value}
+}
+
+func idArg(value string) []string {
+	return []string{flag + idFlagName, value}
+}
+
+func allArg(value string) []string {
+	return []string{flag + allFlagName, value}
+}
+
+func authTokenArg(value string) []string {
+	return []string{flag + common.AuthTokenFlagName, value}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didhistorycmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+const (
+	urlFlagName  = "url"
+	urlFlagUsage = "The URL of the DID history REST endpoint, e.g. https://orb.domain1.com/did/history." +
+		" Alternatively, this can be set with the following environment variable: " + urlEnvKey
+	urlEnvKey = "ORB_CLI_URL"
+
+	didURIFlagName  = "did-uri"
+	didURIFlagUsage = "The DID (or unique suffix of the DID) for which to retrieve the history." +
+		" Alternatively, this can be set with the following environment variable: " + didURIEnvKey
+	didURIEnvKey = "ORB_CLI_DID_URI"
+)
+
+// GetDIDHistoryCmd returns the Cobra DID history command.
+func GetDIDHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Retrieves the anchor history of an orb DID.",
+		Long: "Retrieves the chain of anchors for an orb DID, including the anchor time, witness proofs, " +
+			"VCT log entries, operations and alternate CAS locations of each anchor.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeGet(cmd)
+		},
+	}
+
+	common.AddCommonFlags(cmd)
+
+	cmd.Flags().StringP(urlFlagName, "", "", urlFlagUsage)
+	cmd.Flags().StringP(didURIFlagName, "", "", didURIFlagUsage)
+
+	return cmd
+}
+
+func executeGet(cmd *cobra.Command) error {
+	u, err := cmdutils.GetUserSetVarFromString(cmd, urlFlagName, urlEnvKey, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("invalid URL %s: %w", u, err)
+	}
+
+	did, err := cmdutils.GetUserSetVarFromString(cmd, didURIFlagName, didURIEnvKey, false)
+	if err != nil {
+		return err
+	}
+
+	resp, err := common.SendHTTPRequest(cmd, nil, http.MethodGet,
+		fmt.Sprintf("%s/%s", strings.TrimSuffix(u, "/"), url.PathEscape(did)))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(resp))
+
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didhistorycmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/cmd/orb-cli/common"
+)
+
+const (
+	flag = "--"
+
+	did = "did:orb:uAAA:EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+)
+
+func TestDIDHistoryCmd(t *testing.T) {
+	t.Run("test missing url arg", func(t *testing.T) {
+		cmd := GetDIDHistoryCmd()
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither url (command line flag) nor ORB_CLI_URL (environment variable) have been set.",
+			err.Error())
+	})
+
+	t.Run("test invalid url arg", func(t *testing.T) {
+		cmd := GetDIDHistoryCmd()
+		cmd.SetArgs(urlArg(":invalid"))
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid URL")
+	})
+
+	t.Run("test missing did-uri arg", func(t *testing.T) {
+		cmd := GetDIDHistoryCmd()
+		cmd.SetArgs(urlArg("https://orb.domain1.com/did/history"))
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		require.Equal(t,
+			"Neither did-uri (command line flag) nor ORB_CLI_DID_URI (environment variable) have been set.",
+			err.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/did/history/"+did, r.URL.Path)
+			require.Equal(t, "Bearer ADMIN_TOKEN", r.Header.Get("Authorization"))
+
+			_, err := fmt.Fprint(w, `{"didSuffix":"EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA","anchors":[]}`)
+			require.NoError(t, err)
+		}))
+		defer serv.Close()
+
+		cmd := GetDIDHistoryCmd()
+
+		args := urlArg(serv.URL + "/did/history/")
+		args = append(args, didURIArg(did)...)
+		args = append(args, authTokenArg("ADMIN_TOKEN")...)
+		cmd.SetArgs(args)
+
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer serv.Close()
+
+		cmd := GetDIDHistoryCmd()
+
+		args := urlArg(serv.URL + "/did/history")
+		args = append(args, didURIArg(did)...)
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "404")
+	})
+}
+
+func urlArg(value string) []string {
+	return []string{flag + urlFlagName, value}
+}
+
+func didURIArg(value string) []string {
+	return []string{flag + didURIFlagName, value}
+}
+
+func authTokenArg(value string) []string {
+	return []string{flag + common.AuthTokenFlagName, value}
+}
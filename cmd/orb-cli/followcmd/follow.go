@@ -30,12 +30,12 @@ const (
 	outboxURLEnvKey = "ORB_CLI_OUTBOX_URL"
 
 	actorFlagName  = "actor"
-	actorFlagUsage = "Actor IRI." +
+	actorFlagUsage = "Actor IRI or 'acct' URI (e.g. acct:orb@orb.domain1.com) which is resolved using WebFinger." +
 		" Alternatively, this can be set with the following environment variable: " + actorEnvKey
 	actorEnvKey = "ORB_CLI_ACTOR"
 
 	toFlagName  = "to"
-	toFlagUsage = "To IRI." +
+	toFlagUsage = "To IRI or 'acct' URI (e.g. acct:orb@orb.domain2.com) which is resolved using WebFinger." +
 		" Alternatively, this can be set with the following environment variable: " + toEnvKey
 	toEnvKey = "ORB_CLI_TO"
 
@@ -130,7 +130,7 @@ func createCmd() *cobra.Command { //nolint:funlen,gocyclo,cyclop,gocognit
 				return err
 			}
 
-			actorIRI, err := url.Parse(actor)
+			actorIRI, err := common.ResolveActorIRI(httpClient, actor)
 			if err != nil {
 				return fmt.Errorf("parse 'actor' URL %s: %w", actor, err)
 			}
@@ -141,7 +141,7 @@ func createCmd() *cobra.Command { //nolint:funlen,gocyclo,cyclop,gocognit
 				return err
 			}
 
-			toIRI, err := url.Parse(to)
+			toIRI, err := common.ResolveActorIRI(httpClient, to)
 			if err != nil {
 				return fmt.Errorf("parse 'to' URL %s: %w", to, err)
 			}
@@ -232,7 +232,7 @@ func createCmd() *cobra.Command { //nolint:funlen,gocyclo,cyclop,gocognit
 
 			for i := 0; i < maxRetry; i++ {
 				resp, err := common.SendRequest(httpClient, nil, headers, http.MethodGet,
-					fmt.Sprintf("%s/following?page=true", actor))
+					fmt.Sprintf("%s/following?page=true", actorIRI))
 				if err != nil {
 					return fmt.Errorf("failed to send http request: %w", err)
 				}
@@ -246,7 +246,7 @@ func createCmd() *cobra.Command { //nolint:funlen,gocyclo,cyclop,gocognit
 				exists := false
 
 				for _, item := range followingResp.Items {
-					if item == to {
+					if item == toIRI.String() {
 						exists = true
 					}
 				}
@@ -11,8 +11,11 @@ import (
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/orb/cmd/orb-cli/acceptlistcmd"
+	"github.com/trustbloc/orb/cmd/orb-cli/backfillcmd"
 	"github.com/trustbloc/orb/cmd/orb-cli/createdidcmd"
 	"github.com/trustbloc/orb/cmd/orb-cli/deactivatedidcmd"
+	"github.com/trustbloc/orb/cmd/orb-cli/deadlettercmd"
+	"github.com/trustbloc/orb/cmd/orb-cli/didhistorycmd"
 	"github.com/trustbloc/orb/cmd/orb-cli/followcmd"
 	"github.com/trustbloc/orb/cmd/orb-cli/ipfskeygencmd"
 	"github.com/trustbloc/orb/cmd/orb-cli/ipnshostmetagencmd"
@@ -56,12 +59,15 @@ func main() {
 	didCmd.AddCommand(recoverdidcmd.GetRecoverDIDCmd())
 	didCmd.AddCommand(deactivatedidcmd.GetDeactivateDIDCmd())
 	didCmd.AddCommand(resolvedidcmd.GetResolveDIDCmd())
+	didCmd.AddCommand(didhistorycmd.GetDIDHistoryCmd())
 
 	rootCmd.AddCommand(didCmd)
 	rootCmd.AddCommand(ipfsCmd)
 	rootCmd.AddCommand(followcmd.GetCmd())
 	rootCmd.AddCommand(witnesscmd.GetCmd())
 	rootCmd.AddCommand(acceptlistcmd.GetCmd())
+	rootCmd.AddCommand(deadlettercmd.GetCmd())
+	rootCmd.AddCommand(backfillcmd.GetCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Fatalf("Failed to run orb-cli: %s", err.Error())
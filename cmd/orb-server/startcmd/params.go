@@ -18,6 +18,10 @@ import (
 	cmdutils "github.com/trustbloc/edge-core/pkg/utils/cmd"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 
+	"github.com/trustbloc/orb/pkg/activitypub/httpsig"
+	"github.com/trustbloc/orb/pkg/activitypub/service/ratelimiter"
+	apariesstore "github.com/trustbloc/orb/pkg/activitypub/store/ariesstore"
+	activitypubspi "github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
 	"github.com/trustbloc/orb/pkg/context/opqueue"
 	"github.com/trustbloc/orb/pkg/httpserver/auth"
@@ -34,6 +38,7 @@ const (
 	defaultServerIdleTimeout                = 20 * time.Second
 	defaultHTTPTimeout                      = 20 * time.Second
 	defaultUnpublishedOperationLifespan     = time.Minute * 5
+	defaultOperationStatusLifespan          = 24 * time.Hour
 	defaultTaskMgrCheckInterval             = 10 * time.Second
 	defaultDataExpiryCheckInterval          = time.Minute
 	defaultAnchorSyncInterval               = time.Minute
@@ -53,9 +58,14 @@ const (
 	defaultActivityPubClientCacheExpiration = time.Hour
 	defaultActivityPubIRICacheSize          = 100
 	defaultActivityPubIRICacheExpiration    = time.Hour
+	defaultCircuitBreakerFailureThreshold   = 5
+	defaultCircuitBreakerOpenTimeout        = time.Minute
 	defaultFollowAuthType                   = acceptAllPolicy
 	defaultInviteWitnessAuthType            = acceptAllPolicy
 	defaultWitnessPolicyCacheExpiration     = 30 * time.Second
+	defaultWitnessHealthThreshold           = 0.5
+	defaultWitnessHealthBackoff             = time.Minute
+	defaultWitnessHealthMaxBackoff          = time.Hour
 	defaultAnchorAttachmentMediaType        = vocab.GzipMediaType
 
 	opQueueDefaultPoolSize                = 5
@@ -283,13 +293,15 @@ const (
 	databaseTypeEnvKey        = "DATABASE_TYPE"
 	databaseTypeFlagShorthand = "t"
 	databaseTypeFlagUsage     = "The type of database to use for everything except key storage. " +
-		"Supported options: mem, couchdb, mongodb. " + commonEnvVarUsageText + databaseTypeEnvKey
+		"Supported options: mem, couchdb, mongodb, embedded. " + commonEnvVarUsageText + databaseTypeEnvKey
 
 	databaseURLFlagName      = "database-url"
 	databaseURLEnvKey        = "DATABASE_URL"
 	databaseURLFlagShorthand = "v"
 	databaseURLFlagUsage     = "The URL (or connection string) of the database. Not needed if using memstore." +
-		" For CouchDB, include the username:password@ text if required. " + commonEnvVarUsageText + databaseURLEnvKey
+		" For CouchDB, include the username:password@ text if required." +
+		" For embedded, this is the path of the directory in which data is stored. " +
+		commonEnvVarUsageText + databaseURLEnvKey
 
 	databasePrefixFlagName  = "database-prefix"
 	databasePrefixEnvKey    = "DATABASE_PREFIX"
@@ -301,13 +313,14 @@ const (
 	kmsSecretsDatabaseTypeEnvKey        = "KMSSECRETS_DATABASE_TYPE"  //nolint: gosec
 	kmsSecretsDatabaseTypeFlagShorthand = "k"
 	kmsSecretsDatabaseTypeFlagUsage     = "The type of database to use for storage of KMS secrets. " +
-		"Supported options: mem, couchdb, mongodb. " + commonEnvVarUsageText + kmsSecretsDatabaseTypeEnvKey
+		"Supported options: mem, couchdb, mongodb, embedded. " + commonEnvVarUsageText + kmsSecretsDatabaseTypeEnvKey
 
 	kmsSecretsDatabaseURLFlagName      = "kms-secrets-database-url" //nolint: gosec
 	kmsSecretsDatabaseURLEnvKey        = "KMSSECRETS_DATABASE_URL"  //nolint: gosec
 	kmsSecretsDatabaseURLFlagShorthand = "s"
 	kmsSecretsDatabaseURLFlagUsage     = "The URL (or connection string) of the database. Not needed if using memstore. For CouchDB, " +
 		"include the username:password@ text if required. " +
+		"For embedded, this is the path of the directory in which KMS secrets are stored. " +
 		commonEnvVarUsageText + databaseURLEnvKey
 
 	kmsSecretsDatabasePrefixFlagName  = "kms-secrets-database-prefix" //nolint: gosec
@@ -321,9 +334,10 @@ const (
 		"Currently this setting only applies if you're using MongoDB. " +
 		commonEnvVarUsageText + databaseTimeoutEnvKey
 
-	databaseTypeMemOption     = "mem"
-	databaseTypeCouchDBOption = "couchdb"
-	databaseTypeMongoDBOption = "mongodb"
+	databaseTypeMemOption      = "mem"
+	databaseTypeCouchDBOption  = "couchdb"
+	databaseTypeMongoDBOption  = "mongodb"
+	databaseTypeEmbeddedOption = "embedded"
 
 	anchorCredentialIssuerFlagName      = "anchor-credential-issuer"
 	anchorCredentialIssuerEnvKey        = "ANCHOR_CREDENTIAL_ISSUER"
@@ -388,6 +402,24 @@ const (
 	httpSignaturesEnabledUsage     = `Set to "true" to enable HTTP signatures in ActivityPub. ` +
 		commonEnvVarUsageText + httpSignaturesEnabledEnvKey
 
+	httpSignatureSchemeFlagName  = "http-signature-scheme"
+	httpSignatureSchemeEnvKey    = "HTTP_SIGNATURE_SCHEME"
+	httpSignatureSchemeFlagUsage = "The scheme used to sign ActivityPub HTTP requests. Supported options: " +
+		"draft-cavage, rfc9421. Requests signed with either scheme are accepted. Defaults to draft-cavage. " +
+		commonEnvVarUsageText + httpSignatureSchemeEnvKey
+
+	httpSignaturePeerSchemesFlagName  = "http-signature-peer-schemes"
+	httpSignaturePeerSchemesEnvKey    = "HTTP_SIGNATURE_PEER_SCHEMES"
+	httpSignaturePeerSchemesFlagUsage = "Overrides the HTTP signature scheme for requests sent to the given peers, " +
+		"in the format Host[:Port]=scheme, for example, 'orb.domain1.com=rfc9421'. Multiple peers may be " +
+		"specified. " + commonEnvVarUsageText + httpSignaturePeerSchemesEnvKey
+
+	httpSignatureMaxClockSkewFlagName  = "http-signature-max-clock-skew"
+	httpSignatureMaxClockSkewEnvKey    = "HTTP_SIGNATURE_MAX_CLOCK_SKEW"
+	httpSignatureMaxClockSkewFlagUsage = "The maximum difference between the date of a signed ActivityPub HTTP " +
+		"request and the local time. Requests outside of this window are rejected. For example, '2m' for two " +
+		"minutes. Defaults to 5m. " + commonEnvVarUsageText + httpSignatureMaxClockSkewEnvKey
+
 	enableDidDiscoveryFlagName = "enable-did-discovery"
 	enableDidDiscoveryEnvKey   = "DID_DISCOVERY_ENABLED"
 	enableDidDiscoveryUsage    = `Set to "true" to enable did discovery. ` +
@@ -449,6 +481,18 @@ const (
 	activityPubPageSizeFlagUsage     = "The maximum page size for an ActivityPub collection or ordered collection. " +
 		commonEnvVarUsageText + activityPubPageSizeEnvKey
 
+	alsoKnownAsFlagName  = "also-known-as"
+	alsoKnownAsEnvKey    = "ALSO_KNOWN_AS"
+	alsoKnownAsFlagUsage = "The IRIs by which this service was previously known, for example, the service IRI " +
+		"on the old domain after a domain migration. " + commonEnvVarUsageText + alsoKnownAsEnvKey
+
+	activityProofsEnabledFlagName = "enable-activity-proofs"
+	activityProofsEnabledEnvKey   = "ACTIVITY_PROOFS_ENABLED"
+	activityProofsEnabledUsage    = `Set to "true" to add a linked-data proof to each activity posted to the outbox ` +
+		`and to verify the proofs embedded in announced activities received by the inbox ` +
+		`(activities without a proof are rejected). ` +
+		commonEnvVarUsageText + activityProofsEnabledEnvKey
+
 	devModeEnabledFlagName = "enable-dev-mode"
 	devModeEnabledEnvKey   = "DEV_MODE_ENABLED"
 	devModeEnabledUsage    = `Set to "true" to enable dev mode. ` +
@@ -477,6 +521,13 @@ const (
 		"(and thus, being deleted some time later). For example, '1m' for a 1 minute lifespan. " +
 		"Defaults to 1 minute if not set. " + commonEnvVarUsageText + unpublishedOperationLifespanEnvKey
 
+	operationStatusLifespanFlagName  = "operation-status-lifetime"
+	operationStatusLifespanEnvKey    = "OPERATION_STATUS_LIFETIME"
+	operationStatusLifespanFlagUsage = "How long the status of a submitted operation remains stored before expiring " +
+		"(and thus, being deleted some time later). The status may be retrieved by the tracking ID that's returned " +
+		"when the operation is submitted. For example, '24h' for a 24 hour lifespan. " +
+		"Defaults to 24h if not set. " + commonEnvVarUsageText + operationStatusLifespanEnvKey
+
 	taskMgrCheckIntervalFlagName  = "task-manager-check-interval"
 	taskMgrCheckIntervalEnvKey    = "TASK_MANAGER_CHECK_INTERVAL"
 	taskMgrCheckIntervalFlagUsage = "How frequently to check for scheduled tasks. " +
@@ -550,6 +601,55 @@ const (
 	activityPubIRICacheExpirationFlagUsage = "The expiration time of an ActivityPub actor IRI cache. " +
 		commonEnvVarUsageText + activityPubIRICacheExpirationEnvKey
 
+	circuitBreakerFailureThresholdFlagName  = "circuit-breaker-failure-threshold"
+	circuitBreakerFailureThresholdEnvKey    = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	circuitBreakerFailureThresholdFlagUsage = "The number of consecutive failures when delivering activities to " +
+		"a host after which deliveries to that host are suspended. Defaults to 5. " +
+		commonEnvVarUsageText + circuitBreakerFailureThresholdEnvKey
+
+	circuitBreakerOpenTimeoutFlagName  = "circuit-breaker-open-timeout"
+	circuitBreakerOpenTimeoutEnvKey    = "CIRCUIT_BREAKER_OPEN_TIMEOUT"
+	circuitBreakerOpenTimeoutFlagUsage = "The time that deliveries to a failing host are suspended before a " +
+		"probe delivery is attempted. For example, '1m' for one minute. Defaults to 1m. " +
+		commonEnvVarUsageText + circuitBreakerOpenTimeoutEnvKey
+
+	inboxRateLimitFlagName  = "inbox-rate-limit"
+	inboxRateLimitEnvKey    = "INBOX_RATE_LIMIT"
+	inboxRateLimitFlagUsage = "The maximum number of activities per second that a single actor may post to the " +
+		"inbox, optionally followed by a burst size, for example, '10' or '10:50'. Excess requests are rejected " +
+		"with HTTP status 429. Requests without an HTTP signature are limited by remote address. If not set " +
+		"then the rate is not limited. " + commonEnvVarUsageText + inboxRateLimitEnvKey
+
+	inboxActivityRateLimitsFlagName  = "inbox-activity-rate-limits"
+	inboxActivityRateLimitsEnvKey    = "INBOX_ACTIVITY_RATE_LIMITS"
+	inboxActivityRateLimitsFlagUsage = "Rate limits for activities of a given type posted to the inbox by a single " +
+		"actor, in the format ActivityType=rate[:burst], for example, 'Create=1:5'. Multiple limits may be " +
+		"specified. " + commonEnvVarUsageText + inboxActivityRateLimitsEnvKey
+
+	activityRetentionPeriodsFlagName  = "activity-retention-periods"
+	activityRetentionPeriodsEnvKey    = "ACTIVITY_RETENTION_PERIODS"
+	activityRetentionPeriodsFlagUsage = "The retention periods of activities in the ActivityPub store, per " +
+		"reference type, in the format ReferenceType=period, for example, 'LIKE=720h'. Supported reference types: " +
+		"INBOX, OUTBOX, LIKE, SHARE. Only Create, Announce, Like and Offer activities are removed, and an activity is " +
+		"removed once all of its references have expired, so activities in the public outbox are never removed. " +
+		"The anchor sync task pages through the inbox and outbox of a peer using cursors, so its position isn't " +
+		"affected when older activities are removed. If not set then activities are kept forever. Retention is only supported by the couchdb, mongodb and embedded database types. " +
+		commonEnvVarUsageText + activityRetentionPeriodsEnvKey
+
+	activityArchiveTypeFlagName  = "activity-archive-type"
+	activityArchiveTypeEnvKey    = "ACTIVITY_ARCHIVE_TYPE"
+	activityArchiveTypeFlagUsage = "Archives activities before they are removed from the ActivityPub store " +
+		"according to the activity retention periods. Supported options: cas, file. If not set then activities " +
+		"are not archived. " + commonEnvVarUsageText + activityArchiveTypeEnvKey
+
+	activityArchiveDirFlagName  = "activity-archive-dir"
+	activityArchiveDirEnvKey    = "ACTIVITY_ARCHIVE_DIR"
+	activityArchiveDirFlagUsage = "The directory to which compressed activity export files are written if the " +
+		"activity archive type is 'file'. " + commonEnvVarUsageText + activityArchiveDirEnvKey
+
+	activityArchiveTypeCASOption  = "cas"
+	activityArchiveTypeFileOption = "file"
+
 	serverIdleTimeoutFlagName  = "server-idle-timeout"
 	serverIdleTimeoutEnvKey    = "SERVER_IDLE_TIMEOUT"
 	serverIdleTimeoutFlagUsage = "The timeout for server idle timeout. For example, '30s' for a 30 second timeout. " +
@@ -560,6 +660,40 @@ const (
 	witnessPolicyCacheExpirationFlagUsage = "The expiration time of witness policy cache. " +
 		commonEnvVarUsageText + witnessPolicyCacheExpirationEnvKey
 
+	witnessSelectionStrategyFlagName  = "witness-selection-strategy"
+	witnessSelectionStrategyEnvKey    = "WITNESS_SELECTION_STRATEGY"
+	witnessSelectionStrategyFlagUsage = "The strategy used to select a subset of witnesses according to the witness " +
+		"policy. Supported options: random, round-robin, least-recently-used, lowest-latency, " +
+		"fewest-outstanding-offers. The statistics used by the least-recently-used, lowest-latency and " +
+		"fewest-outstanding-offers strategies are aggregated across all server instances and the round-robin " +
+		"position is shared by all server instances. Defaults to random. " +
+		commonEnvVarUsageText + witnessSelectionStrategyEnvKey
+
+	witnessSelectionStrategyRandom                  = "random"
+	witnessSelectionStrategyRoundRobin              = "round-robin"
+	witnessSelectionStrategyLeastRecentlyUsed       = "least-recently-used"
+	witnessSelectionStrategyLowestLatency           = "lowest-latency"
+	witnessSelectionStrategyFewestOutstandingOffers = "fewest-outstanding-offers"
+
+	witnessHealthThresholdFlagName  = "witness-health-threshold"
+	witnessHealthThresholdEnvKey    = "WITNESS_HEALTH_THRESHOLD"
+	witnessHealthThresholdFlagUsage = "The health score (between 0 and 1) below which a witness is skipped during " +
+		"witness selection. The health score is based on the recent responses (valid proofs, timeouts and invalid " +
+		"proofs) of the witness. Set to 0 to disable the exclusion of unhealthy witnesses. Defaults to 0.5. " +
+		commonEnvVarUsageText + witnessHealthThresholdEnvKey
+
+	witnessHealthBackoffFlagName  = "witness-health-backoff"
+	witnessHealthBackoffEnvKey    = "WITNESS_HEALTH_BACKOFF"
+	witnessHealthBackoffFlagUsage = "The period for which an unhealthy witness is skipped during witness selection " +
+		"before it is retried. The period is doubled each time the witness fails while it remains unhealthy. " +
+		"For example, '1m' for a 1 minute backoff. Defaults to 1m. " +
+		commonEnvVarUsageText + witnessHealthBackoffEnvKey
+
+	witnessHealthMaxBackoffFlagName  = "witness-health-max-backoff"
+	witnessHealthMaxBackoffEnvKey    = "WITNESS_HEALTH_MAX_BACKOFF"
+	witnessHealthMaxBackoffFlagUsage = "The maximum period for which an unhealthy witness is skipped during " +
+		"witness selection. Defaults to 1h. " + commonEnvVarUsageText + witnessHealthMaxBackoffEnvKey
+
 	anchorAttachmentMediaTypeFlagName  = "anchor-attachment-media-type"
 	anchorAttachmentMediaTypeEnvKey    = "ANCHOR_ATTACHMENT_MEDIA_TYPE"
 	anchorAttachmentMediaTypeFlagUsage = "The media type for attachments in an AnchorEvent. Possible values are " +
@@ -630,6 +764,9 @@ type orbParameters struct {
 	syncTimeout                             uint64
 	signWithLocalWitness                    bool
 	httpSignaturesEnabled                   bool
+	httpSignatureScheme                     httpsig.SignatureScheme
+	httpSignaturePeerSchemes                map[string]httpsig.SignatureScheme
+	httpSignatureMaxClockSkew               time.Duration
 	didDiscoveryEnabled                     bool
 	unpublishedOperationStoreEnabled        bool
 	unpublishedOperationStoreOperationTypes []operation.Type
@@ -642,7 +779,9 @@ type orbParameters struct {
 	clientAuthTokenDefinitions              []*auth.TokenDef
 	clientAuthTokens                        map[string]string
 	activityPubPageSize                     int
+	alsoKnownAs                             []*url.URL
 	enableDevMode                           bool
+	activityProofsEnabled                   bool
 	nodeInfoRefreshInterval                 time.Duration
 	ipfsTimeout                             time.Duration
 	databaseTimeout                         time.Duration
@@ -651,6 +790,7 @@ type orbParameters struct {
 	serverIdleTimeout                       time.Duration
 	contextProviderURLs                     []string
 	unpublishedOperationLifespan            time.Duration
+	operationStatusLifespan                 time.Duration
 	dataExpiryCheckInterval                 time.Duration
 	inviteWitnessAuthPolicy                 acceptRejectPolicy
 	followAuthPolicy                        acceptRejectPolicy
@@ -664,7 +804,16 @@ type orbParameters struct {
 	apClientCacheExpiration                 time.Duration
 	apIRICacheSize                          int
 	apIRICacheExpiration                    time.Duration
+	circuitBreakerFailureThreshold          int
+	circuitBreakerOpenTimeout               time.Duration
+	inboxRateLimit                          ratelimiter.Limit
+	inboxActivityRateLimits                 map[string]ratelimiter.Limit
+	activityRetentionParams                 *activityRetentionParameters
 	witnessPolicyCacheExpiration            time.Duration
+	witnessSelectionStrategy                string
+	witnessHealthThreshold                  float64
+	witnessHealthBackoff                    time.Duration
+	witnessHealthMaxBackoff                 time.Duration
 	sidetreeProtocolVersions                []string
 	currentSidetreeProtocolVersion          string
 }
@@ -880,6 +1029,21 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		httpSignaturesEnabled = enable
 	}
 
+	httpSignatureScheme, httpSignaturePeerSchemes, err := getHTTPSignatureSchemeParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	httpSignatureMaxClockSkew, err := getDuration(cmd, httpSignatureMaxClockSkewFlagName,
+		httpSignatureMaxClockSkewEnvKey, httpsig.DefaultMaxClockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", httpSignatureMaxClockSkewFlagName, err)
+	}
+
+	if httpSignatureMaxClockSkew <= 0 {
+		return nil, fmt.Errorf("%s: value must be greater than 0", httpSignatureMaxClockSkewFlagName)
+	}
+
 	enableDidDiscoveryStr, err := cmdutils.GetUserSetVarFromString(cmd, enableDidDiscoveryFlagName, enableDidDiscoveryEnvKey, true)
 	if err != nil {
 		return nil, err
@@ -907,6 +1071,19 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		enableDevMode = enable
 	}
 
+	activityProofsEnabledStr := cmdutils.GetUserSetOptionalVarFromString(cmd, activityProofsEnabledFlagName,
+		activityProofsEnabledEnvKey)
+
+	activityProofsEnabled := defaultActivityProofsEnabled
+	if activityProofsEnabledStr != "" {
+		enable, parseErr := strconv.ParseBool(activityProofsEnabledStr)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", activityProofsEnabledFlagName, parseErr)
+		}
+
+		activityProofsEnabled = enable
+	}
+
 	enableUnpublishedOperationStoreStr, err := cmdutils.GetUserSetVarFromString(cmd, enableUnpublishedOperationStoreFlagName, enableUnpublishedOperationStoreEnvKey, true)
 	if err != nil {
 		return nil, err
@@ -1074,6 +1251,11 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		return nil, fmt.Errorf("%s: %w", activityPubPageSizeFlagName, err)
 	}
 
+	alsoKnownAs, err := getAlsoKnownAs(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", alsoKnownAsFlagName, err)
+	}
+
 	nodeInfoRefreshInterval, err := getDuration(cmd, nodeInfoRefreshIntervalFlagName,
 		nodeInfoRefreshIntervalEnvKey, defaultNodeInfoRefreshInterval)
 	if err != nil {
@@ -1116,6 +1298,12 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		return nil, fmt.Errorf("%s: %w", unpublishedOperationLifespanFlagName, err)
 	}
 
+	operationStatusLifespan, err := getDuration(cmd, operationStatusLifespanFlagName,
+		operationStatusLifespanEnvKey, defaultOperationStatusLifespan)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operationStatusLifespanFlagName, err)
+	}
+
 	dataExpiryCheckInterval, err := getDuration(cmd, dataExpiryCheckIntervalFlagName,
 		dataExpiryCheckIntervalEnvKey, defaultDataExpiryCheckInterval)
 	if err != nil {
@@ -1167,6 +1355,16 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		return nil, fmt.Errorf("%s: %w", witnessPolicyCacheExpirationFlagName, err)
 	}
 
+	witnessSelectionStrategy, err := getWitnessSelectionStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	witnessHealthThreshold, witnessHealthBackoff, witnessHealthMaxBackoff, err := getWitnessHealthParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	apClientCacheSize, apClientCacheExpiration, err := getActivityPubClientParameters(cmd)
 	if err != nil {
 		return nil, err
@@ -1177,6 +1375,21 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		return nil, err
 	}
 
+	cbFailureThreshold, cbOpenTimeout, err := getCircuitBreakerParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	inboxRateLimit, inboxActivityRateLimits, err := getInboxRateLimitParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	activityRetentionParams, err := getActivityRetentionParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	sidetreeProtocolVersionsArr := cmdutils.GetUserSetOptionalVarFromArrayString(cmd, sidetreeProtocolVersionsFlagName, sidetreeProtocolVersionsEnvKey)
 
 	defaultSidetreeProtocolVersions := []string{"1.0"}
@@ -1222,6 +1435,9 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		syncTimeout:                             syncTimeout,
 		signWithLocalWitness:                    signWithLocalWitness,
 		httpSignaturesEnabled:                   httpSignaturesEnabled,
+		httpSignatureScheme:                     httpSignatureScheme,
+		httpSignaturePeerSchemes:                httpSignaturePeerSchemes,
+		httpSignatureMaxClockSkew:               httpSignatureMaxClockSkew,
 		didDiscoveryEnabled:                     didDiscoveryEnabled,
 		unpublishedOperationStoreEnabled:        unpublishedOperationStoreEnabled,
 		unpublishedOperationStoreOperationTypes: unpublishedOperationStoreOperationTypes,
@@ -1234,12 +1450,15 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		clientAuthTokenDefinitions:              clientAuthTokenDefs,
 		clientAuthTokens:                        clientAuthTokens,
 		activityPubPageSize:                     activityPubPageSize,
+		alsoKnownAs:                             alsoKnownAs,
 		enableDevMode:                           enableDevMode,
+		activityProofsEnabled:                   activityProofsEnabled,
 		nodeInfoRefreshInterval:                 nodeInfoRefreshInterval,
 		ipfsTimeout:                             ipfsTimeout,
 		databaseTimeout:                         databaseTimeout,
 		contextProviderURLs:                     contextProviderURLs,
 		unpublishedOperationLifespan:            unpublishedOperationLifespan,
+		operationStatusLifespan:                 operationStatusLifespan,
 		dataExpiryCheckInterval:                 dataExpiryCheckInterval,
 		followAuthPolicy:                        followAuthPolicy,
 		inviteWitnessAuthPolicy:                 inviteWitnessAuthPolicy,
@@ -1252,10 +1471,19 @@ func getOrbParameters(cmd *cobra.Command) (*orbParameters, error) {
 		anchorStatusMonitoringInterval:          anchorStatusMonitoringInterval,
 		anchorStatusInProcessGracePeriod:        anchorStatusInProcessGracePeriod,
 		witnessPolicyCacheExpiration:            witnessPolicyCacheExpiration,
+		witnessSelectionStrategy:                witnessSelectionStrategy,
+		witnessHealthThreshold:                  witnessHealthThreshold,
+		witnessHealthBackoff:                    witnessHealthBackoff,
+		witnessHealthMaxBackoff:                 witnessHealthMaxBackoff,
 		apClientCacheSize:                       apClientCacheSize,
 		apClientCacheExpiration:                 apClientCacheExpiration,
 		apIRICacheSize:                          apIRICacheSize,
 		apIRICacheExpiration:                    apIRICacheExpiration,
+		circuitBreakerFailureThreshold:          cbFailureThreshold,
+		circuitBreakerOpenTimeout:               cbOpenTimeout,
+		inboxRateLimit:                          inboxRateLimit,
+		inboxActivityRateLimits:                 inboxActivityRateLimits,
+		activityRetentionParams:                 activityRetentionParams,
 		serverIdleTimeout:                       serverIdleTimeout,
 		anchorAttachmentMediaType:               anchorAttachmentMediaType,
 		sidetreeProtocolVersions:                sidetreeProtocolVersions,
@@ -1451,6 +1679,26 @@ func getAuthTokens(cmd *cobra.Command, flagName, envKey string, defaultTokens ma
 	return authTokens, nil
 }
 
+func getAlsoKnownAs(cmd *cobra.Command) ([]*url.URL, error) {
+	alsoKnownAsStr, err := cmdutils.GetUserSetVarFromArrayString(cmd, alsoKnownAsFlagName, alsoKnownAsEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var alsoKnownAs []*url.URL
+
+	for _, iriStr := range alsoKnownAsStr {
+		iri, err := url.Parse(iriStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IRI [%s]: %w", iriStr, err)
+		}
+
+		alsoKnownAs = append(alsoKnownAs, iri)
+	}
+
+	return alsoKnownAs, nil
+}
+
 func getActivityPubPageSize(cmd *cobra.Command) (int, error) {
 	activityPubPageSizeStr, err := cmdutils.GetUserSetVarFromString(cmd, activityPubPageSizeFlagName, activityPubPageSizeEnvKey, true)
 	if err != nil {
@@ -1768,6 +2016,246 @@ func getActivityPubIRICacheParameters(cmd *cobra.Command) (int, time.Duration, e
 	return cacheSize, cacheExpiration, nil
 }
 
+func getCircuitBreakerParameters(cmd *cobra.Command) (int, time.Duration, error) {
+	failureThreshold, err := getInt(cmd, circuitBreakerFailureThresholdFlagName,
+		circuitBreakerFailureThresholdEnvKey, defaultCircuitBreakerFailureThreshold)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if failureThreshold <= 0 {
+		return 0, 0, fmt.Errorf("value for parameter [%s] must be greater than 0",
+			circuitBreakerFailureThresholdFlagName)
+	}
+
+	openTimeout, err := getDuration(cmd, circuitBreakerOpenTimeoutFlagName,
+		circuitBreakerOpenTimeoutEnvKey, defaultCircuitBreakerOpenTimeout)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid value for parameter [%s]: %w",
+			circuitBreakerOpenTimeoutFlagName, err)
+	}
+
+	return failureThreshold, openTimeout, nil
+}
+
+func getInboxRateLimitParameters(cmd *cobra.Command) (ratelimiter.Limit, map[string]ratelimiter.Limit, error) {
+	var actorLimit ratelimiter.Limit
+
+	actorLimitStr, err := cmdutils.GetUserSetVarFromString(cmd, inboxRateLimitFlagName, inboxRateLimitEnvKey, true)
+	if err != nil {
+		return ratelimiter.Limit{}, nil, err
+	}
+
+	if actorLimitStr != "" {
+		actorLimit, err = parseRateLimit(actorLimitStr)
+		if err != nil {
+			return ratelimiter.Limit{}, nil, fmt.Errorf("invalid value for parameter [%s]: %w",
+				inboxRateLimitFlagName, err)
+		}
+	}
+
+	activityLimits := make(map[string]ratelimiter.Limit)
+
+	activityLimitsArr := cmdutils.GetUserSetOptionalVarFromArrayString(cmd, inboxActivityRateLimitsFlagName,
+		inboxActivityRateLimitsEnvKey)
+
+	for _, limitStr := range activityLimitsArr {
+		parts := strings.Split(limitStr, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return ratelimiter.Limit{}, nil, fmt.Errorf("invalid value for parameter [%s]: %s",
+				inboxActivityRateLimitsFlagName, limitStr)
+		}
+
+		limit, err := parseRateLimit(parts[1])
+		if err != nil {
+			return ratelimiter.Limit{}, nil, fmt.Errorf("invalid value for parameter [%s]: %w",
+				inboxActivityRateLimitsFlagName, err)
+		}
+
+		activityLimits[parts[0]] = limit
+	}
+
+	return actorLimit, activityLimits, nil
+}
+
+// parseRateLimit parses a rate limit in the format rate[:burst].
+func parseRateLimit(limitStr string) (ratelimiter.Limit, error) {
+	parts := strings.Split(limitStr, ":")
+	if len(parts) > 2 {
+		return ratelimiter.Limit{}, fmt.Errorf("invalid rate limit [%s]", limitStr)
+	}
+
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate <= 0 {
+		return ratelimiter.Limit{}, fmt.Errorf("invalid rate [%s]: must be a number greater than 0", parts[0])
+	}
+
+	limit := ratelimiter.Limit{Rate: rate}
+
+	if len(parts) == 2 {
+		limit.Burst, err = strconv.Atoi(parts[1])
+		if err != nil || limit.Burst <= 0 {
+			return ratelimiter.Limit{}, fmt.Errorf("invalid burst [%s]: must be an integer greater than 0", parts[1])
+		}
+	}
+
+	return limit, nil
+}
+
+func getWitnessSelectionStrategy(cmd *cobra.Command) (string, error) {
+	strategy, err := cmdutils.GetUserSetVarFromString(cmd, witnessSelectionStrategyFlagName,
+		witnessSelectionStrategyEnvKey, true)
+	if err != nil {
+		return "", err
+	}
+
+	strategy = strings.ToLower(strategy)
+
+	switch strategy {
+	case "":
+		return witnessSelectionStrategyRandom, nil
+	case witnessSelectionStrategyRandom, witnessSelectionStrategyRoundRobin, witnessSelectionStrategyLeastRecentlyUsed,
+		witnessSelectionStrategyLowestLatency, witnessSelectionStrategyFewestOutstandingOffers:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid value for parameter [%s]: %s", witnessSelectionStrategyFlagName, strategy)
+	}
+}
+
+func getWitnessHealthParameters(cmd *cobra.Command) (threshold float64, backoff, maxBackoff time.Duration,
+	err error) {
+	threshold, err = getFloat(cmd, witnessHealthThresholdFlagName, witnessHealthThresholdEnvKey,
+		defaultWitnessHealthThreshold)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if threshold < 0 || threshold > 1 {
+		return 0, 0, 0, fmt.Errorf("invalid value for parameter [%s]: %g (must be between 0 and 1)",
+			witnessHealthThresholdFlagName, threshold)
+	}
+
+	backoff, err = getDuration(cmd, witnessHealthBackoffFlagName, witnessHealthBackoffEnvKey,
+		defaultWitnessHealthBackoff)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%s: %w", witnessHealthBackoffFlagName, err)
+	}
+
+	maxBackoff, err = getDuration(cmd, witnessHealthMaxBackoffFlagName, witnessHealthMaxBackoffEnvKey,
+		defaultWitnessHealthMaxBackoff)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%s: %w", witnessHealthMaxBackoffFlagName, err)
+	}
+
+	if maxBackoff < backoff {
+		return 0, 0, 0, fmt.Errorf("invalid value for parameter [%s]: %s (must not be less than %s)",
+			witnessHealthMaxBackoffFlagName, maxBackoff, backoff)
+	}
+
+	return threshold, backoff, maxBackoff, nil
+}
+
+func getHTTPSignatureSchemeParameters(cmd *cobra.Command) (httpsig.SignatureScheme,
+	map[string]httpsig.SignatureScheme, error) {
+	schemeStr, err := cmdutils.GetUserSetVarFromString(cmd, httpSignatureSchemeFlagName,
+		httpSignatureSchemeEnvKey, true)
+	if err != nil {
+		return "", nil, err
+	}
+
+	scheme := httpsig.SchemeCavage
+
+	if schemeStr != "" {
+		scheme, err = httpsig.ParseSignatureScheme(strings.ToLower(schemeStr))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid value for parameter [%s]: %w", httpSignatureSchemeFlagName, err)
+		}
+	}
+
+	peerSchemes := make(map[string]httpsig.SignatureScheme)
+
+	peerSchemesArr := cmdutils.GetUserSetOptionalVarFromArrayString(cmd, httpSignaturePeerSchemesFlagName,
+		httpSignaturePeerSchemesEnvKey)
+
+	for _, peerSchemeStr := range peerSchemesArr {
+		parts := strings.Split(peerSchemeStr, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return "", nil, fmt.Errorf("invalid value for parameter [%s]: %s",
+				httpSignaturePeerSchemesFlagName, peerSchemeStr)
+		}
+
+		peerScheme, parseErr := httpsig.ParseSignatureScheme(strings.ToLower(parts[1]))
+		if parseErr != nil {
+			return "", nil, fmt.Errorf("invalid value for parameter [%s]: %w",
+				httpSignaturePeerSchemesFlagName, parseErr)
+		}
+
+		peerSchemes[strings.ToLower(parts[0])] = peerScheme
+	}
+
+	return scheme, peerSchemes, nil
+}
+
+type activityRetentionParameters struct {
+	periods     map[activitypubspi.ReferenceType]time.Duration
+	archiveType string
+	archiveDir  string
+}
+
+func getActivityRetentionParameters(cmd *cobra.Command) (*activityRetentionParameters, error) {
+	periods := make(map[activitypubspi.ReferenceType]time.Duration)
+
+	periodsArr := cmdutils.GetUserSetOptionalVarFromArrayString(cmd, activityRetentionPeriodsFlagName,
+		activityRetentionPeriodsEnvKey)
+
+	for _, periodStr := range periodsArr {
+		parts := strings.Split(periodStr, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid value for parameter [%s]: %s",
+				activityRetentionPeriodsFlagName, periodStr)
+		}
+
+		period, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter [%s]: %w", activityRetentionPeriodsFlagName, err)
+		}
+
+		periods[activitypubspi.ReferenceType(strings.ToUpper(parts[0]))] = period
+	}
+
+	if err := apariesstore.ValidateRetentionPeriods(periods); err != nil {
+		return nil, fmt.Errorf("invalid value for parameter [%s]: %w", activityRetentionPeriodsFlagName, err)
+	}
+
+	archiveType, err := cmdutils.GetUserSetVarFromString(cmd, activityArchiveTypeFlagName,
+		activityArchiveTypeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveType = strings.ToLower(archiveType)
+
+	var archiveDir string
+
+	switch archiveType {
+	case "", activityArchiveTypeCASOption:
+	case activityArchiveTypeFileOption:
+		archiveDir, err = cmdutils.GetUserSetVarFromString(cmd, activityArchiveDirFlagName,
+			activityArchiveDirEnvKey, false)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid value for parameter [%s]: %s", activityArchiveTypeFlagName, archiveType)
+	}
+
+	return &activityRetentionParameters{
+		periods:     periods,
+		archiveType: archiveType,
+		archiveDir:  archiveDir,
+	}, nil
+}
+
 func getAnchorSyncParameters(cmd *cobra.Command) (syncPeriod, minActivityAge time.Duration, err error) {
 	syncPeriod, err = getDuration(cmd, anchorSyncIntervalFlagName, anchorSyncIntervalEnvKey, defaultAnchorSyncInterval)
 	if err != nil {
@@ -1804,6 +2292,9 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(witnessStoreExpiryPeriodFlagName, "", "", witnessStoreExpiryPeriodFlagUsage)
 	startCmd.Flags().StringP(signWithLocalWitnessFlagName, signWithLocalWitnessFlagShorthand, "", signWithLocalWitnessFlagUsage)
 	startCmd.Flags().StringP(httpSignaturesEnabledFlagName, httpSignaturesEnabledShorthand, "", httpSignaturesEnabledUsage)
+	startCmd.Flags().StringP(httpSignatureSchemeFlagName, "", "", httpSignatureSchemeFlagUsage)
+	startCmd.Flags().StringArrayP(httpSignaturePeerSchemesFlagName, "", []string{}, httpSignaturePeerSchemesFlagUsage)
+	startCmd.Flags().StringP(httpSignatureMaxClockSkewFlagName, "", "", httpSignatureMaxClockSkewFlagUsage)
 	startCmd.Flags().String(enableDidDiscoveryFlagName, "", enableDidDiscoveryUsage)
 	startCmd.Flags().String(enableUnpublishedOperationStoreFlagName, "", enableUnpublishedOperationStoreUsage)
 	startCmd.Flags().String(unpublishedOperationStoreOperationTypesFlagName, "", unpublishedOperationStoreOperationTypesUsage)
@@ -1852,12 +2343,15 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringArrayP(clientAuthTokensDefFlagName, "", nil, clientAuthTokensDefFlagUsage)
 	startCmd.Flags().StringArrayP(clientAuthTokensFlagName, "", nil, clientAuthTokensFlagUsage)
 	startCmd.Flags().StringP(activityPubPageSizeFlagName, activityPubPageSizeFlagShorthand, "", activityPubPageSizeFlagUsage)
+	startCmd.Flags().StringArrayP(alsoKnownAsFlagName, "", []string{}, alsoKnownAsFlagUsage)
 	startCmd.Flags().String(devModeEnabledFlagName, "false", devModeEnabledUsage)
+	startCmd.Flags().String(activityProofsEnabledFlagName, "false", activityProofsEnabledUsage)
 	startCmd.Flags().StringP(nodeInfoRefreshIntervalFlagName, nodeInfoRefreshIntervalFlagShorthand, "", nodeInfoRefreshIntervalFlagUsage)
 	startCmd.Flags().StringP(ipfsTimeoutFlagName, ipfsTimeoutFlagShorthand, "", ipfsTimeoutFlagUsage)
 	startCmd.Flags().StringArrayP(contextProviderFlagName, "", []string{}, contextProviderFlagUsage)
 	startCmd.Flags().StringP(databaseTimeoutFlagName, "", "", databaseTimeoutFlagUsage)
 	startCmd.Flags().StringP(unpublishedOperationLifespanFlagName, "", "", unpublishedOperationLifespanFlagUsage)
+	startCmd.Flags().StringP(operationStatusLifespanFlagName, "", "", operationStatusLifespanFlagUsage)
 	startCmd.Flags().StringP(taskMgrCheckIntervalFlagName, "", "", taskMgrCheckIntervalFlagUsage)
 	startCmd.Flags().StringP(dataExpiryCheckIntervalFlagName, "", "", dataExpiryCheckIntervalFlagUsage)
 	startCmd.Flags().StringP(followAuthPolicyFlagName, followAuthPolicyFlagShorthand, "", followAuthPolicyFlagUsage)
@@ -1870,10 +2364,21 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(anchorStatusMonitoringIntervalFlagName, "", "", anchorStatusMonitoringIntervalFlagUsage)
 	startCmd.Flags().StringP(anchorStatusInProcessGracePeriodFlagName, "", "", anchorStatusInProcessGracePeriodFlagUsage)
 	startCmd.Flags().StringP(witnessPolicyCacheExpirationFlagName, "", "", witnessPolicyCacheExpirationFlagUsage)
+	startCmd.Flags().StringP(witnessSelectionStrategyFlagName, "", "", witnessSelectionStrategyFlagUsage)
+	startCmd.Flags().StringP(witnessHealthThresholdFlagName, "", "", witnessHealthThresholdFlagUsage)
+	startCmd.Flags().StringP(witnessHealthBackoffFlagName, "", "", witnessHealthBackoffFlagUsage)
+	startCmd.Flags().StringP(witnessHealthMaxBackoffFlagName, "", "", witnessHealthMaxBackoffFlagUsage)
 	startCmd.Flags().StringP(activityPubClientCacheSizeFlagName, "", "", activityPubClientCacheSizeFlagUsage)
 	startCmd.Flags().StringP(activityPubIRICacheSizeFlagName, "", "", activityPubIRICacheSizeFlagUsage)
 	startCmd.Flags().StringP(activityPubIRICacheExpirationFlagName, "", "", activityPubIRICacheExpirationFlagUsage)
 	startCmd.Flags().StringP(activityPubClientCacheExpirationFlagName, "", "", activityPubClientCacheExpirationFlagUsage)
+	startCmd.Flags().StringP(circuitBreakerFailureThresholdFlagName, "", "", circuitBreakerFailureThresholdFlagUsage)
+	startCmd.Flags().StringP(circuitBreakerOpenTimeoutFlagName, "", "", circuitBreakerOpenTimeoutFlagUsage)
+	startCmd.Flags().StringP(inboxRateLimitFlagName, "", "", inboxRateLimitFlagUsage)
+	startCmd.Flags().StringArrayP(inboxActivityRateLimitsFlagName, "", []string{}, inboxActivityRateLimitsFlagUsage)
+	startCmd.Flags().StringArrayP(activityRetentionPeriodsFlagName, "", []string{}, activityRetentionPeriodsFlagUsage)
+	startCmd.Flags().StringP(activityArchiveTypeFlagName, "", "", activityArchiveTypeFlagUsage)
+	startCmd.Flags().StringP(activityArchiveDirFlagName, "", "", activityArchiveDirFlagUsage)
 	startCmd.Flags().StringP(serverIdleTimeoutFlagName, "", "", serverIdleTimeoutFlagUsage)
 	startCmd.Flags().StringP(anchorAttachmentMediaTypeFlagName, "", "", anchorAttachmentMediaTypeFlagUsage)
 	startCmd.Flags().String(sidetreeProtocolVersionsFlagName, "", sidetreeProtocolVersionsUsage)
@@ -19,6 +19,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/httpsig"
+	"github.com/trustbloc/orb/pkg/activitypub/service/ratelimiter"
+	activitypubspi "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/store/expiry"
+	"github.com/trustbloc/orb/pkg/taskmgr"
 )
 
 func TestStartCmdContents(t *testing.T) {
@@ -403,6 +409,67 @@ func TestStartCmdWithMissingArg(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid value for enable-http-signatures")
 	})
 
+	t.Run("test invalid http-signature-max-clock-skew", func(t *testing.T) {
+		for value, errMsg := range map[string]string{
+			"invalid": "http-signature-max-clock-skew: invalid value [invalid]",
+			"-1m":     "http-signature-max-clock-skew: value must be greater than 0",
+		} {
+			startCmd := GetStartCmd()
+
+			args := []string{
+				"--" + hostURLFlagName, "localhost:8247",
+				"--" + hostMetricsURLFlagName, "localhost:8248",
+				"--" + vctURLFlagName, "localhost:8081",
+				"--" + externalEndpointFlagName, "orb.example.com",
+				"--" + casTypeFlagName, "ipfs",
+				"--" + ipfsURLFlagName, "localhost:8081",
+				"--" + didNamespaceFlagName, "namespace", "--" + databaseTypeFlagName, databaseTypeMemOption,
+				"--" + kmsSecretsDatabaseTypeFlagName, databaseTypeMemOption,
+				"--" + anchorCredentialSignatureSuiteFlagName, "suite",
+				"--" + anchorCredentialDomainFlagName, "domain.com",
+				"--" + anchorCredentialIssuerFlagName, "issuer.com",
+				"--" + anchorCredentialURLFlagName, "peer.com",
+				"--" + LogLevelFlagName, log.ParseString(log.ERROR),
+				"--" + httpSignatureMaxClockSkewFlagName, value,
+			}
+
+			startCmd.SetArgs(args)
+
+			err := startCmd.Execute()
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), errMsg)
+		}
+	})
+
+	t.Run("test invalid enable-activity-proofs", func(t *testing.T) {
+		startCmd := GetStartCmd()
+
+		args := []string{
+			"--" + hostURLFlagName, "localhost:8247",
+			"--" + hostMetricsURLFlagName, "localhost:8248",
+			"--" + vctURLFlagName, "localhost:8081",
+			"--" + externalEndpointFlagName, "orb.example.com",
+			"--" + casTypeFlagName, "ipfs",
+			"--" + ipfsURLFlagName, "localhost:8081",
+			"--" + didNamespaceFlagName, "namespace", "--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + kmsSecretsDatabaseTypeFlagName, databaseTypeMemOption,
+			"--" + anchorCredentialSignatureSuiteFlagName, "suite",
+			"--" + anchorCredentialDomainFlagName, "domain.com",
+			"--" + anchorCredentialIssuerFlagName, "issuer.com",
+			"--" + anchorCredentialURLFlagName, "peer.com",
+			"--" + LogLevelFlagName, log.ParseString(log.ERROR),
+			"--" + activityProofsEnabledFlagName, "invalid bool",
+		}
+
+		startCmd.SetArgs(args)
+
+		err := startCmd.Execute()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid value for enable-activity-proofs")
+	})
+
 	t.Run("test invalid enable-did-discovery", func(t *testing.T) {
 		startCmd := GetStartCmd()
 
@@ -636,6 +703,19 @@ func TestStartCmdWithMissingArg(t *testing.T) {
 		require.Contains(t, err.Error(), "missing unit in duration")
 	})
 
+	t.Run("Invalid operation status lifespan", func(t *testing.T) {
+		restoreEnv := setEnv(t, operationStatusLifespanEnvKey, "5")
+		defer restoreEnv()
+
+		startCmd := GetStartCmd()
+
+		startCmd.SetArgs(getTestArgs("localhost:8081", "local", "false", databaseTypeMemOption, ""))
+
+		err := startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing unit in duration")
+	})
+
 	t.Run("Invalid expiry check interval", func(t *testing.T) {
 		restoreEnv := setEnv(t, dataExpiryCheckIntervalEnvKey, "5")
 		defer restoreEnv()
@@ -1062,6 +1142,37 @@ func TestStartCmdWithInvalidCASType(t *testing.T) {
 	require.EqualError(t, err, "InvalidName is not a valid CAS type. It must be either local or ipfs")
 }
 
+func TestGetAlsoKnownAs(t *testing.T) {
+	t.Run("Not specified -> empty", func(t *testing.T) {
+		cmd := getTestCmd(t)
+
+		alsoKnownAs, err := getAlsoKnownAs(cmd)
+		require.NoError(t, err)
+		require.Empty(t, alsoKnownAs)
+	})
+
+	t.Run("Invalid value -> error", func(t *testing.T) {
+		cmd := getTestCmd(t, "--"+alsoKnownAsFlagName, ":invalid")
+
+		_, err := getAlsoKnownAs(cmd)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid IRI")
+	})
+
+	t.Run("Valid value -> success", func(t *testing.T) {
+		cmd := getTestCmd(t,
+			"--"+alsoKnownAsFlagName, "https://old1.example.com/services/orb",
+			"--"+alsoKnownAsFlagName, "https://old2.example.com/services/orb",
+		)
+
+		alsoKnownAs, err := getAlsoKnownAs(cmd)
+		require.NoError(t, err)
+		require.Len(t, alsoKnownAs, 2)
+		require.Equal(t, "https://old1.example.com/services/orb", alsoKnownAs[0].String())
+		require.Equal(t, "https://old2.example.com/services/orb", alsoKnownAs[1].String())
+	})
+}
+
 func TestGetActivityPubPageSize(t *testing.T) {
 	t.Run("Not specified -> default value", func(t *testing.T) {
 		cmd := getTestCmd(t)
@@ -1366,6 +1477,63 @@ func TestCreateActivityPubStore(t *testing.T) {
 	})
 }
 
+func TestGetActivityPubStoreOptions(t *testing.T) {
+	expiryService := expiry.NewService(taskmgr.New(&storage.MockStore{}, time.Second), time.Second)
+
+	t.Run("Retention not enabled", func(t *testing.T) {
+		opts, err := getActivityPubStoreOptions(&activityRetentionParameters{}, expiryService, nil,
+			ariesmemstorage.NewProvider())
+		require.NoError(t, err)
+		require.Empty(t, opts)
+	})
+
+	t.Run("Retention with archive", func(t *testing.T) {
+		periods := map[activitypubspi.ReferenceType]time.Duration{activitypubspi.Like: time.Hour}
+
+		opts, err := getActivityPubStoreOptions(&activityRetentionParameters{
+			periods:     periods,
+			archiveType: activityArchiveTypeCASOption,
+		}, expiryService, nil, ariesmemstorage.NewProvider())
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+
+		opts, err = getActivityPubStoreOptions(&activityRetentionParameters{
+			periods:     periods,
+			archiveType: activityArchiveTypeFileOption,
+			archiveDir:  t.TempDir(),
+		}, expiryService, nil, ariesmemstorage.NewProvider())
+		require.NoError(t, err)
+		require.Len(t, opts, 1)
+
+		activityPubStore, err := createActivityPubStore(
+			&storageProvider{ariesmemstorage.NewProvider(), databaseTypeCouchDBOption},
+			"serviceEndpoint", opts...)
+		require.NoError(t, err)
+		require.NotNil(t, activityPubStore)
+	})
+
+	t.Run("Archive error", func(t *testing.T) {
+		periods := map[activitypubspi.ReferenceType]time.Duration{activitypubspi.Like: time.Hour}
+
+		p := storage.NewMockStoreProvider()
+		p.ErrOpenStoreHandle = errors.New("injected open store error")
+
+		_, err := getActivityPubStoreOptions(&activityRetentionParameters{
+			periods:     periods,
+			archiveType: activityArchiveTypeCASOption,
+		}, expiryService, nil, p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create CAS activity archiver")
+
+		_, err = getActivityPubStoreOptions(&activityRetentionParameters{
+			periods:     periods,
+			archiveType: activityArchiveTypeFileOption,
+		}, expiryService, nil, ariesmemstorage.NewProvider())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create file activity archiver")
+	})
+}
+
 func TestGetFollowAuthParameters(t *testing.T) {
 	t.Run("Valid env value -> error", func(t *testing.T) {
 		restoreEnv := setEnv(t, followAuthPolicyEnvKey, string(acceptListPolicy))
@@ -1556,6 +1724,418 @@ func TestGetActivityPubIRICacheParameters(t *testing.T) {
 	})
 }
 
+func TestGetCircuitBreakerParameters(t *testing.T) {
+	t.Run("Valid env value", func(t *testing.T) {
+		restoreThresholdEnv := setEnv(t, circuitBreakerFailureThresholdEnvKey, "10")
+		restoreTimeoutEnv := setEnv(t, circuitBreakerOpenTimeoutEnvKey, "5m")
+
+		defer func() {
+			restoreThresholdEnv()
+			restoreTimeoutEnv()
+		}()
+
+		cmd := getTestCmd(t)
+
+		threshold, timeout, err := getCircuitBreakerParameters(cmd)
+		require.NoError(t, err)
+		require.Equal(t, 10, threshold)
+		require.Equal(t, 5*time.Minute, timeout)
+	})
+
+	t.Run("Not specified -> default value", func(t *testing.T) {
+		cmd := getTestCmd(t)
+
+		threshold, timeout, err := getCircuitBreakerParameters(cmd)
+		require.NoError(t, err)
+		require.Equal(t, defaultCircuitBreakerFailureThreshold, threshold)
+		require.Equal(t, defaultCircuitBreakerOpenTimeout, timeout)
+	})
+
+	t.Run("Invalid env value -> error", func(t *testing.T) {
+		t.Run("Invalid number for failure threshold", func(t *testing.T) {
+			restoreEnv := setEnv(t, circuitBreakerFailureThresholdEnvKey, "invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getCircuitBreakerParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for circuit-breaker-failure-threshold [invalid]")
+		})
+
+		t.Run("Failure threshold less than 1", func(t *testing.T) {
+			restoreEnv := setEnv(t, circuitBreakerFailureThresholdEnvKey, "0")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getCircuitBreakerParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(),
+				"value for parameter [circuit-breaker-failure-threshold] must be greater than 0")
+		})
+
+		t.Run("Invalid open timeout", func(t *testing.T) {
+			restoreEnv := setEnv(t, circuitBreakerOpenTimeoutEnvKey, "invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getCircuitBreakerParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [circuit-breaker-open-timeout]")
+		})
+	})
+}
+
+func TestGetInboxRateLimitParameters(t *testing.T) {
+	t.Run("Valid env value", func(t *testing.T) {
+		restoreLimitEnv := setEnv(t, inboxRateLimitEnvKey, "10:50")
+		restoreActivityLimitsEnv := setEnv(t, inboxActivityRateLimitsEnvKey, "Create=0.5,Announce=2:10")
+
+		defer func() {
+			restoreLimitEnv()
+			restoreActivityLimitsEnv()
+		}()
+
+		cmd := getTestCmd(t)
+
+		limit, activityLimits, err := getInboxRateLimitParameters(cmd)
+		require.NoError(t, err)
+		require.Equal(t, ratelimiter.Limit{Rate: 10, Burst: 50}, limit)
+		require.Len(t, activityLimits, 2)
+		require.Equal(t, ratelimiter.Limit{Rate: 0.5}, activityLimits["Create"])
+		require.Equal(t, ratelimiter.Limit{Rate: 2, Burst: 10}, activityLimits["Announce"])
+	})
+
+	t.Run("Not specified -> disabled", func(t *testing.T) {
+		cmd := getTestCmd(t)
+
+		limit, activityLimits, err := getInboxRateLimitParameters(cmd)
+		require.NoError(t, err)
+		require.False(t, limit.Enabled())
+		require.Empty(t, activityLimits)
+	})
+
+	t.Run("Invalid env value -> error", func(t *testing.T) {
+		t.Run("Invalid rate", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxRateLimitEnvKey, "invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [inbox-rate-limit]")
+		})
+
+		t.Run("Rate less than or equal to 0", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxRateLimitEnvKey, "0")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "must be a number greater than 0")
+		})
+
+		t.Run("Invalid burst", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxRateLimitEnvKey, "10:x")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid burst [x]")
+		})
+
+		t.Run("Too many parts", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxRateLimitEnvKey, "10:5:1")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid rate limit [10:5:1]")
+		})
+
+		t.Run("Invalid activity limit format", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxActivityRateLimitsEnvKey, "Create")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [inbox-activity-rate-limits]: Create")
+		})
+
+		t.Run("Invalid activity limit", func(t *testing.T) {
+			restoreEnv := setEnv(t, inboxActivityRateLimitsEnvKey, "Create=x")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getInboxRateLimitParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [inbox-activity-rate-limits]")
+		})
+	})
+}
+
+func TestGetHTTPSignatureSchemeParameters(t *testing.T) {
+	t.Run("Valid env value", func(t *testing.T) {
+		restoreSchemeEnv := setEnv(t, httpSignatureSchemeEnvKey, "RFC9421")
+		restorePeerSchemesEnv := setEnv(t, httpSignaturePeerSchemesEnvKey,
+			"orb.domain1.com=draft-cavage,orb.domain2.com:8443=rfc9421")
+
+		defer func() {
+			restoreSchemeEnv()
+			restorePeerSchemesEnv()
+		}()
+
+		cmd := getTestCmd(t)
+
+		scheme, peerSchemes, err := getHTTPSignatureSchemeParameters(cmd)
+		require.NoError(t, err)
+		require.Equal(t, httpsig.SchemeRFC9421, scheme)
+		require.Len(t, peerSchemes, 2)
+		require.Equal(t, httpsig.SchemeCavage, peerSchemes["orb.domain1.com"])
+		require.Equal(t, httpsig.SchemeRFC9421, peerSchemes["orb.domain2.com:8443"])
+	})
+
+	t.Run("Not specified -> default value", func(t *testing.T) {
+		cmd := getTestCmd(t)
+
+		scheme, peerSchemes, err := getHTTPSignatureSchemeParameters(cmd)
+		require.NoError(t, err)
+		require.Equal(t, httpsig.SchemeCavage, scheme)
+		require.Empty(t, peerSchemes)
+	})
+
+	t.Run("Invalid env value -> error", func(t *testing.T) {
+		t.Run("Invalid scheme", func(t *testing.T) {
+			restoreEnv := setEnv(t, httpSignatureSchemeEnvKey, "invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getHTTPSignatureSchemeParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [http-signature-scheme]")
+		})
+
+		t.Run("Invalid peer scheme format", func(t *testing.T) {
+			restoreEnv := setEnv(t, httpSignaturePeerSchemesEnvKey, "orb.domain1.com")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getHTTPSignatureSchemeParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [http-signature-peer-schemes]")
+		})
+
+		t.Run("Invalid peer scheme", func(t *testing.T) {
+			restoreEnv := setEnv(t, httpSignaturePeerSchemesEnvKey, "orb.domain1.com=invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, _, err := getHTTPSignatureSchemeParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "unsupported HTTP signature scheme [invalid]")
+		})
+	})
+
+}
+
+func TestGetWitnessSelectionStrategy(t *testing.T) {
+	t.Run("Valid env value", func(t *testing.T) {
+		restoreEnv := setEnv(t, witnessSelectionStrategyEnvKey, "Lowest-Latency")
+		defer restoreEnv()
+
+		strategy, err := getWitnessSelectionStrategy(getTestCmd(t))
+		require.NoError(t, err)
+		require.Equal(t, witnessSelectionStrategyLowestLatency, strategy)
+	})
+
+	t.Run("Not specified -> default value", func(t *testing.T) {
+		strategy, err := getWitnessSelectionStrategy(getTestCmd(t))
+		require.NoError(t, err)
+		require.Equal(t, witnessSelectionStrategyRandom, strategy)
+	})
+
+	t.Run("Invalid env value -> error", func(t *testing.T) {
+		restoreEnv := setEnv(t, witnessSelectionStrategyEnvKey, "invalid")
+		defer restoreEnv()
+
+		_, err := getWitnessSelectionStrategy(getTestCmd(t))
+		require.EqualError(t, err, "invalid value for parameter [witness-selection-strategy]: invalid")
+	})
+}
+
+func TestGetWitnessHealthParameters(t *testing.T) {
+	t.Run("Valid env values", func(t *testing.T) {
+		restoreThresholdEnv := setEnv(t, witnessHealthThresholdEnvKey, "0.7")
+		restoreBackoffEnv := setEnv(t, witnessHealthBackoffEnvKey, "30s")
+		restoreMaxBackoffEnv := setEnv(t, witnessHealthMaxBackoffEnvKey, "10m")
+
+		defer func() {
+			restoreThresholdEnv()
+			restoreBackoffEnv()
+			restoreMaxBackoffEnv()
+		}()
+
+		threshold, backoff, maxBackoff, err := getWitnessHealthParameters(getTestCmd(t))
+		require.NoError(t, err)
+		require.Equal(t, 0.7, threshold)
+		require.Equal(t, 30*time.Second, backoff)
+		require.Equal(t, 10*time.Minute, maxBackoff)
+	})
+
+	t.Run("Not specified -> default values", func(t *testing.T) {
+		threshold, backoff, maxBackoff, err := getWitnessHealthParameters(getTestCmd(t))
+		require.NoError(t, err)
+		require.Equal(t, defaultWitnessHealthThreshold, threshold)
+		require.Equal(t, defaultWitnessHealthBackoff, backoff)
+		require.Equal(t, defaultWitnessHealthMaxBackoff, maxBackoff)
+	})
+
+	t.Run("Invalid threshold -> error", func(t *testing.T) {
+		restoreEnv := setEnv(t, witnessHealthThresholdEnvKey, "1.5")
+		defer restoreEnv()
+
+		_, _, _, err := getWitnessHealthParameters(getTestCmd(t))
+		require.EqualError(t, err,
+			"invalid value for parameter [witness-health-threshold]: 1.5 (must be between 0 and 1)")
+	})
+
+	t.Run("Invalid backoff -> error", func(t *testing.T) {
+		restoreEnv := setEnv(t, witnessHealthBackoffEnvKey, "invalid")
+		defer restoreEnv()
+
+		_, _, _, err := getWitnessHealthParameters(getTestCmd(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "witness-health-backoff: invalid value [invalid]")
+	})
+
+	t.Run("Max backoff less than backoff -> error", func(t *testing.T) {
+		restoreEnv := setEnv(t, witnessHealthMaxBackoffEnvKey, "10s")
+		defer restoreEnv()
+
+		_, _, _, err := getWitnessHealthParameters(getTestCmd(t))
+		require.EqualError(t, err,
+			"invalid value for parameter [witness-health-max-backoff]: 10s (must not be less than 1m0s)")
+	})
+}
+
+func TestGetActivityRetentionParameters(t *testing.T) {
+	t.Run("Valid env value", func(t *testing.T) {
+		restorePeriodsEnv := setEnv(t, activityRetentionPeriodsEnvKey, "like=720h,SHARE=2160h,inbox=48h,OUTBOX=96h")
+		restoreArchiveTypeEnv := setEnv(t, activityArchiveTypeEnvKey, "file")
+		restoreArchiveDirEnv := setEnv(t, activityArchiveDirEnvKey, "/tmp/archive")
+
+		defer func() {
+			restorePeriodsEnv()
+			restoreArchiveTypeEnv()
+			restoreArchiveDirEnv()
+		}()
+
+		cmd := getTestCmd(t)
+
+		params, err := getActivityRetentionParameters(cmd)
+		require.NoError(t, err)
+		require.Len(t, params.periods, 4)
+		require.Equal(t, 720*time.Hour, params.periods[activitypubspi.Like])
+		require.Equal(t, 2160*time.Hour, params.periods[activitypubspi.Share])
+		require.Equal(t, 48*time.Hour, params.periods[activitypubspi.Inbox])
+		require.Equal(t, 96*time.Hour, params.periods[activitypubspi.Outbox])
+		require.Equal(t, activityArchiveTypeFileOption, params.archiveType)
+		require.Equal(t, "/tmp/archive", params.archiveDir)
+	})
+
+	t.Run("Not specified -> disabled", func(t *testing.T) {
+		cmd := getTestCmd(t)
+
+		params, err := getActivityRetentionParameters(cmd)
+		require.NoError(t, err)
+		require.Empty(t, params.periods)
+		require.Empty(t, params.archiveType)
+	})
+
+	t.Run("Invalid env value -> error", func(t *testing.T) {
+		t.Run("Invalid period format", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityRetentionPeriodsEnvKey, "LIKE")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [activity-retention-periods]: LIKE")
+		})
+
+		t.Run("Invalid period", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityRetentionPeriodsEnvKey, "LIKE=x")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [activity-retention-periods]")
+		})
+
+		t.Run("Period less than or equal to 0", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityRetentionPeriodsEnvKey, "LIKE=0s")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "retention period for reference type [LIKE] must be greater than 0")
+		})
+
+		t.Run("Unsupported reference type", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityRetentionPeriodsEnvKey, "FOLLOWER=720h")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "retention is not supported for reference type [FOLLOWER]")
+		})
+
+		t.Run("Invalid archive type", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityArchiveTypeEnvKey, "invalid")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid value for parameter [activity-archive-type]: invalid")
+		})
+
+		t.Run("Archive directory not specified", func(t *testing.T) {
+			restoreEnv := setEnv(t, activityArchiveTypeEnvKey, "file")
+			defer restoreEnv()
+
+			cmd := getTestCmd(t)
+
+			_, err := getActivityRetentionParameters(cmd)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "activity-archive-dir")
+		})
+	})
+}
+
 func setEnvVars(t *testing.T, databaseType, casType, replicateLocalCASToIPFS string) {
 	t.Helper()
 
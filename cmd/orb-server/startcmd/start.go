@@ -69,14 +69,22 @@ import (
 	"github.com/trustbloc/orb/pkg/activitypub/client"
 	"github.com/trustbloc/orb/pkg/activitypub/client/transport"
 	"github.com/trustbloc/orb/pkg/activitypub/httpsig"
+	"github.com/trustbloc/orb/pkg/activitypub/ldproof"
 	aphandler "github.com/trustbloc/orb/pkg/activitypub/resthandler"
 	apservice "github.com/trustbloc/orb/pkg/activitypub/service"
 	"github.com/trustbloc/orb/pkg/activitypub/service/acceptlist"
 	"github.com/trustbloc/orb/pkg/activitypub/service/activityhandler"
 	"github.com/trustbloc/orb/pkg/activitypub/service/anchorsynctask"
+	"github.com/trustbloc/orb/pkg/activitypub/service/backfill"
+	"github.com/trustbloc/orb/pkg/activitypub/service/blocklist"
+	"github.com/trustbloc/orb/pkg/activitypub/service/circuitbreaker"
+	"github.com/trustbloc/orb/pkg/activitypub/service/deadletter"
 	"github.com/trustbloc/orb/pkg/activitypub/service/monitoring"
+	"github.com/trustbloc/orb/pkg/activitypub/service/moveregistry"
+	"github.com/trustbloc/orb/pkg/activitypub/service/ratelimiter"
 	apspi "github.com/trustbloc/orb/pkg/activitypub/service/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/service/vct"
+	activityarchive "github.com/trustbloc/orb/pkg/activitypub/store/archive"
 	apariesstore "github.com/trustbloc/orb/pkg/activitypub/store/ariesstore"
 	apmemstore "github.com/trustbloc/orb/pkg/activitypub/store/memstore"
 	activitypubspi "github.com/trustbloc/orb/pkg/activitypub/store/spi"
@@ -88,9 +96,14 @@ import (
 	"github.com/trustbloc/orb/pkg/anchor/handler/credential"
 	"github.com/trustbloc/orb/pkg/anchor/handler/proof"
 	"github.com/trustbloc/orb/pkg/anchor/linkstore"
+	anchorstatushandler "github.com/trustbloc/orb/pkg/anchor/status/resthandler"
+	witnesshealthhandler "github.com/trustbloc/orb/pkg/anchor/witness/health/resthandler"
+	witnessmetadata "github.com/trustbloc/orb/pkg/anchor/witness/metadata"
+	witnessmetadatahandler "github.com/trustbloc/orb/pkg/anchor/witness/metadata/resthandler"
 	"github.com/trustbloc/orb/pkg/anchor/witness/policy"
 	"github.com/trustbloc/orb/pkg/anchor/witness/policy/inspector"
 	policyhandler "github.com/trustbloc/orb/pkg/anchor/witness/policy/resthandler"
+	selectorstats "github.com/trustbloc/orb/pkg/anchor/witness/policy/selector/stats"
 	"github.com/trustbloc/orb/pkg/anchor/writer"
 	"github.com/trustbloc/orb/pkg/cas/extendedcasclient"
 	ipfscas "github.com/trustbloc/orb/pkg/cas/ipfs"
@@ -104,10 +117,14 @@ import (
 	localdiscovery "github.com/trustbloc/orb/pkg/discovery/did/local"
 	discoveryclient "github.com/trustbloc/orb/pkg/discovery/endpoint/client"
 	discoveryrest "github.com/trustbloc/orb/pkg/discovery/endpoint/restapi"
+	"github.com/trustbloc/orb/pkg/document/historyhandler"
 	"github.com/trustbloc/orb/pkg/document/remoteresolver"
 	"github.com/trustbloc/orb/pkg/document/resolvehandler"
+	"github.com/trustbloc/orb/pkg/document/statushandler"
 	"github.com/trustbloc/orb/pkg/document/updatehandler"
 	"github.com/trustbloc/orb/pkg/document/updatehandler/decorator"
+	"github.com/trustbloc/orb/pkg/eventstream"
+	eventstreamhandler "github.com/trustbloc/orb/pkg/eventstream/resthandler"
 	"github.com/trustbloc/orb/pkg/httpserver"
 	"github.com/trustbloc/orb/pkg/httpserver/auth"
 	"github.com/trustbloc/orb/pkg/httpserver/auth/signature"
@@ -125,15 +142,21 @@ import (
 	"github.com/trustbloc/orb/pkg/store/anchoreventstatus"
 	casstore "github.com/trustbloc/orb/pkg/store/cas"
 	didanchorstore "github.com/trustbloc/orb/pkg/store/didanchor"
+	embeddedstorage "github.com/trustbloc/orb/pkg/store/embedded"
 	"github.com/trustbloc/orb/pkg/store/expiry"
 	opstore "github.com/trustbloc/orb/pkg/store/operation"
 	unpublishedopstore "github.com/trustbloc/orb/pkg/store/operation/unpublished"
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
+	"github.com/trustbloc/orb/pkg/store/webhooksubscription"
 	proofstore "github.com/trustbloc/orb/pkg/store/witness"
+	"github.com/trustbloc/orb/pkg/store/witnessstats"
 	"github.com/trustbloc/orb/pkg/store/wrapper"
 	"github.com/trustbloc/orb/pkg/taskmgr"
 	"github.com/trustbloc/orb/pkg/vcsigner"
 	"github.com/trustbloc/orb/pkg/webcas"
 	wfclient "github.com/trustbloc/orb/pkg/webfinger/client"
+	"github.com/trustbloc/orb/pkg/webhook"
+	webhookhandler "github.com/trustbloc/orb/pkg/webhook/resthandler"
 )
 
 const (
@@ -151,6 +174,7 @@ const (
 	defaultVerifyLatestFromAnchorOrigin     = false
 	defaultLocalCASReplicateInIPFSEnabled   = false
 	defaultDevModeEnabled                   = false
+	defaultActivityProofsEnabled            = false
 	defaultCasCacheSize                     = 1000
 
 	unpublishedDIDLabel = "uAAA"
@@ -475,7 +499,10 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("create client Token Manager: %w", err)
 	}
 
-	apGetSigner, apPostSigner := getActivityPubSigners(parameters, km, cr)
+	apGetSigner, apPostSigner, err := getActivityPubSigners(parameters, km, cr)
+	if err != nil {
+		return fmt.Errorf("HTTP signatures are enabled but the key can't be used to sign requests: %w", err)
+	}
 
 	t := transport.New(httpClient, apServicePublicKeyIRI, apGetSigner, apPostSigner, clientTokenManager)
 
@@ -483,14 +510,18 @@ func startOrbServices(parameters *orbParameters) error {
 
 	webCASResolver := resolver.NewWebCASResolver(t, wfClient, webFingerURIScheme)
 
+	moveRegistry := moveregistry.New(configStore)
+
 	var ipfsReader *ipfscas.Client
 	var casResolver *resolver.Resolver
 	if parameters.ipfsURL != "" {
 		ipfsReader = ipfscas.New(parameters.ipfsURL, parameters.ipfsTimeout, defaultCasCacheSize, metrics.Get(),
 			extendedcasclient.WithCIDVersion(parameters.cidVersion))
-		casResolver = resolver.New(coreCASClient, ipfsReader, webCASResolver, metrics.Get())
+		casResolver = resolver.New(coreCASClient, ipfsReader, webCASResolver, metrics.Get(),
+			resolver.WithURLMapper(moveRegistry))
 	} else {
-		casResolver = resolver.New(coreCASClient, nil, webCASResolver, metrics.Get())
+		casResolver = resolver.New(coreCASClient, nil, webCASResolver, metrics.Get(),
+			resolver.WithURLMapper(moveRegistry))
 	}
 
 	graphProviders := &graph.Providers{
@@ -563,7 +594,24 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("failed to create anchor event store: %s", err.Error())
 	}
 
-	witnessProofStore, err := proofstore.New(storeProviders.provider, expiryService, parameters.witnessStoreExpiryPeriod)
+	witnessStatsStore, err := witnessstats.New(storeProviders.provider, expiryService, parameters.maxWitnessDelay,
+		witnessstats.WithHealthThreshold(parameters.witnessHealthThreshold),
+		witnessstats.WithBackoff(parameters.witnessHealthBackoff, parameters.witnessHealthMaxBackoff),
+		witnessstats.WithMetrics(metrics.Get()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create witness stats store: %s", err.Error())
+	}
+
+	witnessPolicyOpts := []policy.Option{policy.WithHealthChecker(witnessStatsStore)}
+
+	if parameters.witnessSelectionStrategy != witnessSelectionStrategyRandom {
+		witnessPolicyOpts = append(witnessPolicyOpts,
+			getWitnessSelectorOption(parameters.witnessSelectionStrategy, witnessStatsStore))
+	}
+
+	witnessProofStore, err := proofstore.New(storeProviders.provider, expiryService, parameters.witnessStoreExpiryPeriod,
+		proofstore.WithStatsRecorder(witnessStatsStore))
 	if err != nil {
 		return fmt.Errorf("failed to create proof store: %s", err.Error())
 	}
@@ -611,7 +659,13 @@ func startOrbServices(parameters *orbParameters) error {
 		IRICacheExpiration:     parameters.apIRICacheExpiration,
 	}
 
-	apStore, err := createActivityPubStore(storeProviders.provider, apConfig.ServiceEndpoint)
+	apStoreOpts, err := getActivityPubStoreOptions(parameters.activityRetentionParams, expiryService,
+		coreCASClient, storeProviders.provider)
+	if err != nil {
+		return err
+	}
+
+	apStore, err := createActivityPubStore(storeProviders.provider, apConfig.ServiceEndpoint, apStoreOpts...)
 	if err != nil {
 		return err
 	}
@@ -640,6 +694,8 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("get public key: %w", err)
 	}
 
+	apConfig.PublicKey = activePublicKey
+
 	apClient := client.New(client.Config{
 		CacheSize:       parameters.apClientCacheSize,
 		CacheExpiration: parameters.apClientCacheExpiration,
@@ -653,7 +709,7 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("new VCT monitoring service: %w", err)
 	}
 
-	witnessPolicy, err := policy.New(configStore, parameters.witnessPolicyCacheExpiration)
+	witnessPolicy, err := policy.New(configStore, parameters.witnessPolicyCacheExpiration, witnessPolicyOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create witness policy: %s", err.Error())
 	}
@@ -667,7 +723,8 @@ func startOrbServices(parameters *orbParameters) error {
 		WitnessPolicy:    witnessPolicy,
 	}
 
-	policyInspector, err := inspector.New(witnessPolicyInspectorProviders, parameters.maxWitnessDelay)
+	policyInspector, err := inspector.New(witnessPolicyInspectorProviders, parameters.maxWitnessDelay,
+		inspector.WithHealthRecorder(witnessStatsStore))
 	if err != nil {
 		return fmt.Errorf("failed to create witness policy inspector: %s", err.Error())
 	}
@@ -691,7 +748,8 @@ func startOrbServices(parameters *orbParameters) error {
 			WitnessPolicy:    witnessPolicy,
 			Metrics:          metrics.Get(),
 		},
-		pubSub)
+		pubSub,
+		proof.WithHealthRecorder(witnessStatsStore))
 
 	witness := vct.New(parameters.vctURL, vcSigner, metrics.Get(),
 		vct.WithHTTPClient(httpClient),
@@ -705,6 +763,25 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("open store: %w", err)
 	}
 
+	operationStatusStore, err := operationstatus.New(storeProviders.provider, expiryService,
+		parameters.operationStatusLifespan)
+	if err != nil {
+		return fmt.Errorf("failed to create operation status store: %w", err)
+	}
+
+	webhookSubscriptionStore, err := webhooksubscription.New(storeProviders.provider)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription store: %w", err)
+	}
+
+	webhookNotifier, err := webhook.New(pubSub, webhookSubscriptionStore, httpClient, apPostSigner,
+		apServicePublicKeyIRI, webhook.WithSubscriberPoolSize(parameters.mqParams.observerPoolSize))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook notifier: %w", err)
+	}
+
+	eventStream := eventstream.New()
+
 	// create new observer and start it
 	providers := &observer.Providers{
 		ProtocolClientProvider: pcp,
@@ -718,6 +795,9 @@ func startOrbServices(parameters *orbParameters) error {
 		DocLoader:              orbDocumentLoader,
 		Pkf:                    verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher(),
 		AnchorLinkStore:        anchorLinkStore,
+		OperationStatusStore:   operationStatusStore,
+		WebhookNotifier:        webhookNotifier,
+		EventStream:            eventStream,
 	}
 
 	o, err := observer.New(apConfig.ServiceIRI, providers,
@@ -745,8 +825,39 @@ func startOrbServices(parameters *orbParameters) error {
 		return fmt.Errorf("failed to register anchor sync task: %w", err)
 	}
 
-	activityPubService, err = apservice.New(apConfig,
-		apStore, t, apSigVerifier, pubSub, apClient, resourceResolver, authTokenManager, metrics.Get(),
+	backfillStore, err := storeProviders.provider.OpenStore("backfill")
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+
+	blockListMgr := blocklist.NewManager(configStore, apStore, apServiceIRI)
+
+	backfillMgr := backfill.NewManager(backfill.Config{}, taskMgr, backfillStore, apClient, apStore, blockListMgr,
+		func() apspi.InboxHandler {
+			return activityPubService.InboxHandler()
+		},
+	)
+
+	deadLetterStore, err := storeProviders.provider.OpenStore("deadletter")
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+
+	deadLetterMgr := deadletter.NewManager(deadLetterStore,
+		func() apspi.Outbox {
+			return activityPubService.Outbox()
+		},
+	)
+
+	circuitBreakers := circuitbreaker.New(
+		circuitbreaker.Config{
+			FailureThreshold: parameters.circuitBreakerFailureThreshold,
+			OpenTimeout:      parameters.circuitBreakerOpenTimeout,
+		},
+		metrics.Get(),
+	)
+
+	apServiceOpts := []apspi.HandlerOpt{
 		apspi.WithProofHandler(proofHandler),
 		apspi.WithWitness(witness),
 		apspi.WithAnchorEventHandler(credential.New(
@@ -755,14 +866,51 @@ func startOrbServices(parameters *orbParameters) error {
 		apspi.WithInviteWitnessAuth(NewAcceptRejectHandler(activityhandler.InviteWitnessType, parameters.inviteWitnessAuthPolicy, configStore)),
 		apspi.WithFollowAuth(NewAcceptRejectHandler(activityhandler.FollowType, parameters.followAuthPolicy, configStore)),
 		apspi.WithAnchorEventAcknowledgementHandler(anchorEventHandler),
-		// TODO: Define the following ActivityPub handlers.
-		// apspi.WithUndeliverableHandler(undeliverableHandler),
+		apspi.WithBlockList(blockListMgr),
+		apspi.WithMoveRegistry(moveRegistry),
+		apspi.WithUndeliverableHandler(deadLetterMgr),
+		apspi.WithCircuitBreaker(circuitBreakers),
+	}
+
+	if parameters.inboxRateLimit.Enabled() || len(parameters.inboxActivityRateLimits) > 0 {
+		apServiceOpts = append(apServiceOpts,
+			apspi.WithRateLimiter(ratelimiter.New(
+				ratelimiter.Config{
+					ActorLimit:         parameters.inboxRateLimit,
+					ActivityTypeLimits: parameters.inboxActivityRateLimits,
+				},
+				metrics.Get(),
+			)),
+		)
+	}
+
+	if parameters.activityProofsEnabled {
+		activitySigner, e := ldproof.NewSigner(cr, km, parameters.activeKeyID, kmsKeyType, apServicePublicKeyIRI,
+			orbDocumentLoader)
+		if e != nil {
+			return fmt.Errorf("activity proofs are enabled but the key can't be used to sign activities: %w", e)
+		}
+
+		apServiceOpts = append(apServiceOpts,
+			apspi.WithActivitySigner(activitySigner),
+			apspi.WithActivityVerifier(ldproof.NewVerifier(apClient, orbDocumentLoader)),
+		)
+	}
+
+	activityPubService, err = apservice.New(apConfig,
+		apStore, t, apSigVerifier, pubSub, apClient, resourceResolver, authTokenManager, metrics.Get(),
+		apServiceOpts...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create ActivityPub service: %s", err.Error())
 	}
 
 	go monitorActivities(activityPubService.Subscribe(), logger)
+	go eventStream.Listen(activityPubService.Subscribe())
+
+	webhookNotifier.Start()
+
+	eventStream.Start()
 
 	o.Start()
 
@@ -789,6 +937,8 @@ func startOrbServices(parameters *orbParameters) error {
 		WFClient:               wfClient,
 		DocumentLoader:         orbDocumentLoader,
 		VCStore:                vcStore,
+		WitnessMetadata:        witnessmetadata.New(configStore, parameters.witnessPolicyCacheExpiration),
+		OperationStatusStore:   operationStatusStore,
 	}
 
 	anchorWriter, err := writer.New(parameters.didNamespace,
@@ -805,7 +955,7 @@ func startOrbServices(parameters *orbParameters) error {
 	}
 
 	opQueue, err := opqueue.New(*parameters.opQueueParams, pubSub, storeProviders.provider, taskMgr,
-		expiryService, metrics.Get())
+		expiryService, metrics.Get(), opqueue.WithOperationStatusStore(operationStatusStore))
 	if err != nil {
 		return fmt.Errorf("failed to create operation queue: %s", err.Error())
 	}
@@ -870,6 +1020,7 @@ func startOrbServices(parameters *orbParameters) error {
 		ObjectIRI:              apServiceIRI,
 		VerifyActorInSignature: parameters.httpSignaturesEnabled,
 		PageSize:               parameters.activityPubPageSize,
+		AlsoKnownAs:            parameters.alsoKnownAs,
 	}
 
 	var resolveHandlerOpts []resolvehandler.Option
@@ -949,6 +1100,7 @@ func startOrbServices(parameters *orbParameters) error {
 			apStore, apSigVerifier, authTokenManager,
 		),
 		activityPubService.InboxHTTPHandler(),
+		activityPubService.SharedInboxHTTPHandler(),
 		aphandler.NewServices(apEndpointCfg, apStore, activePublicKey, authTokenManager),
 		aphandler.NewPublicKeys(apEndpointCfg, apStore, activePublicKey, authTokenManager),
 		aphandler.NewFollowers(apEndpointCfg, apStore, apSigVerifier, authTokenManager),
@@ -972,6 +1124,26 @@ func startOrbServices(parameters *orbParameters) error {
 		),
 		auth.NewHandlerWrapper(policyhandler.New(configStore), authTokenManager),
 		auth.NewHandlerWrapper(policyhandler.NewRetriever(configStore), authTokenManager),
+		auth.NewHandlerWrapper(witnesshealthhandler.New(witnessStatsStore), authTokenManager),
+		auth.NewHandlerWrapper(witnessmetadatahandler.New(configStore), authTokenManager),
+		auth.NewHandlerWrapper(witnessmetadatahandler.NewRetriever(configStore), authTokenManager),
+		auth.NewHandlerWrapper(anchorstatushandler.NewInProcessRetriever(anchorEventStatusStore,
+			parameters.maxWitnessDelay), authTokenManager),
+		auth.NewHandlerWrapper(anchorstatushandler.NewStatusRetriever(anchorEventStatusStore, witnessProofStore,
+			witnessPolicy, parameters.maxWitnessDelay), authTokenManager),
+		auth.NewHandlerWrapper(statushandler.NewStatusRetriever(operationStatusStore, anchorEventStatusStore),
+			authTokenManager),
+		auth.NewHandlerWrapper(historyhandler.NewHistoryRetriever(&historyhandler.Providers{
+			DidAnchors:             didAnchors,
+			AnchorGraph:            anchorGraph,
+			AnchorLinkStore:        anchorLinkStore,
+			ProtocolClientProvider: pcp,
+			DocLoader:              orbDocumentLoader,
+		}), authTokenManager),
+		auth.NewHandlerWrapper(webhookhandler.NewSubscriptionCreator(webhookSubscriptionStore), authTokenManager),
+		auth.NewHandlerWrapper(webhookhandler.NewSubscriptionsRetriever(webhookSubscriptionStore), authTokenManager),
+		auth.NewHandlerWrapper(webhookhandler.NewSubscriptionDeleter(webhookSubscriptionStore), authTokenManager),
+		auth.NewHandlerWrapper(eventstreamhandler.New(eventStream), authTokenManager),
 		auth.NewHandlerWrapper(nodeinfo.NewHandler(nodeinfo.V2_0, nodeInfoService, nodeInfoLogger), authTokenManager),
 		auth.NewHandlerWrapper(nodeinfo.NewHandler(nodeinfo.V2_1, nodeInfoService, nodeInfoLogger), authTokenManager),
 		auth.NewHandlerWrapper(vcresthandler.New(vcStore), authTokenManager),
@@ -984,6 +1156,25 @@ func startOrbServices(parameters *orbParameters) error {
 		handlers = append(handlers, auth.NewHandlerWrapper(&httpHandler{handler}, authTokenManager))
 	}
 
+	// Register endpoints to manage the 'block list'.
+	handlers = append(handlers,
+		auth.NewHandlerWrapper(aphandler.NewBlockListWriter(apEndpointCfg, blockListMgr), authTokenManager),
+		auth.NewHandlerWrapper(aphandler.NewBlockListReader(apEndpointCfg, blockListMgr), authTokenManager),
+	)
+
+	// Register endpoints to inspect, replay and purge undeliverable activities.
+	handlers = append(handlers,
+		auth.NewHandlerWrapper(aphandler.NewDeadLetterWriter(apEndpointCfg, deadLetterMgr), authTokenManager),
+		auth.NewHandlerWrapper(aphandler.NewDeadLetterReader(apEndpointCfg, deadLetterMgr), authTokenManager),
+		auth.NewHandlerWrapper(aphandler.NewBackfillWriter(apEndpointCfg, backfillMgr), authTokenManager),
+		auth.NewHandlerWrapper(aphandler.NewBackfillReader(apEndpointCfg, backfillMgr), authTokenManager),
+	)
+
+	// Register endpoint to read the state of the per-host delivery circuit breakers.
+	handlers = append(handlers,
+		auth.NewHandlerWrapper(aphandler.NewCircuitBreakers(apEndpointCfg, circuitBreakers), authTokenManager),
+	)
+
 	if parameters.followAuthPolicy == acceptListPolicy || parameters.inviteWitnessAuthPolicy == acceptListPolicy {
 		// Register endpoints to manage the 'accept list'.
 		handlers = append(handlers, auth.NewHandlerWrapper(
@@ -1035,6 +1226,10 @@ func startOrbServices(parameters *orbParameters) error {
 
 	o.Stop()
 
+	webhookNotifier.Stop()
+
+	eventStream.Stop()
+
 	activityPubService.Stop()
 
 	taskMgr.Stop()
@@ -1091,11 +1286,11 @@ func getProtocolClientProvider(parameters *orbParameters, casClient casapi.Clien
 	return pcp, nil
 }
 
-func createActivityPubStore(storageProvider *storageProvider,
-	serviceEndpoint string) (activitypubspi.Store, error) {
+func createActivityPubStore(storageProvider *storageProvider, serviceEndpoint string,
+	opts ...apariesstore.Option) (activitypubspi.Store, error) {
 	switch strings.ToLower(storageProvider.dbType) {
-	case databaseTypeMongoDBOption:
-		apStore, err := apariesstore.New(serviceEndpoint, storageProvider, true)
+	case databaseTypeMongoDBOption, databaseTypeEmbeddedOption:
+		apStore, err := apariesstore.New(serviceEndpoint, storageProvider, true, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Aries storage provider for ActivityPub: %w", err)
 		}
@@ -1103,7 +1298,7 @@ func createActivityPubStore(storageProvider *storageProvider,
 		return apStore, nil
 
 	case databaseTypeCouchDBOption:
-		apStore, err := apariesstore.New(serviceEndpoint, storageProvider, false)
+		apStore, err := apariesstore.New(serviceEndpoint, storageProvider, false, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Aries storage provider for ActivityPub: %w", err)
 		}
@@ -1111,10 +1306,58 @@ func createActivityPubStore(storageProvider *storageProvider,
 		return apStore, nil
 
 	default:
+		if len(opts) > 0 {
+			logger.Warnf("Activity retention is not supported by the in-memory ActivityPub store")
+		}
+
 		return apmemstore.New(serviceEndpoint), nil
 	}
 }
 
+func getWitnessSelectorOption(strategy string, statsStore *witnessstats.Store) policy.Option {
+	switch strategy {
+	case witnessSelectionStrategyRoundRobin:
+		return policy.WithSelector(selectorstats.NewRoundRobin(statsStore))
+	case witnessSelectionStrategyLeastRecentlyUsed:
+		return policy.WithSelector(selectorstats.NewLeastRecentlyUsed(statsStore))
+	case witnessSelectionStrategyLowestLatency:
+		return policy.WithSelector(selectorstats.NewLowestLatency(statsStore))
+	default:
+		return policy.WithSelector(selectorstats.NewFewestOutstandingOffers(statsStore))
+	}
+}
+
+func getActivityPubStoreOptions(params *activityRetentionParameters, expiryService *expiry.Service,
+	casWriter extendedcasclient.Client, provider storage.Provider) ([]apariesstore.Option, error) {
+	if params == nil || len(params.periods) == 0 {
+		return nil, nil
+	}
+
+	cfg := &apariesstore.RetentionConfig{
+		Periods:       params.periods,
+		ExpiryService: expiryService,
+	}
+
+	switch params.archiveType {
+	case activityArchiveTypeCASOption:
+		archiver, err := activityarchive.NewCAS(casWriter, provider)
+		if err != nil {
+			return nil, fmt.Errorf("create CAS activity archiver: %w", err)
+		}
+
+		cfg.Archiver = archiver
+	case activityArchiveTypeFileOption:
+		archiver, err := activityarchive.NewFile(params.archiveDir)
+		if err != nil {
+			return nil, fmt.Errorf("create file activity archiver: %w", err)
+		}
+
+		cfg.Archiver = archiver
+	}
+
+	return []apariesstore.Option{apariesstore.WithRetention(cfg)}, nil
+}
+
 type discoveryCAS struct {
 	resolver common.CASResolver
 }
@@ -1191,6 +1434,15 @@ func createStoreProviders(parameters *orbParameters) (*storageProviders, error)
 
 		edgeServiceProvs.provider = &storageProvider{wrapper.NewProvider(mongoDBProvider, "MongoDB"),
 			databaseTypeMongoDBOption}
+	case strings.EqualFold(parameters.dbParameters.databaseType, databaseTypeEmbeddedOption):
+		embeddedProvider, err := embeddedstorage.NewProvider(parameters.dbParameters.databaseURL,
+			embeddedstorage.WithDBPrefix(parameters.dbParameters.databasePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("create embedded storage provider: %w", err)
+		}
+
+		edgeServiceProvs.provider = &storageProvider{wrapper.NewProvider(embeddedProvider, "Embedded"),
+			databaseTypeEmbeddedOption}
 
 	default:
 		return &storageProviders{}, fmt.Errorf("database type not set to a valid type." +
@@ -1223,6 +1475,15 @@ func createStoreProviders(parameters *orbParameters) (*storageProviders, error)
 		}
 
 		edgeServiceProvs.kmsSecretsProvider = wrapper.NewProvider(mongoDBProvider, "MongoDB")
+	case strings.EqualFold(parameters.dbParameters.kmsSecretsDatabaseType, databaseTypeEmbeddedOption):
+		embeddedProvider, err := embeddedstorage.NewProvider(parameters.dbParameters.kmsSecretsDatabaseURL,
+			embeddedstorage.WithDBPrefix(parameters.dbParameters.kmsSecretsDatabasePrefix),
+			embeddedstorage.WithSyncWrites(true))
+		if err != nil {
+			return nil, fmt.Errorf("create embedded storage provider for KMS secrets: %w", err)
+		}
+
+		edgeServiceProvs.kmsSecretsProvider = wrapper.NewProvider(embeddedProvider, "Embedded")
 	default:
 		return &storageProviders{}, fmt.Errorf("key database type not set to a valid type." +
 			" run start --help to see the available options")
@@ -1328,22 +1589,38 @@ type signatureVerifier interface {
 }
 
 func getActivityPubSigners(parameters *orbParameters, km kms.KeyManager,
-	cr acrypto.Crypto) (getSigner signer, postSigner signer) {
-	if parameters.httpSignaturesEnabled {
-		getSigner = httpsig.NewSigner(httpsig.DefaultGetSignerConfig(), cr, km, parameters.activeKeyID)
-		postSigner = httpsig.NewSigner(httpsig.DefaultPostSignerConfig(), cr, km, parameters.activeKeyID)
-	} else {
-		getSigner = &transport.NoOpSigner{}
-		postSigner = &transport.NoOpSigner{}
+	cr acrypto.Crypto) (getSigner signer, postSigner signer, err error) {
+	if !parameters.httpSignaturesEnabled {
+		return &transport.NoOpSigner{}, &transport.NoOpSigner{}, nil
+	}
+
+	getCfg := httpsig.DefaultGetSignerConfig()
+	getCfg.Scheme = parameters.httpSignatureScheme
+	getCfg.PeerSchemes = parameters.httpSignaturePeerSchemes
+	getCfg.KeyType = kmsKeyType
+
+	postCfg := httpsig.DefaultPostSignerConfig()
+	postCfg.Scheme = parameters.httpSignatureScheme
+	postCfg.PeerSchemes = parameters.httpSignaturePeerSchemes
+	postCfg.KeyType = kmsKeyType
+
+	getSigner, err = httpsig.NewSigner(getCfg, cr, km, parameters.activeKeyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	postSigner, err = httpsig.NewSigner(postCfg, cr, km, parameters.activeKeyID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return
+	return getSigner, postSigner, nil
 }
 
 func getActivityPubVerifier(parameters *orbParameters, km kms.KeyManager,
 	cr acrypto.Crypto, apClient *client.Client) signatureVerifier {
 	if parameters.httpSignaturesEnabled {
-		return httpsig.NewVerifier(apClient, cr, km)
+		return httpsig.NewVerifier(apClient, cr, km, httpsig.WithMaxClockSkew(parameters.httpSignatureMaxClockSkew))
 	}
 
 	logger.Warnf("HTTP signature verification for ActivityPub is disabled.")
@@ -35,6 +35,22 @@ func TestCreateProviders(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to ping couchDB: url can't be blank")
 	})
+	t.Run("test error from create new embedded", func(t *testing.T) {
+		err := startOrbServices(&orbParameters{dbParameters: &dbParameters{databaseType: databaseTypeEmbeddedOption}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create embedded storage provider: data directory is required")
+	})
+	t.Run("test error from create new kms secrets embedded", func(t *testing.T) {
+		err := startOrbServices(&orbParameters{
+			dbParameters: &dbParameters{
+				databaseType:           databaseTypeEmbeddedOption,
+				databaseURL:            t.TempDir(),
+				kmsSecretsDatabaseType: databaseTypeEmbeddedOption,
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create embedded storage provider for KMS secrets: data directory is required")
+	})
 	t.Run("test invalid database type", func(t *testing.T) {
 		err := startOrbServices(&orbParameters{dbParameters: &dbParameters{databaseType: "data1"}})
 		require.Error(t, err)
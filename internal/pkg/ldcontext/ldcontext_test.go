@@ -16,7 +16,8 @@ import (
 
 func TestMustGetAll(t *testing.T) {
 	res := ldcontext.MustGetAll()
-	require.Len(t, res, 2)
-	require.Equal(t, "https://w3id.org/activityanchors/v1", res[0].URL)
-	require.Equal(t, "https://www.w3.org/ns/activitystreams", res[1].URL)
+	require.Len(t, res, 3)
+	require.Equal(t, "https://w3id.org/activityanchors/ext/v1", res[0].URL)
+	require.Equal(t, "https://w3id.org/activityanchors/v1", res[1].URL)
+	require.Equal(t, "https://www.w3.org/ns/activitystreams", res[2].URL)
 }
@@ -186,6 +186,28 @@ func (c *Client) getPublicKey(keyIRI *url.URL) (*vocab.PublicKeyType, error) {
 	return pubKey, nil
 }
 
+// ClearActor removes the given actor, along with any public keys owned by the actor, from the cache
+// so that the next retrieval is made from the actor's server. This should be called when the actor
+// is known to have changed (for example, after an 'Update' activity).
+func (c *Client) ClearActor(actorIRI *url.URL) {
+	for k := range c.actorCache.GetALL(false) {
+		if iri, ok := k.(*url.URL); ok && iri.String() == actorIRI.String() {
+			c.actorCache.Remove(k)
+		}
+	}
+
+	for k, v := range c.publicKeyCache.GetALL(false) {
+		pubKey, ok := v.(*vocab.PublicKeyType)
+		if !ok || pubKey.Owner == nil || pubKey.Owner.String() != actorIRI.String() {
+			continue
+		}
+
+		c.publicKeyCache.Remove(k)
+	}
+
+	logger.Debugf("Cleared actor [%s] and its public keys from the cache", actorIRI)
+}
+
 // GetReferences returns an iterator that reads all references at the given IRI. The IRI either resolves
 // to an ActivityPub actor, collection or ordered collection.
 func (c *Client) GetReferences(iri *url.URL) (ReferenceIterator, error) {
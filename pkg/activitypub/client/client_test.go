@@ -182,6 +182,67 @@ func TestClient_GetActor(t *testing.T) {
 	})
 }
 
+func TestClient_ClearActor(t *testing.T) {
+	actorIRI := testutil.MustParseURL("https://example.com/services/service1")
+	keyIRI := testutil.MustParseURL("https://example.com/services/service1/keys/main-key")
+
+	actorBytes, err := json.Marshal(aptestutil.NewMockService(actorIRI))
+	require.NoError(t, err)
+
+	publicKeyBytes, err := json.Marshal(aptestutil.NewMockPublicKey(actorIRI))
+	require.NoError(t, err)
+
+	errExpected := errors.New("not found")
+
+	newResponse := func(b []byte) *http.Response {
+		rw := httptest.NewRecorder()
+
+		_, e := rw.Write(b)
+		require.NoError(t, e)
+
+		return rw.Result()
+	}
+
+	actorResult := newResponse(actorBytes)
+	keyResult := newResponse(publicKeyBytes)
+
+	httpClient := &mocks.HTTPTransport{}
+	httpClient.GetReturnsOnCall(0, actorResult, nil)
+	httpClient.GetReturnsOnCall(1, keyResult, nil)
+	httpClient.GetReturnsOnCall(2, nil, errExpected)
+	httpClient.GetReturnsOnCall(3, nil, errExpected)
+
+	c := New(Config{CacheExpiration: time.Minute}, httpClient)
+	require.NotNil(t, c)
+
+	actor, err := c.GetActor(actorIRI)
+	require.NoError(t, err)
+	require.NotNil(t, actor)
+
+	pubKey, err := c.GetPublicKey(keyIRI)
+	require.NoError(t, err)
+	require.NotNil(t, pubKey)
+
+	// Both should be served from the cache.
+	_, err = c.GetActor(actorIRI)
+	require.NoError(t, err)
+
+	_, err = c.GetPublicKey(keyIRI)
+	require.NoError(t, err)
+
+	c.ClearActor(testutil.MustParseURL(actorIRI.String()))
+
+	// The actor and key should now be retrieved from the server.
+	_, err = c.GetActor(actorIRI)
+	require.True(t, errors.Is(err, errExpected))
+
+	_, err = c.GetPublicKey(keyIRI)
+	require.True(t, errors.Is(err, errExpected))
+
+	require.NoError(t, actorResult.Body.Close())
+	require.NoError(t, keyResult.Body.Close())
+}
+
 func TestClient_GetReferences(t *testing.T) {
 	log.SetLevel("activitypub_client", log.DEBUG)
 
@@ -22,8 +22,29 @@ import (
 
 const orbHTTPSigAlgorithm = "Ed25519"
 
-// ErrInvalidSignature indicates that the signature is not valid for the given data.
-var ErrInvalidSignature = errors.New("invalid HTTP signature")
+var (
+	// ErrInvalidSignature indicates that the signature is not valid for the given data.
+	ErrInvalidSignature = errors.New("invalid HTTP signature")
+
+	// ErrUnsupportedKeyType indicates that HTTP requests may not be signed with the given type of key.
+	ErrUnsupportedKeyType = errors.New("unsupported key type for HTTP signatures")
+)
+
+// messageSignatureAlgorithms maps the KMS key type to the RFC 9421 signature algorithm. Public keys
+// are always resolved as Ed25519 keys (see KeyResolver) so only Ed25519 keys are supported.
+var messageSignatureAlgorithms = map[kms.KeyType]string{
+	kms.ED25519Type: algEd25519,
+}
+
+// messageSignatureAlgorithm returns the RFC 9421 signature algorithm for the given key type.
+func messageSignatureAlgorithm(keyType kms.KeyType) (string, error) {
+	alg, ok := messageSignatureAlgorithms[keyType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedKeyType, keyType)
+	}
+
+	return alg, nil
+}
 
 type keyResolver interface {
 	// Resolve returns the public key bytes and the type of public key for the given key ID.
@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpsig
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+const (
+	digestHeader = "Digest"
+
+	// digestAlgorithm is the algorithm used for the Digest header of signed requests. SHA-256 is
+	// the algorithm that's most widely supported by ActivityPub servers.
+	digestAlgorithm = "SHA-256"
+)
+
+// verifyDigest verifies the Digest header (RFC 3230) against the request body. Only SHA-256 and SHA-512
+// digests are accepted and all of the supported digests in the header must match.
+func verifyDigest(req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	var verified bool
+
+	for _, member := range strings.Split(strings.Join(req.Header.Values(digestHeader), ","), ",") {
+		alg, v, err := parseMember(member)
+		if err != nil {
+			return fmt.Errorf("parse %s header: %w", digestHeader, err)
+		}
+
+		h := newDigestHash(alg)
+		if h == nil {
+			continue
+		}
+
+		expected, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("parse %s header: %w", digestHeader, err)
+		}
+
+		h.Write(body) //nolint:errcheck,gosec
+
+		if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+			return fmt.Errorf("%s does not match the content", digestHeader)
+		}
+
+		verified = true
+	}
+
+	if !verified {
+		return fmt.Errorf("no supported digest found in %s header", digestHeader)
+	}
+
+	return nil
+}
+
+// verifyContentDigest verifies the Content-Digest header (RFC 9530) against the request body. Only SHA-256 and
+// SHA-512 digests are accepted and all of the supported digests in the header must match.
+func verifyContentDigest(req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	var verified bool
+
+	for _, member := range splitOutsideQuotes(strings.Join(req.Header.Values(contentDigestHeader), ", "), ',') {
+		alg, v, err := parseMember(member)
+		if err != nil {
+			return fmt.Errorf("parse %s header: %w", contentDigestHeader, err)
+		}
+
+		h := newDigestHash(alg)
+		if h == nil {
+			continue
+		}
+
+		expected, err := decodeByteSequence(v)
+		if err != nil {
+			return fmt.Errorf("parse %s header: %w", contentDigestHeader, err)
+		}
+
+		h.Write(body) //nolint:errcheck,gosec
+
+		if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+			return fmt.Errorf("%s does not match the content", contentDigestHeader)
+		}
+
+		verified = true
+	}
+
+	if !verified {
+		return fmt.Errorf("no supported digest found in %s header", contentDigestHeader)
+	}
+
+	return nil
+}
+
+// validateDigest ensures that the content of the request is protected by the signature. A state-changing
+// request must be signed over either the Digest or the Content-Digest header.
+func validateDigest(req *http.Request, si *signatureInput) error {
+	switch {
+	case si.covers(componentContentDigest):
+		return verifyContentDigest(req)
+	case si.covers(cavageDigest):
+		return verifyDigest(req)
+	case isStateChanging(req.Method):
+		return errors.New("signature must cover the Digest or Content-Digest header")
+	default:
+		return nil
+	}
+}
+
+func newDigestHash(alg string) hash.Hash {
+	switch strings.ToLower(alg) {
+	case "sha-256":
+		return sha256.New()
+	case "sha-512":
+		return sha512.New()
+	default:
+		return nil
+	}
+}
+
+// isStateChanging returns true if the given HTTP method may change state on the server.
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpsig
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDigest(t *testing.T) {
+	const (
+		sha256Digest  = "I59Z7VXnN8dxR89VrQwbAwttfudIp0JpUvm4UtWpNeU="
+		contentDigest = "sha-256=:" + sha256Digest + ":"
+	)
+
+	newRequest := func(method string, payload []byte) *http.Request {
+		req, err := http.NewRequest(method, "https://domain1.com/services/orb/inbox", bytes.NewBuffer(payload))
+		require.NoError(t, err)
+
+		return req
+	}
+
+	t.Run("Digest", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("payload"))
+		req.Header.Set(digestHeader, "SHA-256="+sha256Digest)
+
+		require.NoError(t, validateDigest(req, &signatureInput{components: []string{cavageDigest}}))
+
+		req.Header.Set(digestHeader, "SHA-256=invalid")
+
+		require.Error(t, validateDigest(req, &signatureInput{components: []string{cavageDigest}}))
+
+		req.Header.Set(digestHeader, "invalid")
+
+		require.Error(t, validateDigest(req, &signatureInput{components: []string{cavageDigest}}))
+	})
+
+	t.Run("Content-Digest", func(t *testing.T) {
+		req := newRequest(http.MethodPut, []byte("payload"))
+		req.Header.Set(contentDigestHeader, contentDigest)
+
+		require.NoError(t, validateDigest(req, &signatureInput{components: []string{componentContentDigest}}))
+
+		req.Header.Set(contentDigestHeader, "md5=:Mhw89IbtUJFk7eweGYH+yA==:")
+
+		err := validateDigest(req, &signatureInput{components: []string{componentContentDigest}})
+		require.EqualError(t, err, "no supported digest found in Content-Digest header")
+	})
+
+	t.Run("State-changing request without digest", func(t *testing.T) {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			err := validateDigest(newRequest(method, nil), &signatureInput{components: []string{"date"}})
+			require.EqualError(t, err, "signature must cover the Digest or Content-Digest header")
+		}
+	})
+
+	t.Run("Safe request without digest", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+			require.NoError(t, validateDigest(newRequest(method, nil), &signatureInput{components: []string{"date"}}))
+		}
+	})
+}
@@ -0,0 +1,518 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpsig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpsig "github.com/igor-pavlenko/httpsignatures-go"
+)
+
+const (
+	signatureInputHeader = "Signature-Input"
+	signatureHeader      = "Signature"
+	contentDigestHeader  = "Content-Digest"
+
+	defaultSignatureLabel = "sig1"
+	algEd25519            = "ed25519"
+
+	componentMethod        = "@method"
+	componentTargetURI     = "@target-uri"
+	componentAuthority     = "@authority"
+	componentScheme        = "@scheme"
+	componentRequestTarget = "@request-target"
+	componentPath          = "@path"
+	componentQuery         = "@query"
+	componentContentDigest = "content-digest"
+
+	signatureParamsComponent = "@signature-params"
+
+	paramCreated = "created"
+	paramExpires = "expires"
+	paramKeyID   = "keyid"
+	paramAlg     = "alg"
+
+	cavageRequestTarget = "(request-target)"
+	cavageDigest        = "digest"
+)
+
+type signatureHashAlgorithm interface {
+	Create(secret httpsig.Secret, data []byte) ([]byte, error)
+	Verify(secret httpsig.Secret, data, signature []byte) error
+}
+
+// messageSignatures signs and verifies HTTP requests according to RFC 9421 (HTTP Message Signatures)
+// using the 'Signature-Input' and 'Signature' headers.
+type messageSignatures struct {
+	algo signatureHashAlgorithm
+	alg  string
+	now  func() time.Time
+}
+
+// newMessageSignatures returns a new messageSignatures which uses the given RFC 9421 algorithm name
+// in the 'alg' signature parameter.
+func newMessageSignatures(algo signatureHashAlgorithm, alg string) *messageSignatures {
+	return &messageSignatures{
+		algo: algo,
+		alg:  alg,
+		now:  time.Now,
+	}
+}
+
+// Sign signs the request with the given key ID. The given headers are in the same format as for the
+// draft-cavage scheme and are converted to RFC 9421 components, i.e. '(request-target)' is covered by
+// the '@method', '@path' and '@query' components and 'Digest' is replaced with 'Content-Digest'.
+func (s *messageSignatures) Sign(keyID string, headers []string, req *http.Request) error {
+	components := toComponents(headers, req)
+
+	for _, c := range components {
+		if c == componentContentDigest {
+			digest, err := contentDigest(req)
+			if err != nil {
+				return err
+			}
+
+			req.Header.Set(contentDigestHeader, digest)
+
+			break
+		}
+	}
+
+	params := serializeSignatureParams(components, s.now().Unix(), keyID, s.alg)
+
+	base, err := signatureBase(req, components, params)
+	if err != nil {
+		return fmt.Errorf("create signature base: %w", err)
+	}
+
+	sig, err := s.algo.Create(httpsig.Secret{KeyID: keyID, Algorithm: orbHTTPSigAlgorithm}, base)
+	if err != nil {
+		return fmt.Errorf("create signature: %w", err)
+	}
+
+	req.Header.Set(signatureInputHeader, fmt.Sprintf("%s=%s", defaultSignatureLabel, params))
+	req.Header.Set(signatureHeader, fmt.Sprintf("%s=:%s:", defaultSignatureLabel,
+		base64.StdEncoding.EncodeToString(sig)))
+
+	return nil
+}
+
+// Verify verifies the first signature in the request and returns the signature input, which includes the ID
+// of the key that signed it and the covered components.
+func (s *messageSignatures) Verify(req *http.Request) (*signatureInput, error) {
+	label, params, err := firstMember(strings.Join(req.Header.Values(signatureInputHeader), ", "))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s header: %w", signatureInputHeader, err)
+	}
+
+	sigInput, err := parseSignatureInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s header: %w", signatureInputHeader, err)
+	}
+
+	sig, err := getSignature(req, label)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSignatureInput(req, sigInput); err != nil {
+		return nil, err
+	}
+
+	base, err := signatureBase(req, sigInput.components, params)
+	if err != nil {
+		return nil, fmt.Errorf("create signature base: %w", err)
+	}
+
+	err = s.algo.Verify(httpsig.Secret{KeyID: sigInput.keyID, Algorithm: orbHTTPSigAlgorithm}, base, sig)
+	if err != nil {
+		return nil, fmt.Errorf("verify signature: %w", err)
+	}
+
+	return sigInput, nil
+}
+
+func (s *messageSignatures) validateSignatureInput(req *http.Request, sigInput *signatureInput) error {
+	if sigInput.keyID == "" {
+		return errors.New("keyid parameter not found in signature input")
+	}
+
+	if sigInput.alg != "" && sigInput.alg != s.alg {
+		return fmt.Errorf("unsupported signature algorithm [%s]", sigInput.alg)
+	}
+
+	if !sigInput.covers(componentMethod) {
+		return fmt.Errorf("signature must cover the %s component", componentMethod)
+	}
+
+	if !sigInput.covers(componentTargetURI) && !sigInput.covers(componentPath) &&
+		!sigInput.covers(componentRequestTarget) {
+		return errors.New("signature must cover the target of the request")
+	}
+
+	// The Content-Digest header is verified against the content (along with the date) by the verifier.
+	hasBody := req.ContentLength > 0 || (req.Body != nil && req.Body != http.NoBody && req.ContentLength < 0)
+
+	if hasBody && !sigInput.covers(componentContentDigest) {
+		return fmt.Errorf("signature must cover the %s header for a request with content", componentContentDigest)
+	}
+
+	return nil
+}
+
+type signatureInput struct {
+	components []string
+	created    *time.Time
+	expires    *time.Time
+	keyID      string
+	alg        string
+}
+
+func (si *signatureInput) covers(component string) bool {
+	for _, c := range si.components {
+		if c == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSignatureInput parses a signature input in the format ("c1" "c2");created=123;keyid="key".
+func parseSignatureInput(value string) (*signatureInput, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.HasPrefix(value, "(") {
+		return nil, errors.New("inner list expected")
+	}
+
+	end := strings.Index(value, ")")
+	if end < 0 {
+		return nil, errors.New("unterminated inner list")
+	}
+
+	si := &signatureInput{}
+
+	for _, item := range strings.Fields(value[1:end]) {
+		if strings.Contains(item, ";") {
+			return nil, fmt.Errorf("component parameters are not supported: %s", item)
+		}
+
+		component, err := unquote(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component: %w", err)
+		}
+
+		si.components = append(si.components, component)
+	}
+
+	for _, param := range splitOutsideQuotes(value[end+1:], ';') {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+
+		if err := si.setParam(param); err != nil {
+			return nil, err
+		}
+	}
+
+	return si, nil
+}
+
+func (si *signatureInput) setParam(param string) error {
+	const kvLength = 2
+
+	kv := strings.SplitN(param, "=", kvLength)
+	if len(kv) != kvLength {
+		return nil
+	}
+
+	switch kv[0] {
+	case paramCreated, paramExpires:
+		sec, err := strconv.ParseInt(kv[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s parameter: %w", kv[0], err)
+		}
+
+		t := time.Unix(sec, 0)
+
+		if kv[0] == paramCreated {
+			si.created = &t
+		} else {
+			si.expires = &t
+		}
+	case paramKeyID, paramAlg:
+		v, err := unquote(kv[1])
+		if err != nil {
+			return fmt.Errorf("invalid %s parameter: %w", kv[0], err)
+		}
+
+		if kv[0] == paramKeyID {
+			si.keyID = v
+		} else {
+			si.alg = v
+		}
+	}
+
+	return nil
+}
+
+func getSignature(req *http.Request, label string) ([]byte, error) {
+	for _, member := range splitOutsideQuotes(strings.Join(req.Header.Values(signatureHeader), ", "), ',') {
+		l, v, err := parseMember(member)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s header: %w", signatureHeader, err)
+		}
+
+		if l != label {
+			continue
+		}
+
+		sig, err := decodeByteSequence(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s header: %w", signatureHeader, err)
+		}
+
+		return sig, nil
+	}
+
+	return nil, fmt.Errorf("signature [%s] not found in %s header", label, signatureHeader)
+}
+
+// signatureBase creates the signature base (the data that's signed) as defined in section 2.5 of RFC 9421.
+func signatureBase(req *http.Request, components []string, params string) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, c := range components {
+		value, err := componentValue(req, c)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&b, "%q: %s\n", c, value)
+	}
+
+	fmt.Fprintf(&b, "%q: %s", signatureParamsComponent, params)
+
+	return b.Bytes(), nil
+}
+
+func componentValue(req *http.Request, component string) (string, error) {
+	switch component {
+	case componentMethod:
+		return req.Method, nil
+	case componentTargetURI:
+		return fmt.Sprintf("%s://%s%s", scheme(req), authority(req), req.URL.RequestURI()), nil
+	case componentAuthority:
+		return authority(req), nil
+	case componentScheme:
+		return scheme(req), nil
+	case componentRequestTarget:
+		return req.URL.RequestURI(), nil
+	case componentPath:
+		path := req.URL.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+
+		return path, nil
+	case componentQuery:
+		return "?" + req.URL.RawQuery, nil
+	}
+
+	if strings.HasPrefix(component, "@") {
+		return "", fmt.Errorf("unsupported derived component [%s]", component)
+	}
+
+	values := req.Header.Values(component)
+	if len(values) == 0 {
+		return "", fmt.Errorf("header [%s], required in signature, not found", component)
+	}
+
+	trimmed := make([]string, len(values))
+
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+
+	return strings.Join(trimmed, ", "), nil
+}
+
+func authority(req *http.Request) string {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	return strings.ToLower(host)
+}
+
+func scheme(req *http.Request) string {
+	if req.URL.Scheme != "" {
+		return strings.ToLower(req.URL.Scheme)
+	}
+
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		return strings.ToLower(proto)
+	}
+
+	if req.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+// toComponents converts draft-cavage header names to RFC 9421 component identifiers.
+func toComponents(headers []string, req *http.Request) []string {
+	var components []string
+
+	for _, h := range headers {
+		switch strings.ToLower(h) {
+		case cavageRequestTarget:
+			components = append(components, componentMethod, componentPath)
+
+			if req.URL.RawQuery != "" {
+				components = append(components, componentQuery)
+			}
+		case cavageDigest:
+			components = append(components, componentContentDigest)
+		default:
+			components = append(components, strings.ToLower(h))
+		}
+	}
+
+	return components
+}
+
+func serializeSignatureParams(components []string, created int64, keyID, alg string) string {
+	quoted := make([]string, len(components))
+
+	for i, c := range components {
+		quoted[i] = strconv.Quote(c)
+	}
+
+	return fmt.Sprintf("(%s);%s=%d;%s=%q;%s=%q", strings.Join(quoted, " "),
+		paramCreated, created, paramKeyID, keyID, paramAlg, alg)
+}
+
+// contentDigest returns the value of the Content-Digest header (RFC 9530) for the request body.
+func contentDigest(req *http.Request) (string, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+
+	return fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(sum[:])), nil
+}
+
+// readBody reads the request body and replaces it so that it may be read again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func firstMember(dict string) (string, string, error) {
+	members := splitOutsideQuotes(dict, ',')
+	if len(members) == 0 || strings.TrimSpace(members[0]) == "" {
+		return "", "", errors.New("no members found")
+	}
+
+	return parseMember(members[0])
+}
+
+func parseMember(member string) (string, string, error) {
+	const kvLength = 2
+
+	kv := strings.SplitN(strings.TrimSpace(member), "=", kvLength)
+	if len(kv) != kvLength || kv[0] == "" {
+		return "", "", fmt.Errorf("invalid dictionary member [%s]", member)
+	}
+
+	return kv[0], kv[1], nil
+}
+
+func decodeByteSequence(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) < 2 || !strings.HasPrefix(value, ":") || !strings.HasSuffix(value, ":") {
+		return nil, fmt.Errorf("invalid byte sequence [%s]", value)
+	}
+
+	return base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+}
+
+func unquote(value string) (string, error) {
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return "", fmt.Errorf("quoted string expected [%s]", value)
+	}
+
+	return strings.ReplaceAll(strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`), `\\`, `\`), nil
+}
+
+// splitOutsideQuotes splits the given string by the separator, ignoring separators within quoted strings
+// and inner lists.
+func splitOutsideQuotes(s string, sep rune) []string {
+	var parts []string
+
+	var inQuotes, escaped bool
+
+	depth := 0
+	start := 0
+
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	if strings.TrimSpace(s[start:]) != "" {
+		parts = append(parts, s[start:])
+	}
+
+	return parts
+}
+
+// isMessageSignature returns true if the request was signed according to RFC 9421.
+func isMessageSignature(req *http.Request) bool {
+	return req.Header.Get(signatureInputHeader) != ""
+}
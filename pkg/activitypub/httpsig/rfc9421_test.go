@@ -0,0 +1,406 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	ariesverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	httpsig "github.com/igor-pavlenko/httpsignatures-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/mocks"
+)
+
+func TestMessageSignatures(t *testing.T) {
+	const keyID = "https://domain1.com/services/orb/keys/main-key"
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	algo := &ed25519Algorithm{privateKey: privKey, publicKey: pubKey}
+
+	t.Run("GET", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req, err := http.NewRequest(http.MethodGet, "https://domain1.com/services/orb/outbox?page=true", nil)
+		require.NoError(t, err)
+
+		req.Header.Set(dateHeader, date())
+
+		require.NoError(t, ms.Sign(keyID, DefaultGetSignerConfig().Headers, req))
+
+		require.Empty(t, req.Header.Get(contentDigestHeader))
+		require.True(t, isMessageSignature(req))
+		require.True(t, strings.HasPrefix(req.Header.Get(signatureInputHeader),
+			`sig1=("@method" "@path" "@query" "date");created=`))
+		require.True(t, strings.HasPrefix(req.Header.Get(signatureHeader), "sig1=:"))
+
+		si, err := ms.Verify(req)
+		require.NoError(t, err)
+		require.Equal(t, keyID, si.keyID)
+	})
+
+	t.Run("POST", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req))
+
+		require.Equal(t, "sha-256=:I59Z7VXnN8dxR89VrQwbAwttfudIp0JpUvm4UtWpNeU=:", req.Header.Get(contentDigestHeader))
+
+		si, err := ms.Verify(req)
+		require.NoError(t, err)
+		require.Equal(t, keyID, si.keyID)
+	})
+
+	t.Run("Sign error", func(t *testing.T) {
+		errExpected := errors.New("injected sign error")
+
+		ms := newMessageSignatures(&ed25519Algorithm{err: errExpected}, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.ErrorIs(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req), errExpected)
+	})
+
+	t.Run("Missing header", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		err := ms.Sign(keyID, []string{"(request-target)", "Host-Name"}, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "header [host-name], required in signature, not found")
+	})
+
+	t.Run("Tampered content", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req))
+
+		req.Body = http.NoBody
+		req.ContentLength = 0
+
+		// The signature covers the Content-Digest header, which is verified against the content by validateDigest.
+		si, err := ms.Verify(req)
+		require.NoError(t, err)
+
+		err = validateDigest(req, si)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Content-Digest does not match the content")
+	})
+
+	t.Run("Tampered digest", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req))
+
+		req.Body = http.NoBody
+		req.ContentLength = 0
+		req.Header.Set(contentDigestHeader, "sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:")
+
+		_, err := ms.Verify(req)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("Tampered request target", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req))
+
+		req.URL.Path = "/services/orb/outbox"
+
+		_, err := ms.Verify(req)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("Content not covered", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultGetSignerConfig().Headers, req))
+
+		_, err := ms.Verify(req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature must cover the content-digest header")
+	})
+
+	t.Run("Method not covered", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, nil)
+
+		require.NoError(t, ms.Sign(keyID, []string{"Date"}, req))
+
+		_, err := ms.Verify(req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature must cover the @method component")
+	})
+
+	t.Run("Target not covered", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, nil)
+
+		require.NoError(t, ms.Sign(keyID, []string{"@method", "Date"}, req))
+
+		_, err := ms.Verify(req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature must cover the target of the request")
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		req := newPostRequest(t, []byte("payload"))
+
+		require.NoError(t, ms.Sign(keyID, DefaultPostSignerConfig().Headers, req))
+
+		req.Header.Set(signatureInputHeader,
+			strings.Replace(req.Header.Get(signatureInputHeader), `alg="ed25519"`, `alg="rsa-pss-sha512"`, 1))
+
+		_, err := ms.Verify(req)
+		require.EqualError(t, err, "unsupported signature algorithm [rsa-pss-sha512]")
+	})
+
+	t.Run("Invalid headers", func(t *testing.T) {
+		ms := newMessageSignatures(algo, algEd25519)
+
+		tests := []struct {
+			sigInput string
+			sig      string
+			err      string
+		}{
+			{
+				sigInput: "",
+				err:      "no members found",
+			},
+			{
+				sigInput: `sig1="@method"`,
+				err:      "inner list expected",
+			},
+			{
+				sigInput: `sig1=("@method";req "@path");keyid="key1"`,
+				err:      "component parameters are not supported",
+			},
+			{
+				sigInput: `sig1=("@method" "@path");created=abc;keyid="key1"`,
+				err:      "invalid created parameter",
+			},
+			{
+				sigInput: `sig1=("@method" "@path");created=1`,
+				sig:      "sig1=:YWJj:",
+				err:      "keyid parameter not found in signature input",
+			},
+			{
+				sigInput: `sig1=("@method" "@path");keyid="key1"`,
+				err:      "signature [sig1] not found in Signature header",
+			},
+			{
+				sigInput: `sig1=("@method" "@path");keyid="key1"`,
+				sig:      "sig1=abc",
+				err:      "invalid byte sequence",
+			},
+			{
+				sigInput: `sig1=("@method" "@path" "@status");keyid="key1"`,
+				sig:      "sig1=:YWJj:",
+				err:      "unsupported derived component [@status]",
+			},
+		}
+
+		for _, test := range tests {
+			req, err := http.NewRequest(http.MethodGet, "https://domain1.com/services/orb", nil)
+			require.NoError(t, err)
+
+			req.Header.Set(signatureInputHeader, test.sigInput)
+
+			if test.sig != "" {
+				req.Header.Set(signatureHeader, test.sig)
+			}
+
+			_, err = ms.Verify(req)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.err)
+		}
+	})
+}
+
+func TestSignatureBase(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://Domain1.com:8443/services/orb/inbox?a=b", nil)
+	require.NoError(t, err)
+
+	req.Header.Set(dateHeader, "Tue, 20 Apr 2021 02:07:55 GMT")
+	req.Header.Add("X-Custom", " value1 ")
+	req.Header.Add("X-Custom", "value2")
+
+	components := []string{
+		componentMethod, componentTargetURI, componentAuthority, componentScheme,
+		componentRequestTarget, componentPath, componentQuery, "date", "x-custom",
+	}
+
+	params := serializeSignatureParams(components, 1618884475, "key1", algEd25519)
+
+	base, err := signatureBase(req, components, params)
+	require.NoError(t, err)
+	require.Equal(t, `"@method": POST
+"@target-uri": https://domain1.com:8443/services/orb/inbox?a=b
+"@authority": domain1.com:8443
+"@scheme": https
+"@request-target": /services/orb/inbox?a=b
+"@path": /services/orb/inbox
+"@query": ?a=b
+"date": Tue, 20 Apr 2021 02:07:55 GMT
+"x-custom": value1, value2
+"@signature-params": ("@method" "@target-uri" "@authority" "@scheme" "@request-target" "@path" "@query" "date" `+
+		`"x-custom");created=1618884475;keyid="key1";alg="ed25519"`, string(base))
+}
+
+// TestMessageSignatures_RFC9421TestVector verifies the signature from section B.2.6 of RFC 9421, which was
+// created by an independent implementation using the test-key-ed25519 key from section B.1.4.
+func TestMessageSignatures_RFC9421TestVector(t *testing.T) {
+	const (
+		publicKeyPEM = `-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAJrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs=
+-----END PUBLIC KEY-----`
+
+		signatureInput = `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length")` +
+			`;created=1618884473;keyid="test-key-ed25519"`
+		signature = `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5W` +
+			`PpBKRCw==:`
+
+		expectedBase = `"date": Tue, 20 Apr 2021 02:07:55 GMT
+"@method": POST
+"@path": /foo
+"@authority": example.com
+"content-type": application/json
+"content-length": 18
+"@signature-params": ("date" "@method" "@path" "@authority" "content-type" "content-length")` +
+			`;created=1618884473;keyid="test-key-ed25519"`
+	)
+
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	require.NotNil(t, block)
+
+	pk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+
+	resolver := &mocks.KeyResolver{}
+	resolver.ResolveReturns(&ariesverifier.PublicKey{Type: kms.ED25519, Value: pk.(ed25519.PublicKey)}, nil)
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/foo?param=Value&Pet=dog",
+			bytes.NewBufferString(`{"hello": "world"}`))
+		require.NoError(t, err)
+
+		req.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyeal"+
+			"dVLvRwEmTHWXvJwew==:")
+		req.Header.Set("Content-Length", "18")
+		req.Header.Set(signatureInputHeader, signatureInput)
+		req.Header.Set(signatureHeader, signature)
+
+		return req
+	}
+
+	t.Run("Signature base and signature", func(t *testing.T) {
+		req := newRequest(t)
+
+		label, params, err := firstMember(req.Header.Get(signatureInputHeader))
+		require.NoError(t, err)
+		require.Equal(t, "sig-b26", label)
+
+		sigInput, err := parseSignatureInput(params)
+		require.NoError(t, err)
+		require.Equal(t, "test-key-ed25519", sigInput.keyID)
+
+		base, err := signatureBase(req, sigInput.components, params)
+		require.NoError(t, err)
+		require.Equal(t, expectedBase, string(base))
+
+		sig, err := getSignature(req, label)
+		require.NoError(t, err)
+
+		algo := NewVerifierAlgorithm(&mockcrypto.Crypto{}, &mockkms.KeyManager{}, resolver)
+
+		require.NoError(t, algo.Verify(httpsig.Secret{KeyID: sigInput.keyID}, base, sig))
+
+		// The signature must not verify if a covered component is changed.
+		req.Header.Set("Content-Type", "text/plain")
+
+		base, err = signatureBase(req, sigInput.components, params)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, algo.Verify(httpsig.Secret{KeyID: sigInput.keyID}, base, sig), ErrInvalidSignature)
+	})
+
+	t.Run("Content not covered", func(t *testing.T) {
+		// The test signature is cryptographically valid but doesn't cover the content of the request,
+		// which is required for requests with content.
+		ms := newMessageSignatures(NewVerifierAlgorithm(&mockcrypto.Crypto{}, &mockkms.KeyManager{}, resolver),
+			algEd25519)
+
+		_, err := ms.Verify(newRequest(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature must cover the content-digest header")
+	})
+}
+
+func newPostRequest(t *testing.T, payload []byte) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "https://domain1.com/services/orb/inbox", bytes.NewBuffer(payload))
+	require.NoError(t, err)
+
+	req.Header.Set(dateHeader, date())
+
+	return req
+}
+
+type ed25519Algorithm struct {
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+	err        error
+}
+
+func (a *ed25519Algorithm) Create(_ httpsig.Secret, data []byte) ([]byte, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	return ed25519.Sign(a.privateKey, data), nil
+}
+
+func (a *ed25519Algorithm) Verify(_ httpsig.Secret, data, signature []byte) error {
+	if !ed25519.Verify(a.publicKey, data, signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ package httpsig
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -37,9 +38,42 @@ func DefaultPostSignerConfig() SignerConfig {
 	}
 }
 
+// SignatureScheme specifies the scheme used to sign HTTP requests.
+type SignatureScheme string
+
+const (
+	// SchemeCavage signs requests using the 'Signature' header as defined in
+	// draft-cavage-http-signatures.
+	SchemeCavage SignatureScheme = "draft-cavage"
+
+	// SchemeRFC9421 signs requests using the 'Signature-Input' and 'Signature' headers as defined
+	// in RFC 9421 (HTTP Message Signatures).
+	SchemeRFC9421 SignatureScheme = "rfc9421"
+)
+
 // SignerConfig contains the configuration for signing HTTP requests.
 type SignerConfig struct {
 	Headers []string
+
+	// Scheme is the default signature scheme. If not set then SchemeCavage is used.
+	Scheme SignatureScheme
+
+	// PeerSchemes overrides the signature scheme for the given peers. The key is the host (with
+	// optional port) of the peer.
+	PeerSchemes map[string]SignatureScheme
+
+	// KeyType is the type of the signing key. If not set then kms.ED25519Type is used.
+	KeyType kms.KeyType
+}
+
+// ParseSignatureScheme parses the given signature scheme.
+func ParseSignatureScheme(value string) (SignatureScheme, error) {
+	switch scheme := SignatureScheme(value); scheme {
+	case SchemeCavage, SchemeRFC9421:
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("unsupported HTTP signature scheme [%s]", value)
+	}
 }
 
 type signer interface {
@@ -49,11 +83,23 @@ type signer interface {
 // Signer signs HTTP requests.
 type Signer struct {
 	SignerConfig
-	signer func() signer
+	signer        func() signer
+	messageSigner *messageSignatures
 }
 
-// NewSigner returns a new signer.
-func NewSigner(cfg SignerConfig, cr crypto.Crypto, km kms.KeyManager, keyID string) *Signer {
+// NewSigner returns a new signer. ErrUnsupportedKeyType is returned if requests may not be signed
+// with the configured type of key.
+func NewSigner(cfg SignerConfig, cr crypto.Crypto, km kms.KeyManager, keyID string) (*Signer, error) {
+	keyType := cfg.KeyType
+	if keyType == "" {
+		keyType = kms.ED25519Type
+	}
+
+	alg, err := messageSignatureAlgorithm(keyType)
+	if err != nil {
+		return nil, err
+	}
+
 	algo := NewSignerAlgorithm(cr, km, keyID)
 	secretRetriever := &SecretRetriever{}
 
@@ -66,18 +112,35 @@ func NewSigner(cfg SignerConfig, cr crypto.Crypto, km kms.KeyManager, keyID stri
 			hs.SetDefaultSignatureHeaders(cfg.Headers)
 			hs.SetSignatureHashAlgorithm(algo)
 
+			if err := hs.SetDefaultDigestAlgorithm(digestAlgorithm); err != nil {
+				// Should never happen since SHA-256 is supported by the HTTP signature library.
+				logger.Warnf("Error setting digest algorithm [%s]: %s", digestAlgorithm, err)
+			}
+
 			return hs
 		},
-	}
+		messageSigner: newMessageSignatures(algo, alg),
+	}, nil
 }
 
 // SignRequest signs an HTTP request.
 func (s *Signer) SignRequest(pubKeyID string, req *http.Request) error {
 	req.Header.Add(dateHeader, date())
 
-	logger.Debugf("Signing request for %s. Public key ID [%s]. Headers: %s", req.RequestURI, pubKeyID, req.Header)
+	scheme := s.schemeFor(req)
+
+	logger.Debugf("Signing request for %s using scheme [%s]. Public key ID [%s]. Headers: %s",
+		req.RequestURI, scheme, pubKeyID, req.Header)
+
+	var err error
+
+	if scheme == SchemeRFC9421 {
+		err = s.messageSigner.Sign(pubKeyID, s.Headers, req)
+	} else {
+		err = s.signer().Sign(pubKeyID, req)
+	}
 
-	if err := s.signer().Sign(pubKeyID, req); err != nil {
+	if err != nil {
 		return fmt.Errorf("sign request with public key ID [%s]: %w", pubKeyID, err)
 	}
 
@@ -86,6 +149,28 @@ func (s *Signer) SignRequest(pubKeyID string, req *http.Request) error {
 	return nil
 }
 
+// schemeFor returns the signature scheme for the target of the given request. A scheme configured
+// for the peer's host and port takes precedence over one configured for the host only.
+func (s *Signer) schemeFor(req *http.Request) SignatureScheme {
+	if req.URL != nil && len(s.PeerSchemes) > 0 {
+		if scheme, ok := s.PeerSchemes[req.URL.Host]; ok {
+			return scheme
+		}
+
+		if host, _, err := net.SplitHostPort(req.URL.Host); err == nil {
+			if scheme, ok := s.PeerSchemes[host]; ok {
+				return scheme
+			}
+		}
+	}
+
+	if s.Scheme == "" {
+		return SchemeCavage
+	}
+
+	return s.Scheme
+}
+
 func date() string {
 	return fmt.Sprintf("%s GMT", time.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05"))
 }
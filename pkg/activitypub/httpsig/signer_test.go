@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	"github.com/stretchr/testify/require"
@@ -21,7 +22,8 @@ func TestSigner(t *testing.T) {
 	const keyID = "123456"
 
 	t.Run("GET", func(t *testing.T) {
-		s := NewSigner(DefaultGetSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		s, err := NewSigner(DefaultGetSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		require.NoError(t, err)
 
 		req, err := http.NewRequest(http.MethodGet, "https://domain1.com", nil)
 		require.NoError(t, err)
@@ -33,7 +35,8 @@ func TestSigner(t *testing.T) {
 	})
 
 	t.Run("POST", func(t *testing.T) {
-		s := NewSigner(DefaultPostSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		s, err := NewSigner(DefaultPostSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		require.NoError(t, err)
 
 		payload := []byte("payload")
 
@@ -47,10 +50,59 @@ func TestSigner(t *testing.T) {
 		require.NotEmpty(t, req.Header["Signature"])
 	})
 
+	t.Run("RFC 9421", func(t *testing.T) {
+		cfg := DefaultPostSignerConfig()
+		cfg.Scheme = SchemeRFC9421
+
+		s, err := NewSigner(cfg, &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		require.NoError(t, err)
+
+		payload := []byte("payload")
+
+		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SignRequest("pubKeyID", req))
+
+		require.NotEmpty(t, req.Header[dateHeader])
+		require.NotEmpty(t, req.Header["Content-Digest"])
+		require.NotEmpty(t, req.Header["Signature-Input"])
+		require.NotEmpty(t, req.Header["Signature"])
+		require.Empty(t, req.Header["Digest"])
+	})
+
+	t.Run("Peer schemes", func(t *testing.T) {
+		cfg := DefaultGetSignerConfig()
+		cfg.PeerSchemes = map[string]SignatureScheme{
+			"domain2.com":      SchemeRFC9421,
+			"domain3.com":      SchemeRFC9421,
+			"domain3.com:8443": SchemeCavage,
+		}
+
+		s, err := NewSigner(cfg, &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		require.NoError(t, err)
+
+		for target, scheme := range map[string]SignatureScheme{
+			"https://domain1.com":           SchemeCavage,
+			"https://domain2.com":           SchemeRFC9421,
+			"https://domain2.com:8443/path": SchemeRFC9421,
+			"https://domain3.com:8443":      SchemeCavage,
+			"https://domain3.com:9443":      SchemeRFC9421,
+		} {
+			req, err := http.NewRequest(http.MethodGet, target, nil)
+			require.NoError(t, err)
+
+			require.NoError(t, s.SignRequest("pubKeyID", req))
+			require.NotEmpty(t, req.Header["Signature"])
+			require.Equalf(t, scheme == SchemeRFC9421, isMessageSignature(req), "unexpected scheme for %s", target)
+		}
+	})
+
 	t.Run("Signer error", func(t *testing.T) {
 		errExpected := errors.New("injected KMS error")
 
-		s := NewSigner(SignerConfig{}, &mockcrypto.Crypto{}, &mockkms.KeyManager{GetKeyErr: errExpected}, keyID)
+		s, err := NewSigner(SignerConfig{}, &mockcrypto.Crypto{}, &mockkms.KeyManager{GetKeyErr: errExpected}, keyID)
+		require.NoError(t, err)
 
 		payload := []byte("payload")
 
@@ -61,4 +113,43 @@ func TestSigner(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), err.Error())
 	})
+
+	t.Run("RFC 9421 signer error", func(t *testing.T) {
+		errExpected := errors.New("injected KMS error")
+
+		cfg := DefaultPostSignerConfig()
+		cfg.Scheme = SchemeRFC9421
+
+		s, err := NewSigner(cfg, &mockcrypto.Crypto{}, &mockkms.KeyManager{GetKeyErr: errExpected}, keyID)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer([]byte("payload")))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, s.SignRequest("pubKeyID", req), errExpected)
+	})
+
+	t.Run("Unsupported key type", func(t *testing.T) {
+		cfg := DefaultPostSignerConfig()
+		cfg.Scheme = SchemeRFC9421
+		cfg.KeyType = kms.ECDSAP256TypeIEEEP1363
+
+		s, err := NewSigner(cfg, &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+		require.ErrorIs(t, err, ErrUnsupportedKeyType)
+		require.Contains(t, err.Error(), string(kms.ECDSAP256TypeIEEEP1363))
+		require.Nil(t, s)
+	})
+}
+
+func TestParseSignatureScheme(t *testing.T) {
+	scheme, err := ParseSignatureScheme("rfc9421")
+	require.NoError(t, err)
+	require.Equal(t, SchemeRFC9421, scheme)
+
+	scheme, err = ParseSignatureScheme("draft-cavage")
+	require.NoError(t, err)
+	require.Equal(t, SchemeCavage, scheme)
+
+	_, err = ParseSignatureScheme("invalid")
+	require.EqualError(t, err, "unsupported HTTP signature scheme [invalid]")
 }
@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package httpsig
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -33,18 +35,35 @@ type verifier interface {
 	Verify(r *http.Request) error
 }
 
+// DefaultMaxClockSkew is the default maximum difference between the date of a signed request and the
+// local time.
+const DefaultMaxClockSkew = 5 * time.Minute
+
 // Verifier verifies signatures of HTTP requests.
 type Verifier struct {
-	actorRetriever actorRetriever
-	verifier       func() verifier
+	actorRetriever  actorRetriever
+	verifier        func() verifier
+	messageVerifier *messageSignatures
+	maxClockSkew    time.Duration
+}
+
+// VerifierOpt sets a Verifier option.
+type VerifierOpt func(v *Verifier)
+
+// WithMaxClockSkew sets the maximum difference between the date of a signed request and the local time.
+// Requests that are older (or newer) than this are rejected.
+func WithMaxClockSkew(skew time.Duration) VerifierOpt {
+	return func(v *Verifier) {
+		v.maxClockSkew = skew
+	}
 }
 
 // NewVerifier returns a new HTTP signature verifier.
-func NewVerifier(actorRetriever actorRetriever, cr crypto.Crypto, km kms.KeyManager) *Verifier {
+func NewVerifier(actorRetriever actorRetriever, cr crypto.Crypto, km kms.KeyManager, opts ...VerifierOpt) *Verifier {
 	algo := NewVerifierAlgorithm(cr, km, NewKeyResolver(actorRetriever))
 	secretRetriever := &SecretRetriever{}
 
-	return &Verifier{
+	v := &Verifier{
 		actorRetriever: actorRetriever,
 		verifier: func() verifier {
 			// Return a new instance for each verification since the HTTP signature
@@ -54,11 +73,21 @@ func NewVerifier(actorRetriever actorRetriever, cr crypto.Crypto, km kms.KeyMana
 
 			return hs
 		},
+		messageVerifier: newMessageSignatures(algo, algEd25519),
+		maxClockSkew:    DefaultMaxClockSkew,
+	}
+
+	for _, opt := range opts {
+		opt(v)
 	}
+
+	return v
 }
 
 // VerifyRequest verifies the following:
-// - HTTP signature on the request.
+// - HTTP signature on the request (either RFC 9421 or draft-cavage).
+// - The date of the request is within the allowed clock skew.
+// - The digest of the content for state-changing requests (e.g. POST).
 // - Ensures that the key ID in the request header is owned by the actor.
 //
 // Returns:
@@ -68,20 +97,19 @@ func NewVerifier(actorRetriever actorRetriever, cr crypto.Crypto, km kms.KeyMana
 func (v *Verifier) VerifyRequest(req *http.Request) (bool, *url.URL, error) {
 	logger.Debugf("Verifying request. Headers: %s", req.Header)
 
-	err := v.verifier().Verify(req)
-	if err != nil {
-		logger.Infof("Signature verification failed for request %s: %s", req.URL, err)
-
+	sigInput, ok := v.verifySignature(req)
+	if !ok {
 		return false, nil, nil
 	}
 
-	keyID := getKeyIDFromSignatureHeader(req)
-	if keyID == "" {
-		logger.Debugf("'keyId' not found in Signature header in request %s", req.URL)
+	if err := v.validateRequest(req, sigInput); err != nil {
+		logger.Infof("Invalid signed request %s: %s", req.URL, err)
 
 		return false, nil, nil
 	}
 
+	keyID := sigInput.keyID
+
 	logger.Debugf("Verifying keyId [%s] from signature header ...", keyID)
 
 	keyIRI, err := url.Parse(keyID)
@@ -123,30 +151,112 @@ func (v *Verifier) VerifyRequest(req *http.Request) (bool, *url.URL, error) {
 	return true, actor.ID().URL(), nil
 }
 
-func getKeyIDFromSignatureHeader(req *http.Request) string {
-	signatureHeader, ok := req.Header["Signature"]
-	if !ok || len(signatureHeader) == 0 {
-		logger.Debugf("'Signature' not found in request header for request %s", req.URL)
+// verifySignature verifies the signature on the request and returns the key ID and the headers
+// covered by the signature.
+func (v *Verifier) verifySignature(req *http.Request) (*signatureInput, bool) {
+	if isMessageSignature(req) {
+		sigInput, err := v.messageVerifier.Verify(req)
+		if err != nil {
+			logger.Infof("RFC 9421 signature verification failed for request %s: %s", req.URL, err)
+
+			return nil, false
+		}
+
+		return sigInput, true
+	}
+
+	err := v.verifier().Verify(req)
+	if err != nil {
+		logger.Infof("Signature verification failed for request %s: %s", req.URL, err)
+
+		return nil, false
+	}
+
+	params := getSignatureParams(req)
+
+	keyID := params["keyId"]
+	if keyID == "" {
+		logger.Debugf("'keyId' not found in Signature header in request %s", req.URL)
+
+		return nil, false
+	}
+
+	return &signatureInput{
+		keyID:      keyID,
+		components: strings.Fields(strings.ToLower(params["headers"])),
+	}, true
+}
+
+// validateRequest ensures that the date and the content of the request are protected by the signature.
+func (v *Verifier) validateRequest(req *http.Request, sigInput *signatureInput) error {
+	if err := v.validateDate(req, sigInput); err != nil {
+		return err
+	}
+
+	return validateDigest(req, sigInput)
+}
+
+func (v *Verifier) validateDate(req *http.Request, sigInput *signatureInput) error {
+	var date time.Time
+
+	switch {
+	case sigInput.covers(strings.ToLower(dateHeader)):
+		d, err := http.ParseTime(req.Header.Get(dateHeader))
+		if err != nil {
+			return fmt.Errorf("invalid %s header: %w", dateHeader, err)
+		}
+
+		date = d
+	case sigInput.created != nil:
+		date = *sigInput.created
+	default:
+		return fmt.Errorf("signature must cover the %s header", dateHeader)
+	}
+
+	now := time.Now()
+
+	skew := now.Sub(date)
+
+	if skew > v.maxClockSkew || skew < -v.maxClockSkew {
+		return fmt.Errorf("request date [%s] is not within the allowed clock skew of %s",
+			date.UTC().Format(http.TimeFormat), v.maxClockSkew)
+	}
+
+	// The 'created' and 'expires' parameters of an RFC 9421 signature are subject to the same clock skew.
+	if sigInput.created != nil && sigInput.created.After(now.Add(v.maxClockSkew)) {
+		return errors.New("signature created in the future")
+	}
 
-		return ""
+	if sigInput.expires != nil && now.After(sigInput.expires.Add(v.maxClockSkew)) {
+		return errors.New("signature expired")
 	}
 
-	var keyID string
+	return nil
+}
+
+// getSignatureParams returns the parameters of the draft-cavage Signature header.
+func getSignatureParams(req *http.Request) map[string]string {
+	params := make(map[string]string)
+
+	values, ok := req.Header[signatureHeader]
+	if !ok || len(values) == 0 {
+		logger.Debugf("'Signature' not found in request header for request %s", req.URL)
+
+		return params
+	}
 
 	const kvLength = 2
 
-	for _, v := range signatureHeader {
+	for _, v := range values {
 		for _, kv := range strings.Split(v, ",") {
-			parts := strings.Split(kv, "=")
+			parts := strings.SplitN(strings.TrimSpace(kv), "=", kvLength)
 			if len(parts) != kvLength {
 				continue
 			}
 
-			if parts[0] == "keyId" {
-				keyID = strings.ReplaceAll(parts[1], `"`, "")
-			}
+			params[parts[0]] = strings.ReplaceAll(parts[1], `"`, "")
 		}
 	}
 
-	return keyID
+	return params
 }
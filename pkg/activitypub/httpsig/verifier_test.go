@@ -13,8 +13,10 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
@@ -59,8 +61,8 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 	actorIRI := testutil.MustParseURL("https://example.com/services/orb")
 	pubKeyIRI := testutil.NewMockID(actorIRI, "/keys/main-key")
 
-	signer := NewSigner(DefaultGetSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
-	require.NotNil(t, signer)
+	signer, err := NewSigner(DefaultPostSignerConfig(), &mockcrypto.Crypto{}, &mockkms.KeyManager{}, keyID)
+	require.NoError(t, err)
 
 	payload := []byte("payload")
 
@@ -84,6 +86,7 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 		v := &Verifier{
 			actorRetriever: retriever,
 			verifier:       func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew:   DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -119,6 +122,7 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 		v := &Verifier{
 			actorRetriever: retriever,
 			verifier:       func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew:   DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -136,12 +140,15 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 		v := &Verifier{
 			actorRetriever: retriever,
 			verifier:       func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew:   DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
 		require.NoError(t, err)
 
-		req.Header["Signature"] = []string{fmt.Sprintf(`keyId="%s"`, []byte{0})}
+		require.NoError(t, signer.SignRequest(publicKey.ID.String(), req))
+
+		req.Header["Signature"] = []string{fmt.Sprintf(`keyId="%s",headers="(request-target) date digest"`, []byte{0})}
 
 		ok, actorID, err := v.VerifyRequest(req)
 		require.NoError(t, err)
@@ -153,6 +160,7 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 		v := &Verifier{
 			actorRetriever: retriever,
 			verifier:       func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew:   DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -171,6 +179,7 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 		v := &Verifier{
 			actorRetriever: servicemocks.NewActivitPubClient().WithPublicKey(publicKey),
 			verifier:       func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew:   DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -190,7 +199,8 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 			actorRetriever: servicemocks.NewActivitPubClient().
 				WithPublicKey(publicKey).
 				WithActor(aptestutil.NewMockService(actorIRI, aptestutil.WithPublicKey(nil))),
-			verifier: func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			verifier:     func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew: DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -215,7 +225,8 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 			actorRetriever: servicemocks.NewActivitPubClient().
 				WithPublicKey(publicKey).
 				WithActor(aptestutil.NewMockService(actorIRI, aptestutil.WithPublicKey(actorPublicKey))),
-			verifier: func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			verifier:     func() verifier { return &mocks.HTTPSignatureVerifier{} },
+			maxClockSkew: DefaultMaxClockSkew,
 		}
 
 		req, err := http.NewRequest(http.MethodPost, "https://domain1.com", bytes.NewBuffer(payload))
@@ -230,6 +241,256 @@ func TestVerifier_VerifyRequest(t *testing.T) {
 	})
 }
 
+func TestVerifier_ValidateRequest(t *testing.T) {
+	actorIRI := testutil.MustParseURL("https://example.com/services/orb")
+	pubKeyIRI := testutil.NewMockID(actorIRI, "/keys/main-key")
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyPem, err := getPublicKeyPem(pubKey)
+	require.NoError(t, err)
+
+	publicKey := vocab.NewPublicKey(
+		vocab.WithID(pubKeyIRI),
+		vocab.WithOwner(actorIRI),
+		vocab.WithPublicKeyPem(string(pubKeyPem)),
+	)
+
+	retriever := servicemocks.NewActivitPubClient().
+		WithPublicKey(publicKey).
+		WithActor(aptestutil.NewMockService(actorIRI, aptestutil.WithPublicKey(publicKey)))
+
+	newVerifier := func(opts ...VerifierOpt) *Verifier {
+		v := NewVerifier(retriever, &mockcrypto.Crypto{}, &mockkms.KeyManager{}, opts...)
+		v.verifier = func() verifier { return &mocks.HTTPSignatureVerifier{} }
+
+		return v
+	}
+
+	newRequest := func(method string, payload []byte, headers, date string) *http.Request {
+		req, err := http.NewRequest(method, "https://domain1.com/services/orb/inbox", bytes.NewBuffer(payload))
+		require.NoError(t, err)
+
+		req.Header.Set(signatureHeader, fmt.Sprintf(`keyId="%s",algorithm="Ed25519",headers="%s",signature="c2ln"`,
+			publicKey.ID, headers))
+
+		if date != "" {
+			req.Header.Set(dateHeader, date)
+		}
+
+		return req
+	}
+
+	now := time.Now().UTC()
+
+	t.Run("Success", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("payload"), "(request-target) date digest",
+			now.Format(http.TimeFormat))
+		req.Header.Set(digestHeader, "SHA-256=I59Z7VXnN8dxR89VrQwbAwttfudIp0JpUvm4UtWpNeU=")
+
+		ok, actorID, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, actorIRI.String(), actorID.String())
+
+		// The body may still be read after verification.
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, "payload", string(body))
+	})
+
+	t.Run("GET without digest", func(t *testing.T) {
+		req := newRequest(http.MethodGet, nil, "(request-target) date", now.Format(http.TimeFormat))
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("SHA-512 digest", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("payload"), "(request-target) date digest",
+			now.Format(http.TimeFormat))
+		req.Header.Set(digestHeader, "SHA-512=cLM86ckEfjD5F+fqE+Qvd2cAjD9PnJuvSeQ5D8YlVJ6WJe7jm5RUUHTooYJM8/I4Rj"+
+			"sRvAPZc0jg/CmZyh//fw==")
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("Digest not signed", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("payload"), "(request-target) date", now.Format(http.TimeFormat))
+		req.Header.Set(digestHeader, "SHA-256=I59Z7VXnN8dxR89VrQwbAwttfudIp0JpUvm4UtWpNeU=")
+
+		ok, actorID, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Nil(t, actorID)
+	})
+
+	t.Run("Digest mismatch", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("tampered payload"), "(request-target) date digest",
+			now.Format(http.TimeFormat))
+		req.Header.Set(digestHeader, "SHA-256=I59Z7VXnN8dxR89VrQwbAwttfudIp0JpUvm4UtWpNeU=")
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Unsupported digest algorithm", func(t *testing.T) {
+		req := newRequest(http.MethodPost, []byte("payload"), "(request-target) date digest",
+			now.Format(http.TimeFormat))
+		req.Header.Set(digestHeader, "MD5=Mhw89IbtUJFk7eweGYH+yA==")
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Date not signed", func(t *testing.T) {
+		req := newRequest(http.MethodGet, nil, "(request-target)", now.Format(http.TimeFormat))
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		req := newRequest(http.MethodGet, nil, "(request-target) date", "invalid")
+
+		ok, _, err := newVerifier().VerifyRequest(req)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Date outside of clock skew", func(t *testing.T) {
+		for _, date := range []time.Time{now.Add(-10 * time.Minute), now.Add(10 * time.Minute)} {
+			req := newRequest(http.MethodGet, nil, "(request-target) date", date.Format(http.TimeFormat))
+
+			ok, _, err := newVerifier().VerifyRequest(req)
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			req = newRequest(http.MethodGet, nil, "(request-target) date", date.Format(http.TimeFormat))
+
+			ok, _, err = newVerifier(WithMaxClockSkew(time.Hour)).VerifyRequest(req)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("Signature created and expires outside of clock skew", func(t *testing.T) {
+		req := newRequest(http.MethodGet, nil, "", now.Format(http.TimeFormat))
+
+		components := []string{"date"}
+		created := now.Add(time.Minute)
+
+		require.NoError(t, newVerifier().validateDate(req,
+			&signatureInput{components: components, created: &created}))
+		require.EqualError(t, newVerifier(WithMaxClockSkew(10*time.Second)).validateDate(req,
+			&signatureInput{components: components, created: &created}), "signature created in the future")
+
+		created = now
+		expires := now.Add(-time.Minute)
+
+		require.NoError(t, newVerifier().validateDate(req,
+			&signatureInput{components: components, created: &created, expires: &expires}))
+		require.EqualError(t, newVerifier(WithMaxClockSkew(10*time.Second)).validateDate(req,
+			&signatureInput{components: components, created: &created, expires: &expires}), "signature expired")
+	})
+}
+
+func TestVerifier_Interop(t *testing.T) {
+	const keyID = "123456"
+
+	actorIRI := testutil.MustParseURL("https://example.com/services/orb")
+	pubKeyIRI := testutil.NewMockID(actorIRI, "/keys/main-key")
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyPem, err := getPublicKeyPem(pubKey)
+	require.NoError(t, err)
+
+	publicKey := vocab.NewPublicKey(
+		vocab.WithID(pubKeyIRI),
+		vocab.WithOwner(actorIRI),
+		vocab.WithPublicKeyPem(string(pubKeyPem)),
+	)
+
+	retriever := servicemocks.NewActivitPubClient().
+		WithPublicKey(publicKey).
+		WithActor(aptestutil.NewMockService(actorIRI, aptestutil.WithPublicKey(publicKey)))
+
+	cr := &mockcrypto.Crypto{
+		SignFn: func(msg []byte, _ interface{}) ([]byte, error) {
+			return ed25519.Sign(privKey, msg), nil
+		},
+	}
+
+	v := NewVerifier(retriever, cr, &mockkms.KeyManager{})
+
+	for _, scheme := range []SignatureScheme{SchemeCavage, SchemeRFC9421} {
+		scheme := scheme
+
+		t.Run(string(scheme), func(t *testing.T) {
+			getCfg := DefaultGetSignerConfig()
+			getCfg.Scheme = scheme
+
+			postCfg := DefaultPostSignerConfig()
+			postCfg.Scheme = scheme
+
+			t.Run("GET", func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodGet, "https://domain1.com/services/orb/outbox?page=true", nil)
+				require.NoError(t, err)
+
+				s, err := NewSigner(getCfg, cr, &mockkms.KeyManager{}, keyID)
+				require.NoError(t, err)
+				require.NoError(t, s.SignRequest(publicKey.ID.String(), req))
+
+				ok, actorID, err := v.VerifyRequest(req)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, actorIRI.String(), actorID.String())
+			})
+
+			t.Run("POST", func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodPost, "https://domain1.com/services/orb/inbox",
+					bytes.NewBuffer([]byte("payload")))
+				require.NoError(t, err)
+
+				s, err := NewSigner(postCfg, cr, &mockkms.KeyManager{}, keyID)
+				require.NoError(t, err)
+				require.NoError(t, s.SignRequest(publicKey.ID.String(), req))
+
+				ok, actorID, err := v.VerifyRequest(req)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, actorIRI.String(), actorID.String())
+			})
+
+			t.Run("Tampered request", func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodPost, "https://domain1.com/services/orb/inbox",
+					bytes.NewBuffer([]byte("payload")))
+				require.NoError(t, err)
+
+				s, err := NewSigner(postCfg, cr, &mockkms.KeyManager{}, keyID)
+				require.NoError(t, err)
+				require.NoError(t, s.SignRequest(publicKey.ID.String(), req))
+
+				req.Header.Set(dateHeader, "Tue, 20 Apr 2021 02:07:55 GMT")
+
+				ok, actorID, err := v.VerifyRequest(req)
+				require.NoError(t, err)
+				require.False(t, ok)
+				require.Nil(t, actorID)
+			})
+		})
+	}
+}
+
 func getPublicKeyPem(pubKey interface{}) ([]byte, error) {
 	keyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
 	if err != nil {
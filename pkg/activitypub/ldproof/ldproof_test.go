@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ldproof
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+const kmsKeyID = "123456"
+
+var (
+	service1IRI  = testutil.MustParseURL("https://orb.domain1.com/services/orb")
+	service2IRI  = testutil.MustParseURL("https://orb.domain2.com/services/orb")
+	publicKeyIRI = testutil.MustParseURL("https://orb.domain1.com/services/orb/keys/main-key")
+)
+
+func TestSignAndVerify(t *testing.T) {
+	pubKeyBytes, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cr := &mockcrypto.Crypto{SignFn: func(bytes []byte, i interface{}) ([]byte, error) {
+		return ed25519.Sign(privKey, bytes), nil
+	}}
+
+	publicKey := newPublicKey(t, pubKeyBytes, service1IRI)
+
+	signer, err := NewSigner(cr, &mockkms.KeyManager{}, kmsKeyID, kms.ED25519Type, publicKeyIRI,
+		testutil.GetLoader(t))
+	require.NoError(t, err)
+	require.NotNil(t, signer)
+
+	verifier := NewVerifier(mocks.NewActivitPubClient().WithPublicKey(publicKey), testutil.GetLoader(t))
+	require.NotNil(t, verifier)
+
+	t.Run("Success", func(t *testing.T) {
+		activity := newActivity(service1IRI)
+		require.False(t, HasProof(activity))
+
+		signed, err := signer.Sign(activity)
+		require.NoError(t, err)
+		require.True(t, HasProof(signed))
+		require.Equal(t, activity.ID().String(), signed.ID().String())
+
+		require.NoError(t, verifier.Verify(signed))
+
+		// Sign the activity again to ensure that the existing proof is replaced.
+		signed, err = signer.Sign(signed)
+		require.NoError(t, err)
+
+		require.NoError(t, verifier.Verify(signed))
+	})
+
+	t.Run("Activity Anchors extension terms", func(t *testing.T) {
+		anchorEvent := vocab.NewAnchorEvent(
+			vocab.WithURL(testutil.MustParseURL("hl:uEiBN4vd1lgKx_K93ltpdI32T6nIGlwXhJcSwbeVAg8NMxg")),
+			vocab.WithIndex(testutil.MustParseURL("hl:uEiCJWrCq8ttsWob5UVueRQiQ_QUrocJY6ZA8BDgzgakuhg")),
+		)
+
+		activity := vocab.NewCreateActivity(
+			vocab.NewObjectProperty(vocab.WithAnchorEvent(anchorEvent)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/create")),
+			vocab.WithActor(service1IRI),
+			vocab.WithTo(service2IRI),
+		)
+
+		signed, err := signer.Sign(activity)
+		require.NoError(t, err)
+		require.True(t, signed.Context().Contains(vocab.ContextActivityAnchorsExt))
+
+		require.NoError(t, verifier.Verify(signed))
+
+		doc, err := vocab.MarshalToDoc(signed)
+		require.NoError(t, err)
+
+		obj, ok := doc["object"].(map[string]interface{})
+		require.True(t, ok)
+
+		obj["index"] = "hl:uEiAbcd"
+
+		tampered := &vocab.ActivityType{}
+		require.NoError(t, tampered.UnmarshalJSON(mustMarshal(t, doc)))
+
+		err = verifier.Verify(tampered)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify proof of activity")
+	})
+
+	t.Run("KMS error", func(t *testing.T) {
+		errExpected := errors.New("injected KMS error")
+
+		s, err := NewSigner(cr, &mockkms.KeyManager{GetKeyErr: errExpected}, kmsKeyID, kms.ED25519Type,
+			publicKeyIRI, testutil.GetLoader(t))
+		require.NoError(t, err)
+
+		_, err = s.Sign(newActivity(service1IRI))
+		require.True(t, errors.Is(err, errExpected))
+	})
+
+	t.Run("Crypto error", func(t *testing.T) {
+		errExpected := errors.New("injected sign error")
+
+		s, err := NewSigner(&mockcrypto.Crypto{SignErr: errExpected}, &mockkms.KeyManager{}, kmsKeyID,
+			kms.ED25519Type, publicKeyIRI, testutil.GetLoader(t))
+		require.NoError(t, err)
+
+		_, err = s.Sign(newActivity(service1IRI))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("No proof", func(t *testing.T) {
+		err := verifier.Verify(newActivity(service1IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+
+	t.Run("Tampered activity", func(t *testing.T) {
+		signed, err := signer.Sign(newActivity(service1IRI))
+		require.NoError(t, err)
+
+		doc, err := vocab.MarshalToDoc(signed)
+		require.NoError(t, err)
+
+		doc["to"] = testutil.MustParseURL("https://orb.domain3.com/services/orb").String()
+
+		tampered := &vocab.ActivityType{}
+		require.NoError(t, tampered.UnmarshalJSON(mustMarshal(t, doc)))
+
+		err = verifier.Verify(tampered)
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "verify proof of activity")
+	})
+
+	t.Run("Undefined term", func(t *testing.T) {
+		activity := newActivity(service1IRI)
+
+		doc, err := vocab.MarshalToDoc(activity)
+		require.NoError(t, err)
+
+		doc["undefinedTerm"] = "some value"
+
+		a := &vocab.ActivityType{}
+		require.NoError(t, a.UnmarshalJSON(mustMarshal(t, doc)))
+
+		_, err = signer.Sign(a)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "term [undefinedTerm] is not defined")
+
+		signed, err := signer.Sign(activity)
+		require.NoError(t, err)
+
+		doc, err = vocab.MarshalToDoc(signed)
+		require.NoError(t, err)
+
+		doc["undefinedTerm"] = "some value"
+
+		tampered := &vocab.ActivityType{}
+		require.NoError(t, tampered.UnmarshalJSON(mustMarshal(t, doc)))
+
+		err = verifier.Verify(tampered)
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "term [undefinedTerm] is not defined")
+	})
+
+	t.Run("Unsupported proof type", func(t *testing.T) {
+		signed, err := signer.Sign(newActivity(service1IRI))
+		require.NoError(t, err)
+
+		doc := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(mustMarshal(t, signed), &doc))
+
+		proofs, ok := doc["proof"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, proofs, 1)
+
+		proof, ok := proofs[0].(map[string]interface{})
+		require.True(t, ok)
+
+		proof["type"] = "JsonWebSignature2020"
+
+		a := &vocab.ActivityType{}
+		require.NoError(t, a.UnmarshalJSON(mustMarshal(t, doc)))
+
+		err = verifier.Verify(a)
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "unsupported proof type")
+	})
+
+	t.Run("Key not owned by actor", func(t *testing.T) {
+		signed, err := signer.Sign(newActivity(service2IRI))
+		require.NoError(t, err)
+
+		err = verifier.Verify(signed)
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "is not owned by actor")
+	})
+
+	t.Run("Public key retrieval error", func(t *testing.T) {
+		errExpected := errors.New("injected client error")
+
+		signed, err := signer.Sign(newActivity(service1IRI))
+		require.NoError(t, err)
+
+		v := NewVerifier(mocks.NewActivitPubClient().WithError(errExpected), testutil.GetLoader(t))
+
+		err = v.Verify(signed)
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
+
+func TestNewSigner_UnsupportedKeyType(t *testing.T) {
+	s, err := NewSigner(&mockcrypto.Crypto{}, &mockkms.KeyManager{}, kmsKeyID, kms.ECDSAP256TypeIEEEP1363,
+		publicKeyIRI, testutil.GetLoader(t))
+	require.True(t, errors.Is(err, ErrUnsupportedKeyType))
+	require.Nil(t, s)
+}
+
+func newActivity(actor *url.URL) *vocab.ActivityType {
+	return vocab.NewFollowActivity(
+		vocab.NewObjectProperty(vocab.WithIRI(service2IRI)),
+		vocab.WithID(testutil.NewMockID(actor, "/activities/follow")),
+		vocab.WithActor(actor),
+		vocab.WithTo(service2IRI),
+	)
+}
+
+func newPublicKey(t *testing.T, pubKey crypto.PublicKey, owner *url.URL) *vocab.PublicKeyType {
+	t.Helper()
+
+	keyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
+	require.NoError(t, err)
+
+	return vocab.NewPublicKey(
+		vocab.WithID(publicKeyIRI),
+		vocab.WithOwner(owner),
+		vocab.WithPublicKeyPem(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes}))),
+	)
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	b, err := vocab.Marshal(v)
+	require.NoError(t, err)
+
+	return b
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ldproof
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	ariessigner "github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/piprate/json-gold/ld"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+var logger = log.New("activitypub_ldproof")
+
+const (
+	// Ed25519Signature2018 is the signature suite used for activity proofs.
+	Ed25519Signature2018 = "Ed25519Signature2018"
+
+	// ContextEd25519Signature2018 is the JSON-LD context that defines the terms of the proof.
+	ContextEd25519Signature2018 = "https://w3id.org/security/suites/ed25519-2018/v1"
+
+	assertionMethod = "assertionMethod"
+	propertyContext = "@context"
+	propertyProof   = "proof"
+)
+
+// ErrUnsupportedKeyType is returned by NewSigner if proofs can't be created with the given type of key.
+var ErrUnsupportedKeyType = errors.New("unsupported key type for activity proofs")
+
+// signatureTypes maps the KMS key types that may be used to sign activities to the signature suite of the proof.
+var signatureTypes = map[kms.KeyType]string{ //nolint:gochecknoglobals
+	kms.ED25519Type: Ed25519Signature2018,
+}
+
+// signatureContexts maps each supported signature suite to the JSON-LD context that defines the terms of the proof.
+var signatureContexts = map[string]string{ //nolint:gochecknoglobals
+	Ed25519Signature2018: ContextEd25519Signature2018,
+}
+
+// Signer adds a linked-data proof to an activity using the service's key. The proof allows a third
+// party to verify who created the activity after it has been re-served (for example, from the
+// activities endpoint) or embedded in another activity.
+type Signer struct {
+	crypto             crypto.Crypto
+	km                 kms.KeyManager
+	keyID              string
+	signatureType      string
+	verificationMethod string
+	docLoader          ld.DocumentLoader
+}
+
+// NewSigner returns a new activity signer. The key ID is the ID of the key in the KMS and the
+// verification method is the IRI of the service's public key. The signature suite of the proof is
+// chosen from the type of the key. ErrUnsupportedKeyType is returned if no suite supports the key type.
+func NewSigner(c crypto.Crypto, km kms.KeyManager, keyID string, keyType kms.KeyType, verificationMethod *url.URL,
+	docLoader ld.DocumentLoader) (*Signer, error) {
+	signatureType, ok := signatureTypes[keyType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, keyType)
+	}
+
+	return &Signer{
+		crypto:             c,
+		km:                 km,
+		keyID:              keyID,
+		signatureType:      signatureType,
+		verificationMethod: verificationMethod.String(),
+		docLoader:          docLoader,
+	}, nil
+}
+
+// Sign returns a copy of the given activity with a linked-data proof. Any existing proof is replaced.
+func (s *Signer) Sign(activity *vocab.ActivityType) (*vocab.ActivityType, error) {
+	doc, err := vocab.MarshalToDoc(activity)
+	if err != nil {
+		return nil, fmt.Errorf("marshal activity: %w", err)
+	}
+
+	delete(doc, propertyProof)
+
+	// The extension context defines the terms that aren't in the Activity Anchors v1 context so that every
+	// property of the activity is covered by the proof.
+	addContext(doc, string(vocab.ContextActivityAnchorsExt))
+	addContext(doc, signatureContexts[s.signatureType])
+
+	if err := ensureTermsDefined(doc, s.docLoader); err != nil {
+		return nil, fmt.Errorf("activity [%s]: %w", activity.ID(), err)
+	}
+
+	docBytes, err := vocab.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal activity: %w", err)
+	}
+
+	kh, err := s.km.Get(s.keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get key handle [%s]: %w", s.keyID, err)
+	}
+
+	now := time.Now()
+
+	signedBytes, err := ariessigner.New(
+		newSignerSuite(s.signatureType, suite.NewCryptoSigner(s.crypto, kh)),
+	).Sign(
+		&ariessigner.Context{
+			SignatureType:           s.signatureType,
+			SignatureRepresentation: proof.SignatureJWS,
+			VerificationMethod:      s.verificationMethod,
+			Purpose:                 assertionMethod,
+			Created:                 &now,
+		},
+		docBytes, jsonld.WithDocumentLoader(s.docLoader),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("add proof to activity [%s]: %w", activity.ID(), err)
+	}
+
+	signed := &vocab.ActivityType{}
+
+	if err := signed.UnmarshalJSON(signedBytes); err != nil {
+		return nil, fmt.Errorf("unmarshal signed activity: %w", err)
+	}
+
+	logger.Debugf("Added proof to activity [%s] using verification method [%s]", activity.ID(), s.verificationMethod)
+
+	return signed, nil
+}
+
+func newSignerSuite(signatureType string, s *suite.CryptoSigner) ariessigner.SignatureSuite {
+	switch signatureType {
+	case Ed25519Signature2018:
+		return ed25519signature2018.New(suite.WithSigner(s))
+	default:
+		// Not reachable since the signature type is validated in NewSigner.
+		panic(fmt.Sprintf("unsupported signature type [%s]", signatureType))
+	}
+}
+
+func addContext(doc vocab.Document, ctx string) {
+	switch c := doc[propertyContext].(type) {
+	case nil:
+		doc[propertyContext] = ctx
+	case string:
+		if c != ctx {
+			doc[propertyContext] = []interface{}{c, ctx}
+		}
+	case []interface{}:
+		for _, v := range c {
+			if v == ctx {
+				return
+			}
+		}
+
+		doc[propertyContext] = append(c, ctx)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ldproof
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+const blankNodePrefix = "_:"
+
+// ensureTermsDefined returns an error if a property of the given document isn't defined by one of the document's
+// contexts. The ActivityStreams context maps undefined terms to blank node identifiers and properties with a blank
+// node identifier are dropped during canonicalization, so such a property wouldn't be covered by the proof.
+func ensureTermsDefined(doc vocab.Document, docLoader ld.DocumentLoader) error {
+	opts := ld.NewJsonLdOptions("")
+	opts.DocumentLoader = docLoader
+
+	expanded, err := ld.NewJsonLdProcessor().Expand(map[string]interface{}(doc), opts)
+	if err != nil {
+		return fmt.Errorf("expand document: %w", err)
+	}
+
+	return checkExpanded(expanded)
+}
+
+func checkExpanded(value interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := checkExpanded(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for property, item := range v {
+			if strings.HasPrefix(property, blankNodePrefix) {
+				return fmt.Errorf("term [%s] is not defined in the context",
+					strings.TrimPrefix(property, blankNodePrefix))
+			}
+
+			if err := checkExpanded(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
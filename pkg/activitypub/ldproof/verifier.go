@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ldproof
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	ariesverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/trustbloc/orb/pkg/activitypub/httpsig"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+type keyRetriever interface {
+	GetActor(actorIRI *url.URL) (*vocab.ActorType, error)
+	GetPublicKey(keyIRI *url.URL) (*vocab.PublicKeyType, error)
+}
+
+// Verifier verifies the linked-data proofs embedded in an activity.
+type Verifier struct {
+	keyRetriever keyRetriever
+	docLoader    ld.DocumentLoader
+}
+
+// NewVerifier returns a new activity proof verifier.
+func NewVerifier(keyRetriever keyRetriever, docLoader ld.DocumentLoader) *Verifier {
+	return &Verifier{
+		keyRetriever: keyRetriever,
+		docLoader:    docLoader,
+	}
+}
+
+// HasProof returns true if the given activity contains a linked-data proof.
+func HasProof(activity *vocab.ActivityType) bool {
+	_, ok := activity.Value(propertyProof)
+
+	return ok
+}
+
+// Verify verifies all of the proofs in the given activity. Each proof must have been created with
+// a key that is owned by the actor of the activity. A bad request error is returned if the activity
+// contains no proof or if any of the proofs is invalid.
+func (v *Verifier) Verify(activity *vocab.ActivityType) error {
+	doc, err := vocab.MarshalToDoc(activity)
+	if err != nil {
+		return fmt.Errorf("marshal activity: %w", err)
+	}
+
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return orberrors.NewBadRequestf("get proofs from activity [%s]: %w", activity.ID(), err)
+	}
+
+	for _, p := range proofs {
+		if _, ok := signatureContexts[p.Type]; !ok {
+			return orberrors.NewBadRequestf("unsupported proof type [%s] in activity [%s]", p.Type, activity.ID())
+		}
+
+		if err := v.verifyOwner(activity, p); err != nil {
+			return err
+		}
+	}
+
+	// Properties whose terms aren't defined are dropped during canonicalization, so reject them since they
+	// aren't covered by the proof.
+	if err := ensureTermsDefined(doc, v.docLoader); err != nil {
+		return orberrors.NewBadRequestf("activity [%s]: %w", activity.ID(), err)
+	}
+
+	docBytes, err := vocab.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshal activity: %w", err)
+	}
+
+	verifier, err := ariesverifier.New(httpsig.NewKeyResolver(v.keyRetriever), newVerifierSuites()...)
+	if err != nil {
+		return fmt.Errorf("create verifier: %w", err)
+	}
+
+	err = verifier.Verify(docBytes, jsonld.WithDocumentLoader(v.docLoader))
+	if err != nil {
+		return orberrors.NewBadRequestf("verify proof of activity [%s]: %w", activity.ID(), err)
+	}
+
+	logger.Debugf("Verified proof of activity [%s]", activity.ID())
+
+	return nil
+}
+
+func (v *Verifier) verifyOwner(activity *vocab.ActivityType, p *proof.Proof) error {
+	keyID, err := p.PublicKeyID()
+	if err != nil {
+		return orberrors.NewBadRequestf("get key ID from proof of activity [%s]: %w", activity.ID(), err)
+	}
+
+	keyIRI, err := url.Parse(keyID)
+	if err != nil {
+		return orberrors.NewBadRequestf("parse key ID [%s]: %w", keyID, err)
+	}
+
+	pubKey, err := v.keyRetriever.GetPublicKey(keyIRI)
+	if err != nil {
+		return orberrors.NewTransientf("get public key [%s]: %w", keyIRI, err)
+	}
+
+	actor := activity.Actor()
+
+	if actor == nil || pubKey.Owner == nil || pubKey.Owner.String() != actor.String() {
+		return orberrors.NewBadRequestf("key [%s] in proof is not owned by actor [%s] of activity [%s]",
+			keyIRI, actor, activity.ID())
+	}
+
+	return nil
+}
+
+// newVerifierSuites returns the verifier suites for the signature suites that are supported for activity proofs.
+func newVerifierSuites() []ariesverifier.SignatureSuite {
+	return []ariesverifier.SignatureSuite{
+		ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/store/storeutil"
@@ -125,16 +126,27 @@ func (h *Activities) handleActivityRefsOfType(w http.ResponseWriter, req *http.R
 		return
 	}
 
+	filter, err := h.getFilter(req)
+	if err != nil {
+		logger.Debugf("[%s] Invalid filter: %s", h.endpoint, err)
+
+		h.writeResponse(w, http.StatusBadRequest, []byte(badRequestResponse))
+
+		return
+	}
+
+	id = filter.applyToURL(id)
+
 	if h.isPaging(req) {
-		h.handleActivitiesPage(w, req, objectIRI, id, refType)
+		h.handleActivitiesPage(w, req, objectIRI, id, refType, filter)
 	} else {
-		h.handleActivities(w, req, objectIRI, id, refType)
+		h.handleActivities(w, req, objectIRI, id, refType, filter)
 	}
 }
 
 func (h *Activities) handleActivities(rw http.ResponseWriter, _ *http.Request, objectIRI, id *url.URL,
-	refType spi.ReferenceType) {
-	activities, err := h.getActivities(objectIRI, id, refType)
+	refType spi.ReferenceType, filter *activityFilter) {
+	activities, err := h.getActivities(objectIRI, id, refType, filter)
 	if err != nil {
 		logger.Errorf("[%s] Error retrieving %s for object IRI [%s]: %s",
 			h.endpoint, h.refType, objectIRI, err)
@@ -158,26 +170,39 @@ func (h *Activities) handleActivities(rw http.ResponseWriter, _ *http.Request, o
 }
 
 func (h *Activities) handleActivitiesPage(rw http.ResponseWriter, req *http.Request, objectIRI, id *url.URL,
-	refType spi.ReferenceType) {
+	refType spi.ReferenceType, filter *activityFilter) {
 	var page *vocab.OrderedCollectionPageType
 
 	var err error
 
-	pageNum, ok := h.getPageNum(req)
-	if ok {
-		page, err = h.getPage(objectIRI, id, refType,
+	cursor, useCursor := h.getCursor(req)
+	pageNum, hasPageNum := h.getPageNum(req)
+
+	switch {
+	case useCursor:
+		page, err = h.getCursorPage(objectIRI, id, refType, filter, cursor, h.paramAsBool(req, beforeParam))
+	case hasPageNum:
+		page, err = h.getPage(objectIRI, id, refType, filter,
 			spi.WithPageSize(h.PageSize),
 			spi.WithPageNum(pageNum),
 			spi.WithSortOrder(h.sortOrder),
 		)
-	} else {
-		page, err = h.getPage(objectIRI, id, refType,
+	default:
+		page, err = h.getPage(objectIRI, id, refType, filter,
 			spi.WithPageSize(h.PageSize),
 			spi.WithSortOrder(h.sortOrder),
 		)
 	}
 
 	if err != nil {
+		if orberrors.IsBadRequest(err) {
+			logger.Debugf("[%s] Invalid page request for object IRI [%s]: %s", h.endpoint, objectIRI, err)
+
+			h.writeResponse(rw, http.StatusBadRequest, []byte(badRequestResponse))
+
+			return
+		}
+
 		logger.Errorf("[%s] Error retrieving page for object IRI [%s]: %s",
 			h.endpoint, objectIRI, err)
 
@@ -199,10 +224,10 @@ func (h *Activities) handleActivitiesPage(rw http.ResponseWriter, req *http.Requ
 	h.writeResponse(rw, http.StatusOK, pageBytes)
 }
 
-func (h *Activities) getActivities(objectIRI, id *url.URL,
-	refType spi.ReferenceType) (*vocab.OrderedCollectionType, error) {
+func (h *Activities) getActivities(objectIRI, id *url.URL, refType spi.ReferenceType,
+	filter *activityFilter) (*vocab.OrderedCollectionType, error) {
 	it, err := h.activityStore.QueryReferences(refType,
-		spi.NewCriteria(
+		filter.criteria(
 			spi.WithObjectIRI(objectIRI),
 		),
 	)
@@ -241,10 +266,10 @@ func (h *Activities) getActivities(objectIRI, id *url.URL,
 	), nil
 }
 
-func (h *Activities) getPage(objectIRI, id *url.URL, refType spi.ReferenceType,
+func (h *Activities) getPage(objectIRI, id *url.URL, refType spi.ReferenceType, filter *activityFilter,
 	opts ...spi.QueryOpt) (*vocab.OrderedCollectionPageType, error) {
 	it, err := h.activityStore.QueryActivities(
-		spi.NewCriteria(
+		filter.criteria(
 			spi.WithReferenceType(refType),
 			spi.WithObjectIRI(objectIRI),
 		), opts...,
@@ -292,6 +317,102 @@ func (h *Activities) getPage(objectIRI, id *url.URL, refType spi.ReferenceType,
 	), nil
 }
 
+// getCursorPage returns the page of activities that follow the given cursor (or precede the cursor if
+// before is true). The next and previous links of the returned page contain the cursors of the last and
+// first items in the page, respectively, so paging is not affected by new activities being added.
+func (h *Activities) getCursorPage(objectIRI, id *url.URL, refType spi.ReferenceType, filter *activityFilter,
+	cursor string, before bool) (*vocab.OrderedCollectionPageType, error) {
+	sortOrder := h.sortOrder
+
+	if before {
+		sortOrder = reverseSortOrder(sortOrder)
+	}
+
+	// Query one more item than the page size in order to determine whether or not there are more items.
+	opts := []spi.QueryOpt{spi.WithPageSize(h.PageSize + 1), spi.WithSortOrder(sortOrder)}
+
+	if cursor != "" {
+		opts = append(opts, spi.WithCursor(cursor))
+	}
+
+	it, err := h.activityStore.QueryActivities(
+		filter.criteria(
+			spi.WithReferenceType(refType),
+			spi.WithObjectIRI(objectIRI),
+		), opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = it.Close()
+		if err != nil {
+			logger.Errorf("failed to close iterator: %s", err.Error())
+		}
+	}()
+
+	activities, cursors, err := readActivitiesWithCursors(it, h.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(activities) > h.PageSize
+
+	if hasMore {
+		activities = activities[:h.PageSize]
+		cursors = cursors[:h.PageSize]
+	}
+
+	if before {
+		// Restore the sort order of the collection.
+		reverseActivities(activities)
+		reverseCursors(cursors)
+	}
+
+	items := make([]*vocab.ObjectProperty, len(activities))
+
+	for i, activity := range activities {
+		items[i] = vocab.NewObjectProperty(vocab.WithActivity(activity))
+	}
+
+	totalItems, err := it.TotalItems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total items from activity query: %w", err)
+	}
+
+	pageID, err := h.getCursorPageURL(id, cursor, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var prev, next *url.URL
+
+	if len(activities) > 0 {
+		if hasMore || before {
+			next, err = h.getCursorPageURL(id, cursors[len(cursors)-1], false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if (hasMore && before) || (!before && cursor != "") {
+			prev, err = h.getCursorPageURL(id, cursors[0], true)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return vocab.NewOrderedCollectionPage(items,
+		vocab.WithContext(vocab.ContextActivityStreams),
+		vocab.WithID(pageID),
+		vocab.WithPrev(prev),
+		vocab.WithNext(next),
+		vocab.WithTotalItems(totalItems),
+	), nil
+}
+
 func (h *Activities) getObjectIRIAndID(req *http.Request) (*url.URL, *url.URL, error) {
 	objectIRI, err := h.getObjectIRI(req)
 	if err != nil {
@@ -306,6 +427,79 @@ func (h *Activities) getObjectIRIAndID(req *http.Request) (*url.URL, *url.URL, e
 	return objectIRI, id, nil
 }
 
+func (h *Activities) getFilter(req *http.Request) (*activityFilter, error) {
+	since, err := h.paramAsTime(req, sinceParam)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := h.paramAsTime(req, untilParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if since != nil && until != nil && !since.Before(*until) {
+		return nil, orberrors.NewBadRequest(fmt.Errorf("'%s' must be before '%s'", sinceParam, untilParam))
+	}
+
+	return &activityFilter{
+		activityType: vocab.Type(h.paramAsString(req, typeParam)),
+		since:        since,
+		until:        until,
+	}, nil
+}
+
+// activityFilter holds the optional activity type and time range (based on the time that the
+// activity was added to the collection) that are used to filter the items in a collection.
+type activityFilter struct {
+	activityType vocab.Type
+	since        *time.Time
+	until        *time.Time
+}
+
+func (f *activityFilter) criteria(opts ...spi.CriteriaOpt) *spi.Criteria {
+	if f.activityType != "" {
+		opts = append(opts, spi.WithType(f.activityType))
+	}
+
+	if f.since != nil {
+		opts = append(opts, spi.WithSince(*f.since))
+	}
+
+	if f.until != nil {
+		opts = append(opts, spi.WithUntil(*f.until))
+	}
+
+	return spi.NewCriteria(opts...)
+}
+
+// applyToURL adds the filter parameters to the given URL so that the collection ID
+// and the page links returned to the client retain the filter.
+func (f *activityFilter) applyToURL(u *url.URL) *url.URL {
+	if f.activityType == "" && f.since == nil && f.until == nil {
+		return u
+	}
+
+	q := u.Query()
+
+	if f.activityType != "" {
+		q.Set(typeParam, string(f.activityType))
+	}
+
+	if f.since != nil {
+		q.Set(sinceParam, f.since.Format(time.RFC3339Nano))
+	}
+
+	if f.until != nil {
+		q.Set(untilParam, f.until.Format(time.RFC3339Nano))
+	}
+
+	filteredURL := *u
+	filteredURL.RawQuery = q.Encode()
+
+	return &filteredURL
+}
+
 // Activity implements a REST handler that retrieves a single activity by ID.
 type Activity struct {
 	*handler
@@ -425,3 +619,45 @@ func getObjectIRIFromIDParam(req *http.Request) (*url.URL, error) {
 
 	return url.Parse(id)
 }
+
+func readActivitiesWithCursors(it spi.ActivityIterator, maxItems int) ([]*vocab.ActivityType, []string, error) {
+	var activities []*vocab.ActivityType
+
+	var cursors []string
+
+	for len(activities) < maxItems {
+		activity, err := it.Next()
+		if err != nil {
+			if errors.Is(err, spi.ErrNotFound) {
+				break
+			}
+
+			return nil, nil, err
+		}
+
+		activities = append(activities, activity)
+		cursors = append(cursors, it.Cursor())
+	}
+
+	return activities, cursors, nil
+}
+
+func reverseSortOrder(sortOrder spi.SortOrder) spi.SortOrder {
+	if sortOrder == spi.SortDescending {
+		return spi.SortAscending
+	}
+
+	return spi.SortDescending
+}
+
+func reverseActivities(activities []*vocab.ActivityType) {
+	for i, j := 0, len(activities)-1; i < j; i, j = i+1, j-1 {
+		activities[i], activities[j] = activities[j], activities[i]
+	}
+}
+
+func reverseCursors(cursors []string) {
+	for i, j := 0, len(cursors)-1; i < j; i, j = i+1, j-1 {
+		cursors[i], cursors[j] = cursors[j], cursors[i]
+	}
+}
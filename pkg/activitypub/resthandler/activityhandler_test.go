@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package resthandler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -437,6 +438,241 @@ func TestActivities_PageHandler(t *testing.T) {
 	})
 }
 
+func TestActivities_Filter(t *testing.T) {
+	activityStore := memstore.New("")
+
+	for _, activity := range newMockCreateActivities(5) {
+		require.NoError(t, activityStore.AddActivity(activity))
+		require.NoError(t, activityStore.AddReference(spi.Inbox, serviceIRI, activity.ID().URL(),
+			spi.WithActivityType(vocab.TypeCreate)))
+	}
+
+	for _, activity := range newMockActivities(vocab.TypeAnnounce, 3, func(i int) string {
+		return fmt.Sprintf("https://example.com/activities/announce_activity_%d", i)
+	}) {
+		require.NoError(t, activityStore.AddActivity(activity))
+		require.NoError(t, activityStore.AddReference(spi.Inbox, serviceIRI, activity.ID().URL(),
+			spi.WithActivityType(vocab.TypeAnnounce)))
+	}
+
+	cfg := &Config{
+		BasePath:  basePath,
+		ObjectIRI: serviceIRI,
+		PageSize:  4,
+	}
+
+	verifier := &mocks.SignatureVerifier{}
+	verifier.VerifyRequestReturns(true, service2IRI, nil)
+
+	h := NewInbox(cfg, activityStore, verifier, spi.SortDescending, &apmocks.AuthTokenMgr{})
+	require.NotNil(t, h)
+
+	t.Run("Filter by type", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, inboxURL+"?type=Announce", nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		coll := &vocab.OrderedCollectionType{}
+		require.NoError(t, json.Unmarshal(respBytes, coll))
+		require.Equal(t, 3, coll.TotalItems())
+		// The collection ID is based on the object IRI of the service rather than the URL of the request.
+		inboxIRI := serviceIRI.String() + InboxPath
+
+		require.Equal(t, inboxIRI+"?type=Announce", coll.ID().String())
+		require.Equal(t, inboxIRI+"?type=Announce&page=true", coll.First().String())
+	})
+
+	t.Run("Filter by time range", func(t *testing.T) {
+		since := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		until := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s?type=Create&since=%s&until=%s&page=true", inboxURL, since, until), nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		page := &vocab.OrderedCollectionPageType{}
+		require.NoError(t, json.Unmarshal(respBytes, page))
+		require.Equal(t, 5, page.TotalItems())
+		require.Len(t, page.Items(), 4)
+		require.NotNil(t, page.Next())
+		require.Equal(t, since, page.Next().Query().Get(sinceParam))
+		require.Equal(t, until, page.Next().Query().Get(untilParam))
+		require.Equal(t, "Create", page.Next().Query().Get(typeParam))
+	})
+
+	t.Run("No items in time range", func(t *testing.T) {
+		since := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?since=%s", inboxURL, since), nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		coll := &vocab.OrderedCollectionType{}
+		require.NoError(t, json.Unmarshal(respBytes, coll))
+		require.Equal(t, 0, coll.TotalItems())
+	})
+
+	t.Run("Invalid since", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, inboxURL+"?since=yesterday", nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Invalid until", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, inboxURL+"?until=tomorrow", nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Since not before until", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet,
+			inboxURL+"?since=2021-12-01T00:00:00Z&until=2021-11-01T00:00:00Z", nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
+func TestActivities_CursorPaging(t *testing.T) {
+	activityStore := memstore.New("")
+
+	activities := newMockCreateActivities(8)
+
+	for _, activity := range activities {
+		require.NoError(t, activityStore.AddActivity(activity))
+		require.NoError(t, activityStore.AddReference(spi.Inbox, serviceIRI, activity.ID().URL()))
+	}
+
+	cfg := &Config{
+		BasePath:  basePath,
+		ObjectIRI: serviceIRI,
+		PageSize:  3,
+	}
+
+	verifier := &mocks.SignatureVerifier{}
+	verifier.VerifyRequestReturns(true, service2IRI, nil)
+
+	h := NewInbox(cfg, activityStore, verifier, spi.SortDescending, &apmocks.AuthTokenMgr{})
+	require.NotNil(t, h)
+
+	getPage := func(t *testing.T, pageURL string) *vocab.OrderedCollectionPageType {
+		t.Helper()
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, pageURL, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		page := &vocab.OrderedCollectionPageType{}
+		require.NoError(t, json.Unmarshal(respBytes, page))
+
+		return page
+	}
+
+	checkItems := func(t *testing.T, page *vocab.OrderedCollectionPageType, expected ...int) {
+		t.Helper()
+
+		require.Len(t, page.Items(), len(expected))
+
+		for i, item := range page.Items() {
+			require.Equal(t, activities[expected[i]].ID().String(), item.Activity().ID().String())
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		page := getPage(t, inboxURL+"?page=true&cursor=")
+		checkItems(t, page, 7, 6, 5)
+		require.Nil(t, page.Prev())
+		require.NotNil(t, page.Next())
+
+		page = getPage(t, page.Next().String())
+		checkItems(t, page, 4, 3, 2)
+		require.NotNil(t, page.Prev())
+		require.NotNil(t, page.Next())
+
+		// Items added while paging should not affect the next page.
+		newActivity := newMockCreateActivity("https://activity_new")
+		require.NoError(t, activityStore.AddActivity(newActivity))
+		require.NoError(t, activityStore.AddReference(spi.Inbox, serviceIRI, newActivity.ID().URL()))
+
+		lastPage := getPage(t, page.Next().String())
+		checkItems(t, lastPage, 1, 0)
+		require.NotNil(t, lastPage.Prev())
+		require.Nil(t, lastPage.Next())
+
+		page = getPage(t, lastPage.Prev().String())
+		checkItems(t, page, 4, 3, 2)
+		require.NotNil(t, page.Prev())
+		require.NotNil(t, page.Next())
+
+		page = getPage(t, page.Prev().String())
+		checkItems(t, page, 7, 6, 5)
+		require.NotNil(t, page.Prev())
+		require.NotNil(t, page.Next())
+
+		page = getPage(t, page.Prev().String())
+		require.Len(t, page.Items(), 1)
+		require.Equal(t, newActivity.ID().String(), page.Items()[0].Activity().ID().String())
+		require.Nil(t, page.Prev())
+		require.NotNil(t, page.Next())
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, inboxURL+"?page=true&cursor=!!!", nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
 func TestReadOutbox_Handler(t *testing.T) {
 	activityStore := memstore.New("")
 
@@ -910,7 +1146,7 @@ func TestGetActivities(t *testing.T) {
 
 	activitiesHandler := Activities{handler: &handler{AuthHandler: &AuthHandler{activityStore: store}}}
 
-	activities, err := activitiesHandler.getActivities(&url.URL{}, &url.URL{}, spi.Inbox)
+	activities, err := activitiesHandler.getActivities(&url.URL{}, &url.URL{}, spi.Inbox, &activityFilter{})
 	require.EqualError(t, err, "failed to get total items from reference query: total items error")
 	require.Nil(t, activities)
 }
@@ -932,7 +1168,7 @@ func TestActivityHandlerGetPage(t *testing.T) {
 
 	activitiesHandler := Activities{handler: &handler{AuthHandler: &AuthHandler{activityStore: &mockActivityStore}}}
 
-	page, err := activitiesHandler.getPage(&url.URL{}, &url.URL{}, spi.Inbox)
+	page, err := activitiesHandler.getPage(&url.URL{}, &url.URL{}, spi.Inbox, &activityFilter{})
 	require.EqualError(t, err, "failed to get total items from activity query: total items error")
 	require.Nil(t, page)
 }
@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/backfill"
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+type backfillMgr interface {
+	Start(req *backfill.Request) (*backfill.Job, error)
+	Get(id string) (*backfill.Job, error)
+	GetAll() ([]*backfill.Job, error)
+}
+
+// BackfillWriter implements a REST handler that starts a job to backfill activities from a peer server.
+type BackfillWriter struct {
+	endpoint string
+	mgr      backfillMgr
+	readAll  func(r io.Reader) ([]byte, error)
+	marshal  func(v interface{}) ([]byte, error)
+}
+
+// NewBackfillWriter returns a new REST handler that starts a job to backfill activities from a peer server.
+func NewBackfillWriter(cfg *Config, mgr backfillMgr) *BackfillWriter {
+	return &BackfillWriter{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, BackfillPath),
+		readAll:  ioutil.ReadAll,
+		marshal:  json.Marshal,
+	}
+}
+
+// Method returns the HTTP method, which is always POST.
+func (h *BackfillWriter) Method() string {
+	return http.MethodPost
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *BackfillWriter) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP POST is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *BackfillWriter) Handler() common.HTTPRequestHandler {
+	return h.handlePost
+}
+
+func (h *BackfillWriter) handlePost(w http.ResponseWriter, req *http.Request) {
+	reqBytes, err := h.readAll(req.Body)
+	if err != nil {
+		logger.Errorf("[%s] Error reading request body: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Got request to start backfill job: %s", h.endpoint, reqBytes)
+
+	r := &backfill.Request{}
+
+	if err := json.Unmarshal(reqBytes, r); err != nil {
+		logger.Infof("[%s] Error unmarshalling request: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusBadRequest, []byte(fmt.Sprintf("invalid backfill request: %s", err)))
+
+		return
+	}
+
+	job, err := h.mgr.Start(r)
+	if err != nil {
+		if orberrors.IsBadRequest(err) {
+			logger.Infof("[%s] Invalid backfill request: %s", h.endpoint, err)
+
+			writeResponse(h.endpoint, w, http.StatusBadRequest, []byte(fmt.Sprintf("invalid backfill request: %s", err)))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error starting backfill job: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	respBytes, err := h.marshal(job)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling backfill job: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, respBytes)
+}
+
+// BackfillReader implements a REST handler to read the status of backfill jobs. If an "id" parameter
+// is specified then the job with the given ID is returned, otherwise all jobs are returned.
+type BackfillReader struct {
+	endpoint string
+	mgr      backfillMgr
+	marshal  func(v interface{}) ([]byte, error)
+}
+
+// NewBackfillReader returns a new REST handler to read the status of backfill jobs.
+func NewBackfillReader(cfg *Config, mgr backfillMgr) *BackfillReader {
+	return &BackfillReader{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, BackfillPath),
+		marshal:  json.Marshal,
+	}
+}
+
+// Method returns the HTTP method, which is always GET.
+func (h *BackfillReader) Method() string {
+	return http.MethodGet
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *BackfillReader) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP GET is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *BackfillReader) Handler() common.HTTPRequestHandler {
+	return h.handleGet
+}
+
+func (h *BackfillReader) handleGet(w http.ResponseWriter, req *http.Request) {
+	var result interface{}
+
+	var err error
+
+	if id := getIDParam(req); id != "" {
+		result, err = h.mgr.Get(id)
+	} else {
+		var jobs []*backfill.Job
+
+		jobs, err = h.mgr.GetAll()
+		if jobs == nil {
+			jobs = []*backfill.Job{}
+		}
+
+		result = jobs
+	}
+
+	if err != nil {
+		if errors.Is(err, spi.ErrNotFound) {
+			writeResponse(h.endpoint, w, http.StatusNotFound, []byte(notFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error querying backfill jobs: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	respBytes, err := h.marshal(result)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling backfill jobs: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, respBytes)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	backfillmocks "github.com/trustbloc/orb/pkg/activitypub/resthandler/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/backfill"
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+//go:generate counterfeiter -o ./mocks/backfillmgr.gen.go --fake-name BackfillMgr . backfillMgr
+
+const (
+	backfillURL        = "https://example.com/services/orb/backfill"
+	backfillServiceIRI = "https://domain2.com/services/orb"
+)
+
+func TestNewBackfillWriter(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewBackfillWriter(cfg, &backfillmocks.BackfillMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodPost, h.Method())
+	require.Equal(t, "/services/orb/backfill", h.Path())
+}
+
+func TestBackfillWriter_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.StartReturns(&backfill.Job{ID: "job1", Status: backfill.StatusPending}, nil)
+
+		h := NewBackfillWriter(cfg, mgr)
+
+		result, respBytes := postBackfillRequest(t, h,
+			fmt.Sprintf(`{"serviceIRI":"%s","since":"2021-11-01T00:00:00Z"}`, backfillServiceIRI))
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, 1, mgr.StartCallCount())
+
+		req := mgr.StartArgsForCall(0)
+		require.Equal(t, backfillServiceIRI, req.ServiceIRI)
+		require.NotNil(t, req.Since)
+		require.Nil(t, req.Until)
+
+		job := &backfill.Job{}
+		require.NoError(t, json.Unmarshal(respBytes, job))
+		require.Equal(t, "job1", job.ID)
+		require.Equal(t, backfill.StatusPending, job.Status)
+	})
+
+	t.Run("Read request error", func(t *testing.T) {
+		h := NewBackfillWriter(cfg, &backfillmocks.BackfillMgr{})
+		h.readAll = func(r io.Reader) ([]byte, error) {
+			return nil, errors.New("injected read error")
+		}
+
+		result, _ := postBackfillRequest(t, h, `{}`)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+
+	t.Run("Unmarshal request error", func(t *testing.T) {
+		h := NewBackfillWriter(cfg, &backfillmocks.BackfillMgr{})
+
+		result, _ := postBackfillRequest(t, h, `invalid`)
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.StartReturns(nil, orberrors.NewBadRequest(errors.New("service IRI is required")))
+
+		h := NewBackfillWriter(cfg, mgr)
+
+		result, respBytes := postBackfillRequest(t, h, `{}`)
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.Contains(t, string(respBytes), "service IRI is required")
+	})
+
+	t.Run("Manager error", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.StartReturns(nil, errors.New("injected manager error"))
+
+		h := NewBackfillWriter(cfg, mgr)
+
+		result, _ := postBackfillRequest(t, h, `{}`)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.StartReturns(&backfill.Job{ID: "job1"}, nil)
+
+		h := NewBackfillWriter(cfg, mgr)
+		h.marshal = func(v interface{}) ([]byte, error) {
+			return nil, errors.New("injected marshal error")
+		}
+
+		result, _ := postBackfillRequest(t, h, `{}`)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+}
+
+func TestNewBackfillReader(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewBackfillReader(cfg, &backfillmocks.BackfillMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.Equal(t, "/services/orb/backfill", h.Path())
+}
+
+func TestBackfillReader_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	job := &backfill.Job{
+		Request:   backfill.Request{ServiceIRI: backfillServiceIRI},
+		ID:        "job1",
+		Status:    backfill.StatusRunning,
+		Processed: 10,
+	}
+
+	t.Run("Get all -> success", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.GetAllReturns([]*backfill.Job{job}, nil)
+
+		h := NewBackfillReader(cfg, mgr)
+
+		result, respBytes := getBackfillJobs(t, h, backfillURL)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		var jobs []*backfill.Job
+		require.NoError(t, json.Unmarshal(respBytes, &jobs))
+		require.Len(t, jobs, 1)
+		require.Equal(t, job.ID, jobs[0].ID)
+		require.Equal(t, job.ServiceIRI, jobs[0].ServiceIRI)
+		require.Equal(t, job.Processed, jobs[0].Processed)
+	})
+
+	t.Run("Get all -> empty", func(t *testing.T) {
+		h := NewBackfillReader(cfg, &backfillmocks.BackfillMgr{})
+
+		result, respBytes := getBackfillJobs(t, h, backfillURL)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "[]", string(respBytes))
+	})
+
+	t.Run("Get by ID -> success", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.GetReturns(job, nil)
+
+		h := NewBackfillReader(cfg, mgr)
+
+		result, respBytes := getBackfillJobs(t, h, fmt.Sprintf("%s?id=%s", backfillURL, job.ID))
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, 1, mgr.GetCallCount())
+		require.Equal(t, job.ID, mgr.GetArgsForCall(0))
+
+		j := &backfill.Job{}
+		require.NoError(t, json.Unmarshal(respBytes, j))
+		require.Equal(t, job.ID, j.ID)
+		require.Equal(t, backfill.StatusRunning, j.Status)
+	})
+
+	t.Run("Get by ID -> not found", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.GetReturns(nil, spi.ErrNotFound)
+
+		h := NewBackfillReader(cfg, mgr)
+
+		result, _ := getBackfillJobs(t, h, backfillURL+"?id=job2")
+		require.Equal(t, http.StatusNotFound, result.StatusCode)
+	})
+
+	t.Run("Manager error", func(t *testing.T) {
+		mgr := &backfillmocks.BackfillMgr{}
+		mgr.GetAllReturns(nil, errors.New("injected manager error"))
+
+		h := NewBackfillReader(cfg, mgr)
+
+		result, _ := getBackfillJobs(t, h, backfillURL)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		h := NewBackfillReader(cfg, &backfillmocks.BackfillMgr{})
+		h.marshal = func(v interface{}) ([]byte, error) {
+			return nil, errors.New("injected marshal error")
+		}
+
+		result, _ := getBackfillJobs(t, h, backfillURL)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+}
+
+func postBackfillRequest(t *testing.T, h *BackfillWriter, request string) (*http.Response, []byte) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, backfillURL, bytes.NewBuffer([]byte(request)))
+
+	h.handlePost(rw, req)
+
+	result := rw.Result()
+
+	respBytes, err := ioutil.ReadAll(result.Body)
+	require.NoError(t, err)
+	require.NoError(t, result.Body.Close())
+
+	return result, respBytes
+}
+
+func getBackfillJobs(t *testing.T, h *BackfillReader, u string) (*http.Response, []byte) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, u, nil)
+
+	h.handleGet(rw, req)
+
+	result := rw.Result()
+
+	respBytes, err := ioutil.ReadAll(result.Body)
+	require.NoError(t, err)
+	require.NoError(t, result.Body.Close())
+
+	return result, respBytes
+}
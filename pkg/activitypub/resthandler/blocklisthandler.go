@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+type blockListMgr interface {
+	Update(additions, removals []*url.URL) error
+	Get() ([]*url.URL, error)
+}
+
+// BlockListWriter implements a REST handler to update a service's "block list".
+type BlockListWriter struct {
+	endpoint string
+	mgr      blockListMgr
+	readAll  func(r io.Reader) ([]byte, error)
+}
+
+// NewBlockListWriter returns a new REST handler to update the "block list".
+func NewBlockListWriter(cfg *Config, mgr blockListMgr) *BlockListWriter {
+	return &BlockListWriter{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, BlockListPath),
+		readAll:  ioutil.ReadAll,
+	}
+}
+
+// Method returns the HTTP method, which is always POST.
+func (h *BlockListWriter) Method() string {
+	return http.MethodPost
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *BlockListWriter) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP POST is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *BlockListWriter) Handler() common.HTTPRequestHandler {
+	return h.handlePost
+}
+
+func (h *BlockListWriter) handlePost(w http.ResponseWriter, req *http.Request) {
+	reqBytes, err := h.readAll(req.Body)
+	if err != nil {
+		logger.Errorf("[%s] Error reading request body: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Got request to update block list: %s", h.endpoint, reqBytes)
+
+	additions, deletions, err := unmarshalAndValidateBlockListRequest(reqBytes)
+	if err != nil {
+		logger.Infof("[%s] Error validating request: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusBadRequest, []byte(err.Error()))
+
+		return
+	}
+
+	err = h.mgr.Update(additions, deletions)
+	if err != nil {
+		logger.Errorf("[%s] Error updating block list: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, nil)
+}
+
+// BlockListReader implements a REST handler to read a service's "block list".
+type BlockListReader struct {
+	endpoint string
+	mgr      blockListMgr
+	marshal  func(v interface{}) ([]byte, error)
+}
+
+// NewBlockListReader returns a new REST handler to read a service's "block list".
+func NewBlockListReader(cfg *Config, mgr blockListMgr) *BlockListReader {
+	return &BlockListReader{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, BlockListPath),
+		marshal:  json.Marshal,
+	}
+}
+
+// Method returns the HTTP method, which is always GET.
+func (h *BlockListReader) Method() string {
+	return http.MethodGet
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *BlockListReader) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP GET is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *BlockListReader) Handler() common.HTTPRequestHandler {
+	return h.handleGet
+}
+
+func (h *BlockListReader) handleGet(w http.ResponseWriter, _ *http.Request) {
+	uris, err := h.mgr.Get()
+	if err != nil {
+		logger.Errorf("[%s] Error querying block list: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	list := &blockList{
+		URLs: make([]string, len(uris)),
+	}
+
+	for i, uri := range uris {
+		list.URLs[i] = uri.String()
+	}
+
+	blockListBytes, err := h.marshal(list)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling block list: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, blockListBytes)
+}
+
+type blockListRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+type blockList struct {
+	URLs []string `json:"url"`
+}
+
+func unmarshalAndValidateBlockListRequest(reqBytes []byte) ([]*url.URL, []*url.URL, error) {
+	r := &blockListRequest{}
+
+	if err := json.Unmarshal(reqBytes, r); err != nil {
+		return nil, nil, fmt.Errorf("invalid block list request: %w", err)
+	}
+
+	additions, err := parseURIs(r.Add)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse URIs for additions: %w", err)
+	}
+
+	deletions, err := parseURIs(r.Remove)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse URIs for deletion: %w", err)
+	}
+
+	return additions, deletions, nil
+}
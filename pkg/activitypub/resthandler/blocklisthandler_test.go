@@ -0,0 +1,236 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+//go:generate counterfeiter -o ../mocks/blocklistmgr.gen.go --fake-name BlockListMgr . blockListMgr
+
+const (
+	blockListURL = "https://example.com/services/orb/blocklist"
+)
+
+func TestNewBlockListWriter(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewBlockListWriter(cfg, &mocks.BlockListMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodPost, h.Method())
+	require.Equal(t, "/services/orb/blocklist", h.Path())
+}
+
+func TestBlockListWriter_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		const (
+			domain1 = "https://domain1.com/services/orb"
+			domain2 = "https://domain2.com/services/orb"
+		)
+
+		requestBytes, err := json.Marshal(&blockListRequest{
+			Add:    []string{domain1},
+			Remove: []string{domain2},
+		})
+		require.NoError(t, err)
+
+		mgr := &mocks.BlockListMgr{}
+
+		h := NewBlockListWriter(cfg, mgr)
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, blockListURL, bytes.NewBuffer(requestBytes))
+
+		h.handlePost(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		require.Equal(t, 1, mgr.UpdateCallCount())
+
+		additions, deletions := mgr.UpdateArgsForCall(0)
+		require.Len(t, additions, 1)
+		require.Equal(t, domain1, additions[0].String())
+		require.Len(t, deletions, 1)
+		require.Equal(t, domain2, deletions[0].String())
+	})
+
+	t.Run("Read request error", func(t *testing.T) {
+		errExpected := errors.New("injected read error")
+
+		h := NewBlockListWriter(cfg, &mocks.BlockListMgr{})
+		require.NotNil(t, h.Handler())
+
+		h.readAll = func(r io.Reader) ([]byte, error) {
+			return nil, errExpected
+		}
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, blockListURL, bytes.NewBuffer([]byte(`{}`)))
+
+		h.handlePost(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Block list manager error", func(t *testing.T) {
+		errExpected := errors.New("injected manager error")
+
+		mgr := &mocks.BlockListMgr{}
+		mgr.UpdateReturns(errExpected)
+
+		h := NewBlockListWriter(cfg, mgr)
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, blockListURL,
+			bytes.NewBuffer([]byte(`{"add":["https://domain1.com/services/orb"]}`)))
+
+		h.handlePost(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Bad request", func(t *testing.T) {
+		testBlockListPostBadRequest(t, "Unmarshal request error", "invalid")
+		testBlockListPostBadRequest(t, "Invalid add URI", `{"add":[":invalid"]}`)
+		testBlockListPostBadRequest(t, "Invalid remove URI", `{"remove":[":invalid"]}`)
+	})
+}
+
+func TestNewBlockListReader(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewBlockListReader(cfg, &mocks.BlockListMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.Equal(t, "/services/orb/blocklist", h.Path())
+}
+
+func TestBlockListReader_Handler(t *testing.T) {
+	var (
+		domain1 = vocab.MustParseURL("https://domain1.com/services/orb")
+		domain2 = vocab.MustParseURL("https://domain2.com/services/orb")
+	)
+
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mgr := &mocks.BlockListMgr{}
+		mgr.GetReturns([]*url.URL{domain1, domain2}, nil)
+
+		h := NewBlockListReader(cfg, mgr)
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, blockListURL, nil)
+
+		h.handleGet(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		list := &blockList{}
+		require.NoError(t, json.Unmarshal(respBytes, list))
+		require.Len(t, list.URLs, 2)
+		require.Equal(t, domain1.String(), list.URLs[0])
+		require.Equal(t, domain2.String(), list.URLs[1])
+	})
+
+	t.Run("Manager error", func(t *testing.T) {
+		mgr := &mocks.BlockListMgr{}
+		mgr.GetReturns(nil, errors.New("injected manager error"))
+
+		h := NewBlockListReader(cfg, mgr)
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, blockListURL, nil)
+
+		h.handleGet(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		mgr := &mocks.BlockListMgr{}
+		mgr.GetReturns([]*url.URL{domain1}, nil)
+
+		h := NewBlockListReader(cfg, mgr)
+		require.NotNil(t, h.Handler())
+
+		h.marshal = func(v interface{}) ([]byte, error) {
+			return nil, errors.New("injected marshal error")
+		}
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, blockListURL, nil)
+
+		h.handleGet(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
+func testBlockListPostBadRequest(t *testing.T, desc, request string) {
+	t.Helper()
+
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	t.Run(desc, func(t *testing.T) {
+		h := NewBlockListWriter(cfg, &mocks.BlockListMgr{})
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, blockListURL, bytes.NewBuffer([]byte(request)))
+
+		h.handlePost(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/circuitbreaker"
+)
+
+type circuitBreakerStatusProvider interface {
+	Status() []*circuitbreaker.Status
+}
+
+// CircuitBreakers implements a REST handler that returns the state of the circuit breakers
+// for the destination hosts to which activities are delivered.
+type CircuitBreakers struct {
+	endpoint string
+	provider circuitBreakerStatusProvider
+	marshal  func(v interface{}) ([]byte, error)
+}
+
+// NewCircuitBreakers returns a new REST handler that returns the state of the circuit breakers.
+func NewCircuitBreakers(cfg *Config, provider circuitBreakerStatusProvider) *CircuitBreakers {
+	return &CircuitBreakers{
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, CircuitBreakersPath),
+		provider: provider,
+		marshal:  json.Marshal,
+	}
+}
+
+// Method returns the HTTP method, which is always GET.
+func (h *CircuitBreakers) Method() string {
+	return http.MethodGet
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *CircuitBreakers) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP GET is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *CircuitBreakers) Handler() common.HTTPRequestHandler {
+	return h.handleGet
+}
+
+func (h *CircuitBreakers) handleGet(w http.ResponseWriter, _ *http.Request) {
+	respBytes, err := h.marshal(h.provider.Status())
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling circuit breaker status: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, respBytes)
+}
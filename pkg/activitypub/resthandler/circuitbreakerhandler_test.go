@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/circuitbreaker"
+	orbmocks "github.com/trustbloc/orb/pkg/mocks"
+)
+
+const circuitBreakersURL = "https://example.com/services/orb/circuitbreakers"
+
+func TestNewCircuitBreakers(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewCircuitBreakers(cfg, circuitbreaker.New(circuitbreaker.Config{}, &orbmocks.MetricsProvider{}))
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.Equal(t, "/services/orb/circuitbreakers", h.Path())
+}
+
+func TestCircuitBreakers_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	cb := circuitbreaker.New(circuitbreaker.Config{FailureThreshold: 1}, &orbmocks.MetricsProvider{})
+	cb.Failure("orb.domain1.com")
+	cb.Failure("orb.domain2.com")
+
+	t.Run("Success", func(t *testing.T) {
+		h := NewCircuitBreakers(cfg, cb)
+
+		rw := httptest.NewRecorder()
+		h.handleGet(rw, httptest.NewRequest(http.MethodGet, circuitBreakersURL, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		var status []*circuitbreaker.Status
+		require.NoError(t, json.Unmarshal(respBytes, &status))
+		require.Len(t, status, 2)
+		require.Equal(t, "orb.domain1.com", status[0].Host)
+		require.Equal(t, "open", status[0].State)
+		require.Equal(t, "orb.domain2.com", status[1].Host)
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		h := NewCircuitBreakers(cfg, cb)
+		h.marshal = func(v interface{}) ([]byte, error) {
+			return nil, errors.New("injected marshal error")
+		}
+
+		rw := httptest.NewRecorder()
+		h.handleGet(rw, httptest.NewRequest(http.MethodGet, circuitBreakersURL, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
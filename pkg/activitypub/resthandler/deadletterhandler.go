@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/deadletter"
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+)
+
+type deadLetterMgr interface {
+	Get(id string) (*deadletter.Activity, error)
+	GetAll() ([]*deadletter.Activity, error)
+	Replay(id string) error
+	Purge(id string) error
+}
+
+// DeadLetterWriter implements a REST handler to replay or purge undeliverable activities.
+type DeadLetterWriter struct {
+	endpoint string
+	mgr      deadLetterMgr
+	readAll  func(r io.Reader) ([]byte, error)
+}
+
+// NewDeadLetterWriter returns a new REST handler to replay or purge undeliverable activities.
+func NewDeadLetterWriter(cfg *Config, mgr deadLetterMgr) *DeadLetterWriter {
+	return &DeadLetterWriter{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, DeadLetterPath),
+		readAll:  ioutil.ReadAll,
+	}
+}
+
+// Method returns the HTTP method, which is always POST.
+func (h *DeadLetterWriter) Method() string {
+	return http.MethodPost
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *DeadLetterWriter) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP POST is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *DeadLetterWriter) Handler() common.HTTPRequestHandler {
+	return h.handlePost
+}
+
+func (h *DeadLetterWriter) handlePost(w http.ResponseWriter, req *http.Request) {
+	reqBytes, err := h.readAll(req.Body)
+	if err != nil {
+		logger.Errorf("[%s] Error reading request body: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Got request to update dead letters: %s", h.endpoint, reqBytes)
+
+	r := &deadLetterRequest{}
+
+	if err := json.Unmarshal(reqBytes, r); err != nil {
+		logger.Infof("[%s] Error unmarshalling request: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusBadRequest, []byte(fmt.Sprintf("invalid dead letter request: %s", err)))
+
+		return
+	}
+
+	for _, id := range r.Replay {
+		if err := h.mgr.Replay(id); err != nil {
+			h.writeErrorResponse(w, fmt.Errorf("replay [%s]: %w", id, err))
+
+			return
+		}
+	}
+
+	for _, id := range r.Purge {
+		if err := h.mgr.Purge(id); err != nil {
+			h.writeErrorResponse(w, fmt.Errorf("purge [%s]: %w", id, err))
+
+			return
+		}
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, nil)
+}
+
+func (h *DeadLetterWriter) writeErrorResponse(w http.ResponseWriter, err error) {
+	if errors.Is(err, spi.ErrNotFound) {
+		logger.Infof("[%s] Dead letter not found: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusNotFound, []byte(notFoundResponse))
+
+		return
+	}
+
+	logger.Errorf("[%s] Error updating dead letters: %s", h.endpoint, err)
+
+	writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+}
+
+// DeadLetterReader implements a REST handler to read undeliverable activities. If an "id" parameter
+// is specified then the undeliverable activity with the given ID is returned, otherwise all
+// undeliverable activities are returned.
+type DeadLetterReader struct {
+	endpoint string
+	mgr      deadLetterMgr
+	marshal  func(v interface{}) ([]byte, error)
+}
+
+// NewDeadLetterReader returns a new REST handler to read undeliverable activities.
+func NewDeadLetterReader(cfg *Config, mgr deadLetterMgr) *DeadLetterReader {
+	return &DeadLetterReader{
+		mgr:      mgr,
+		endpoint: fmt.Sprintf("%s%s", cfg.BasePath, DeadLetterPath),
+		marshal:  json.Marshal,
+	}
+}
+
+// Method returns the HTTP method, which is always GET.
+func (h *DeadLetterReader) Method() string {
+	return http.MethodGet
+}
+
+// Path returns the base path of the target URL for this handler.
+func (h *DeadLetterReader) Path() string {
+	return h.endpoint
+}
+
+// Handler returns the handler that should be invoked when an HTTP GET is requested to the target endpoint.
+// This handler must be registered with an HTTP server.
+func (h *DeadLetterReader) Handler() common.HTTPRequestHandler {
+	return h.handleGet
+}
+
+func (h *DeadLetterReader) handleGet(w http.ResponseWriter, req *http.Request) {
+	var result interface{}
+
+	var err error
+
+	if id := getIDParam(req); id != "" {
+		result, err = h.mgr.Get(id)
+	} else {
+		var activities []*deadletter.Activity
+
+		activities, err = h.mgr.GetAll()
+		if activities == nil {
+			activities = []*deadletter.Activity{}
+		}
+
+		result = activities
+	}
+
+	if err != nil {
+		if errors.Is(err, spi.ErrNotFound) {
+			writeResponse(h.endpoint, w, http.StatusNotFound, []byte(notFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error querying dead letters: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	respBytes, err := h.marshal(result)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling dead letters: %s", h.endpoint, err)
+
+		writeResponse(h.endpoint, w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(h.endpoint, w, http.StatusOK, respBytes)
+}
+
+type deadLetterRequest struct {
+	Replay []string `json:"replay,omitempty"`
+	Purge  []string `json:"purge,omitempty"`
+}
@@ -0,0 +1,232 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/deadletter"
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+//go:generate counterfeiter -o ../mocks/deadlettermgr.gen.go --fake-name DeadLetterMgr . deadLetterMgr
+
+const (
+	deadLetterURL        = "https://example.com/services/orb/deadletter"
+	deadLetterActivityID = "https://example.com/services/orb/activities/1234"
+)
+
+func TestNewDeadLetterWriter(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewDeadLetterWriter(cfg, &mocks.DeadLetterMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodPost, h.Method())
+	require.Equal(t, "/services/orb/deadletter", h.Path())
+}
+
+func TestDeadLetterWriter_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+
+		h := NewDeadLetterWriter(cfg, mgr)
+
+		result := postDeadLetterRequest(t, h, `{"replay":["id1","id2"],"purge":["id3"]}`)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		require.Equal(t, 2, mgr.ReplayCallCount())
+		require.Equal(t, "id1", mgr.ReplayArgsForCall(0))
+		require.Equal(t, "id2", mgr.ReplayArgsForCall(1))
+		require.Equal(t, 1, mgr.PurgeCallCount())
+		require.Equal(t, "id3", mgr.PurgeArgsForCall(0))
+	})
+
+	t.Run("Read request error", func(t *testing.T) {
+		h := NewDeadLetterWriter(cfg, &mocks.DeadLetterMgr{})
+		h.readAll = func(r io.Reader) ([]byte, error) {
+			return nil, errors.New("injected read error")
+		}
+
+		result := postDeadLetterRequest(t, h, `{}`)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Unmarshal request error", func(t *testing.T) {
+		h := NewDeadLetterWriter(cfg, &mocks.DeadLetterMgr{})
+
+		result := postDeadLetterRequest(t, h, `invalid`)
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Replay not found", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.ReplayReturns(spi.ErrNotFound)
+
+		h := NewDeadLetterWriter(cfg, mgr)
+
+		result := postDeadLetterRequest(t, h, `{"replay":["id1"],"purge":["id3"]}`)
+		require.Equal(t, http.StatusNotFound, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+		require.Zero(t, mgr.PurgeCallCount())
+	})
+
+	t.Run("Purge error", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.PurgeReturns(errors.New("injected purge error"))
+
+		h := NewDeadLetterWriter(cfg, mgr)
+
+		result := postDeadLetterRequest(t, h, `{"purge":["id3"]}`)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
+func TestNewDeadLetterReader(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	h := NewDeadLetterReader(cfg, &mocks.DeadLetterMgr{})
+	require.NotNil(t, h.Handler())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.Equal(t, "/services/orb/deadletter", h.Path())
+}
+
+func TestDeadLetterReader_Handler(t *testing.T) {
+	cfg := &Config{
+		BasePath: "/services/orb",
+	}
+
+	activity := &deadletter.Activity{
+		ID:       "id1",
+		Inbox:    "https://domain1.com/services/orb/inbox",
+		Time:     time.Now(),
+		Activity: vocab.NewCreateActivity(nil, vocab.WithID(vocab.MustParseURL(deadLetterActivityID))),
+	}
+
+	t.Run("Get all -> success", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.GetAllReturns([]*deadletter.Activity{activity}, nil)
+
+		h := NewDeadLetterReader(cfg, mgr)
+
+		result, respBytes := getDeadLetters(t, h, deadLetterURL)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		var activities []*deadletter.Activity
+		require.NoError(t, json.Unmarshal(respBytes, &activities))
+		require.Len(t, activities, 1)
+		require.Equal(t, activity.ID, activities[0].ID)
+		require.Equal(t, activity.Inbox, activities[0].Inbox)
+		require.Equal(t, deadLetterActivityID, activities[0].Activity.ID().String())
+	})
+
+	t.Run("Get all -> empty", func(t *testing.T) {
+		h := NewDeadLetterReader(cfg, &mocks.DeadLetterMgr{})
+
+		result, respBytes := getDeadLetters(t, h, deadLetterURL)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "[]", string(respBytes))
+	})
+
+	t.Run("Get by ID -> success", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.GetReturns(activity, nil)
+
+		h := NewDeadLetterReader(cfg, mgr)
+
+		result, respBytes := getDeadLetters(t, h, fmt.Sprintf("%s?id=%s", deadLetterURL, activity.ID))
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, 1, mgr.GetCallCount())
+		require.Equal(t, activity.ID, mgr.GetArgsForCall(0))
+
+		a := &deadletter.Activity{}
+		require.NoError(t, json.Unmarshal(respBytes, a))
+		require.Equal(t, activity.ID, a.ID)
+	})
+
+	t.Run("Get by ID -> not found", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.GetReturns(nil, spi.ErrNotFound)
+
+		h := NewDeadLetterReader(cfg, mgr)
+
+		result, _ := getDeadLetters(t, h, deadLetterURL+"?id=id2")
+		require.Equal(t, http.StatusNotFound, result.StatusCode)
+	})
+
+	t.Run("Manager error", func(t *testing.T) {
+		mgr := &mocks.DeadLetterMgr{}
+		mgr.GetAllReturns(nil, errors.New("injected manager error"))
+
+		h := NewDeadLetterReader(cfg, mgr)
+
+		result, _ := getDeadLetters(t, h, deadLetterURL)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		h := NewDeadLetterReader(cfg, &mocks.DeadLetterMgr{})
+		h.marshal = func(v interface{}) ([]byte, error) {
+			return nil, errors.New("injected marshal error")
+		}
+
+		result, _ := getDeadLetters(t, h, deadLetterURL)
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+}
+
+func postDeadLetterRequest(t *testing.T, h *DeadLetterWriter, request string) *http.Response {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, deadLetterURL, bytes.NewBuffer([]byte(request)))
+
+	h.handlePost(rw, req)
+
+	return rw.Result()
+}
+
+func getDeadLetters(t *testing.T, h *DeadLetterReader, u string) (*http.Response, []byte) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, u, nil)
+
+	h.handleGet(rw, req)
+
+	result := rw.Result()
+
+	respBytes, err := ioutil.ReadAll(result.Body)
+	require.NoError(t, err)
+	require.NoError(t, result.Body.Close())
+
+	return result, respBytes
+}
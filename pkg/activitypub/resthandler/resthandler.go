@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/trustbloc/edge-core/pkg/log"
@@ -36,6 +37,8 @@ const (
 	OutboxPath = "/outbox"
 	// InboxPath specifies the service's 'inbox' endpoint.
 	InboxPath = "/inbox"
+	// SharedInboxPath specifies the service's 'sharedInbox' endpoint.
+	SharedInboxPath = "/sharedinbox"
 	// WitnessesPath specifies the service's 'witnesses' endpoint.
 	WitnessesPath = "/witnesses"
 	// WitnessingPath specifies the service's 'witnessing' endpoint.
@@ -50,6 +53,14 @@ const (
 	ActivitiesPath = "/activities/{id}"
 	// AcceptListPath specifies the endpoint to manage an "accept list" for a service.
 	AcceptListPath = "/acceptlist"
+	// BlockListPath specifies the endpoint to manage the "block list" for a service.
+	BlockListPath = "/blocklist"
+	// DeadLetterPath specifies the endpoint to manage activities that could not be delivered.
+	DeadLetterPath = "/deadletter"
+	// CircuitBreakersPath specifies the endpoint to read the state of the per-host delivery circuit breakers.
+	CircuitBreakersPath = "/circuitbreakers"
+	// BackfillPath specifies the endpoint to start and monitor historical backfill jobs.
+	BackfillPath = "/backfill"
 )
 
 const (
@@ -57,6 +68,10 @@ const (
 	pageNumParam = "page-num"
 	idParam      = "id"
 	typeParam    = "type"
+	sinceParam   = "since"
+	untilParam   = "until"
+	cursorParam  = "cursor"
+	beforeParam  = "before"
 
 	authHeader  = "Authorization"
 	tokenPrefix = "Bearer "
@@ -73,6 +88,7 @@ type Config struct {
 	ObjectIRI              *url.URL
 	PageSize               int
 	VerifyActorInSignature bool
+	AlsoKnownAs            []*url.URL
 }
 
 type handler struct {
@@ -126,13 +142,7 @@ func (h *handler) Handler() common.HTTPRequestHandler {
 }
 
 func (h *handler) getPageID(objectIRI fmt.Stringer, pageNum int) string {
-	var delimiter string
-
-	if strings.Contains(objectIRI.String(), "?") {
-		delimiter = "&"
-	} else {
-		delimiter = "?"
-	}
+	delimiter := getQueryDelimiter(objectIRI)
 
 	if pageNum >= 0 {
 		return fmt.Sprintf("%s%s%s=true&%s=%d", objectIRI, delimiter, pageParam, pageNumParam, pageNum)
@@ -152,6 +162,24 @@ func (h *handler) getPageURL(objectIRI fmt.Stringer, pageNum int) (*url.URL, err
 	return pageURL, nil
 }
 
+// getCursorPageURL returns the URL of the page that starts after the given cursor. If before is true
+// then the page ends before the given cursor.
+func (h *handler) getCursorPageURL(objectIRI fmt.Stringer, cursor string, before bool) (*url.URL, error) {
+	pageID := fmt.Sprintf("%s%s%s=true&%s=%s", objectIRI, getQueryDelimiter(objectIRI),
+		pageParam, cursorParam, url.QueryEscape(cursor))
+
+	if before {
+		pageID = fmt.Sprintf("%s&%s=true", pageID, beforeParam)
+	}
+
+	pageURL, err := url.Parse(pageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'page' URL [%s]: %w", pageID, err)
+	}
+
+	return pageURL, nil
+}
+
 func (h *handler) getCurrentPrevNext(totalItems int, options *spi.QueryOptions) (int, int, int) {
 	first := getFirstPageNum(totalItems, options.PageSize, options.SortOrder)
 	last := getLastPageNum(totalItems, options.PageSize, options.SortOrder)
@@ -214,6 +242,21 @@ func (h *handler) getPageNum(req *http.Request) (int, bool) {
 	return h.paramAsInt(req, pageNumParam)
 }
 
+// getCursor returns the value of the cursor parameter and true if the parameter was specified.
+// An empty cursor indicates that paging should start from the beginning of the collection.
+func (h *handler) getCursor(req *http.Request) (string, bool) {
+	values, ok := h.getParams(req)[cursorParam]
+	if !ok {
+		return "", false
+	}
+
+	if len(values) == 0 {
+		return "", true
+	}
+
+	return values[0], true
+}
+
 func (h *handler) paramAsInt(req *http.Request, param string) (int, bool) {
 	params := h.getParams(req)
 
@@ -232,6 +275,29 @@ func (h *handler) paramAsInt(req *http.Request, param string) (int, bool) {
 	return size, true
 }
 
+func (h *handler) paramAsString(req *http.Request, param string) string {
+	values := h.getParams(req)[param]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (h *handler) paramAsTime(req *http.Request, param string) (*time.Time, error) {
+	value := h.paramAsString(req, param)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, orberrors.NewBadRequest(fmt.Errorf("invalid value for parameter [%s]: %w", param, err))
+	}
+
+	return &t, nil
+}
+
 func (h *handler) paramAsBool(req *http.Request, param string) bool {
 	params := h.getParams(req)
 
@@ -312,6 +378,14 @@ func getLastPageNum(totalItems, pageSize int, sortOrder spi.SortOrder) int {
 	return totalItems/pageSize - 1
 }
 
+func getQueryDelimiter(objectIRI fmt.Stringer) string {
+	if strings.Contains(objectIRI.String(), "?") {
+		return "&"
+	}
+
+	return "?"
+}
+
 type paramsBuilder []string
 
 func (p paramsBuilder) build() map[string]string {
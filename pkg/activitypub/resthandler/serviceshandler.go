@@ -119,62 +119,77 @@ func (h *Services) handlePublicKey(w http.ResponseWriter, req *http.Request) {
 }
 
 func (h *Services) newService() (*vocab.ActorType, error) {
-	inbox, err := newID(h.ObjectIRI, InboxPath)
+	return NewServiceActor(h.ObjectIRI, h.publicKey, vocab.WithAlsoKnownAs(h.AlsoKnownAs...))
+}
+
+// NewServiceActor returns the 'Service' actor for the given service IRI and public key. Additional
+// properties may be set on the actor with the given options.
+func NewServiceActor(serviceIRI *url.URL, publicKey *vocab.PublicKeyType,
+	opts ...vocab.Opt) (*vocab.ActorType, error) {
+	inbox, err := newID(serviceIRI, InboxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedInbox, err := newID(serviceIRI, SharedInboxPath)
 	if err != nil {
 		return nil, err
 	}
 
-	outbox, err := newID(h.ObjectIRI, OutboxPath)
+	outbox, err := newID(serviceIRI, OutboxPath)
 	if err != nil {
 		return nil, err
 	}
 
-	followers, err := newID(h.ObjectIRI, FollowersPath)
+	followers, err := newID(serviceIRI, FollowersPath)
 	if err != nil {
 		return nil, err
 	}
 
-	following, err := newID(h.ObjectIRI, FollowingPath)
+	following, err := newID(serviceIRI, FollowingPath)
 	if err != nil {
 		return nil, err
 	}
 
-	witnesses, err := newID(h.ObjectIRI, WitnessesPath)
+	witnesses, err := newID(serviceIRI, WitnessesPath)
 	if err != nil {
 		return nil, err
 	}
 
-	witnessing, err := newID(h.ObjectIRI, WitnessingPath)
+	witnessing, err := newID(serviceIRI, WitnessingPath)
 	if err != nil {
 		return nil, err
 	}
 
-	liked, err := newID(h.ObjectIRI, LikedPath)
+	liked, err := newID(serviceIRI, LikedPath)
 	if err != nil {
 		return nil, err
 	}
 
-	likes, err := newID(h.ObjectIRI, LikesPath)
+	likes, err := newID(serviceIRI, LikesPath)
 	if err != nil {
 		return nil, err
 	}
 
-	shares, err := newID(h.ObjectIRI, SharesPath)
+	shares, err := newID(serviceIRI, SharesPath)
 	if err != nil {
 		return nil, err
 	}
 
-	return vocab.NewService(h.ObjectIRI,
-		vocab.WithPublicKey(h.publicKey),
-		vocab.WithInbox(inbox),
-		vocab.WithOutbox(outbox),
-		vocab.WithFollowers(followers),
-		vocab.WithFollowing(following),
-		vocab.WithWitnesses(witnesses),
-		vocab.WithWitnessing(witnessing),
-		vocab.WithLiked(liked),
-		vocab.WithLikes(likes),
-		vocab.WithShares(shares),
+	return vocab.NewService(serviceIRI,
+		append([]vocab.Opt{
+			vocab.WithPublicKey(publicKey),
+			vocab.WithInbox(inbox),
+			vocab.WithSharedInbox(sharedInbox),
+			vocab.WithOutbox(outbox),
+			vocab.WithFollowers(followers),
+			vocab.WithFollowing(following),
+			vocab.WithWitnesses(witnesses),
+			vocab.WithWitnessing(witnessing),
+			vocab.WithLiked(liked),
+			vocab.WithLikes(likes),
+			vocab.WithShares(shares),
+		}, opts...)...,
 	), nil
 }
 
@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package resthandler
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -67,6 +69,16 @@ func TestNewPublicKey(t *testing.T) {
 	require.NotNil(t, h.Handler())
 }
 
+func TestNewServiceActor(t *testing.T) {
+	actor, err := NewServiceActor(serviceIRI, publicKey)
+	require.NoError(t, err)
+	require.NotNil(t, actor)
+	require.Equal(t, serviceIRI.String(), actor.ID().String())
+	require.Equal(t, publicKeyIRI.String(), actor.PublicKey().ID.String())
+	require.Equal(t, testutil.NewMockID(serviceIRI, FollowersPath).String(), actor.Followers().String())
+	require.Equal(t, testutil.NewMockID(serviceIRI, WitnessingPath).String(), actor.Witnessing().String())
+}
+
 func TestServices_Handler(t *testing.T) {
 	cfg := &Config{
 		BasePath:  basePath,
@@ -97,6 +109,36 @@ func TestServices_Handler(t *testing.T) {
 		require.NoError(t, result.Body.Close())
 	})
 
+	t.Run("Also known as", func(t *testing.T) {
+		oldServiceIRI := testutil.MustParseURL("https://old.example.com/services/orb")
+
+		h := NewServices(
+			&Config{
+				BasePath:    basePath,
+				ObjectIRI:   serviceIRI,
+				AlsoKnownAs: []*url.URL{oldServiceIRI},
+			},
+			activityStore, publicKey, &apmocks.AuthTokenMgr{},
+		)
+		require.NotNil(t, h)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, serviceIRI.String(), nil)
+
+		h.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.NoError(t, result.Body.Close())
+
+		actor := &vocab.ActorType{}
+		require.NoError(t, json.Unmarshal(respBytes, actor))
+		require.True(t, actor.AlsoKnownAs().Contains(oldServiceIRI))
+	})
+
 	t.Run("Marshal error", func(t *testing.T) {
 		h := NewServices(cfg, activityStore, publicKey, &apmocks.AuthTokenMgr{})
 		require.NotNil(t, h)
@@ -261,6 +303,9 @@ const (
     "https://w3id.org/security/v1",
     "https://w3id.org/activityanchors/v1"
   ],
+  "endpoints": {
+    "sharedInbox": "https://example1.com/services/orb/sharedinbox"
+  },
   "followers": "https://example1.com/services/orb/followers",
   "following": "https://example1.com/services/orb/following",
   "id": "https://example1.com/services/orb",
@@ -50,6 +50,7 @@ type Config struct {
 type activityPubClient interface {
 	GetActor(iri *url.URL) (*vocab.ActorType, error)
 	GetActivities(iri *url.URL, order client.Order) (client.ActivityIterator, error)
+	ClearActor(iri *url.URL)
 }
 
 type undoFunc func(activity *vocab.ActivityType) error
@@ -197,6 +198,8 @@ func defaultOptions() *service.Handlers {
 		WitnessInvitationAuth: &AcceptAllActorsAuth{},
 		ProofHandler:          &noOpProofHandler{},
 		AnchorEventAckHandler: &noOpAnchorEventAcknowledgementHandler{},
+		BlockList:             &noOpBlockList{},
+		MoveRegistry:          &noOpMoveRegistry{},
 	}
 }
 
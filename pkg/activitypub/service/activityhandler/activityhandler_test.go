@@ -1486,6 +1486,133 @@ func TestHandler_HandleAnnounceActivity(t *testing.T) {
 	})
 }
 
+func TestHandler_HandleAnnounceActivityWithProof(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newAnnounce := func(withProof bool) *vocab.ActivityType {
+		published := time.Now()
+
+		announce := vocab.NewAnnounceActivity(
+			vocab.NewObjectProperty(
+				vocab.WithCollection(
+					vocab.NewCollection([]*vocab.ObjectProperty{
+						vocab.NewObjectProperty(
+							vocab.WithAnchorEvent(aptestutil.NewMockAnchorEventRef(t)),
+						),
+					}),
+				),
+			),
+			vocab.WithID(aptestutil.NewActivityID(service2IRI)),
+			vocab.WithActor(service2IRI),
+			vocab.WithTo(service1IRI),
+			vocab.WithPublishedTime(&published),
+		)
+
+		if !withProof {
+			return announce
+		}
+
+		doc, err := vocab.MarshalToDoc(announce)
+		require.NoError(t, err)
+
+		doc["proof"] = map[string]interface{}{
+			"type":               "Ed25519Signature2018",
+			"verificationMethod": service2IRI.String() + "/keys/main-key",
+			"jws":                "eyJ...",
+		}
+
+		docBytes, err := vocab.Marshal(doc)
+		require.NoError(t, err)
+
+		signed := &vocab.ActivityType{}
+		require.NoError(t, signed.UnmarshalJSON(docBytes))
+
+		return signed
+	}
+
+	t.Run("Valid proof", func(t *testing.T) {
+		verifier := servicemocks.NewActivityVerifier()
+
+		ib := NewInbox(cfg, memstore.New(cfg.ServiceName), &servicemocks.Outbox{}, servicemocks.NewActivitPubClient(),
+			spi.WithAnchorEventHandler(servicemocks.NewAnchorEventHandler()),
+			spi.WithActivityVerifier(verifier))
+		require.NotNil(t, ib)
+
+		ib.Start()
+		defer ib.Stop()
+
+		require.NoError(t, ib.HandleActivity(nil, newAnnounce(true)))
+		require.Len(t, verifier.Activities(), 1)
+	})
+
+	t.Run("No verifier -> not verified", func(t *testing.T) {
+		ib := NewInbox(cfg, memstore.New(cfg.ServiceName), &servicemocks.Outbox{}, servicemocks.NewActivitPubClient(),
+			spi.WithAnchorEventHandler(servicemocks.NewAnchorEventHandler()))
+		require.NotNil(t, ib)
+
+		ib.Start()
+		defer ib.Stop()
+
+		require.NoError(t, ib.HandleActivity(nil, newAnnounce(false)))
+	})
+
+	t.Run("Stripped proof", func(t *testing.T) {
+		verifier := servicemocks.NewActivityVerifier()
+		apStore := &servicemocks.ActivityStore{}
+
+		ib := NewInbox(cfg, apStore, &servicemocks.Outbox{}, servicemocks.NewActivitPubClient(),
+			spi.WithAnchorEventHandler(servicemocks.NewAnchorEventHandler()),
+			spi.WithActivityVerifier(verifier))
+		require.NotNil(t, ib)
+
+		ib.Start()
+		defer ib.Stop()
+
+		doc, err := vocab.MarshalToDoc(newAnnounce(true))
+		require.NoError(t, err)
+
+		delete(doc, "proof")
+
+		docBytes, err := vocab.Marshal(doc)
+		require.NoError(t, err)
+
+		stripped := &vocab.ActivityType{}
+		require.NoError(t, stripped.UnmarshalJSON(docBytes))
+
+		err = ib.HandleActivity(nil, stripped)
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "has no proof")
+		require.Empty(t, verifier.Activities())
+		require.Zero(t, apStore.AddReferenceCallCount())
+	})
+
+	t.Run("Invalid proof", func(t *testing.T) {
+		errExpected := orberrors.NewBadRequest(errors.New("injected verify error"))
+
+		apStore := &servicemocks.ActivityStore{}
+
+		ib := NewInbox(cfg, apStore, &servicemocks.Outbox{}, servicemocks.NewActivitPubClient(),
+			spi.WithAnchorEventHandler(servicemocks.NewAnchorEventHandler()),
+			spi.WithActivityVerifier(servicemocks.NewActivityVerifier().WithError(errExpected)))
+		require.NotNil(t, ib)
+
+		ib.Start()
+		defer ib.Stop()
+
+		err := ib.HandleActivity(nil, newAnnounce(true))
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Zero(t, apStore.AddReferenceCallCount())
+	})
+}
+
 func TestHandler_HandleOfferActivity(t *testing.T) {
 	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
 	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
@@ -3174,6 +3301,726 @@ const proof = `{
   }
 }`
 
+func TestHandler_InboxHandleUpdateActivity(t *testing.T) {
+	log.SetLevel("activitypub_service", log.DEBUG)
+
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newUpdate := func(actor *vocab.ActorType) *vocab.ActivityType {
+		return vocab.NewUpdateActivity(
+			vocab.NewObjectProperty(vocab.WithActorObject(actor)),
+			vocab.WithID(testutil.NewMockID(service2IRI, "/activities/update")),
+			vocab.WithActor(service2IRI),
+			vocab.WithTo(testutil.NewMockID(service2IRI, "/followers")),
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		activityStore := memstore.New(cfg.ServiceName)
+		apClient := servicemocks.NewActivitPubClient()
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox(), apClient)
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		subscriber := newMockActivitySubscriber(h.Subscribe())
+		go subscriber.Listen()
+
+		newKey := vocab.NewPublicKey(
+			vocab.WithID(testutil.NewMockID(service2IRI, "/keys/new-key")),
+			vocab.WithOwner(service2IRI),
+			vocab.WithPublicKeyPem("-----BEGIN PUBLIC KEY-----\nnew....."),
+		)
+
+		update := newUpdate(aptestutil.NewMockService(service2IRI, aptestutil.WithPublicKey(newKey)))
+
+		require.NoError(t, h.HandleActivity(nil, update))
+
+		time.Sleep(50 * time.Millisecond)
+
+		require.NotNil(t, subscriber.Activity(update.ID()))
+
+		actor, err := activityStore.GetActor(service2IRI)
+		require.NoError(t, err)
+		require.Equal(t, newKey.ID.String(), actor.PublicKey().ID.String())
+
+		cleared := apClient.ClearedActors()
+		require.Len(t, cleared, 1)
+		require.Equal(t, service2IRI.String(), cleared[0].String())
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		t.Run("No actor", func(t *testing.T) {
+			update := vocab.NewUpdateActivity(
+				vocab.NewObjectProperty(vocab.WithActorObject(aptestutil.NewMockService(service2IRI))),
+				vocab.WithID(testutil.NewMockID(service2IRI, "/activities/update")),
+			)
+
+			err := h.HandleActivity(nil, update)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "actor is required")
+		})
+
+		t.Run("Unsupported object", func(t *testing.T) {
+			update := vocab.NewUpdateActivity(
+				vocab.NewObjectProperty(vocab.WithIRI(service2IRI)),
+				vocab.WithID(testutil.NewMockID(service2IRI, "/activities/update")),
+				vocab.WithActor(service2IRI),
+			)
+
+			err := h.HandleActivity(nil, update)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "unsupported object type")
+		})
+
+		t.Run("Actor mismatch", func(t *testing.T) {
+			err := h.HandleActivity(nil, newUpdate(aptestutil.NewMockService(service1IRI)))
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "does not match the actor of the activity")
+		})
+
+		t.Run("Public key owner mismatch", func(t *testing.T) {
+			update := newUpdate(aptestutil.NewMockService(service2IRI,
+				aptestutil.WithPublicKey(aptestutil.NewMockPublicKey(service1IRI))))
+
+			err := h.HandleActivity(nil, update)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "public key owner")
+		})
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		activityStore := &servicemocks.ActivityStore{}
+		activityStore.PutActorReturns(errExpected)
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox(), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newUpdate(aptestutil.NewMockService(service2IRI)))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+		require.True(t, errors.Is(err, errExpected))
+	})
+}
+
+func TestHandler_OutboxHandleUpdateActivity(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newUpdate := func(actor *vocab.ActorType) *vocab.ActivityType {
+		return vocab.NewUpdateActivity(
+			vocab.NewObjectProperty(vocab.WithActorObject(actor)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/update")),
+			vocab.WithActor(service1IRI),
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		activityStore := memstore.New(cfg.ServiceName)
+
+		h := NewOutbox(cfg, activityStore, servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		require.NoError(t, h.HandleActivity(nil, newUpdate(aptestutil.NewMockService(service1IRI))))
+
+		actor, err := activityStore.GetActor(service1IRI)
+		require.NoError(t, err)
+		require.Equal(t, service1IRI.String(), actor.ID().String())
+	})
+
+	t.Run("Not local actor", func(t *testing.T) {
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newUpdate(aptestutil.NewMockService(service2IRI)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "this service is not the actor")
+	})
+
+	t.Run("Unsupported object", func(t *testing.T) {
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		update := vocab.NewUpdateActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(service1IRI)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/update")),
+			vocab.WithActor(service1IRI),
+		)
+
+		err := h.HandleActivity(nil, update)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported object type")
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		activityStore := &servicemocks.ActivityStore{}
+		activityStore.PutActorReturns(errExpected)
+
+		h := NewOutbox(cfg, activityStore, servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newUpdate(aptestutil.NewMockService(service1IRI)))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
+
+func TestHandler_InboxHandleBlockActivity(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newBlock := func(obj *url.URL) *vocab.ActivityType {
+		return vocab.NewBlockActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(obj)),
+			vocab.WithID(testutil.NewMockID(service2IRI, "/activities/block")),
+			vocab.WithActor(service2IRI),
+			vocab.WithTo(service1IRI),
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		activityStore := memstore.New(cfg.ServiceName)
+
+		require.NoError(t, activityStore.AddReference(store.Following, service1IRI, service2IRI))
+		require.NoError(t, activityStore.AddReference(store.Witness, service1IRI, service2IRI))
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox(), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		subscriber := newMockActivitySubscriber(h.Subscribe())
+		go subscriber.Listen()
+
+		block := newBlock(service1IRI)
+
+		require.NoError(t, h.HandleActivity(nil, block))
+
+		time.Sleep(50 * time.Millisecond)
+
+		require.NotNil(t, subscriber.Activity(block.ID()))
+
+		for _, refType := range []store.ReferenceType{store.Following, store.Witness} {
+			it, err := activityStore.QueryReferences(refType, store.NewCriteria(store.WithObjectIRI(service1IRI)))
+			require.NoError(t, err)
+
+			refs, err := storeutil.ReadReferences(it, -1)
+			require.NoError(t, err)
+			require.Empty(t, refs)
+		}
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		t.Run("No actor", func(t *testing.T) {
+			block := vocab.NewBlockActivity(
+				vocab.NewObjectProperty(vocab.WithIRI(service1IRI)),
+				vocab.WithID(testutil.NewMockID(service2IRI, "/activities/block")),
+			)
+
+			err := h.HandleActivity(nil, block)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "no actor specified")
+		})
+
+		t.Run("No object IRI", func(t *testing.T) {
+			block := vocab.NewBlockActivity(
+				vocab.NewObjectProperty(),
+				vocab.WithID(testutil.NewMockID(service2IRI, "/activities/block")),
+				vocab.WithActor(service2IRI),
+			)
+
+			err := h.HandleActivity(nil, block)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "no IRI specified")
+		})
+
+		t.Run("Not this service", func(t *testing.T) {
+			err := h.HandleActivity(nil, newBlock(testutil.MustParseURL("http://localhost:8303/services/service3")))
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "this service is not the object")
+		})
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		activityStore := &servicemocks.ActivityStore{}
+		activityStore.DeleteReferenceReturns(errExpected)
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox(), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newBlock(service1IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
+
+func TestHandler_OutboxHandleBlockActivity(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newBlock := func(actor, obj *url.URL) *vocab.ActivityType {
+		return vocab.NewBlockActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(obj)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/block")),
+			vocab.WithActor(actor),
+			vocab.WithTo(obj),
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		blockList := servicemocks.NewBlockList()
+
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient(),
+			spi.WithBlockList(blockList))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		require.NoError(t, h.HandleActivity(nil, newBlock(service1IRI, service2IRI)))
+
+		blocked, err := blockList.IsBlocked(service2IRI)
+		require.NoError(t, err)
+		require.True(t, blocked)
+	})
+
+	t.Run("No block list -> Success", func(t *testing.T) {
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		require.NoError(t, h.HandleActivity(nil, newBlock(service1IRI, service2IRI)))
+	})
+
+	t.Run("Not local actor", func(t *testing.T) {
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newBlock(service2IRI, service1IRI))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "this service is not the actor")
+	})
+
+	t.Run("No object IRI", func(t *testing.T) {
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		block := vocab.NewBlockActivity(
+			vocab.NewObjectProperty(),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/block")),
+			vocab.WithActor(service1IRI),
+		)
+
+		err := h.HandleActivity(nil, block)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no IRI specified")
+	})
+
+	t.Run("Block list error", func(t *testing.T) {
+		errExpected := errors.New("injected block list error")
+
+		h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient(),
+			spi.WithBlockList(servicemocks.NewBlockList().WithError(errExpected)))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newBlock(service1IRI, service2IRI))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errExpected))
+	})
+}
+
+func TestHandler_InboxHandleMoveActivity(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	oldService2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+	newService2IRI := testutil.MustParseURL("http://localhost:8303/services/service2")
+	service3IRI := testutil.MustParseURL("http://localhost:8304/services/service3")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	newMove := func(actor, obj, target *url.URL) *vocab.ActivityType {
+		return vocab.NewMoveActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(obj)),
+			vocab.WithID(testutil.NewMockID(oldService2IRI, "/activities/move")),
+			vocab.WithActor(actor),
+			vocab.WithTarget(vocab.NewObjectProperty(vocab.WithIRI(target))),
+			vocab.WithTo(testutil.NewMockID(oldService2IRI, "/followers")),
+		)
+	}
+
+	newActor := vocab.NewService(newService2IRI, vocab.WithAlsoKnownAs(oldService2IRI))
+
+	t.Run("Success", func(t *testing.T) {
+		activityStore := memstore.New(cfg.ServiceName)
+
+		for _, refType := range []store.ReferenceType{store.Follower, store.Following, store.Witness, store.Witnessing} {
+			require.NoError(t, activityStore.AddReference(refType, service1IRI, oldService2IRI))
+			require.NoError(t, activityStore.AddReference(refType, service1IRI, service3IRI))
+		}
+
+		ob := servicemocks.NewOutbox()
+		apClient := servicemocks.NewActivitPubClient().WithActor(newActor)
+		moveRegistry := servicemocks.NewMoveRegistry()
+
+		h := NewInbox(cfg, activityStore, ob, apClient, spi.WithMoveRegistry(moveRegistry))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		subscriber := newMockActivitySubscriber(h.Subscribe())
+		go subscriber.Listen()
+
+		move := newMove(oldService2IRI, oldService2IRI, newService2IRI)
+
+		require.NoError(t, h.HandleActivity(nil, move))
+
+		time.Sleep(50 * time.Millisecond)
+
+		require.NotNil(t, subscriber.Activity(move.ID()))
+
+		// The 'follower' and 'witnessing' references should have been replaced with the new actor.
+		for _, refType := range []store.ReferenceType{store.Follower, store.Witnessing} {
+			refs := queryReferences(t, activityStore, refType, service1IRI)
+			require.Len(t, refs, 2)
+			require.True(t, containsIRI(refs, newService2IRI))
+			require.True(t, containsIRI(refs, service3IRI))
+			require.False(t, containsIRI(refs, oldService2IRI))
+		}
+
+		// The 'following' and 'witness' references to the old actor should have been removed. The new actor
+		// is added when it accepts the 'Follow' and 'Invite'.
+		for _, refType := range []store.ReferenceType{store.Following, store.Witness} {
+			refs := queryReferences(t, activityStore, refType, service1IRI)
+			require.Len(t, refs, 1)
+			require.True(t, containsIRI(refs, service3IRI))
+		}
+
+		follows := ob.Activities().QueryByType(vocab.TypeFollow)
+		require.Len(t, follows, 1)
+		require.Equal(t, newService2IRI.String(), follows[0].Object().IRI().String())
+		require.True(t, follows[0].To().Contains(newService2IRI))
+
+		invites := ob.Activities().QueryByType(vocab.TypeInvite)
+		require.Len(t, invites, 1)
+		require.Equal(t, newService2IRI.String(), invites[0].Target().IRI().String())
+		require.True(t, invites[0].To().Contains(newService2IRI))
+
+		require.Equal(t, newService2IRI.String(), moveRegistry.Get(oldService2IRI).String())
+		require.Contains(t, apClient.ClearedActors(), oldService2IRI)
+	})
+
+	t.Run("No references -> Success", func(t *testing.T) {
+		ob := servicemocks.NewOutbox()
+
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), ob, servicemocks.NewActivitPubClient().WithActor(newActor))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		require.NoError(t, h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI)))
+		require.Empty(t, ob.Activities())
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(),
+			servicemocks.NewActivitPubClient().WithActor(newActor))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		t.Run("No actor", func(t *testing.T) {
+			move := vocab.NewMoveActivity(
+				vocab.NewObjectProperty(vocab.WithIRI(oldService2IRI)),
+				vocab.WithID(testutil.NewMockID(oldService2IRI, "/activities/move")),
+				vocab.WithTarget(vocab.NewObjectProperty(vocab.WithIRI(newService2IRI))),
+			)
+
+			err := h.HandleActivity(nil, move)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "no actor specified")
+		})
+
+		t.Run("No object", func(t *testing.T) {
+			move := vocab.NewMoveActivity(
+				vocab.NewObjectProperty(),
+				vocab.WithID(testutil.NewMockID(oldService2IRI, "/activities/move")),
+				vocab.WithActor(oldService2IRI),
+				vocab.WithTarget(vocab.NewObjectProperty(vocab.WithIRI(newService2IRI))),
+			)
+
+			err := h.HandleActivity(nil, move)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "no IRI specified in 'object' field")
+		})
+
+		t.Run("Object is not the actor", func(t *testing.T) {
+			err := h.HandleActivity(nil, newMove(oldService2IRI, service3IRI, newService2IRI))
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "is not the actor")
+		})
+
+		t.Run("No target", func(t *testing.T) {
+			move := vocab.NewMoveActivity(
+				vocab.NewObjectProperty(vocab.WithIRI(oldService2IRI)),
+				vocab.WithID(testutil.NewMockID(oldService2IRI, "/activities/move")),
+				vocab.WithActor(oldService2IRI),
+			)
+
+			err := h.HandleActivity(nil, move)
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "no IRI specified in 'target' field")
+		})
+
+		t.Run("Target is the actor", func(t *testing.T) {
+			err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, oldService2IRI))
+			require.Error(t, err)
+			require.True(t, orberrors.IsBadRequest(err))
+			require.Contains(t, err.Error(), "the target is the same as the actor")
+		})
+	})
+
+	t.Run("New actor not also known as old actor", func(t *testing.T) {
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(),
+			servicemocks.NewActivitPubClient().WithActor(vocab.NewService(newService2IRI)))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "is not also known as")
+	})
+
+	t.Run("Resolve new actor error", func(t *testing.T) {
+		errExpected := errors.New("injected client error")
+
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(),
+			servicemocks.NewActivitPubClient().WithError(errExpected))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+		require.True(t, errors.Is(err, errExpected))
+	})
+
+	t.Run("Outbox error", func(t *testing.T) {
+		errExpected := errors.New("injected outbox error")
+
+		activityStore := memstore.New(cfg.ServiceName)
+		require.NoError(t, activityStore.AddReference(store.Following, service1IRI, oldService2IRI))
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox().WithError(errExpected),
+			servicemocks.NewActivitPubClient().WithActor(newActor))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+
+		// The reference to the old actor should still be there so that the 'Follow' is re-issued on retry.
+		refs := queryReferences(t, activityStore, store.Following, service1IRI)
+		require.True(t, containsIRI(refs, oldService2IRI))
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		activityStore := &servicemocks.ActivityStore{}
+		activityStore.QueryReferencesReturns(nil, errExpected)
+
+		h := NewInbox(cfg, activityStore, servicemocks.NewOutbox(),
+			servicemocks.NewActivitPubClient().WithActor(newActor))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Move registry error", func(t *testing.T) {
+		errExpected := errors.New("injected registry error")
+
+		h := NewInbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewOutbox(),
+			servicemocks.NewActivitPubClient().WithActor(newActor),
+			spi.WithMoveRegistry(servicemocks.NewMoveRegistry().WithError(errExpected)))
+		require.NotNil(t, h)
+
+		h.Start()
+		defer h.Stop()
+
+		err := h.HandleActivity(nil, newMove(oldService2IRI, oldService2IRI, newService2IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+		require.True(t, errors.Is(err, errExpected))
+	})
+}
+
+func TestHandler_OutboxHandleMoveActivity(t *testing.T) {
+	service1IRI := testutil.MustParseURL("http://localhost:8301/services/service1")
+	newService1IRI := testutil.MustParseURL("http://localhost:8311/services/service1")
+	service2IRI := testutil.MustParseURL("http://localhost:8302/services/service2")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1IRI,
+	}
+
+	h := NewOutbox(cfg, memstore.New(cfg.ServiceName), servicemocks.NewActivitPubClient())
+	require.NotNil(t, h)
+
+	h.Start()
+	defer h.Stop()
+
+	newMove := func(actor, obj, target *url.URL) *vocab.ActivityType {
+		return vocab.NewMoveActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(obj)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/move")),
+			vocab.WithActor(actor),
+			vocab.WithTarget(vocab.NewObjectProperty(vocab.WithIRI(target))),
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, h.HandleActivity(nil, newMove(service1IRI, service1IRI, newService1IRI)))
+	})
+
+	t.Run("Not local actor", func(t *testing.T) {
+		err := h.HandleActivity(nil, newMove(service2IRI, service2IRI, newService1IRI))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "this service is not the actor")
+	})
+
+	t.Run("Object is not local service", func(t *testing.T) {
+		err := h.HandleActivity(nil, newMove(service1IRI, service2IRI, newService1IRI))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "this service is not the object")
+	})
+
+	t.Run("No target", func(t *testing.T) {
+		move := vocab.NewMoveActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(service1IRI)),
+			vocab.WithID(testutil.NewMockID(service1IRI, "/activities/move")),
+			vocab.WithActor(service1IRI),
+		)
+
+		err := h.HandleActivity(nil, move)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no IRI specified in 'target' field")
+	})
+}
+
+func queryReferences(t *testing.T, activityStore store.Store, refType store.ReferenceType,
+	objectIRI *url.URL) []*url.URL {
+	t.Helper()
+
+	it, err := activityStore.QueryReferences(refType, store.NewCriteria(store.WithObjectIRI(objectIRI)))
+	require.NoError(t, err)
+
+	refs, err := storeutil.ReadReferences(it, -1)
+	require.NoError(t, err)
+
+	return refs
+}
+
 func TestNoOpAnchorEventAcknowledgementHandler(t *testing.T) {
 	actor := testutil.MustParseURL("https://orb.domain2.com/services/orb")
 	ref := testutil.MustParseURL("hl:uEiC0IYovFG8fmxcyK-9049AY2VUbQmb6K6x9XmbCSf4_Mg:" +
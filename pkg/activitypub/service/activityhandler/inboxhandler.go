@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/trustbloc/orb/pkg/activitypub/ldproof"
 	"github.com/trustbloc/orb/pkg/activitypub/resthandler"
 	service "github.com/trustbloc/orb/pkg/activitypub/service/spi"
 	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
@@ -96,6 +97,12 @@ func (h *Inbox) HandleActivity(source *url.URL, activity *vocab.ActivityType) er
 		return h.handleLikeActivity(activity)
 	case typeProp.Is(vocab.TypeUndo):
 		return h.handleUndoActivity(activity)
+	case typeProp.Is(vocab.TypeUpdate):
+		return h.handleUpdateActivity(activity)
+	case typeProp.Is(vocab.TypeBlock):
+		return h.handleBlockActivity(activity)
+	case typeProp.Is(vocab.TypeMove):
+		return h.handleMoveActivity(activity)
 	default:
 		return fmt.Errorf("unsupported activity type: %s", typeProp.Types())
 	}
@@ -105,6 +112,10 @@ func (h *Inbox) HandleActivity(source *url.URL, activity *vocab.ActivityType) er
 func (h *Inbox) HandleCreateActivity(source *url.URL, create *vocab.ActivityType, announce bool) error {
 	logger.Debugf("[%s] Handling 'Create' activity: %s", h.ServiceName, create.ID())
 
+	if err := h.verifyProof(create); err != nil {
+		return err
+	}
+
 	if !create.Object().Type().Is(vocab.TypeAnchorEvent) {
 		return fmt.Errorf("unsupported object type in 'Create' activity [%s]: %s", create.Object().Type(), create.ID())
 	}
@@ -453,6 +464,10 @@ func (h *Inbox) hasReference(objectIRI, refIRI *url.URL, refType store.Reference
 func (h *Inbox) HandleAnnounceActivity(source *url.URL, announce *vocab.ActivityType) (numProcessed int, err error) {
 	logger.Debugf("[%s] Handling 'Announce' activity: %s", h.ServiceName, announce.ID())
 
+	if err := h.verifyProof(announce); err != nil {
+		return 0, err
+	}
+
 	obj := announce.Object()
 
 	t := obj.Type()
@@ -479,6 +494,24 @@ func (h *Inbox) HandleAnnounceActivity(source *url.URL, announce *vocab.Activity
 	return numProcessed, nil
 }
 
+// verifyProof verifies the linked-data proof embedded in the given activity. If a verifier is configured then
+// an activity without a proof is rejected, otherwise the proof could be bypassed simply by removing it.
+func (h *Inbox) verifyProof(activity *vocab.ActivityType) error {
+	if h.ActivityVerifier == nil {
+		return nil
+	}
+
+	if !ldproof.HasProof(activity) {
+		return orberrors.NewBadRequestf("activity [%s] has no proof", activity.ID())
+	}
+
+	if err := h.ActivityVerifier.Verify(activity); err != nil {
+		return fmt.Errorf("verify proof of activity [%s]: %w", activity.ID(), err)
+	}
+
+	return nil
+}
+
 func (h *Inbox) handleOfferActivity(offer *vocab.ActivityType) error {
 	logger.Debugf("[%s] Handling 'Offer' activity: %s", h.ServiceName, offer.ID())
 
@@ -696,7 +729,8 @@ func (h *Inbox) handleAnnounceCollection(source *url.URL, announce *vocab.Activi
 		logger.Debugf("[%s] Adding 'Announce' [%s] to shares of anchor event [%s]",
 			h.ServiceIRI, announce.ID(), anchorEventID)
 
-		err := h.store.AddReference(store.Share, anchorEventID, announce.ID().URL())
+		err := h.store.AddReference(store.Share, anchorEventID, announce.ID().URL(),
+			store.WithActivityType(vocab.TypeAnnounce))
 		if err != nil {
 			// This isn't a fatal error so just log a warning.
 			logger.Warnf("[%s] Error adding 'Announce' activity %s to 'shares' of anchor event %s: %s",
@@ -729,7 +763,8 @@ func (h *Inbox) handleLikeActivity(like *vocab.ActivityType) error {
 
 	logger.Debugf("[%s] Storing activity in the 'Likes' collection: %s", h.ServiceName, refURL)
 
-	if err := h.store.AddReference(store.Like, refURL, like.ID().URL()); err != nil {
+	if err := h.store.AddReference(store.Like, refURL, like.ID().URL(),
+		store.WithActivityType(vocab.TypeLike)); err != nil {
 		return orberrors.NewTransient(fmt.Errorf("add activity to 'Likes' collection: %w", err))
 	}
 
@@ -738,6 +773,178 @@ func (h *Inbox) handleLikeActivity(like *vocab.ActivityType) error {
 	return nil
 }
 
+// handleUpdateActivity handles an 'Update' activity in which a remote actor publishes a new version of
+// itself (for example, after rotating its public key). The stored copy of the actor is replaced and any
+// cached copy of the actor and its keys is cleared so that subsequent signature verifications use the new key.
+func (h *Inbox) handleUpdateActivity(update *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Update' activity: %s", h.ServiceName, update.ID())
+
+	if err := h.validateUpdateActivity(update); err != nil {
+		return orberrors.NewBadRequest(fmt.Errorf("invalid 'Update' activity [%s]: %w", update.ID(), err))
+	}
+
+	actor := update.Object().Actor()
+
+	logger.Debugf("[%s] Storing updated actor [%s]", h.ServiceName, actor.ID())
+
+	if err := h.store.PutActor(actor); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store actor [%s]: %w", actor.ID(), err))
+	}
+
+	h.client.ClearActor(update.Actor())
+
+	h.notify(update)
+
+	return nil
+}
+
+// handleBlockActivity handles a 'Block' activity in which a remote actor has blocked this service. The actor is
+// removed from our 'following' and 'witnesses' collections since it will no longer accept our activities.
+func (h *Inbox) handleBlockActivity(block *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Block' activity: %s", h.ServiceName, block.ID())
+
+	if block.Actor() == nil {
+		return orberrors.NewBadRequest(fmt.Errorf("no actor specified in 'Block' activity [%s]", block.ID()))
+	}
+
+	iri := block.Object().IRI()
+	if iri == nil {
+		return orberrors.NewBadRequest(fmt.Errorf("no IRI specified in 'object' field of 'Block' activity [%s]",
+			block.ID()))
+	}
+
+	if iri.String() != h.ServiceIRI.String() {
+		return orberrors.NewBadRequest(fmt.Errorf("this service is not the object of the 'Block' activity [%s]",
+			block.ID()))
+	}
+
+	for _, refType := range []store.ReferenceType{store.Following, store.Witness} {
+		if err := h.store.DeleteReference(refType, h.ServiceIRI, block.Actor()); err != nil {
+			return orberrors.NewTransient(fmt.Errorf("delete %s reference to [%s]: %w", refType, block.Actor(), err))
+		}
+	}
+
+	logger.Infof("[%s] We were blocked by [%s]", h.ServiceName, block.Actor())
+
+	h.notify(block)
+
+	return nil
+}
+
+// handleMoveActivity handles a 'Move' activity in which a remote actor announces that it has moved to a new
+// IRI (for example, after a domain migration). The new actor must declare the old IRI in 'alsoKnownAs'.
+// Our 'follower' and 'witnessing' references to the old actor are replaced with the new actor and new
+// 'Follow' and 'Invite' activities are sent to the new actor for our 'following' and 'witnesses' references.
+func (h *Inbox) handleMoveActivity(move *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Move' activity: %s", h.ServiceName, move.ID())
+
+	if err := h.validateMoveActivity(move); err != nil {
+		return orberrors.NewBadRequest(fmt.Errorf("invalid 'Move' activity [%s]: %w", move.ID(), err))
+	}
+
+	oldIRI := move.Actor()
+	newIRI := move.Target().IRI()
+
+	newActor, err := h.client.GetActor(newIRI)
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("retrieve new actor [%s]: %w", newIRI, err))
+	}
+
+	if newActor.ID().String() != newIRI.String() || !newActor.AlsoKnownAs().Contains(oldIRI) {
+		return orberrors.NewBadRequest(fmt.Errorf("new actor [%s] is not also known as [%s]", newIRI, oldIRI))
+	}
+
+	for _, refType := range []store.ReferenceType{store.Follower, store.Witnessing} {
+		if err := h.replaceReference(refType, oldIRI, newIRI); err != nil {
+			return err
+		}
+	}
+
+	err = h.reissueRequest(store.Following, oldIRI,
+		vocab.NewFollowActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(newIRI)),
+			vocab.WithActor(h.ServiceIRI),
+			vocab.WithTo(newIRI),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = h.reissueRequest(store.Witness, oldIRI,
+		vocab.NewInviteActivity(
+			vocab.NewObjectProperty(vocab.WithIRI(vocab.AnchorWitnessTargetIRI)),
+			vocab.WithTarget(vocab.NewObjectProperty(vocab.WithIRI(newIRI))),
+			vocab.WithActor(h.ServiceIRI),
+			vocab.WithTo(newIRI),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := h.MoveRegistry.Add(oldIRI, newIRI); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("register move of [%s] to [%s]: %w", oldIRI, newIRI, err))
+	}
+
+	h.client.ClearActor(oldIRI)
+
+	logger.Infof("[%s] Actor [%s] has moved to [%s]", h.ServiceName, oldIRI, newIRI)
+
+	h.notify(move)
+
+	return nil
+}
+
+// replaceReference replaces the reference to the old actor with the new actor in the given collection.
+func (h *Inbox) replaceReference(refType store.ReferenceType, oldIRI, newIRI *url.URL) error {
+	exists, err := h.hasReference(h.ServiceIRI, oldIRI, refType)
+	if err != nil || !exists {
+		return err
+	}
+
+	exists, err = h.hasReference(h.ServiceIRI, newIRI, refType)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if err := h.store.AddReference(refType, h.ServiceIRI, newIRI); err != nil {
+			return orberrors.NewTransient(fmt.Errorf("add %s reference to [%s]: %w", refType, newIRI, err))
+		}
+	}
+
+	if err := h.store.DeleteReference(refType, h.ServiceIRI, oldIRI); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("delete %s reference to [%s]: %w", refType, oldIRI, err))
+	}
+
+	logger.Debugf("[%s] Replaced [%s] with [%s] in the %s collection", h.ServiceName, oldIRI, newIRI, refType)
+
+	return nil
+}
+
+// reissueRequest posts the given activity (which is addressed to the new actor) if the old actor is in the
+// given collection and then removes the old actor from the collection. The new actor is added to the collection
+// when it replies with an 'Accept'.
+func (h *Inbox) reissueRequest(refType store.ReferenceType, oldIRI *url.URL, activity *vocab.ActivityType) error {
+	exists, err := h.hasReference(h.ServiceIRI, oldIRI, refType)
+	if err != nil || !exists {
+		return err
+	}
+
+	logger.Debugf("[%s] Publishing '%s' activity to %s", h.ServiceName, activity.Type(), activity.To())
+
+	if _, err := h.outbox.Post(activity); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("post '%s' to %s: %w", activity.Type(), activity.To(), err))
+	}
+
+	if err := h.store.DeleteReference(refType, h.ServiceIRI, oldIRI); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("delete %s reference to [%s]: %w", refType, oldIRI, err))
+	}
+
+	return nil
+}
+
 func (h *Inbox) announceAnchorEvent(create *vocab.ActivityType) error {
 	anchorEvent := create.Object().AnchorEvent()
 
@@ -799,7 +1006,8 @@ func (h *Inbox) announceAnchorEventRef(create *vocab.ActivityType) error {
 
 	logger.Debugf("[%s] Adding 'Announce' %s to shares of %s", h.ServiceIRI, announce.ID(), anchorEventURL)
 
-	err = h.store.AddReference(store.Share, anchorEventURL, activityID)
+	err = h.store.AddReference(store.Share, anchorEventURL, activityID,
+		store.WithActivityType(vocab.TypeAnnounce))
 	if err != nil {
 		logger.Warnf("[%s] Error adding 'Announce' activity %s to 'shares' of %s",
 			h.ServiceIRI, announce.ID(), anchorEventURL)
@@ -892,6 +1100,53 @@ func (h *Inbox) validateLikeActivity(like *vocab.ActivityType) error {
 	return nil
 }
 
+func (h *Inbox) validateUpdateActivity(update *vocab.ActivityType) error {
+	if update.Actor() == nil {
+		return fmt.Errorf("actor is required")
+	}
+
+	actor := update.Object().Actor()
+	if actor == nil {
+		return fmt.Errorf("unsupported object type: %s", update.Object().Type())
+	}
+
+	if actor.ID() == nil || actor.ID().String() != update.Actor().String() {
+		return fmt.Errorf("actor in object [%s] does not match the actor of the activity [%s]",
+			actor.ID(), update.Actor())
+	}
+
+	publicKey := actor.PublicKey()
+	if publicKey != nil && (publicKey.Owner == nil || publicKey.Owner.String() != update.Actor().String()) {
+		return fmt.Errorf("public key owner [%s] does not match the actor [%s]", publicKey.Owner, update.Actor())
+	}
+
+	return nil
+}
+
+func (h *Inbox) validateMoveActivity(move *vocab.ActivityType) error {
+	if move.Actor() == nil {
+		return fmt.Errorf("no actor specified")
+	}
+
+	if move.Object().IRI() == nil {
+		return fmt.Errorf("no IRI specified in 'object' field")
+	}
+
+	if move.Object().IRI().String() != move.Actor().String() {
+		return fmt.Errorf("the object [%s] is not the actor [%s]", move.Object().IRI(), move.Actor())
+	}
+
+	if move.Target().IRI() == nil {
+		return fmt.Errorf("no IRI specified in 'target' field")
+	}
+
+	if move.Target().IRI().String() == move.Actor().String() {
+		return fmt.Errorf("the target is the same as the actor")
+	}
+
+	return nil
+}
+
 func (h *Inbox) witnessAnchorCredential(vc vocab.Document) (*vocab.ObjectType, error) {
 	bytes, err := json.Marshal(vc)
 	if err != nil {
@@ -1043,6 +1298,26 @@ func (a *AcceptAllActorsAuth) AuthorizeActor(*vocab.ActorType) (bool, error) {
 	return true, nil
 }
 
+type noOpBlockList struct{}
+
+func (l *noOpBlockList) IsBlocked(*url.URL) (bool, error) {
+	return false, nil
+}
+
+func (l *noOpBlockList) Block(actorIRI *url.URL) error {
+	logger.Debugf("Block list not configured. Actor [%s] will not be blocked.", actorIRI)
+
+	return nil
+}
+
+type noOpMoveRegistry struct{}
+
+func (r *noOpMoveRegistry) Add(oldIRI, newIRI *url.URL) error {
+	logger.Debugf("Move registry not configured. Move of [%s] to [%s] will not be recorded.", oldIRI, newIRI)
+
+	return nil
+}
+
 type noOpProofHandler struct{}
 
 func (p *noOpProofHandler) HandleProof(witness *url.URL, anchorCredID string,
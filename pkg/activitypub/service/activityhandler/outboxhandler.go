@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net/url"
 
+	service "github.com/trustbloc/orb/pkg/activitypub/service/spi"
 	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
 	orberrors "github.com/trustbloc/orb/pkg/errors"
@@ -19,11 +20,21 @@ import (
 // Outbox handles activities posted to the outbox.
 type Outbox struct {
 	*handler
+	*service.Handlers
 }
 
 // NewOutbox returns a new ActivityPub outbox activity handler.
-func NewOutbox(cfg *Config, s store.Store, activityPubClient activityPubClient) *Outbox {
-	h := &Outbox{}
+func NewOutbox(cfg *Config, s store.Store, activityPubClient activityPubClient,
+	opts ...service.HandlerOpt) *Outbox {
+	options := defaultOptions()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	h := &Outbox{
+		Handlers: options,
+	}
 
 	h.handler = newHandler(cfg, s, activityPubClient,
 		func(activity *vocab.ActivityType) error {
@@ -57,6 +68,12 @@ func (h *Outbox) HandleActivity(_ *url.URL, activity *vocab.ActivityType) error
 		return h.handleUndoActivity(activity)
 	case typeProp.Is(vocab.TypeLike):
 		return h.handleLikeActivity(activity)
+	case typeProp.Is(vocab.TypeUpdate):
+		return h.handleUpdateActivity(activity)
+	case typeProp.Is(vocab.TypeBlock):
+		return h.handleBlockActivity(activity)
+	case typeProp.Is(vocab.TypeMove):
+		return h.handleMoveActivity(activity)
 	default:
 		// Nothing to do for activity.
 		return nil
@@ -91,6 +108,69 @@ func (h *handler) handleCreateActivity(create *vocab.ActivityType) error {
 	return nil
 }
 
+func (h *Outbox) handleUpdateActivity(update *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Update' activity: %s", h.ServiceName, update.ID())
+
+	actor := update.Object().Actor()
+	if actor == nil {
+		return fmt.Errorf("unsupported object type in 'Update' activity [%s]: %s", update.ID(), update.Object().Type())
+	}
+
+	if actor.ID().String() != h.ServiceIRI.String() {
+		return fmt.Errorf("this service is not the actor in the 'Update' activity [%s]", update.ID())
+	}
+
+	logger.Debugf("[%s] Storing updated actor", h.ServiceName)
+
+	if err := h.store.PutActor(actor); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store actor: %w", err))
+	}
+
+	return nil
+}
+
+func (h *Outbox) handleBlockActivity(block *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Block' activity: %s", h.ServiceName, block.ID())
+
+	if block.Actor().String() != h.ServiceIRI.String() {
+		return fmt.Errorf("this service is not the actor for the 'Block' activity [%s]", block.ID())
+	}
+
+	iri := block.Object().IRI()
+	if iri == nil {
+		return fmt.Errorf("no IRI specified in 'object' field of 'Block' activity [%s]", block.ID())
+	}
+
+	if err := h.BlockList.Block(iri); err != nil {
+		return fmt.Errorf("block actor [%s]: %w", iri, err)
+	}
+
+	logger.Infof("[%s] Blocked actor [%s]", h.ServiceName, iri)
+
+	return nil
+}
+
+func (h *Outbox) handleMoveActivity(move *vocab.ActivityType) error {
+	logger.Debugf("[%s] Handling 'Move' activity: %s", h.ServiceName, move.ID())
+
+	if move.Actor().String() != h.ServiceIRI.String() {
+		return fmt.Errorf("this service is not the actor for the 'Move' activity [%s]", move.ID())
+	}
+
+	if iri := move.Object().IRI(); iri == nil || iri.String() != h.ServiceIRI.String() {
+		return fmt.Errorf("this service is not the object of the 'Move' activity [%s]", move.ID())
+	}
+
+	newIRI := move.Target().IRI()
+	if newIRI == nil {
+		return fmt.Errorf("no IRI specified in 'target' field of 'Move' activity [%s]", move.ID())
+	}
+
+	logger.Infof("[%s] Announcing move to [%s]", h.ServiceName, newIRI)
+
+	return nil
+}
+
 func (h *Outbox) undoAddReference(activity *vocab.ActivityType, refType store.ReferenceType,
 	getTargetIRI func() *url.URL) error {
 	if activity.Actor().String() != h.ServiceIRI.String() {
@@ -31,6 +31,9 @@ const (
 	defaultMinActivityAge = time.Minute
 
 	taskName = "activity-sync"
+
+	pageParam   = "page"
+	cursorParam = "cursor"
 )
 
 type activitySource string
@@ -363,10 +366,26 @@ func (m *task) getLastSyncedPage(serviceIRI *url.URL, src activitySource) (*url.
 	}
 
 	if src == inbox {
-		return actor.Inbox(), 0, nil
+		return firstCursorPage(actor.Inbox()), 0, nil
 	}
 
-	return actor.Outbox(), 0, nil
+	return firstCursorPage(actor.Outbox()), 0, nil
+}
+
+// firstCursorPage returns the URL of the first cursor page of the given collection. The next link of a cursor
+// page is positioned after the last activity in the page (rather than at a page number), so the last synced
+// position isn't shifted when older activities are pruned from the collection by the activity retention
+// policy. A server that doesn't support cursors ignores the cursor parameter and returns the first page.
+func firstCursorPage(collectionIRI *url.URL) *url.URL {
+	pageURL := *collectionIRI
+
+	query := pageURL.Query()
+	query.Set(pageParam, "true")
+	query.Set(cursorParam, "")
+
+	pageURL.RawQuery = query.Encode()
+
+	return &pageURL
 }
 
 type progressLogger struct {
@@ -124,6 +124,14 @@ func TestRun(t *testing.T) {
 		task.run()
 
 		require.Equal(t, 3, len(handler.activities))
+
+		// The sync position should be kept in a cursor page so that it isn't affected by activity retention.
+		for _, src := range []activitySource{inbox, outbox} {
+			page, _, err := task.store.GetLastSyncedPage(service2IRI, src)
+			require.NoError(t, err)
+			require.Equal(t, "true", page.Query().Get(pageParam))
+			require.Contains(t, page.Query(), cursorParam)
+		}
 	})
 
 	t.Run("QueryReferences error", func(t *testing.T) {
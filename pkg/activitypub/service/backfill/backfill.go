@@ -0,0 +1,546 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/client"
+	"github.com/trustbloc/orb/pkg/activitypub/service/spi"
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+var logger = log.New("activity_backfill")
+
+const (
+	taskName = "activity-backfill"
+
+	jobTag    = "backfill-job"
+	jobPrefix = "backfill-job-"
+
+	defaultInterval         = 10 * time.Second
+	defaultProgressInterval = 100
+)
+
+// Status is the status of a backfill job.
+type Status string
+
+const (
+	// StatusPending indicates that the job has been submitted but has not yet started.
+	StatusPending Status = "pending"
+	// StatusRunning indicates that the job is in progress.
+	StatusRunning Status = "running"
+	// StatusCompleted indicates that all activities in the requested range have been processed.
+	StatusCompleted Status = "completed"
+	// StatusFailed indicates that the job stopped due to a non-recoverable error.
+	StatusFailed Status = "failed"
+)
+
+type activityPubClient interface {
+	GetActor(iri *url.URL) (*vocab.ActorType, error)
+	GetActivities(iri *url.URL, order client.Order) (client.ActivityIterator, error)
+}
+
+type taskManager interface {
+	RegisterTask(taskType string, interval time.Duration, task func())
+}
+
+// Config contains configuration parameters for the backfill manager.
+type Config struct {
+	// Interval is the interval at which pending and interrupted jobs are checked.
+	Interval time.Duration
+	// ProgressInterval is the number of activities after which job progress is saved.
+	ProgressInterval int
+}
+
+// Request contains the parameters of a backfill job. The optional time range applies to the published
+// time of an activity and the optional activity range is inclusive on both ends.
+type Request struct {
+	ServiceIRI   string     `json:"serviceIRI"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+	FromActivity string     `json:"fromActivity,omitempty"`
+	ToActivity   string     `json:"toActivity,omitempty"`
+}
+
+// Job holds the parameters and progress of a backfill job.
+type Job struct {
+	Request
+
+	ID     string `json:"id"`
+	Status Status `json:"status"`
+
+	LastPage     string `json:"lastPage,omitempty"`
+	LastIndex    int    `json:"lastIndex"`
+	FromReached  bool   `json:"fromReached,omitempty"`
+	Processed    int    `json:"processed"`
+	Skipped      int    `json:"skipped"`
+	Errors       int    `json:"errors"`
+	LastError    string `json:"lastError,omitempty"`
+	AnchorEvents int    `json:"anchorEvents"`
+
+	// Throughput is the number of activities read from the peer per second of running time.
+	Throughput  float64       `json:"throughput"`
+	RunningTime time.Duration `json:"runningTime"`
+
+	Created   time.Time  `json:"created"`
+	Updated   time.Time  `json:"updated"`
+	Completed *time.Time `json:"completed,omitempty"`
+}
+
+// Manager manages jobs that read the activities from the outbox of a peer Orb server and feed them
+// to the inbox handler in order to backfill anchor events that were missed (for example, before this
+// server started following the peer). Jobs are persisted and executed by a single task that is coordinated
+// by the task manager so that only one server instance runs the jobs. If the instance goes down then
+// another instance resumes each job from the last saved position.
+type Manager struct {
+	store            storage.Store
+	apClient         activityPubClient
+	activityPubStore store.Store
+	blockList        spi.BlockList
+	getHandler       func() spi.InboxHandler
+	progressInterval int
+	marshal          func(v interface{}) ([]byte, error)
+	unmarshal        func(data []byte, v interface{}) error
+	newID            func() string
+}
+
+// NewManager returns a new backfill manager and registers the backfill task with the task manager. Activities
+// from actors in the given block list are skipped, in the same way that they're rejected by the inbox.
+func NewManager(cfg Config, taskMgr taskManager, s storage.Store, apClient activityPubClient,
+	apStore store.Store, blockList spi.BlockList, handlerFactory func() spi.InboxHandler) *Manager {
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+
+	progressInterval := cfg.ProgressInterval
+	if progressInterval == 0 {
+		progressInterval = defaultProgressInterval
+	}
+
+	m := &Manager{
+		store:            s,
+		apClient:         apClient,
+		activityPubStore: apStore,
+		blockList:        blockList,
+		getHandler:       handlerFactory,
+		progressInterval: progressInterval,
+		marshal:          json.Marshal,
+		unmarshal:        json.Unmarshal,
+		newID:            uuid.NewString,
+	}
+
+	logger.Infof("Registering activity-backfill task - Interval: %s.", interval)
+
+	taskMgr.RegisterTask(taskName, interval, m.run)
+
+	return m
+}
+
+// Start validates the given request and saves a new pending job. The job is started by the backfill task.
+func (m *Manager) Start(req *Request) (*Job, error) {
+	if err := validate(req); err != nil {
+		return nil, orberrors.NewBadRequest(err)
+	}
+
+	now := time.Now()
+
+	job := &Job{
+		Request: *req,
+		ID:      m.newID(),
+		Status:  StatusPending,
+		Created: now,
+		Updated: now,
+	}
+
+	if err := m.save(job); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Submitted backfill job [%s] for service [%s]", job.ID, job.ServiceIRI)
+
+	return job, nil
+}
+
+// Get returns the job for the given ID. If the job isn't found then store.ErrNotFound is returned.
+func (m *Manager) Get(id string) (*Job, error) {
+	value, err := m.store.Get(newKey(id))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, store.ErrNotFound
+		}
+
+		return nil, orberrors.NewTransientf("get backfill job [%s]: %w", id, err)
+	}
+
+	job := &Job{}
+
+	if err := m.unmarshal(value, job); err != nil {
+		return nil, fmt.Errorf("unmarshal backfill job [%s]: %w", id, err)
+	}
+
+	return job, nil
+}
+
+// GetAll returns all backfill jobs.
+func (m *Manager) GetAll() ([]*Job, error) {
+	it, err := m.store.Query(jobTag)
+	if err != nil {
+		return nil, orberrors.NewTransientf("query backfill jobs: %w", err)
+	}
+
+	defer func() {
+		if e := it.Close(); e != nil {
+			logger.Warnf("Error closing iterator: %s", e)
+		}
+	}()
+
+	var jobs []*Job
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransientf("query next item: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransientf("get value: %w", err)
+		}
+
+		job := &Job{}
+
+		err = m.unmarshal(value, job)
+		if err != nil {
+			logger.Warnf("Error unmarshalling backfill job: %s. The item will be ignored.", err)
+
+			continue
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (m *Manager) run() {
+	jobs, err := m.GetAll()
+	if err != nil {
+		logger.Errorf("Error retrieving backfill jobs: %s", err)
+
+		return
+	}
+
+	for _, job := range jobs {
+		if job.Status != StatusPending && job.Status != StatusRunning {
+			continue
+		}
+
+		m.runJob(job)
+	}
+}
+
+func (m *Manager) runJob(job *Job) {
+	logger.Infof("Running backfill job [%s] for service [%s] starting at page [%s], index [%d]",
+		job.ID, job.ServiceIRI, job.LastPage, job.LastIndex)
+
+	job.Status = StatusRunning
+
+	r := &jobRunner{Manager: m, job: job, started: time.Now()}
+
+	done, err := r.execute()
+	if err != nil {
+		job.Errors++
+		job.LastError = err.Error()
+
+		if orberrors.IsTransient(err) {
+			logger.Warnf("Transient error in backfill job [%s]. The job will be resumed later: %s", job.ID, err)
+		} else {
+			logger.Errorf("Backfill job [%s] failed: %s", job.ID, err)
+
+			job.Status = StatusFailed
+		}
+	} else if done {
+		now := time.Now()
+
+		job.Status = StatusCompleted
+		job.Completed = &now
+
+		logger.Infof("Backfill job [%s] completed. Processed: %d, Skipped: %d, Errors: %d",
+			job.ID, job.Processed, job.Skipped, job.Errors)
+	}
+
+	if err := r.saveProgress(); err != nil {
+		logger.Errorf("Error saving backfill job [%s]: %s", job.ID, err)
+	}
+}
+
+func (m *Manager) save(job *Job) error {
+	value, err := m.marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal backfill job [%s]: %w", job.ID, err)
+	}
+
+	if err := m.store.Put(newKey(job.ID), value, storage.Tag{Name: jobTag}); err != nil {
+		return orberrors.NewTransientf("store backfill job [%s]: %w", job.ID, err)
+	}
+
+	return nil
+}
+
+type jobRunner struct {
+	*Manager
+
+	job          *Job
+	started      time.Time
+	numSinceSave int
+}
+
+// execute processes activities from the last saved position and returns true if the end of the requested
+// range was reached. A transient error stops the job at the current activity so that it may be resumed later.
+//
+//nolint:gocyclo,cyclop
+func (r *jobRunner) execute() (bool, error) {
+	it, err := r.getIterator()
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		a, e := it.Next()
+		if e != nil {
+			if errors.Is(e, client.ErrNotFound) {
+				return true, nil
+			}
+
+			return false, orberrors.NewTransientf("next activity: %w", e)
+		}
+
+		if r.job.Until != nil && a.Published() != nil && !a.Published().Before(*r.job.Until) {
+			return true, nil
+		}
+
+		if e := r.processActivity(a); e != nil {
+			return false, e
+		}
+
+		r.job.LastPage, r.job.LastIndex = it.CurrentPage().String(), it.NextIndex()-1
+
+		if r.job.ToActivity != "" && r.job.ToActivity == a.ID().String() {
+			return true, nil
+		}
+
+		if e := r.checkpoint(); e != nil {
+			return false, e
+		}
+	}
+}
+
+func (r *jobRunner) processActivity(a *vocab.ActivityType) error {
+	if !r.inRange(a) {
+		r.job.Skipped++
+
+		return nil
+	}
+
+	blocked, err := r.blockList.IsBlocked(a.Actor())
+	if err != nil {
+		return orberrors.NewTransientf("check block list for actor [%s]: %w", a.Actor(), err)
+	}
+
+	if blocked {
+		logger.Infof("Backfill job [%s]: skipping activity [%s] since actor [%s] is blocked",
+			r.job.ID, a.ID(), a.Actor())
+
+		r.job.Skipped++
+
+		return nil
+	}
+
+	n, err := r.process(a)
+	if err != nil {
+		if orberrors.IsTransient(err) {
+			return fmt.Errorf("process activity [%s]: %w", a.ID(), err)
+		}
+
+		logger.Warnf("Backfill job [%s]: error processing activity [%s]: %s", r.job.ID, a.ID(), err)
+
+		r.job.Errors++
+		r.job.LastError = err.Error()
+
+		return nil
+	}
+
+	r.job.Processed++
+	r.job.AnchorEvents += n
+
+	return nil
+}
+
+func (r *jobRunner) inRange(a *vocab.ActivityType) bool {
+	if r.job.FromActivity != "" && !r.job.FromReached {
+		if a.ID().String() != r.job.FromActivity {
+			return false
+		}
+
+		r.job.FromReached = true
+	}
+
+	if !a.Type().IsAny(vocab.TypeCreate, vocab.TypeAnnounce) {
+		return false
+	}
+
+	if r.job.Since != nil && a.Published() != nil && a.Published().Before(*r.job.Since) {
+		return false
+	}
+
+	return true
+}
+
+func (r *jobRunner) process(a *vocab.ActivityType) (int, error) {
+	_, err := r.activityPubStore.GetActivity(a.ID().URL())
+	if err == nil {
+		logger.Debugf("Backfill job [%s]: ignoring activity [%s] since it has already been processed.",
+			r.job.ID, a.ID())
+
+		return 0, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return 0, fmt.Errorf("get activity: %w", err)
+	}
+
+	serviceIRI, err := url.Parse(r.job.ServiceIRI)
+	if err != nil {
+		return 0, fmt.Errorf("parse service IRI: %w", err)
+	}
+
+	var numProcessed int
+
+	if a.Type().Is(vocab.TypeCreate) {
+		err = r.getHandler().HandleCreateActivity(serviceIRI, a, false)
+		numProcessed = 1
+	} else {
+		numProcessed, err = r.getHandler().HandleAnnounceActivity(serviceIRI, a)
+	}
+
+	if err != nil {
+		if !errors.Is(err, spi.ErrDuplicateAnchorEvent) {
+			return 0, fmt.Errorf("handle %s activity: %w", a.Type(), err)
+		}
+
+		numProcessed = 0
+	}
+
+	// Store the activity so that it isn't processed again.
+	if err := r.activityPubStore.AddActivity(a); err != nil {
+		return 0, fmt.Errorf("store activity: %w", err)
+	}
+
+	return numProcessed, nil
+}
+
+func (r *jobRunner) getIterator() (client.ActivityIterator, error) {
+	var page *url.URL
+
+	if r.job.LastPage != "" {
+		p, err := url.Parse(r.job.LastPage)
+		if err != nil {
+			return nil, fmt.Errorf("parse last page [%s]: %w", r.job.LastPage, err)
+		}
+
+		page = p
+	} else {
+		serviceIRI, err := url.Parse(r.job.ServiceIRI)
+		if err != nil {
+			return nil, fmt.Errorf("parse service IRI [%s]: %w", r.job.ServiceIRI, err)
+		}
+
+		actor, err := r.apClient.GetActor(serviceIRI)
+		if err != nil {
+			return nil, orberrors.NewTransientf("get actor [%s]: %w", serviceIRI, err)
+		}
+
+		page = actor.Outbox()
+		r.job.LastIndex = -1
+	}
+
+	it, err := r.apClient.GetActivities(page, client.Forward)
+	if err != nil {
+		return nil, orberrors.NewTransientf("get activities from [%s]: %w", page, err)
+	}
+
+	// Set the index to the next activity from the last one processed in the page.
+	it.SetNextIndex(r.job.LastIndex + 1)
+
+	return it, nil
+}
+
+func (r *jobRunner) checkpoint() error {
+	r.numSinceSave++
+
+	if r.numSinceSave < r.progressInterval {
+		return nil
+	}
+
+	return r.saveProgress()
+}
+
+func (r *jobRunner) saveProgress() error {
+	now := time.Now()
+
+	r.job.RunningTime += now.Sub(r.started)
+	r.job.Updated = now
+	r.started = now
+	r.numSinceSave = 0
+
+	if secs := r.job.RunningTime.Seconds(); secs > 0 {
+		r.job.Throughput = float64(r.job.Processed+r.job.Skipped+r.job.Errors) / secs
+	}
+
+	return r.save(r.job)
+}
+
+func validate(req *Request) error {
+	if req.ServiceIRI == "" {
+		return errors.New("service IRI is required")
+	}
+
+	u, err := url.Parse(req.ServiceIRI)
+	if err != nil {
+		return fmt.Errorf("invalid service IRI [%s]: %w", req.ServiceIRI, err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid service IRI [%s]", req.ServiceIRI)
+	}
+
+	if req.Since != nil && req.Until != nil && !req.Since.Before(*req.Until) {
+		return errors.New("since must be before until")
+	}
+
+	return nil
+}
+
+func newKey(id string) string {
+	return jobPrefix + id
+}
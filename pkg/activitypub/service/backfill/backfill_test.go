@@ -0,0 +1,333 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backfill
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	storagemocks "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/store/memstore"
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/aptestutil"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+var service2IRI = testutil.MustParseURL("https://domain2.com/services/orb")
+
+func TestManager_Start(t *testing.T) {
+	mgr := newTestManager(mocks.NewActivitPubClient(), memstore.New("service1"), &mockHandler{})
+
+	t.Run("Success", func(t *testing.T) {
+		since := time.Now().Add(-time.Hour)
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String(), Since: &since})
+		require.NoError(t, err)
+		require.NotEmpty(t, job.ID)
+		require.Equal(t, StatusPending, job.Status)
+
+		j, err := mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, job.ID, j.ID)
+		require.Equal(t, service2IRI.String(), j.ServiceIRI)
+		require.NotNil(t, j.Since)
+
+		jobs, err := mgr.GetAll()
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := mgr.Start(&Request{})
+		require.True(t, orberrors.IsBadRequest(err))
+
+		_, err = mgr.Start(&Request{ServiceIRI: "invalid"})
+		require.True(t, orberrors.IsBadRequest(err))
+
+		since := time.Now()
+		until := since.Add(-time.Minute)
+
+		_, err = mgr.Start(&Request{ServiceIRI: service2IRI.String(), Since: &since, Until: &until})
+		require.True(t, orberrors.IsBadRequest(err))
+		require.Contains(t, err.Error(), "since must be before until")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := mgr.Get("unknown")
+		require.True(t, errors.Is(err, store.ErrNotFound))
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		m := newTestManager(mocks.NewActivitPubClient(), memstore.New("service1"), &mockHandler{})
+		m.store = &storagemocks.MockStore{
+			Store:    make(map[string]storagemocks.DBEntry),
+			ErrPut:   errExpected,
+			ErrGet:   errExpected,
+			ErrQuery: errExpected,
+		}
+
+		_, err := m.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.True(t, orberrors.IsTransient(err))
+
+		_, err = m.Get("id")
+		require.True(t, orberrors.IsTransient(err))
+
+		_, err = m.GetAll()
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
+
+func TestManager_Run(t *testing.T) {
+	createActivities := aptestutil.NewMockCreateActivities(3)
+	announceActivities := aptestutil.NewMockAnnounceActivities(2)
+
+	activities := append(createActivities, announceActivities...)
+	activities = append(activities, aptestutil.NewMockLikeActivities(1)...)
+
+	newClient := func() *mocks.ActivityPubClient {
+		return mocks.NewActivitPubClient().
+			WithActor(aptestutil.NewMockService(service2IRI)).
+			WithActivities(activities)
+	}
+
+	t.Run("All activities", func(t *testing.T) {
+		apStore := memstore.New("service1")
+		require.NoError(t, apStore.AddActivity(createActivities[0])) // Should be ignored.
+
+		handler := &mockHandler{}
+
+		mgr := newTestManager(newClient(), apStore, handler)
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		require.Len(t, handler.activities, 4)
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+		require.Equal(t, 5, job.Processed)
+		require.Equal(t, 4, job.AnchorEvents)
+		require.Equal(t, 1, job.Skipped)
+		require.Zero(t, job.Errors)
+		require.NotNil(t, job.Completed)
+
+		// Completed jobs should not run again.
+		mgr.run()
+
+		require.Len(t, handler.activities, 4)
+	})
+
+	t.Run("Activity range", func(t *testing.T) {
+		handler := &mockHandler{}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+
+		job, err := mgr.Start(&Request{
+			ServiceIRI:   service2IRI.String(),
+			FromActivity: createActivities[1].ID().String(),
+			ToActivity:   announceActivities[0].ID().String(),
+		})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		require.Len(t, handler.activities, 3)
+		require.Equal(t, createActivities[1].ID().String(), handler.activities[0].ID().String())
+		require.Equal(t, announceActivities[0].ID().String(), handler.activities[2].ID().String())
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+		require.Equal(t, 1, job.Skipped)
+	})
+
+	t.Run("Time range", func(t *testing.T) {
+		handler := &mockHandler{}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+
+		until := time.Now().Add(-time.Hour)
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String(), Until: &until})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		require.Empty(t, handler.activities)
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+	})
+
+	t.Run("Blocked actor", func(t *testing.T) {
+		handler := &mockHandler{}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+		mgr.blockList = mocks.NewBlockList().WithBlocked(createActivities[1].Actor())
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		require.Len(t, handler.activities, 3)
+
+		for _, a := range handler.activities {
+			require.NotEqual(t, createActivities[1].Actor().String(), a.Actor().String())
+		}
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+		require.Equal(t, 3, job.Processed)
+		require.Equal(t, 3, job.Skipped)
+	})
+
+	t.Run("Block list error -> resume", func(t *testing.T) {
+		handler := &mockHandler{}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+		mgr.blockList = mocks.NewBlockList().WithError(errors.New("injected block list error"))
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		require.Empty(t, handler.activities)
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusRunning, job.Status)
+		require.Contains(t, job.LastError, "injected block list error")
+	})
+
+	t.Run("Transient handler error -> resume", func(t *testing.T) {
+		handler := &mockHandler{err: orberrors.NewTransient(errors.New("injected handler error"))}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusRunning, job.Status)
+		require.Equal(t, 1, job.Errors)
+		require.Contains(t, job.LastError, "injected handler error")
+
+		handler.err = nil
+
+		mgr.run()
+
+		require.Len(t, handler.activities, 5)
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+	})
+
+	t.Run("Persistent handler error", func(t *testing.T) {
+		handler := &mockHandler{err: errors.New("injected handler error")}
+
+		mgr := newTestManager(newClient(), memstore.New("service1"), handler)
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, job.Status)
+		require.Equal(t, 5, job.Errors)
+		require.Zero(t, job.Processed)
+	})
+
+	t.Run("GetActor error", func(t *testing.T) {
+		apClient := mocks.NewActivitPubClient().WithError(errors.New("injected client error"))
+
+		mgr := newTestManager(apClient, memstore.New("service1"), &mockHandler{})
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		mgr.run()
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusRunning, job.Status)
+		require.Contains(t, job.LastError, "injected client error")
+	})
+
+	t.Run("Invalid last page", func(t *testing.T) {
+		mgr := newTestManager(newClient(), memstore.New("service1"), &mockHandler{})
+
+		job, err := mgr.Start(&Request{ServiceIRI: service2IRI.String()})
+		require.NoError(t, err)
+
+		job.LastPage = ":invalid"
+		require.NoError(t, mgr.save(job))
+
+		mgr.run()
+
+		job, err = mgr.Get(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, StatusFailed, job.Status)
+	})
+}
+
+func newTestManager(apClient activityPubClient, apStore store.Store, handler spi.InboxHandler) *Manager {
+	return NewManager(Config{ProgressInterval: 2}, mocks.NewTaskManager("backfill"),
+		&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)},
+		apClient, apStore, mocks.NewBlockList(),
+		func() spi.InboxHandler {
+			return handler
+		},
+	)
+}
+
+type mockHandler struct {
+	activities []*vocab.ActivityType
+	err        error
+}
+
+func (m *mockHandler) HandleCreateActivity(_ *url.URL, a *vocab.ActivityType, _ bool) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.activities = append(m.activities, a)
+
+	return nil
+}
+
+func (m *mockHandler) HandleAnnounceActivity(_ *url.URL, a *vocab.ActivityType) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	m.activities = append(m.activities, a)
+
+	return 1, nil
+}
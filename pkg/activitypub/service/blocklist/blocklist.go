@@ -0,0 +1,212 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocklist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+var logger = log.New("block_list")
+
+const (
+	blockedActorTag    = "blocked-actor"
+	blockedActorPrefix = "blocked-actor-"
+)
+
+type referenceStore interface {
+	DeleteReference(referenceType store.ReferenceType, objectIRI, referenceIRI *url.URL) error
+}
+
+// Manager manages reads and updates to the list of blocked actors. When an actor is added to the
+// block list, any existing 'follower' and 'witnessing' references to the actor are removed.
+type Manager struct {
+	store         storage.Store
+	activityStore referenceStore
+	serviceIRI    *url.URL
+	unmarshal     func(data []byte, v interface{}) error
+}
+
+// NewManager returns a new block list manager.
+func NewManager(s storage.Store, activityStore referenceStore, serviceIRI *url.URL) *Manager {
+	return &Manager{
+		store:         s,
+		activityStore: activityStore,
+		serviceIRI:    serviceIRI,
+		unmarshal:     json.Unmarshal,
+	}
+}
+
+// Update updates the block list with the given additions and deletions.
+func (m *Manager) Update(additions, deletions []*url.URL) error {
+	current, err := m.Get()
+	if err != nil {
+		return fmt.Errorf("query block list: %w", err)
+	}
+
+	additions = removeDuplicates(current, additions)
+
+	var operations []storage.Operation
+
+	for _, uri := range additions {
+		value, e := json.Marshal(uri.String())
+		if e != nil {
+			return fmt.Errorf("marshal URI [%s]: %w", uri, e)
+		}
+
+		operations = append(operations, storage.Operation{
+			Key:   newKey(uri),
+			Value: value,
+			Tags:  []storage.Tag{{Name: blockedActorTag}},
+		})
+	}
+
+	for _, uri := range deletions {
+		operations = append(operations, storage.Operation{
+			Key: newKey(uri),
+		})
+	}
+
+	if len(operations) == 0 {
+		logger.Debugf("No new additions or deletions for the block list.")
+
+		return nil
+	}
+
+	err = m.store.Batch(operations)
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("batch update: %w", err))
+	}
+
+	logger.Debugf("Successfully updated the block list - Additions: %s, Deletions: %s", additions, deletions)
+
+	for _, uri := range additions {
+		if err := m.removeReferences(uri); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Block adds the given actor to the block list.
+func (m *Manager) Block(actorIRI *url.URL) error {
+	return m.Update([]*url.URL{actorIRI}, nil)
+}
+
+// IsBlocked returns true if the given actor is in the block list.
+func (m *Manager) IsBlocked(actorIRI *url.URL) (bool, error) {
+	_, err := m.store.Get(newKey(actorIRI))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return false, nil
+		}
+
+		return false, orberrors.NewTransientf("get blocked actor [%s]: %w", actorIRI, err)
+	}
+
+	return true, nil
+}
+
+// Get returns the URIs in the block list.
+func (m *Manager) Get() ([]*url.URL, error) {
+	it, err := m.store.Query(blockedActorTag)
+	if err != nil {
+		return nil, orberrors.NewTransientf("query block list: %w", err)
+	}
+
+	var uris []*url.URL
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransientf("query next item: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransientf("get value: %w", err)
+		}
+
+		var rawURL string
+
+		err = m.unmarshal(value, &rawURL)
+		if err != nil {
+			logger.Warnf("Error unmarshalling URI: %s. The item will be ignored.", err)
+
+			continue
+		}
+
+		uri, err := url.Parse(rawURL)
+		if err != nil {
+			logger.Warnf("Invalid URI [%s]: %s. The item will be ignored.", rawURL, err)
+
+			continue
+		}
+
+		uris = append(uris, uri)
+	}
+
+	return uris, nil
+}
+
+func (m *Manager) removeReferences(actorIRI *url.URL) error {
+	for _, refType := range []store.ReferenceType{store.Follower, store.Witnessing} {
+		if err := m.activityStore.DeleteReference(refType, m.serviceIRI, actorIRI); err != nil {
+			return orberrors.NewTransient(fmt.Errorf("delete %s reference to blocked actor [%s]: %w",
+				refType, actorIRI, err))
+		}
+
+		logger.Debugf("Removed blocked actor [%s] (if found) from the %s collection", actorIRI, refType)
+	}
+
+	return nil
+}
+
+func removeDuplicates(current, additions []*url.URL) []*url.URL {
+	uriMap := make(map[string]*url.URL)
+
+	for _, uri := range additions {
+		if !contains(current, uri) {
+			uriMap[uri.String()] = uri
+		}
+	}
+
+	var list []*url.URL
+
+	for _, uri := range uriMap {
+		list = append(list, uri)
+	}
+
+	return list
+}
+
+func newKey(uri fmt.Stringer) string {
+	return blockedActorPrefix + uri.String()
+}
+
+func contains(arr []*url.URL, uri *url.URL) bool {
+	for _, s := range arr {
+		if s.String() == uri.String() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,249 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocklist
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	storagemocks "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+
+	servicemocks "github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/store/memstore"
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/store/storeutil"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+var (
+	serviceIRI = testutil.MustParseURL("https://orb.domain1.com/services/orb")
+
+	domain1 = testutil.MustParseURL("https://domain1.com/services/orb")
+	domain2 = testutil.MustParseURL("https://domain2.com/services/orb")
+	domain3 = testutil.MustParseURL("https://domain3.com/services/orb")
+)
+
+func TestManagerUpdateDelete(t *testing.T) {
+	s, err := mem.NewProvider().OpenStore("blocklist")
+	require.NoError(t, err)
+
+	activityStore := memstore.New("service1")
+
+	require.NoError(t, activityStore.AddReference(store.Follower, serviceIRI, domain1))
+	require.NoError(t, activityStore.AddReference(store.Follower, serviceIRI, domain3))
+	require.NoError(t, activityStore.AddReference(store.Witnessing, serviceIRI, domain1))
+
+	mgr := NewManager(s, activityStore, serviceIRI)
+	require.NotNil(t, mgr)
+
+	require.NoError(t, mgr.Update(
+		[]*url.URL{
+			domain1,
+			domain1, // Duplicates should be ignored.
+			domain2,
+		},
+		nil,
+	))
+
+	blockList, err := mgr.Get()
+	require.NoError(t, err)
+	require.Len(t, blockList, 2)
+	require.Contains(t, blockList, domain1)
+	require.Contains(t, blockList, domain2)
+
+	blocked, err := mgr.IsBlocked(domain1)
+	require.NoError(t, err)
+	require.True(t, blocked)
+
+	blocked, err = mgr.IsBlocked(domain3)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	followers := queryReferences(t, activityStore, store.Follower)
+	require.Len(t, followers, 1)
+	require.Equal(t, domain3.String(), followers[0].String())
+
+	require.Empty(t, queryReferences(t, activityStore, store.Witnessing))
+
+	require.NoError(t, mgr.Update(nil, []*url.URL{domain1}))
+
+	blocked, err = mgr.IsBlocked(domain1)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	require.NoError(t, mgr.Block(domain3))
+
+	blocked, err = mgr.IsBlocked(domain3)
+	require.NoError(t, err)
+	require.True(t, blocked)
+
+	require.Empty(t, queryReferences(t, activityStore, store.Follower))
+
+	// No new URIs added. Request should be ignored.
+	require.NoError(t, mgr.Update([]*url.URL{domain2, domain3}, nil))
+}
+
+func TestManagerError(t *testing.T) {
+	t.Run("Get error", func(t *testing.T) {
+		errExpected := errors.New("injected get error")
+
+		s := &storagemocks.MockStore{
+			Store:  make(map[string]storagemocks.DBEntry),
+			ErrGet: errExpected,
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		_, err := mgr.IsBlocked(domain1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		errExpected := errors.New("injected query error")
+
+		s := &storagemocks.MockStore{
+			Store:    make(map[string]storagemocks.DBEntry),
+			ErrQuery: errExpected,
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		_, err := mgr.Get()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+
+		err = mgr.Update([]*url.URL{domain1}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("Iterator.Next error", func(t *testing.T) {
+		errExpected := errors.New("injected iterator Next error")
+
+		s := &storagemocks.MockStore{
+			Store:   make(map[string]storagemocks.DBEntry),
+			ErrNext: errExpected,
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		_, err := mgr.Get()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("Iterator.Value error", func(t *testing.T) {
+		errExpected := errors.New("injected iterator Value error")
+
+		s := &storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				"key": {
+					Value: []byte("value"),
+					Tags:  []storage.Tag{{Name: blockedActorTag}},
+				},
+			},
+			ErrValue: errExpected,
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		_, err := mgr.Get()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("Unmarshal error -> ignore", func(t *testing.T) {
+		s := &storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				"key": {
+					Value: []byte("invalid JSON string"),
+					Tags:  []storage.Tag{{Name: blockedActorTag}},
+				},
+			},
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		uris, err := mgr.Get()
+		require.NoError(t, err, "unmarshal errors should be ignored")
+		require.Empty(t, uris)
+	})
+
+	t.Run("Parse URI error -> ignore", func(t *testing.T) {
+		s := &storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				"key": {
+					Value: []byte(`":invalid URL"`),
+					Tags:  []storage.Tag{{Name: blockedActorTag}},
+				},
+			},
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		uris, err := mgr.Get()
+		require.NoError(t, err, "invalid URI errors should be ignored")
+		require.Empty(t, uris)
+	})
+
+	t.Run("Batch error", func(t *testing.T) {
+		errExpected := errors.New("injected batch error")
+
+		s := &storagemocks.MockStore{
+			Store:    make(map[string]storagemocks.DBEntry),
+			ErrBatch: errExpected,
+		}
+
+		mgr := NewManager(s, memstore.New("service1"), serviceIRI)
+		require.NotNil(t, mgr)
+
+		err := mgr.Update([]*url.URL{domain1}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+
+	t.Run("Delete reference error", func(t *testing.T) {
+		errExpected := errors.New("injected delete error")
+
+		activityStore := &servicemocks.ActivityStore{}
+		activityStore.DeleteReferenceReturns(errExpected)
+
+		s := &storagemocks.MockStore{
+			Store: make(map[string]storagemocks.DBEntry),
+		}
+
+		mgr := NewManager(s, activityStore, serviceIRI)
+		require.NotNil(t, mgr)
+
+		err := mgr.Block(domain1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+	})
+}
+
+func queryReferences(t *testing.T, activityStore store.Store, refType store.ReferenceType) []*url.URL {
+	t.Helper()
+
+	it, err := activityStore.QueryReferences(refType, store.NewCriteria(store.WithObjectIRI(serviceIRI)))
+	require.NoError(t, err)
+
+	refs, err := storeutil.ReadReferences(it, -1)
+	require.NoError(t, err)
+
+	return refs
+}
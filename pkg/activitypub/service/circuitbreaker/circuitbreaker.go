@@ -0,0 +1,236 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+)
+
+var logger = log.New("circuit_breaker")
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = time.Minute
+)
+
+// ErrOpen indicates that the circuit breaker for a destination is open and the request was not attempted.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed indicates that requests are allowed through to the destination.
+	StateClosed State = iota
+	// StateHalfOpen indicates that a single probe request is allowed through to the destination.
+	StateHalfOpen
+	// StateOpen indicates that requests to the destination are rejected.
+	StateOpen
+)
+
+// String returns the string value of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config holds the configuration parameters for the circuit breakers.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures after which the breaker opens.
+	FailureThreshold int
+
+	// OpenTimeout is the time that a breaker remains open before a probe request is allowed through.
+	OpenTimeout time.Duration
+}
+
+// Status contains the status of the circuit breaker for a destination host.
+type Status struct {
+	Host        string     `json:"host"`
+	State       string     `json:"state"`
+	Failures    int        `json:"failures"`
+	LastFailure *time.Time `json:"lastFailure,omitempty"`
+	OpenedAt    *time.Time `json:"openedAt,omitempty"`
+}
+
+type metricsProvider interface {
+	OutboxCircuitBreakerState(host string, state int)
+}
+
+type breaker struct {
+	state       State
+	failures    int
+	lastFailure time.Time
+	openedAt    time.Time
+	probing     bool
+}
+
+// Registry maintains a circuit breaker per destination host. A breaker opens after a number of
+// consecutive failures, after which requests to the host are rejected. Once the open timeout has
+// elapsed, the breaker becomes half-open and a single probe request is allowed through. If the probe
+// succeeds then the breaker is closed, otherwise it is opened again.
+type Registry struct {
+	Config
+
+	mutex    sync.Mutex
+	breakers map[string]*breaker
+	metrics  metricsProvider
+	now      func() time.Time
+}
+
+// New returns a new circuit breaker registry.
+func New(cfg Config, metrics metricsProvider) *Registry {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+
+	return &Registry{
+		Config:   cfg,
+		breakers: make(map[string]*breaker),
+		metrics:  metrics,
+		now:      time.Now,
+	}
+}
+
+// Allow returns nil if a request may be sent to the given host. If the breaker for the host is open
+// then an error wrapping ErrOpen is returned.
+func (r *Registry) Allow(host string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	b, ok := r.breakers[host]
+	if !ok {
+		return nil
+	}
+
+	switch b.state {
+	case StateOpen:
+		if r.now().Sub(b.openedAt) < r.OpenTimeout {
+			return fmt.Errorf("%w for host [%s]", ErrOpen, host)
+		}
+
+		logger.Infof("Circuit breaker for host [%s] is half-open. Allowing probe request.", host)
+
+		r.setState(host, b, StateHalfOpen)
+
+		b.probing = true
+
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w for host [%s]: probe in progress", ErrOpen, host)
+		}
+
+		b.probing = true
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful request to the given host and closes the breaker.
+func (r *Registry) Success(host string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	b, ok := r.breakers[host]
+	if !ok {
+		return
+	}
+
+	if b.state != StateClosed {
+		logger.Infof("Circuit breaker for host [%s] is closed.", host)
+	}
+
+	b.failures = 0
+	b.probing = false
+
+	r.setState(host, b, StateClosed)
+}
+
+// Failure records a failed request to the given host. The breaker is opened if the number of
+// consecutive failures reaches the threshold or if the probe request of a half-open breaker failed.
+func (r *Registry) Failure(host string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	b, ok := r.breakers[host]
+	if !ok {
+		b = &breaker{}
+		r.breakers[host] = b
+	}
+
+	b.failures++
+	b.lastFailure = r.now()
+	b.probing = false
+
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= r.FailureThreshold) {
+		logger.Warnf("Circuit breaker for host [%s] is open after %d consecutive failure(s).", host, b.failures)
+
+		b.openedAt = b.lastFailure
+
+		r.setState(host, b, StateOpen)
+	}
+}
+
+// Status returns the status of all circuit breakers, sorted by host.
+func (r *Registry) Status() []*Status {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status := make([]*Status, 0, len(r.breakers))
+
+	for host, b := range r.breakers {
+		s := &Status{
+			Host:     host,
+			State:    b.state.String(),
+			Failures: b.failures,
+		}
+
+		if !b.lastFailure.IsZero() {
+			lastFailure := b.lastFailure
+			s.LastFailure = &lastFailure
+		}
+
+		if b.state != StateClosed {
+			openedAt := b.openedAt
+			s.OpenedAt = &openedAt
+		}
+
+		status = append(status, s)
+	}
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Host < status[j].Host
+	})
+
+	return status
+}
+
+func (r *Registry) setState(host string, b *breaker, state State) {
+	b.state = state
+
+	r.metrics.OutboxCircuitBreakerState(host, int(state))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/mocks"
+)
+
+const (
+	host1 = "orb.domain1.com"
+	host2 = "orb.domain2.com"
+)
+
+func TestNew(t *testing.T) {
+	r := New(Config{}, &mocks.MetricsProvider{})
+	require.NotNil(t, r)
+	require.Equal(t, defaultFailureThreshold, r.FailureThreshold)
+	require.Equal(t, defaultOpenTimeout, r.OpenTimeout)
+	require.Empty(t, r.Status())
+}
+
+func TestRegistry(t *testing.T) {
+	now := time.Now()
+
+	r := New(Config{FailureThreshold: 2, OpenTimeout: time.Minute}, &mocks.MetricsProvider{})
+	r.now = func() time.Time { return now }
+
+	require.NoError(t, r.Allow(host1))
+
+	r.Failure(host1)
+	require.NoError(t, r.Allow(host1), "breaker should be closed until the threshold is reached")
+
+	r.Failure(host1)
+
+	err := r.Allow(host1)
+	require.True(t, errors.Is(err, ErrOpen))
+	require.NoError(t, r.Allow(host2), "breakers should be per host")
+
+	status := r.Status()
+	require.Len(t, status, 1)
+	require.Equal(t, host1, status[0].Host)
+	require.Equal(t, StateOpen.String(), status[0].State)
+	require.Equal(t, 2, status[0].Failures)
+	require.NotNil(t, status[0].OpenedAt)
+	require.NotNil(t, status[0].LastFailure)
+
+	t.Run("Failed probe -> open", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+
+		require.NoError(t, r.Allow(host1), "probe should be allowed after the open timeout")
+		require.Equal(t, StateHalfOpen.String(), r.Status()[0].State)
+
+		err := r.Allow(host1)
+		require.True(t, errors.Is(err, ErrOpen), "only one probe should be allowed")
+
+		r.Failure(host1)
+
+		err = r.Allow(host1)
+		require.True(t, errors.Is(err, ErrOpen))
+		require.Equal(t, StateOpen.String(), r.Status()[0].State)
+	})
+
+	t.Run("Successful probe -> closed", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+
+		require.NoError(t, r.Allow(host1))
+
+		r.Success(host1)
+
+		require.NoError(t, r.Allow(host1))
+
+		status := r.Status()
+		require.Len(t, status, 1)
+		require.Equal(t, StateClosed.String(), status[0].State)
+		require.Zero(t, status[0].Failures)
+		require.Nil(t, status[0].OpenedAt)
+	})
+
+	t.Run("Success for unknown host", func(t *testing.T) {
+		r.Success(host2)
+		require.Len(t, r.Status(), 1)
+	})
+}
+
+func TestState_String(t *testing.T) {
+	require.Equal(t, "closed", StateClosed.String())
+	require.Equal(t, "half-open", StateHalfOpen.String())
+	require.Equal(t, "open", StateOpen.String())
+	require.Equal(t, "unknown", State(10).String())
+}
@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/service/spi"
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+var logger = log.New("dead_letter")
+
+const (
+	deadLetterTag    = "dead-letter"
+	deadLetterPrefix = "dead-letter-"
+)
+
+// Activity holds an activity that could not be delivered along with the inbox to which it was being delivered.
+type Activity struct {
+	ID       string              `json:"id"`
+	Inbox    string              `json:"inbox"`
+	Time     time.Time           `json:"time"`
+	Activity *vocab.ActivityType `json:"activity"`
+}
+
+// Manager saves activities that could not be delivered (after all redelivery attempts have failed) so that
+// they may be inspected and either replayed or purged at a later time, for example, when the peer comes back.
+type Manager struct {
+	store     storage.Store
+	getOutbox func() spi.Outbox
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+	newID     func() string
+}
+
+// NewManager returns a new dead-letter manager. The outbox provider is invoked when an activity is replayed.
+func NewManager(s storage.Store, outboxProvider func() spi.Outbox) *Manager {
+	return &Manager{
+		store:     s,
+		getOutbox: outboxProvider,
+		marshal:   json.Marshal,
+		unmarshal: json.Unmarshal,
+		newID:     uuid.NewString,
+	}
+}
+
+// HandleUndeliverableActivity saves the given undeliverable activity along with the URL of the target inbox.
+func (m *Manager) HandleUndeliverableActivity(activity *vocab.ActivityType, toURL string) {
+	a := &Activity{
+		ID:       m.newID(),
+		Inbox:    toURL,
+		Time:     time.Now(),
+		Activity: activity,
+	}
+
+	value, err := m.marshal(a)
+	if err != nil {
+		logger.Errorf("Error marshalling undeliverable activity [%s] to [%s]: %s", activity.ID(), toURL, err)
+
+		return
+	}
+
+	err = m.store.Put(newKey(a.ID), value, storage.Tag{Name: deadLetterTag})
+	if err != nil {
+		logger.Errorf("Error storing undeliverable activity [%s] to [%s]: %s", activity.ID(), toURL, err)
+
+		return
+	}
+
+	logger.Infof("Saved undeliverable activity [%s] to [%s] with ID [%s]", activity.ID(), toURL, a.ID)
+}
+
+// Get returns the undeliverable activity for the given ID. If the activity isn't
+// found then store.ErrNotFound is returned.
+func (m *Manager) Get(id string) (*Activity, error) {
+	value, err := m.store.Get(newKey(id))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, store.ErrNotFound
+		}
+
+		return nil, orberrors.NewTransientf("get undeliverable activity [%s]: %w", id, err)
+	}
+
+	a := &Activity{}
+
+	if err := m.unmarshal(value, a); err != nil {
+		return nil, fmt.Errorf("unmarshal undeliverable activity [%s]: %w", id, err)
+	}
+
+	return a, nil
+}
+
+// GetAll returns all undeliverable activities.
+func (m *Manager) GetAll() ([]*Activity, error) {
+	it, err := m.store.Query(deadLetterTag)
+	if err != nil {
+		return nil, orberrors.NewTransientf("query undeliverable activities: %w", err)
+	}
+
+	defer func() {
+		if e := it.Close(); e != nil {
+			logger.Warnf("Error closing iterator: %s", e)
+		}
+	}()
+
+	var activities []*Activity
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransientf("query next item: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		value, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransientf("get value: %w", err)
+		}
+
+		a := &Activity{}
+
+		err = m.unmarshal(value, a)
+		if err != nil {
+			logger.Warnf("Error unmarshalling undeliverable activity: %s. The item will be ignored.", err)
+
+			continue
+		}
+
+		activities = append(activities, a)
+	}
+
+	return activities, nil
+}
+
+// Replay attempts to deliver the undeliverable activity with the given ID to its target inbox. On
+// success, the activity is removed from the dead-letter store. (If the delivery fails again then
+// the activity will be saved under a new ID.)
+func (m *Manager) Replay(id string) error {
+	a, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+
+	inboxIRI, err := url.Parse(a.Inbox)
+	if err != nil {
+		return fmt.Errorf("parse inbox URL [%s]: %w", a.Inbox, err)
+	}
+
+	err = m.getOutbox().Deliver(a.Activity, inboxIRI)
+	if err != nil {
+		return fmt.Errorf("deliver activity [%s] to [%s]: %w", a.Activity.ID(), inboxIRI, err)
+	}
+
+	logger.Infof("Replayed undeliverable activity [%s] to [%s]", a.Activity.ID(), inboxIRI)
+
+	return m.Purge(id)
+}
+
+// Purge removes the undeliverable activity with the given ID from the dead-letter store.
+func (m *Manager) Purge(id string) error {
+	if err := m.store.Delete(newKey(id)); err != nil {
+		return orberrors.NewTransientf("delete undeliverable activity [%s]: %w", id, err)
+	}
+
+	logger.Debugf("Purged undeliverable activity [%s]", id)
+
+	return nil
+}
+
+func newKey(id string) string {
+	return deadLetterPrefix + id
+}
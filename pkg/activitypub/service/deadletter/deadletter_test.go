@@ -0,0 +1,250 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"errors"
+	"testing"
+
+	storagemocks "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+
+	servicemocks "github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/spi"
+	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+var (
+	serviceIRI = testutil.MustParseURL("https://orb.domain1.com/services/orb")
+	inbox1     = testutil.MustParseURL("https://orb.domain2.com/services/orb/inbox")
+	inbox2     = testutil.MustParseURL("https://orb.domain3.com/services/orb/inbox")
+)
+
+func TestManager(t *testing.T) {
+	activity1 := newActivity("/activities/1")
+	activity2 := newActivity("/activities/2")
+
+	ob := servicemocks.NewOutbox()
+
+	mgr := NewManager(&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)},
+		func() spi.Outbox { return ob },
+	)
+	require.NotNil(t, mgr)
+
+	mgr.HandleUndeliverableActivity(activity1, inbox1.String())
+	mgr.HandleUndeliverableActivity(activity2, inbox2.String())
+
+	activities, err := mgr.GetAll()
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+
+	var id1, id2 string
+
+	for _, a := range activities {
+		switch a.Activity.ID().String() {
+		case activity1.ID().String():
+			require.Equal(t, inbox1.String(), a.Inbox)
+			id1 = a.ID
+		case activity2.ID().String():
+			require.Equal(t, inbox2.String(), a.Inbox)
+			id2 = a.ID
+		default:
+			t.Fatalf("unexpected activity [%s]", a.Activity.ID())
+		}
+
+		require.False(t, a.Time.IsZero())
+	}
+
+	a, err := mgr.Get(id1)
+	require.NoError(t, err)
+	require.Equal(t, activity1.ID().String(), a.Activity.ID().String())
+
+	require.NoError(t, mgr.Replay(id1))
+
+	delivered := ob.Delivered(inbox1)
+	require.Len(t, delivered, 1)
+	require.Equal(t, activity1.ID().String(), delivered[0].ID().String())
+
+	_, err = mgr.Get(id1)
+	require.True(t, errors.Is(err, store.ErrNotFound))
+
+	require.NoError(t, mgr.Purge(id2))
+
+	activities, err = mgr.GetAll()
+	require.NoError(t, err)
+	require.Empty(t, activities)
+	require.Empty(t, ob.Delivered(inbox2))
+
+	err = mgr.Replay(id2)
+	require.True(t, errors.Is(err, store.ErrNotFound))
+}
+
+func TestManagerError(t *testing.T) {
+	activity := newActivity("/activities/1")
+
+	t.Run("Put error -> ignore", func(t *testing.T) {
+		s := &storagemocks.MockStore{
+			Store:  make(map[string]storagemocks.DBEntry),
+			ErrPut: errors.New("injected put error"),
+		}
+
+		mgr := NewManager(s, func() spi.Outbox { return servicemocks.NewOutbox() })
+
+		mgr.HandleUndeliverableActivity(activity, inbox1.String())
+
+		require.Empty(t, s.Store)
+	})
+
+	t.Run("Marshal error -> ignore", func(t *testing.T) {
+		s := &storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)}
+
+		mgr := NewManager(s, func() spi.Outbox { return servicemocks.NewOutbox() })
+		mgr.marshal = func(v interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		mgr.HandleUndeliverableActivity(activity, inbox1.String())
+
+		require.Empty(t, s.Store)
+	})
+
+	t.Run("Get error", func(t *testing.T) {
+		errExpected := errors.New("injected get error")
+
+		mgr := NewManager(&storagemocks.MockStore{
+			Store:  make(map[string]storagemocks.DBEntry),
+			ErrGet: errExpected,
+		}, nil)
+
+		_, err := mgr.Get("1234")
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Unmarshal error", func(t *testing.T) {
+		mgr := NewManager(&storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				newKey("1234"): {Value: []byte("invalid JSON")},
+			},
+		}, nil)
+
+		_, err := mgr.Get("1234")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal undeliverable activity")
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		errExpected := errors.New("injected query error")
+
+		mgr := NewManager(&storagemocks.MockStore{
+			Store:    make(map[string]storagemocks.DBEntry),
+			ErrQuery: errExpected,
+		}, nil)
+
+		_, err := mgr.GetAll()
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Iterator.Next error", func(t *testing.T) {
+		errExpected := errors.New("injected iterator Next error")
+
+		mgr := NewManager(&storagemocks.MockStore{
+			Store:   make(map[string]storagemocks.DBEntry),
+			ErrNext: errExpected,
+		}, nil)
+
+		_, err := mgr.GetAll()
+		require.True(t, errors.Is(err, errExpected))
+	})
+
+	t.Run("Iterator.Value error", func(t *testing.T) {
+		errExpected := errors.New("injected iterator Value error")
+
+		mgr := NewManager(&storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				newKey("1234"): {
+					Value: []byte("{}"),
+					Tags:  []storage.Tag{{Name: deadLetterTag}},
+				},
+			},
+			ErrValue: errExpected,
+		}, nil)
+
+		_, err := mgr.GetAll()
+		require.True(t, errors.Is(err, errExpected))
+	})
+
+	t.Run("Unmarshal error in query -> ignore", func(t *testing.T) {
+		mgr := NewManager(&storagemocks.MockStore{
+			Store: map[string]storagemocks.DBEntry{
+				newKey("1234"): {
+					Value: []byte("invalid JSON"),
+					Tags:  []storage.Tag{{Name: deadLetterTag}},
+				},
+			},
+		}, nil)
+
+		activities, err := mgr.GetAll()
+		require.NoError(t, err)
+		require.Empty(t, activities)
+	})
+
+	t.Run("Replay error", func(t *testing.T) {
+		errExpected := orberrors.NewTransient(errors.New("injected outbox error"))
+
+		s := &storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)}
+
+		mgr := NewManager(s, func() spi.Outbox { return servicemocks.NewOutbox().WithError(errExpected) })
+		mgr.newID = func() string { return "1234" }
+
+		mgr.HandleUndeliverableActivity(activity, inbox1.String())
+
+		err := mgr.Replay("1234")
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsTransient(err))
+
+		_, err = mgr.Get("1234")
+		require.NoError(t, err, "activity should not have been purged")
+	})
+
+	t.Run("Invalid inbox URL", func(t *testing.T) {
+		s := &storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)}
+
+		mgr := NewManager(s, func() spi.Outbox { return servicemocks.NewOutbox() })
+		mgr.newID = func() string { return "1234" }
+
+		mgr.HandleUndeliverableActivity(activity, ":invalid")
+
+		err := mgr.Replay("1234")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse inbox URL")
+	})
+
+	t.Run("Delete error", func(t *testing.T) {
+		errExpected := errors.New("injected delete error")
+
+		mgr := NewManager(&storagemocks.MockStore{
+			Store:     make(map[string]storagemocks.DBEntry),
+			ErrDelete: errExpected,
+		}, nil)
+
+		err := mgr.Purge("1234")
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
+
+func newActivity(path string) *vocab.ActivityType {
+	return vocab.NewCreateActivity(nil,
+		vocab.WithID(testutil.NewMockID(serviceIRI, path)),
+		vocab.WithActor(serviceIRI),
+		vocab.WithTo(vocab.PublicIRI),
+	)
+}
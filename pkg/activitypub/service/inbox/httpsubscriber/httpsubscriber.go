@@ -8,9 +8,13 @@ package httpsubscriber
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	wmhttp "github.com/ThreeDotsLabs/watermill-http/pkg/http"
@@ -18,6 +22,7 @@ import (
 	"github.com/trustbloc/edge-core/pkg/log"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
 	"github.com/trustbloc/orb/pkg/httpserver/auth"
 	"github.com/trustbloc/orb/pkg/lifecycle"
 )
@@ -27,6 +32,8 @@ var logger = log.New("activitypub_service")
 const (
 	// ActorIRIKey is the metadata key for the actor IRI.
 	ActorIRIKey = "actor-iri"
+	// SharedInboxKey is the metadata key that is set on messages which were posted to the shared inbox.
+	SharedInboxKey = "shared-inbox"
 
 	defaultBufferSize = 100
 	stopTimeout       = 250 * time.Millisecond
@@ -34,8 +41,9 @@ const (
 
 // Config holds the HTTP subscriber configuration parameters.
 type Config struct {
-	ServiceEndpoint string
-	BufferSize      int
+	ServiceEndpoint     string
+	SharedInboxEndpoint string
+	BufferSize          int
 }
 
 type signatureVerifier interface {
@@ -46,6 +54,10 @@ type authTokenManager interface {
 	RequiredAuthTokens(endpoint, method string) ([]string, error)
 }
 
+type rateLimiter interface {
+	Allow(actor, activityType string) (bool, time.Duration)
+}
+
 // Subscriber implements a subscriber for Watermill that handles HTTP requests.
 type Subscriber struct {
 	*lifecycle.Lifecycle
@@ -57,10 +69,22 @@ type Subscriber struct {
 	unmarshalMessage wmhttp.UnmarshalMessageFunc
 	verifier         signatureVerifier
 	tokenVerifier    *auth.TokenVerifier
+	rateLimiter      rateLimiter
+}
+
+// Opt sets an option for the HTTP subscriber.
+type Opt func(s *Subscriber)
+
+// WithRateLimiter sets a rate limiter that's used to reject (with HTTP status 429) messages from
+// actors that have exceeded their rate limit before the messages are published.
+func WithRateLimiter(rl rateLimiter) Opt {
+	return func(s *Subscriber) {
+		s.rateLimiter = rl
+	}
 }
 
 // New returns a new HTTP subscriber.
-func New(cfg *Config, sigVerifier signatureVerifier, tm authTokenManager) *Subscriber {
+func New(cfg *Config, sigVerifier signatureVerifier, tm authTokenManager, opts ...Opt) *Subscriber {
 	if cfg.BufferSize == 0 {
 		cfg.BufferSize = defaultBufferSize
 	}
@@ -75,6 +99,10 @@ func New(cfg *Config, sigVerifier signatureVerifier, tm authTokenManager) *Subsc
 		tokenVerifier:    auth.NewTokenVerifier(tm, cfg.ServiceEndpoint, http.MethodPost),
 	}
 
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	s.Lifecycle = lifecycle.New("httpsubscriber-"+cfg.ServiceEndpoint, lifecycle.WithStop(s.stop))
 
 	// Start the service immediately.
@@ -111,7 +139,27 @@ func (s *Subscriber) Handler() common.HTTPRequestHandler {
 	return s.handleMessage
 }
 
+// SharedInboxHandler returns the handler for the shared inbox endpoint, or nil if no shared inbox endpoint
+// was configured. Messages posted to the shared inbox are published to the same channel as messages posted
+// to the service endpoint (and are subject to the same authorization) but they are marked with the
+// SharedInboxKey metadata key.
+func (s *Subscriber) SharedInboxHandler() common.HTTPHandler {
+	if s.SharedInboxEndpoint == "" {
+		return nil
+	}
+
+	return &sharedInboxHandler{s: s}
+}
+
 func (s *Subscriber) handleMessage(w http.ResponseWriter, r *http.Request) {
+	s.handleRequest(w, r, false)
+}
+
+func (s *Subscriber) handleSharedInboxMessage(w http.ResponseWriter, r *http.Request) {
+	s.handleRequest(w, r, true)
+}
+
+func (s *Subscriber) handleRequest(w http.ResponseWriter, r *http.Request, sharedInbox bool) {
 	var actorIRI *url.URL
 
 	if !s.tokenVerifier.Verify(r) {
@@ -152,6 +200,22 @@ func (s *Subscriber) handleMessage(w http.ResponseWriter, r *http.Request) {
 		msg.Metadata[ActorIRIKey] = actorIRI.String()
 	}
 
+	if sharedInbox {
+		msg.Metadata[SharedInboxKey] = "true"
+	}
+
+	rateLimitKey := remoteHost(r)
+	if actorIRI != nil {
+		rateLimitKey = actorIRI.String()
+	}
+
+	if allowed, retryAfter := s.allow(msg, rateLimitKey); !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+
+		return
+	}
+
 	logger.Debugf("[%s] Handling message [%s] from actor [%s]", s.ServiceEndpoint, msg.UUID, actorIRI)
 
 	err = s.publish(msg)
@@ -166,6 +230,39 @@ func (s *Subscriber) handleMessage(w http.ResponseWriter, r *http.Request) {
 	s.respond(msg, w, r)
 }
 
+// allow checks the rate limit for the given key. The key is the actor in the HTTP signature if the request was
+// signed (since it can't be spoofed), otherwise it's the remote address of the request. The actor in the activity
+// isn't used since anyone could set it to another actor in order to use up that actor's quota.
+func (s *Subscriber) allow(msg *message.Message, key string) (bool, time.Duration) {
+	if s.rateLimiter == nil {
+		return true, 0
+	}
+
+	activity := &vocab.ActivityType{}
+
+	// If the activity can't be unmarshalled then it will be rejected by the inbox, so just apply the actor limit.
+	if err := json.Unmarshal(msg.Payload, activity); err != nil {
+		logger.Debugf("[%s] Unable to unmarshal activity in message [%s]: %s", s.ServiceEndpoint, msg.UUID, err)
+	}
+
+	allowed, retryAfter := s.rateLimiter.Allow(key, activity.Type().String())
+	if !allowed {
+		logger.Infof("[%s] Rejecting message [%s] from [%s] since the rate limit was exceeded",
+			s.ServiceEndpoint, msg.UUID, key)
+	}
+
+	return allowed, retryAfter
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 func (s *Subscriber) publish(msg *message.Message) error {
 	select {
 	case s.msgChan <- msg:
@@ -226,3 +323,22 @@ func (s *Subscriber) stop() {
 
 	logger.Infof("[%s] ... HTTP subscriber stopped.", s.ServiceEndpoint)
 }
+
+type sharedInboxHandler struct {
+	s *Subscriber
+}
+
+// Path returns the path of the shared inbox endpoint.
+func (h *sharedInboxHandler) Path() string {
+	return h.s.SharedInboxEndpoint
+}
+
+// Method returns the HTTP method, which is always POST.
+func (h *sharedInboxHandler) Method() string {
+	return http.MethodPost
+}
+
+// Handler returns the handler that should be invoked when an HTTP request is posted to the shared inbox.
+func (h *sharedInboxHandler) Handler() common.HTTPRequestHandler {
+	return h.s.handleSharedInboxMessage
+}
@@ -9,9 +9,11 @@ package httpsubscriber
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
@@ -21,8 +23,11 @@ import (
 
 	apmocks "github.com/trustbloc/orb/pkg/activitypub/mocks"
 	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/ratelimiter"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
 	"github.com/trustbloc/orb/pkg/internal/testutil"
 	"github.com/trustbloc/orb/pkg/lifecycle"
+	orbmocks "github.com/trustbloc/orb/pkg/mocks"
 )
 
 const (
@@ -47,6 +52,127 @@ func TestNew(t *testing.T) {
 	require.Equal(t, lifecycle.StateStopped, s.State())
 }
 
+func TestSubscriber_RateLimit(t *testing.T) {
+	actor1 := testutil.MustParseURL(serviceURL)
+	actor2 := testutil.MustParseURL("http://localhost:8203/services/service2")
+
+	tm := &apmocks.AuthTokenMgr{}
+	tm.RequiredAuthTokensReturns([]string{"admin"}, nil)
+
+	rl := ratelimiter.New(ratelimiter.Config{
+		ActorLimit: ratelimiter.Limit{Rate: 0.01, Burst: 2},
+		ActivityTypeLimits: map[string]ratelimiter.Limit{
+			string(vocab.TypeCreate): {Rate: 0.1, Burst: 1},
+		},
+	}, &orbmocks.MetricsProvider{})
+
+	newActivity := func(actor *url.URL, opts ...vocab.Opt) []byte {
+		activityBytes, err := json.Marshal(vocab.NewCreateActivity(nil, append(opts, vocab.WithActor(actor))...))
+		require.NoError(t, err)
+
+		return activityBytes
+	}
+
+	t.Run("HTTP signature actor", func(t *testing.T) {
+		sigVerifier := &mocks.SignatureVerifier{}
+		sigVerifier.VerifyRequestReturns(true, actor1, nil)
+
+		s := New(&Config{ServiceEndpoint: endpoint}, sigVerifier, tm, WithRateLimiter(rl))
+		require.NotNil(t, s)
+
+		defer s.Stop()
+
+		msgChan, err := s.Subscribe(context.Background(), "")
+		require.NoError(t, err)
+
+		go func() {
+			for msg := range msgChan {
+				msg.Ack()
+			}
+		}()
+
+		result := postMessage(s, newActivity(actor1))
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		result = postMessage(s, newActivity(actor1))
+		require.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+		require.Equal(t, "10", result.Header.Get("Retry-After"))
+		require.NoError(t, result.Body.Close())
+
+		// The actor in the HTTP signature should be used instead of the actor in the activity.
+		result = postMessage(s, newActivity(actor2))
+		require.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("Remote address", func(t *testing.T) {
+		tm := &apmocks.AuthTokenMgr{}
+		tm.RequiredAuthTokensReturns(nil, nil)
+
+		s := New(&Config{ServiceEndpoint: endpoint}, &mocks.SignatureVerifier{}, tm, WithRateLimiter(rl))
+		require.NotNil(t, s)
+
+		defer s.Stop()
+
+		msgChan, err := s.Subscribe(context.Background(), "")
+		require.NoError(t, err)
+
+		go func() {
+			for msg := range msgChan {
+				msg.Ack()
+			}
+		}()
+
+		const (
+			remoteAddr1 = "192.0.2.1:1234"
+			remoteAddr2 = "192.0.2.2:1234"
+		)
+
+		result := postMessageFrom(s, newActivity(actor2), remoteAddr1)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		// The limit applies to the remote address, regardless of the port or the actor in the activity.
+		result = postMessageFrom(s, newActivity(actor1), "192.0.2.1:5678")
+		require.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		// The actor in the activity isn't authenticated so it can't be used to exhaust the actor's quota.
+		result = postMessageFrom(s, newActivity(actor2), remoteAddr2)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		// Invalid activities are also limited.
+		result = postMessageFrom(s, []byte("{"), remoteAddr2)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		result = postMessageFrom(s, []byte("{"), remoteAddr2)
+		require.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
+func postMessage(s *Subscriber, payload []byte) *http.Response {
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(payload))
+
+	s.handleMessage(rw, req)
+
+	return rw.Result()
+}
+
+func postMessageFrom(s *Subscriber, payload []byte, remoteAddr string) *http.Response {
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(payload))
+	req.RemoteAddr = remoteAddr
+
+	s.handleMessage(rw, req)
+
+	return rw.Result()
+}
+
 func TestSubscriber_HandleAck(t *testing.T) {
 	sigVerifier := &mocks.SignatureVerifier{}
 	sigVerifier.VerifyRequestReturns(true, testutil.MustParseURL(serviceURL), nil)
@@ -79,6 +205,68 @@ func TestSubscriber_HandleAck(t *testing.T) {
 	require.NoError(t, result.Body.Close())
 }
 
+func TestSubscriber_SharedInbox(t *testing.T) {
+	const sharedInboxEndpoint = "/services/sharedinbox"
+
+	sigVerifier := &mocks.SignatureVerifier{}
+	sigVerifier.VerifyRequestReturns(true, testutil.MustParseURL(serviceURL), nil)
+
+	tm := &apmocks.AuthTokenMgr{}
+	tm.RequiredAuthTokensReturns([]string{"admin"}, nil)
+
+	t.Run("Not configured", func(t *testing.T) {
+		s := New(&Config{ServiceEndpoint: endpoint}, sigVerifier, tm)
+		require.NotNil(t, s)
+
+		defer s.Stop()
+
+		require.Nil(t, s.SharedInboxHandler())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		s := New(&Config{ServiceEndpoint: endpoint, SharedInboxEndpoint: sharedInboxEndpoint}, sigVerifier, tm)
+		require.NotNil(t, s)
+
+		defer s.Stop()
+
+		h := s.SharedInboxHandler()
+		require.NotNil(t, h)
+		require.Equal(t, sharedInboxEndpoint, h.Path())
+		require.Equal(t, http.MethodPost, h.Method())
+
+		msgChan, err := s.Subscribe(context.Background(), "")
+		require.NoError(t, err)
+
+		sharedInboxValues := make(chan string, 2)
+
+		go func() {
+			for msg := range msgChan {
+				sharedInboxValues <- msg.Metadata.Get(SharedInboxKey)
+
+				msg.Ack()
+			}
+		}()
+
+		rw := httptest.NewRecorder()
+
+		h.Handler()(rw, httptest.NewRequest(http.MethodPost, sharedInboxEndpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+		require.Equal(t, "true", <-sharedInboxValues)
+
+		rw = httptest.NewRecorder()
+
+		s.handleMessage(rw, httptest.NewRequest(http.MethodPost, endpoint, nil))
+
+		result = rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+		require.Empty(t, <-sharedInboxValues)
+	})
+}
+
 func TestSubscriber_HandleNack(t *testing.T) {
 	sigVerifier := &mocks.SignatureVerifier{}
 	sigVerifier.VerifyRequestReturns(true, testutil.MustParseURL(serviceURL), nil)
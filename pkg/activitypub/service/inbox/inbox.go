@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -52,6 +53,7 @@ type authTokenManager interface {
 // Config holds configuration parameters for the Inbox.
 type Config struct {
 	ServiceEndpoint        string
+	SharedInboxEndpoint    string
 	ServiceIRI             *url.URL
 	Topic                  string
 	VerifyActorInSignature bool
@@ -70,17 +72,28 @@ type Inbox struct {
 	jsonUnmarshal          func(data []byte, v interface{}) error
 	metrics                metricsProvider
 	verifyActorInSignature bool
+	blockList              service.BlockList
 }
 
 // New returns a new ActivityPub inbox.
 func New(cfg *Config, s store.Store, pubSub pubSub, activityHandler service.ActivityHandler,
-	sigVerifier signatureVerifier, tm authTokenManager, metrics metricsProvider) (*Inbox, error) {
+	sigVerifier signatureVerifier, tm authTokenManager, metrics metricsProvider,
+	handlerOpts ...service.HandlerOpt) (*Inbox, error) {
+	options := &service.Handlers{
+		BlockList: &noOpBlockList{},
+	}
+
+	for _, opt := range handlerOpts {
+		opt(options)
+	}
+
 	h := &Inbox{
 		Config:          cfg,
 		activityHandler: activityHandler,
 		activityStore:   s,
 		jsonUnmarshal:   json.Unmarshal,
 		metrics:         metrics,
+		blockList:       options.BlockList,
 	}
 
 	h.Lifecycle = lifecycle.New(cfg.ServiceEndpoint,
@@ -93,11 +106,18 @@ func New(cfg *Config, s store.Store, pubSub pubSub, activityHandler service.Acti
 		return nil, fmt.Errorf("subscribe to topic [%s]: %w", cfg.Topic, err)
 	}
 
+	var subscriberOpts []httpsubscriber.Opt
+
+	if options.RateLimiter != nil {
+		subscriberOpts = append(subscriberOpts, httpsubscriber.WithRateLimiter(options.RateLimiter))
+	}
+
 	httpSubscriber := httpsubscriber.New(
 		&httpsubscriber.Config{
-			ServiceEndpoint: cfg.ServiceEndpoint,
+			ServiceEndpoint:     cfg.ServiceEndpoint,
+			SharedInboxEndpoint: cfg.SharedInboxEndpoint,
 		},
-		sigVerifier, tm,
+		sigVerifier, tm, subscriberOpts...,
 	)
 
 	router, err := message.NewRouter(message.RouterConfig{}, wmlogger.New())
@@ -136,6 +156,12 @@ func (h *Inbox) HTTPHandler() common.HTTPHandler {
 	return h.httpSubscriber
 }
 
+// SharedInboxHTTPHandler returns the HTTP handler for the shared inbox, or nil if no shared inbox
+// endpoint was configured. This handler must be registered with an HTTP server.
+func (h *Inbox) SharedInboxHTTPHandler() common.HTTPHandler {
+	return h.httpSubscriber.SharedInboxHandler()
+}
+
 func (h *Inbox) start() {
 	// Start the router
 	go h.route()
@@ -275,5 +301,50 @@ func (h *Inbox) unmarshalAndValidateActivity(msg *message.Message) (*vocab.Activ
 		}
 	}
 
+	blocked, err := h.blockList.IsBlocked(activity.Actor())
+	if err != nil {
+		return nil, fmt.Errorf("check block list for actor [%s]: %w", activity.Actor(), err)
+	}
+
+	if blocked {
+		return nil, fmt.Errorf("actor [%s] in activity [%s] is blocked", activity.Actor(), activity.ID())
+	}
+
+	if msg.Metadata.Get(httpsubscriber.SharedInboxKey) != "" && !h.hasLocalRecipient(activity) {
+		return nil, fmt.Errorf("activity [%s] posted to the shared inbox has no local recipients", activity.ID())
+	}
+
 	return activity, nil
 }
+
+// hasLocalRecipient returns true if an activity that was posted to the shared inbox is addressed to the local
+// service. The activity is delivered to the local service if the service is addressed directly, if the activity
+// is public, or if it's addressed to one of the sending actor's collections (for example, the actor's followers).
+// In the last case the sending server has already resolved the members of the collection and it posts the
+// activity once per server, so the activity is handled once for all of the recipients on this server.
+func (h *Inbox) hasLocalRecipient(activity *vocab.ActivityType) bool {
+	actorPrefix := activity.Actor().String() + "/"
+
+	for _, to := range activity.To() {
+		switch {
+		case to.String() == h.ServiceIRI.String():
+			return true
+		case to.String() == vocab.PublicIRI.String():
+			return true
+		case strings.HasPrefix(to.String(), actorPrefix):
+			return true
+		}
+	}
+
+	return false
+}
+
+type noOpBlockList struct{}
+
+func (l *noOpBlockList) IsBlocked(*url.URL) (bool, error) {
+	return false, nil
+}
+
+func (l *noOpBlockList) Block(*url.URL) error {
+	return nil
+}
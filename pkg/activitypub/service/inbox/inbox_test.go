@@ -29,6 +29,7 @@ import (
 	"github.com/trustbloc/orb/pkg/activitypub/resthandler"
 	"github.com/trustbloc/orb/pkg/activitypub/service/inbox/httpsubscriber"
 	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	service "github.com/trustbloc/orb/pkg/activitypub/service/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/store/memstore"
 	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
@@ -709,6 +710,102 @@ func TestUnmarshalAndValidateActivity(t *testing.T) {
 	})
 }
 
+func TestUnmarshalAndValidateActivity_BlockList(t *testing.T) {
+	activityID := testutil.MustParseURL("https://example1.com/activities/activity1")
+	actor1IRI := testutil.MustParseURL("https://example1.com/services/service1")
+	actor2IRI := testutil.MustParseURL("https://example2.com/services/service2")
+
+	newMessage := func(actorIRI *url.URL) *message.Message {
+		activityBytes, err := json.Marshal(vocab.NewCreateActivity(nil, vocab.WithID(activityID),
+			vocab.WithActor(actorIRI)))
+		require.NoError(t, err)
+
+		return message.NewMessage("msg1", activityBytes)
+	}
+
+	tm := &apmocks.AuthTokenMgr{}
+
+	t.Run("Blocked actor", func(t *testing.T) {
+		ib, err := New(&Config{}, memstore.New(""), mocks.NewPubSub(), nil, nil, tm, &orbmocks.MetricsProvider{},
+			service.WithBlockList(mocks.NewBlockList().WithBlocked(actor2IRI)))
+		require.NoError(t, err)
+
+		a, err := ib.unmarshalAndValidateActivity(newMessage(actor1IRI))
+		require.NoError(t, err)
+		require.NotNil(t, a)
+
+		a, err = ib.unmarshalAndValidateActivity(newMessage(actor2IRI))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is blocked")
+		require.False(t, orberrors.IsTransient(err))
+		require.Nil(t, a)
+	})
+
+	t.Run("Block list error", func(t *testing.T) {
+		errExpected := orberrors.NewTransient(errors.New("injected block list error"))
+
+		ib, err := New(&Config{}, memstore.New(""), mocks.NewPubSub(), nil, nil, tm, &orbmocks.MetricsProvider{},
+			service.WithBlockList(mocks.NewBlockList().WithError(errExpected)))
+		require.NoError(t, err)
+
+		a, err := ib.unmarshalAndValidateActivity(newMessage(actor1IRI))
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+		require.Nil(t, a)
+	})
+}
+
+func TestUnmarshalAndValidateActivity_SharedInbox(t *testing.T) {
+	serviceIRI := testutil.MustParseURL("https://example1.com/services/service1")
+	actorIRI := testutil.MustParseURL("https://example2.com/services/service2")
+
+	newMessage := func(to ...*url.URL) *message.Message {
+		activityBytes, err := json.Marshal(vocab.NewCreateActivity(nil,
+			vocab.WithID(newActivityID(actorIRI.String())),
+			vocab.WithActor(actorIRI),
+			vocab.WithTo(to...),
+		))
+		require.NoError(t, err)
+
+		msg := message.NewMessage("msg1", activityBytes)
+		msg.Metadata[httpsubscriber.SharedInboxKey] = "true"
+
+		return msg
+	}
+
+	ib, err := New(&Config{ServiceIRI: serviceIRI}, memstore.New(""), mocks.NewPubSub(), nil, nil,
+		&apmocks.AuthTokenMgr{}, &orbmocks.MetricsProvider{})
+	require.NoError(t, err)
+
+	t.Run("Addressed to local service", func(t *testing.T) {
+		a, err := ib.unmarshalAndValidateActivity(newMessage(serviceIRI))
+		require.NoError(t, err)
+		require.NotNil(t, a)
+	})
+
+	t.Run("Public", func(t *testing.T) {
+		a, err := ib.unmarshalAndValidateActivity(newMessage(vocab.PublicIRI))
+		require.NoError(t, err)
+		require.NotNil(t, a)
+	})
+
+	t.Run("Addressed to actor's followers", func(t *testing.T) {
+		a, err := ib.unmarshalAndValidateActivity(
+			newMessage(testutil.NewMockID(actorIRI, resthandler.FollowersPath)))
+		require.NoError(t, err)
+		require.NotNil(t, a)
+	})
+
+	t.Run("No local recipients", func(t *testing.T) {
+		a, err := ib.unmarshalAndValidateActivity(
+			newMessage(testutil.MustParseURL("https://example3.com/services/service3")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has no local recipients")
+		require.False(t, orberrors.IsTransient(err))
+		require.Nil(t, a)
+	})
+}
+
 func newHTTPRequest(u string, activity *vocab.ActivityType) (*http.Request, error) {
 	activityBytes, err := json.Marshal(activity)
 	if err != nil {
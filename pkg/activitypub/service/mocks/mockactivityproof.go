@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+// ActivitySigner implements a mock activity signer.
+type ActivitySigner struct {
+	mutex      sync.Mutex
+	err        error
+	activities []*vocab.ActivityType
+}
+
+// NewActivitySigner returns a mock activity signer.
+func NewActivitySigner() *ActivitySigner {
+	return &ActivitySigner{}
+}
+
+// WithError injects an error.
+func (m *ActivitySigner) WithError(err error) *ActivitySigner {
+	m.err = err
+
+	return m
+}
+
+// Sign adds the activity to a list that can be inspected using the Activities function and
+// returns the given activity or the injected error.
+func (m *ActivitySigner) Sign(activity *vocab.ActivityType) (*vocab.ActivityType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	m.activities = append(m.activities, activity)
+
+	return activity, nil
+}
+
+// Activities returns all of the activities that were signed by this mock.
+func (m *ActivitySigner) Activities() []*vocab.ActivityType {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.activities
+}
+
+// ActivityVerifier implements a mock activity verifier.
+type ActivityVerifier struct {
+	mutex      sync.Mutex
+	err        error
+	activities []*vocab.ActivityType
+}
+
+// NewActivityVerifier returns a mock activity verifier.
+func NewActivityVerifier() *ActivityVerifier {
+	return &ActivityVerifier{}
+}
+
+// WithError injects an error.
+func (m *ActivityVerifier) WithError(err error) *ActivityVerifier {
+	m.err = err
+
+	return m
+}
+
+// Verify adds the activity to a list that can be inspected using the Activities function and
+// returns the injected error.
+func (m *ActivityVerifier) Verify(activity *vocab.ActivityType) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.activities = append(m.activities, activity)
+
+	return m.err
+}
+
+// Activities returns all of the activities that were verified by this mock.
+func (m *ActivityVerifier) Activities() []*vocab.ActivityType {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.activities
+}
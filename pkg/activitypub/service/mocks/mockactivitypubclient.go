@@ -9,6 +9,7 @@ package mocks
 import (
 	"fmt"
 	"net/url"
+	"sync"
 
 	"github.com/trustbloc/orb/pkg/activitypub/client"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
@@ -21,6 +22,8 @@ type ActivityPubClient struct {
 	keys       map[string]*vocab.PublicKeyType
 	activities []*vocab.ActivityType
 	err        error
+	mutex      sync.RWMutex
+	cleared    []*url.URL
 }
 
 // NewActivitPubClient returns a mock ActivityPub client.
@@ -91,6 +94,22 @@ func (m *ActivityPubClient) GetActor(actorIRI *url.URL) (*vocab.ActorType, error
 	return actor, nil
 }
 
+// ClearActor records the IRI of the cleared actor.
+func (m *ActivityPubClient) ClearActor(actorIRI *url.URL) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.cleared = append(m.cleared, actorIRI)
+}
+
+// ClearedActors returns the IRIs of all actors that were cleared.
+func (m *ActivityPubClient) ClearedActors() []*url.URL {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.cleared
+}
+
 // GetReferences simply returns an iterator that contains the IRI passed as an arg.
 func (m *ActivityPubClient) GetReferences(iri *url.URL) (client.ReferenceIterator, error) {
 	if m.err != nil {
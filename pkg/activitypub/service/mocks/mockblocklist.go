@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"net/url"
+	"sync"
+)
+
+// BlockList implements a mock block list.
+type BlockList struct {
+	mutex   sync.RWMutex
+	blocked map[string]struct{}
+	err     error
+}
+
+// NewBlockList returns a mock block list.
+func NewBlockList() *BlockList {
+	return &BlockList{
+		blocked: make(map[string]struct{}),
+	}
+}
+
+// WithBlocked adds the given actors to the block list.
+func (m *BlockList) WithBlocked(actorIRIs ...*url.URL) *BlockList {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, iri := range actorIRIs {
+		m.blocked[iri.String()] = struct{}{}
+	}
+
+	return m
+}
+
+// WithError injects an error into the block list.
+func (m *BlockList) WithError(err error) *BlockList {
+	m.err = err
+
+	return m
+}
+
+// IsBlocked returns true if the given actor was added to the block list.
+func (m *BlockList) IsBlocked(actorIRI *url.URL) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	_, ok := m.blocked[actorIRI.String()]
+
+	return ok, nil
+}
+
+// Block adds the given actor to the block list.
+func (m *BlockList) Block(actorIRI *url.URL) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.WithBlocked(actorIRI)
+
+	return nil
+}
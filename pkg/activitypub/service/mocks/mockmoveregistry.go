@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"net/url"
+	"sync"
+)
+
+// MoveRegistry implements a mock registry of moved actors.
+type MoveRegistry struct {
+	mutex sync.RWMutex
+	moved map[string]*url.URL
+	err   error
+}
+
+// NewMoveRegistry returns a mock move registry.
+func NewMoveRegistry() *MoveRegistry {
+	return &MoveRegistry{
+		moved: make(map[string]*url.URL),
+	}
+}
+
+// WithError injects an error into the move registry.
+func (m *MoveRegistry) WithError(err error) *MoveRegistry {
+	m.err = err
+
+	return m
+}
+
+// Add records that the old actor has moved to the new IRI.
+func (m *MoveRegistry) Add(oldIRI, newIRI *url.URL) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.moved[oldIRI.String()] = newIRI
+
+	return nil
+}
+
+// Get returns the new IRI of the given actor or nil if the actor hasn't moved.
+func (m *MoveRegistry) Get(oldIRI *url.URL) *url.URL {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.moved[oldIRI.String()]
+}
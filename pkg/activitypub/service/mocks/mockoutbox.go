@@ -18,6 +18,7 @@ import (
 type Outbox struct {
 	mutex      sync.RWMutex
 	activities Activities
+	delivered  map[string]Activities
 	err        error
 	activityID *url.URL
 }
@@ -64,6 +65,32 @@ func (m *Outbox) Post(activity *vocab.ActivityType, _ ...*url.URL) (*url.URL, er
 	return m.activityID, nil
 }
 
+// Deliver simply stores the activity so that it may be retrieved by the Delivered function.
+func (m *Outbox) Deliver(activity *vocab.ActivityType, inboxIRI *url.URL) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.delivered == nil {
+		m.delivered = make(map[string]Activities)
+	}
+
+	m.delivered[inboxIRI.String()] = append(m.delivered[inboxIRI.String()], activity)
+
+	return nil
+}
+
+// Delivered returns the activities that were delivered to the given inbox.
+func (m *Outbox) Delivered(inboxIRI *url.URL) Activities {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.delivered[inboxIRI.String()]
+}
+
 // Start does nothing.
 func (m *Outbox) Start() {
 }
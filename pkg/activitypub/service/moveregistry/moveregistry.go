@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package moveregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+var logger = log.New("move_registry")
+
+const movedHostPrefix = "moved-host-"
+
+type movedActor struct {
+	OldIRI string `json:"oldIRI"`
+	NewIRI string `json:"newIRI"`
+}
+
+// Registry records actors that have moved to a new IRI. Since an actor move is typically the result of a
+// server domain migration, the mapping is kept per host so that any URL (such as a hashlink or WebCAS link)
+// that points to the old domain may be mapped to the new domain.
+type Registry struct {
+	store     storage.Store
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+// New returns a new move registry.
+func New(s storage.Store) *Registry {
+	return &Registry{
+		store:     s,
+		marshal:   json.Marshal,
+		unmarshal: json.Unmarshal,
+	}
+}
+
+// Add records that the actor at oldIRI has moved to newIRI.
+func (r *Registry) Add(oldIRI, newIRI *url.URL) error {
+	if oldIRI.Host == newIRI.Host {
+		logger.Debugf("Actor [%s] moved to [%s] on the same host. Nothing to record.", oldIRI, newIRI)
+
+		return nil
+	}
+
+	value, err := r.marshal(&movedActor{OldIRI: oldIRI.String(), NewIRI: newIRI.String()})
+	if err != nil {
+		return fmt.Errorf("marshal moved actor: %w", err)
+	}
+
+	if err := r.store.Put(newKey(oldIRI.Host), value); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store moved actor [%s]: %w", oldIRI, err))
+	}
+
+	logger.Infof("Recorded move of [%s] to [%s]", oldIRI, newIRI)
+
+	return nil
+}
+
+// MapURL returns the given URL with its host replaced by the host to which the original host has moved.
+// If the host hasn't moved then nil is returned.
+func (r *Registry) MapURL(u *url.URL) (*url.URL, error) {
+	value, err := r.store.Get(newKey(u.Host))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, nil
+		}
+
+		return nil, orberrors.NewTransient(fmt.Errorf("get moved host [%s]: %w", u.Host, err))
+	}
+
+	moved := &movedActor{}
+
+	if err := r.unmarshal(value, moved); err != nil {
+		return nil, fmt.Errorf("unmarshal moved actor for host [%s]: %w", u.Host, err)
+	}
+
+	newIRI, err := url.Parse(moved.NewIRI)
+	if err != nil {
+		return nil, fmt.Errorf("parse new IRI for host [%s]: %w", u.Host, err)
+	}
+
+	mapped := *u
+	mapped.Scheme = newIRI.Scheme
+	mapped.Host = newIRI.Host
+
+	logger.Debugf("Mapped URL [%s] to [%s]", u, &mapped)
+
+	return &mapped, nil
+}
+
+func newKey(host string) string {
+	return movedHostPrefix + host
+}
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package moveregistry
+
+import (
+	"errors"
+	"testing"
+
+	storagemocks "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/stretchr/testify/require"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+var (
+	oldServiceIRI = testutil.MustParseURL("https://old.domain.com/services/orb")
+	newServiceIRI = testutil.MustParseURL("https://new.domain.com/services/orb")
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := New(&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)})
+		require.NotNil(t, r)
+
+		casURL := testutil.MustParseURL("https://old.domain.com/cas/uEiDaapVGOEWRJcPGu4Yh9ZuNbzeiRKpgFI1NdeNwAJcgEg")
+
+		mapped, err := r.MapURL(casURL)
+		require.NoError(t, err)
+		require.Nil(t, mapped)
+
+		require.NoError(t, r.Add(oldServiceIRI, newServiceIRI))
+
+		mapped, err = r.MapURL(casURL)
+		require.NoError(t, err)
+		require.NotNil(t, mapped)
+		require.Equal(t, "https://new.domain.com/cas/uEiDaapVGOEWRJcPGu4Yh9ZuNbzeiRKpgFI1NdeNwAJcgEg", mapped.String())
+
+		mapped, err = r.MapURL(testutil.MustParseURL("https://other.domain.com/cas/xxx"))
+		require.NoError(t, err)
+		require.Nil(t, mapped)
+	})
+
+	t.Run("Same host", func(t *testing.T) {
+		r := New(&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)})
+
+		require.NoError(t, r.Add(oldServiceIRI, testutil.MustParseURL("https://old.domain.com/services/orb2")))
+
+		mapped, err := r.MapURL(oldServiceIRI)
+		require.NoError(t, err)
+		require.Nil(t, mapped)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		r := New(&storagemocks.MockStore{
+			Store:  make(map[string]storagemocks.DBEntry),
+			ErrPut: errExpected,
+			ErrGet: errExpected,
+		})
+
+		err := r.Add(oldServiceIRI, newServiceIRI)
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+		require.True(t, errors.Is(err, errExpected))
+
+		_, err = r.MapURL(oldServiceIRI)
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		errExpected := errors.New("injected marshal error")
+
+		r := New(&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)})
+		r.marshal = func(v interface{}) ([]byte, error) { return nil, errExpected }
+
+		err := r.Add(oldServiceIRI, newServiceIRI)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errExpected))
+	})
+
+	t.Run("Unmarshal error", func(t *testing.T) {
+		errExpected := errors.New("injected unmarshal error")
+
+		r := New(&storagemocks.MockStore{Store: make(map[string]storagemocks.DBEntry)})
+		r.unmarshal = func(data []byte, v interface{}) error { return errExpected }
+
+		require.NoError(t, r.Add(oldServiceIRI, newServiceIRI))
+
+		_, err := r.MapURL(oldServiceIRI)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errExpected))
+	})
+}
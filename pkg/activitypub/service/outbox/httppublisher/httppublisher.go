@@ -30,27 +30,60 @@ type httpTransport interface {
 	Post(ctx context.Context, req *transport.Request, payload []byte) (*http.Response, error)
 }
 
+type circuitBreaker interface {
+	Allow(host string) error
+	Success(host string)
+	Failure(host string)
+}
+
+// Opt sets an option for the HTTP publisher.
+type Opt func(p *Publisher)
+
+// WithCircuitBreaker sets the circuit breaker that tracks the health of each destination host.
+// Messages to a host whose breaker is open are rejected without being sent.
+func WithCircuitBreaker(cb circuitBreaker) Opt {
+	return func(p *Publisher) {
+		p.circuitBreaker = cb
+	}
+}
+
+// WithUndeliverableHandler sets the handler that's invoked with a message that isn't sent because the
+// circuit breaker for the destination host is open. The message is passed to the handler (and acknowledged)
+// instead of being returned with an error, which would cause the message to be redelivered to the host.
+func WithUndeliverableHandler(handler func(msg *message.Message)) Opt {
+	return func(p *Publisher) {
+		p.undeliverableHandler = handler
+	}
+}
+
 // Publisher is an implementation of a Watermill Publisher that publishes messages over HTTP.
 type Publisher struct {
 	*lifecycle.Lifecycle
 
-	ServiceName    string
-	httpTransport  httpTransport
-	jsonMarshal    func(v interface{}) ([]byte, error)
-	newRequestFunc func(string, *message.Message) (*transport.Request, error)
+	ServiceName          string
+	httpTransport        httpTransport
+	circuitBreaker       circuitBreaker
+	undeliverableHandler func(msg *message.Message)
+	jsonMarshal          func(v interface{}) ([]byte, error)
+	newRequestFunc       func(string, *message.Message) (*transport.Request, error)
 }
 
 // New creates a new HTTP Publisher.
-func New(serviceName string, t httpTransport) *Publisher {
+func New(serviceName string, t httpTransport, opts ...Opt) *Publisher {
 	p := &Publisher{
-		ServiceName:   serviceName,
-		Lifecycle:     lifecycle.New(serviceName),
-		httpTransport: t,
-		jsonMarshal:   json.Marshal,
+		ServiceName:    serviceName,
+		Lifecycle:      lifecycle.New(serviceName),
+		httpTransport:  t,
+		circuitBreaker: &noOpCircuitBreaker{},
+		jsonMarshal:    json.Marshal,
 	}
 
 	p.newRequestFunc = p.newRequest
 
+	for _, opt := range opts {
+		opt(p)
+	}
+
 	// The service must be started immediately.
 	p.Start()
 
@@ -82,10 +115,26 @@ func (p *Publisher) publish(topic string, msg *message.Message) error {
 		return fmt.Errorf("marshal message %s: %w", msg.UUID, err)
 	}
 
+	host := req.URL.Host
+
+	if err := p.circuitBreaker.Allow(host); err != nil {
+		logger.Debugf("[%s] Not sending message [%s] to [%s]: %s", p.ServiceName, msg.UUID, req.URL, err)
+
+		if p.undeliverableHandler != nil {
+			p.undeliverableHandler(msg)
+
+			return nil
+		}
+
+		return fmt.Errorf("send message [%s]: %w", msg.UUID, err)
+	}
+
 	logger.Debugf("[%s] Sending message [%s] to [%s] ", p.ServiceName, msg.UUID, req.URL)
 
 	resp, err := p.httpTransport.Post(context.Background(), req, msg.Payload)
 	if err != nil {
+		p.circuitBreaker.Failure(host)
+
 		return fmt.Errorf("send message [%s]: %w", msg.UUID, err)
 	}
 
@@ -93,6 +142,14 @@ func (p *Publisher) publish(topic string, msg *message.Message) error {
 		logger.Warnf("[%s] Error closing response body: %s", p.ServiceName, err)
 	}
 
+	// Only server errors count against the health of the destination. A client error means
+	// that the server is reachable but rejected this particular message.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		p.circuitBreaker.Failure(host)
+	} else {
+		p.circuitBreaker.Success(host)
+	}
+
 	if resp.StatusCode >= http.StatusBadRequest {
 		logger.Debugf("[%s] Error code %d received in response from [%s] for message [%s]",
 			p.ServiceName, resp.StatusCode, req.URL, msg.UUID)
@@ -127,3 +184,9 @@ func (p *Publisher) newRequest(_ string, msg *message.Message) (*transport.Reque
 		transport.WithHeader(wmhttp.HeaderMetadata, string(metadataBytes)),
 	), nil
 }
+
+type noOpCircuitBreaker struct{}
+
+func (cb *noOpCircuitBreaker) Allow(string) error { return nil }
+func (cb *noOpCircuitBreaker) Success(string)     {}
+func (cb *noOpCircuitBreaker) Failure(string)     {}
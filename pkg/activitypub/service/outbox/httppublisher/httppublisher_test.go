@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -24,8 +25,10 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 
 	"github.com/trustbloc/orb/pkg/activitypub/client/transport"
+	"github.com/trustbloc/orb/pkg/activitypub/service/circuitbreaker"
 	"github.com/trustbloc/orb/pkg/httpserver"
 	"github.com/trustbloc/orb/pkg/lifecycle"
+	"github.com/trustbloc/orb/pkg/mocks"
 )
 
 func TestNew(t *testing.T) {
@@ -136,6 +139,101 @@ func TestPublisher_Publish(t *testing.T) {
 	})
 }
 
+func TestPublisher_CircuitBreaker(t *testing.T) {
+	var mutex sync.RWMutex
+
+	status := http.StatusInternalServerError
+	numRequests := 0
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		numRequests++
+
+		w.WriteHeader(status)
+	}))
+	defer serv.Close()
+
+	cb := circuitbreaker.New(circuitbreaker.Config{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond},
+		&mocks.MetricsProvider{})
+
+	p := New("service1", transport.Default(), WithCircuitBreaker(cb))
+	require.NotNil(t, p)
+
+	newMsg := func() *message.Message {
+		msg := message.NewMessage(watermill.NewUUID(), []byte("payload"))
+		msg.Metadata[MetadataSendTo] = serv.URL + "/services/service1/inbox"
+
+		return msg
+	}
+
+	require.Error(t, p.Publish("topic", newMsg()))
+	require.Error(t, p.Publish("topic", newMsg()))
+
+	err := p.Publish("topic", newMsg())
+	require.True(t, errors.Is(err, circuitbreaker.ErrOpen))
+
+	mutex.RLock()
+	require.Equal(t, 2, numRequests, "request should not have been sent while the breaker is open")
+	mutex.RUnlock()
+
+	time.Sleep(100 * time.Millisecond)
+
+	mutex.Lock()
+	status = http.StatusOK
+	mutex.Unlock()
+
+	require.NoError(t, p.Publish("topic", newMsg()))
+	require.NoError(t, p.Publish("topic", newMsg()))
+
+	mutex.RLock()
+	require.Equal(t, 4, numRequests)
+	mutex.RUnlock()
+}
+
+func TestPublisher_CircuitBreakerUndeliverable(t *testing.T) {
+	numRequests := 0
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer serv.Close()
+
+	cb := circuitbreaker.New(circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: time.Minute},
+		&mocks.MetricsProvider{})
+
+	var undeliverable []*message.Message
+
+	p := New("service1", transport.Default(), WithCircuitBreaker(cb),
+		WithUndeliverableHandler(func(msg *message.Message) {
+			undeliverable = append(undeliverable, msg)
+		}),
+	)
+	require.NotNil(t, p)
+
+	newMsg := func() *message.Message {
+		msg := message.NewMessage(watermill.NewUUID(), []byte("payload"))
+		msg.Metadata[MetadataSendTo] = serv.URL + "/services/service1/inbox"
+
+		return msg
+	}
+
+	// The server error is returned so that the message is redelivered.
+	require.Error(t, p.Publish("topic", newMsg()))
+	require.Empty(t, undeliverable)
+
+	// The breaker is now open so the message is passed to the undeliverable handler instead of being redelivered.
+	msg := newMsg()
+
+	require.NoError(t, p.Publish("topic", msg))
+	require.Len(t, undeliverable, 1)
+	require.Equal(t, msg.UUID, undeliverable[0].UUID)
+	require.Equal(t, 1, numRequests)
+}
+
 func TestNewRequest(t *testing.T) {
 	const serviceURL = "http://localhost:8100/services/service1"
 
@@ -91,6 +91,7 @@ type Outbox struct {
 	publisher            message.Publisher
 	activityHandler      service.ActivityHandler
 	undeliverableHandler service.UndeliverableActivityHandler
+	activitySigner       service.ActivitySigner
 	undeliverableChan    <-chan *message.Message
 	activityStore        store.Store
 	client               activityPubClient
@@ -134,6 +135,7 @@ func New(cnfg *Config, s store.Store, pubSub pubSub, t httpTransport, activityHa
 		Config:               &cfg,
 		activityHandler:      activityHandler,
 		undeliverableHandler: options.UndeliverableHandler,
+		activitySigner:       options.ActivitySigner,
 		activityStore:        s,
 		client:               apClient,
 		resourceResolver:     resourceResolver,
@@ -164,7 +166,16 @@ func New(cnfg *Config, s store.Store, pubSub pubSub, t httpTransport, activityHa
 		panic(err)
 	}
 
-	httpPublisher := httppublisher.New(cfg.ServiceName, t)
+	var publisherOpts []httppublisher.Opt
+
+	if options.CircuitBreaker != nil {
+		publisherOpts = append(publisherOpts,
+			httppublisher.WithCircuitBreaker(options.CircuitBreaker),
+			httppublisher.WithUndeliverableHandler(h.handleCircuitOpen),
+		)
+	}
+
+	httpPublisher := httppublisher.New(cfg.ServiceName, t, publisherOpts...)
 
 	router.AddHandler(
 		"outbox-"+cfg.ServiceName, cfg.Topic,
@@ -229,6 +240,13 @@ func (h *Outbox) Post(activity *vocab.ActivityType, exclude ...*url.URL) (*url.U
 		return nil, err
 	}
 
+	if h.activitySigner != nil {
+		activity, err = h.activitySigner.Sign(activity)
+		if err != nil {
+			return nil, fmt.Errorf("sign activity: %w", err)
+		}
+	}
+
 	activityBytes, err := h.jsonMarshal(activity)
 	if err != nil {
 		return nil, orberrors.NewBadRequest(fmt.Errorf("marshal: %w", err))
@@ -262,6 +280,29 @@ func (h *Outbox) Post(activity *vocab.ActivityType, exclude ...*url.URL) (*url.U
 	return activity.ID().URL(), nil
 }
 
+// Deliver delivers an activity, which was previously posted to the outbox, to the given inbox. This may be
+// used to replay an activity that could not be delivered.
+func (h *Outbox) Deliver(activity *vocab.ActivityType, inboxIRI *url.URL) error {
+	if h.State() != lifecycle.StateStarted {
+		return lifecycle.ErrNotStarted
+	}
+
+	activityBytes, err := h.jsonMarshal(activity)
+	if err != nil {
+		return orberrors.NewBadRequest(fmt.Errorf("marshal: %w", err))
+	}
+
+	logger.Debugf("[%s] Delivering activity [%s] to inbox [%s]", h.ServiceName, activity.ID(), inboxIRI)
+
+	err = h.publish(activity.ID().String(), activityBytes, inboxIRI)
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("publish activity [%s] to inbox [%s]: %w",
+			activity.ID(), inboxIRI, err))
+	}
+
+	return nil
+}
+
 func (h *Outbox) storeActivity(activity *vocab.ActivityType) error {
 	if err := h.activityStore.AddActivity(activity); err != nil {
 		return fmt.Errorf("store activity: %w", err)
@@ -339,6 +380,25 @@ func (h *Outbox) handleUndeliverableActivity(msg *message.Message) {
 	}
 }
 
+// handleCircuitOpen is invoked for a message that wasn't sent because the circuit breaker for the destination
+// host is open. The activity is passed straight to the undeliverable handler so that it isn't redelivered to
+// a host that's known to be unavailable.
+func (h *Outbox) handleCircuitOpen(msg *message.Message) {
+	toURL := msg.Metadata[httppublisher.MetadataSendTo]
+
+	activity := &vocab.ActivityType{}
+	if err := h.jsonUnmarshal(msg.Payload, activity); err != nil {
+		logger.Errorf("[%s] Error unmarshalling activity for message [%s]: %s", h.ServiceName, msg.UUID, err)
+
+		return
+	}
+
+	logger.Warnf("[%s] Circuit breaker is open for [%s]. Activity ID [%s] is undeliverable.",
+		h.ServiceName, toURL, activity.ID())
+
+	h.undeliverableHandler.HandleUndeliverableActivity(activity, toURL)
+}
+
 func (h *Outbox) redeliver() {
 	for msg := range h.redeliveryChan {
 		logger.Infof("[%s] Attempting to redeliver message [%s]", h.ServiceName, msg.UUID)
@@ -363,7 +423,7 @@ func (h *Outbox) resolveInboxes(toIRIs, excludeIRIs []*url.URL) ([]*url.URL, err
 		return nil, err
 	}
 
-	return h.resolveIRIs(
+	inboxIRIs, err := h.resolveIRIs(
 		deduplicateAndFilter(toIRIs, excludeIRIs),
 		func(actorIRI *url.URL) ([]*url.URL, error) {
 			inboxIRI, err := h.resolveInbox(actorIRI)
@@ -374,8 +434,15 @@ func (h *Outbox) resolveInboxes(toIRIs, excludeIRIs []*url.URL) ([]*url.URL, err
 			return []*url.URL{inboxIRI}, nil
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Actors on the same server share the same inbox, so the activity only needs to be delivered to it once.
+	return deduplicateAndFilter(inboxIRIs, nil), nil
 }
 
+// resolveInbox returns the shared inbox of the given actor, if it has one, otherwise the actor's own inbox is returned.
 func (h *Outbox) resolveInbox(iri *url.URL) (*url.URL, error) {
 	logger.Debugf("[%s] Retrieving actor from %s", h.ServiceName, iri)
 
@@ -384,6 +451,12 @@ func (h *Outbox) resolveInbox(iri *url.URL) (*url.URL, error) {
 		return nil, err
 	}
 
+	if sharedInbox := actor.SharedInbox(); sharedInbox != nil {
+		logger.Debugf("[%s] Using shared inbox [%s] for actor [%s]", h.ServiceName, sharedInbox, iri)
+
+		return sharedInbox, nil
+	}
+
 	return actor.Inbox(), nil
 }
 
@@ -409,12 +482,17 @@ func (h *Outbox) doResolveActorIRIs(iri *url.URL) ([]*url.URL, error) {
 	logger.Debugf("[%s] Resolving IRI for actor [%s]", h.ServiceName, iri)
 
 	if strings.HasPrefix(iri.String(), h.ServiceIRI.String()) {
-		// This IRI is for the local service. The only valid paths are /followers and /witnesses.
+		// This IRI is for the local service. The only valid paths are /followers, /following,
+		// /witnesses and /witnessing.
 		switch {
 		case strings.HasSuffix(iri.Path, resthandler.FollowersPath):
 			return h.loadReferences(store.Follower)
+		case strings.HasSuffix(iri.Path, resthandler.FollowingPath):
+			return h.loadReferences(store.Following)
 		case strings.HasSuffix(iri.Path, resthandler.WitnessesPath):
 			return h.loadReferences(store.Witness)
+		case strings.HasSuffix(iri.Path, resthandler.WitnessingPath):
+			return h.loadReferences(store.Witnessing)
 		default:
 			logger.Warnf("[%s] Ignoring local IRI %s since it is not a valid recipient.", h.ServiceName, iri)
 
@@ -13,12 +13,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/stretchr/testify/require"
 	"github.com/trustbloc/edge-core/pkg/log"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
@@ -26,7 +29,9 @@ import (
 	"github.com/trustbloc/orb/pkg/activitypub/client"
 	"github.com/trustbloc/orb/pkg/activitypub/client/transport"
 	"github.com/trustbloc/orb/pkg/activitypub/resthandler"
+	"github.com/trustbloc/orb/pkg/activitypub/service/circuitbreaker"
 	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/outbox/httppublisher"
 	"github.com/trustbloc/orb/pkg/activitypub/service/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/store/memstore"
 	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
@@ -65,6 +70,21 @@ func TestNewOutbox(t *testing.T) {
 		require.NotNil(t, ob)
 	})
 
+	t.Run("With circuit breaker", func(t *testing.T) {
+		cfg := &Config{
+			ServiceName: "service1",
+			ServiceIRI:  service1URL,
+			Topic:       "activities",
+		}
+
+		ob, err := New(cfg, activityStore, mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{},
+			spi.WithUndeliverableHandler(undeliverableHandler),
+			spi.WithCircuitBreaker(circuitbreaker.New(circuitbreaker.Config{}, &orbmocks.MetricsProvider{})))
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+	})
+
 	t.Run("PubSub Subscribe error", func(t *testing.T) {
 		cfg := &Config{
 			ServiceName: "service1",
@@ -113,6 +133,52 @@ func TestOutbox_StartStop(t *testing.T) {
 	require.Equal(t, lifecycle.StateStopped, ob.State())
 }
 
+func TestOutbox_HandleCircuitOpen(t *testing.T) {
+	service1URL := testutil.MustParseURL("http://localhost:8002/services/service1")
+	service2InboxURL := "http://localhost:8003/services/service2/inbox"
+
+	undeliverableHandler := mocks.NewUndeliverableHandler()
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1URL,
+		Topic:       "activities",
+	}
+
+	ob, err := New(cfg, memstore.New("service1"), mocks.NewPubSub(), transport.Default(),
+		&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{},
+		spi.WithUndeliverableHandler(undeliverableHandler),
+		spi.WithCircuitBreaker(circuitbreaker.New(circuitbreaker.Config{}, &orbmocks.MetricsProvider{})))
+	require.NoError(t, err)
+	require.NotNil(t, ob)
+
+	activity := vocab.NewCreateActivity(nil, vocab.WithID(testutil.NewMockID(service1URL, "/activities")))
+
+	activityBytes, err := json.Marshal(activity)
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		msg := message.NewMessage(watermill.NewUUID(), activityBytes)
+		msg.Metadata.Set(httppublisher.MetadataSendTo, service2InboxURL)
+
+		ob.handleCircuitOpen(msg)
+
+		activities := undeliverableHandler.Activities()
+		require.Len(t, activities, 1)
+		require.Equal(t, activity.ID().String(), activities[0].Activity.ID().String())
+		require.Equal(t, service2InboxURL, activities[0].ToURL)
+	})
+
+	t.Run("Unmarshal error", func(t *testing.T) {
+		msg := message.NewMessage(watermill.NewUUID(), []byte("{"))
+		msg.Metadata.Set(httppublisher.MetadataSendTo, service2InboxURL)
+
+		ob.handleCircuitOpen(msg)
+
+		require.Len(t, undeliverableHandler.Activities(), 1)
+	})
+}
+
 func TestOutbox_Post(t *testing.T) {
 	log.SetLevel("activitypub_service", log.DEBUG)
 	log.SetLevel("activitypub_client", log.DEBUG)
@@ -270,6 +336,67 @@ func TestOutbox_Post(t *testing.T) {
 	ob.Stop()
 }
 
+func TestOutbox_PostToSharedInbox(t *testing.T) {
+	var mutex sync.Mutex
+
+	received := make(map[string]int)
+
+	inboxServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		received[req.URL.Path]++
+		mutex.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer inboxServer.Close()
+
+	service1URL := testutil.MustParseURL("http://localhost:8002/services/service1")
+	service2URL := testutil.MustParseURL(inboxServer.URL + "/services/service2")
+	service3URL := testutil.MustParseURL(inboxServer.URL + "/services/service3")
+	sharedInboxURL := testutil.MustParseURL(inboxServer.URL + "/services/sharedinbox")
+
+	activityStore := memstore.New("service1")
+
+	require.NoError(t, activityStore.AddReference(store.Follower, service1URL, service2URL))
+	require.NoError(t, activityStore.AddReference(store.Follower, service1URL, service3URL))
+
+	apClient := mocks.NewActivitPubClient().
+		WithActor(aptestutil.NewMockService(service2URL, aptestutil.WithSharedInbox(sharedInboxURL))).
+		WithActor(aptestutil.NewMockService(service3URL, aptestutil.WithSharedInbox(sharedInboxURL)))
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1URL,
+		Topic:       "activities",
+	}
+
+	ob, err := New(cfg, activityStore, mocks.NewPubSub(), transport.Default(),
+		&mocks.ActivityHandler{}, apClient, &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{})
+	require.NoError(t, err)
+	require.NotNil(t, ob)
+
+	ob.Start()
+	defer ob.Stop()
+
+	activity := vocab.NewCreateActivity(
+		vocab.NewObjectProperty(vocab.WithIRI(testutil.MustParseURL("http://example.com/transactions/txn1"))),
+		vocab.WithTo(testutil.NewMockID(service1URL, resthandler.FollowersPath)),
+	)
+
+	_, err = ob.Post(activity)
+	require.NoError(t, err)
+
+	time.Sleep(250 * time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	require.Equalf(t, 1, received[sharedInboxURL.Path],
+		"the activity should have been posted once to the shared inbox of both followers")
+	require.Zero(t, received[service2URL.Path+resthandler.InboxPath])
+	require.Zero(t, received[service3URL.Path+resthandler.InboxPath])
+}
+
 func TestOutbox_PostError(t *testing.T) {
 	log.SetLevel("activitypub_service", log.DEBUG)
 
@@ -547,6 +674,137 @@ func TestOutbox_PostError(t *testing.T) {
 	})
 }
 
+func TestOutbox_PostWithActivitySigner(t *testing.T) {
+	service1URL := testutil.MustParseURL("http://localhost:8002/services/service1")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1URL,
+		Topic:       "activities",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		activityStore := memstore.New("service1")
+		signer := mocks.NewActivitySigner()
+
+		ob, err := New(cfg, activityStore, mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{},
+			spi.WithUndeliverableHandler(mocks.NewUndeliverableHandler()),
+			spi.WithActivitySigner(signer))
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		ob.Start()
+		defer ob.Stop()
+
+		activityID, err := ob.Post(vocab.NewCreateActivity(nil))
+		require.NoError(t, err)
+		require.NotNil(t, activityID)
+
+		signed := signer.Activities()
+		require.Len(t, signed, 1)
+		require.Equal(t, activityID.String(), signed[0].ID().String())
+		require.Equal(t, service1URL.String(), signed[0].Actor().String())
+
+		a, err := activityStore.GetActivity(activityID)
+		require.NoError(t, err)
+		require.Equal(t, activityID.String(), a.ID().String())
+	})
+
+	t.Run("Sign error", func(t *testing.T) {
+		errExpected := errors.New("injected sign error")
+
+		activityStore := &mocks.ActivityStore{}
+
+		ob, err := New(cfg, activityStore, mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{},
+			spi.WithUndeliverableHandler(mocks.NewUndeliverableHandler()),
+			spi.WithActivitySigner(mocks.NewActivitySigner().WithError(errExpected)))
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		ob.Start()
+		defer ob.Stop()
+
+		activityID, err := ob.Post(vocab.NewCreateActivity(nil))
+		require.True(t, errors.Is(err, errExpected))
+		require.Nil(t, activityID)
+		require.Zero(t, activityStore.AddActivityCallCount())
+	})
+}
+
+func TestOutbox_Deliver(t *testing.T) {
+	service1URL := testutil.MustParseURL("http://localhost:8002/services/service1")
+
+	cfg := &Config{
+		ServiceName: "service1",
+		ServiceIRI:  service1URL,
+		Topic:       "activities",
+	}
+
+	activity := vocab.NewCreateActivity(nil,
+		vocab.WithID(testutil.NewMockID(service1URL, "/activities/123")),
+		vocab.WithActor(service1URL),
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		received := make(chan *vocab.ActivityType, 1)
+
+		inboxServer := httptest.NewServer(http.HandlerFunc(mockInboxHandler(t, func(activity *vocab.ActivityType) {
+			received <- activity
+		})))
+		defer inboxServer.Close()
+
+		ob, err := New(cfg, memstore.New("service1"), mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{},
+			&orbmocks.MetricsProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		ob.Start()
+		defer ob.Stop()
+
+		require.NoError(t, ob.Deliver(activity, testutil.MustParseURL(inboxServer.URL+"/services/service2/inbox")))
+
+		select {
+		case a := <-received:
+			require.Equal(t, activity.ID().String(), a.ID().String())
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for activity to be delivered")
+		}
+	})
+
+	t.Run("Not started", func(t *testing.T) {
+		ob, err := New(cfg, memstore.New("service1"), mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{},
+			&orbmocks.MetricsProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		err = ob.Deliver(activity, testutil.MustParseURL("http://localhost:8003/services/service2/inbox"))
+		require.True(t, errors.Is(err, lifecycle.ErrNotStarted))
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		ob, err := New(cfg, memstore.New("service1"), mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, mocks.NewActivitPubClient(), &mocks.WebFingerResolver{},
+			&orbmocks.MetricsProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		ob.Start()
+		defer ob.Stop()
+
+		errExpected := errors.New("injected marshal error")
+
+		ob.jsonMarshal = func(v interface{}) ([]byte, error) { return nil, errExpected }
+
+		err = ob.Deliver(activity, testutil.MustParseURL("http://localhost:8003/services/service2/inbox"))
+		require.True(t, errors.Is(err, errExpected))
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+}
+
 func TestDeduplicate(t *testing.T) {
 	service1URL := testutil.MustParseURL("http://localhost:8002/services/service1")
 	service2URL := testutil.MustParseURL("http://localhost:8002/services/service2")
@@ -593,6 +851,29 @@ func TestResolveInboxes(t *testing.T) {
 		require.NoError(t, err)
 		require.Empty(t, inboxes)
 	})
+
+	t.Run("Shared inbox", func(t *testing.T) {
+		service2URL := testutil.MustParseURL("http://localhost:8003/services/service2")
+		service3URL := testutil.MustParseURL("http://localhost:8003/services/service3")
+		service4URL := testutil.MustParseURL("http://localhost:8004/services/service4")
+		sharedInboxURL := testutil.MustParseURL("http://localhost:8003/services/inbox")
+
+		apClient := mocks.NewActivitPubClient().
+			WithActor(aptestutil.NewMockService(service2URL, aptestutil.WithSharedInbox(sharedInboxURL))).
+			WithActor(aptestutil.NewMockService(service3URL, aptestutil.WithSharedInbox(sharedInboxURL))).
+			WithActor(aptestutil.NewMockService(service4URL))
+
+		ob, err := New(cfg, activityStore, mocks.NewPubSub(), transport.Default(),
+			&mocks.ActivityHandler{}, apClient, &mocks.WebFingerResolver{}, &orbmocks.MetricsProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, ob)
+
+		inboxes, err := ob.resolveInboxes([]*url.URL{service2URL, service3URL, service4URL}, nil)
+		require.NoError(t, err)
+		require.Len(t, inboxes, 2)
+		require.True(t, contains(inboxes, sharedInboxURL))
+		require.True(t, contains(inboxes, testutil.NewMockID(service4URL, resthandler.InboxPath)))
+	})
 }
 
 type testHandler struct {
@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimiter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+)
+
+var logger = log.New("rate_limiter")
+
+const purgeInterval = time.Minute
+
+// Limit specifies the rate at which requests are allowed.
+type Limit struct {
+	// Rate is the number of requests allowed per second.
+	Rate float64
+
+	// Burst is the maximum number of requests that may be made at once. If not set
+	// then the burst defaults to the rate (with a minimum of one).
+	Burst int
+}
+
+// Enabled returns true if the limit has a rate.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0
+}
+
+// Config holds the configuration parameters for the rate limiter.
+type Config struct {
+	// ActorLimit is applied to all activities posted by an actor.
+	ActorLimit Limit
+
+	// ActivityTypeLimits contains limits, keyed by activity type, that are applied to
+	// activities of the given type posted by an actor.
+	ActivityTypeLimits map[string]Limit
+}
+
+type metricsProvider interface {
+	InboxIncrementRateLimitedCount(activityType string)
+}
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// Limiter limits the rate at which actors may post activities to the inbox. Limits are
+// implemented using token buckets, one per actor and one per actor and activity type.
+type Limiter struct {
+	Config
+
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	metrics   metricsProvider
+	now       func() time.Time
+	lastPurge time.Time
+}
+
+// New returns a new rate limiter.
+func New(cfg Config, metrics metricsProvider) *Limiter {
+	cfg.ActorLimit = withDefaultBurst(cfg.ActorLimit)
+
+	typeLimits := make(map[string]Limit)
+
+	for activityType, limit := range cfg.ActivityTypeLimits {
+		typeLimits[activityType] = withDefaultBurst(limit)
+	}
+
+	cfg.ActivityTypeLimits = typeLimits
+
+	return &Limiter{
+		Config:  cfg,
+		buckets: make(map[string]*bucket),
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+// Allow returns true if the given actor may post an activity of the given type. If false is
+// returned then the returned duration indicates how long the actor should wait before retrying.
+func (l *Limiter) Allow(actor, activityType string) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+
+	l.purgeIfRequired(now)
+
+	var buckets []*bucket
+
+	if l.ActorLimit.Enabled() {
+		buckets = append(buckets, l.get(actor, l.ActorLimit, now))
+	}
+
+	if limit, ok := l.ActivityTypeLimits[activityType]; ok && limit.Enabled() {
+		buckets = append(buckets, l.get(actor+"|"+activityType, limit, now))
+	}
+
+	var retryAfter time.Duration
+
+	for _, b := range buckets {
+		if wait := b.wait(); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		logger.Infof("Rate limit exceeded for actor [%s] and activity type [%s]. Retry after %s",
+			actor, activityType, retryAfter)
+
+		l.metrics.InboxIncrementRateLimitedCount(activityType)
+
+		return false, retryAfter
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	return true, 0
+}
+
+func (l *Limiter) get(key string, limit Limit, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+
+		return b
+	}
+
+	b.refill(now)
+
+	return b
+}
+
+// purgeIfRequired removes the buckets that have been completely refilled since they hold no state.
+func (l *Limiter) purgeIfRequired(now time.Time) {
+	if now.Sub(l.lastPurge) < purgeInterval {
+		return
+	}
+
+	l.lastPurge = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+
+	logger.Debugf("Purged rate limiter buckets. Remaining: %d", len(l.buckets))
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	b.last = now
+}
+
+// wait returns the time until a token is available, or zero if a token is available now.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+func withDefaultBurst(limit Limit) Limit {
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Max(1, math.Ceil(limit.Rate)))
+	}
+
+	return limit
+}
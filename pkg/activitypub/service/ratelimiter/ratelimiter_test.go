@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/mocks"
+)
+
+const (
+	actor1 = "https://orb.domain1.com/services/orb"
+	actor2 = "https://orb.domain2.com/services/orb"
+
+	typeCreate   = "Create"
+	typeAnnounce = "Announce"
+)
+
+func TestNew(t *testing.T) {
+	l := New(Config{
+		ActorLimit: Limit{Rate: 2.5},
+		ActivityTypeLimits: map[string]Limit{
+			typeCreate: {Rate: 0.5},
+		},
+	}, &mocks.MetricsProvider{})
+	require.NotNil(t, l)
+	require.Equal(t, 3, l.ActorLimit.Burst)
+	require.Equal(t, 1, l.ActivityTypeLimits[typeCreate].Burst)
+}
+
+func TestLimiter_ActorLimit(t *testing.T) {
+	now := time.Now()
+
+	l := New(Config{ActorLimit: Limit{Rate: 1, Burst: 2}}, &mocks.MetricsProvider{})
+	l.now = func() time.Time { return now }
+
+	allowed, _ := l.Allow(actor1, typeCreate)
+	require.True(t, allowed)
+
+	allowed, _ = l.Allow(actor1, typeAnnounce)
+	require.True(t, allowed)
+
+	allowed, retryAfter := l.Allow(actor1, typeCreate)
+	require.False(t, allowed, "burst should be exhausted")
+	require.Equal(t, time.Second, retryAfter)
+
+	allowed, _ = l.Allow(actor2, typeCreate)
+	require.True(t, allowed, "limits should be per actor")
+
+	now = now.Add(500 * time.Millisecond)
+
+	allowed, retryAfter = l.Allow(actor1, typeCreate)
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+
+	allowed, _ = l.Allow(actor1, typeCreate)
+	require.True(t, allowed, "a token should have been refilled")
+}
+
+func TestLimiter_ActivityTypeLimit(t *testing.T) {
+	now := time.Now()
+
+	l := New(Config{
+		ActorLimit: Limit{Rate: 10, Burst: 10},
+		ActivityTypeLimits: map[string]Limit{
+			typeCreate: {Rate: 0.1, Burst: 1},
+		},
+	}, &mocks.MetricsProvider{})
+	l.now = func() time.Time { return now }
+
+	allowed, _ := l.Allow(actor1, typeCreate)
+	require.True(t, allowed)
+
+	allowed, retryAfter := l.Allow(actor1, typeCreate)
+	require.False(t, allowed)
+	require.Equal(t, 10*time.Second, retryAfter)
+
+	allowed, _ = l.Allow(actor1, typeAnnounce)
+	require.True(t, allowed, "activity type limit should only apply to the given type")
+
+	allowed, _ = l.Allow(actor2, typeCreate)
+	require.True(t, allowed, "activity type limit should be per actor")
+
+	// The rejected requests must not consume tokens from the actor bucket.
+	require.InDelta(t, 8, l.buckets[actor1].tokens, 0.001)
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := New(Config{}, &mocks.MetricsProvider{})
+
+	for i := 0; i < 100; i++ {
+		allowed, _ := l.Allow(actor1, typeCreate)
+		require.True(t, allowed)
+	}
+
+	require.Empty(t, l.buckets)
+}
+
+func TestLimiter_Purge(t *testing.T) {
+	now := time.Now()
+
+	l := New(Config{ActorLimit: Limit{Rate: 1, Burst: 5}}, &mocks.MetricsProvider{})
+	l.now = func() time.Time { return now }
+
+	allowed, _ := l.Allow(actor1, typeCreate)
+	require.True(t, allowed)
+	require.Len(t, l.buckets, 1)
+
+	now = now.Add(purgeInterval)
+
+	allowed, _ = l.Allow(actor2, typeCreate)
+	require.True(t, allowed)
+	require.Len(t, l.buckets, 1, "bucket for actor1 should have been purged since it's full")
+
+	_, ok := l.buckets[actor2]
+	require.True(t, ok)
+}
@@ -8,12 +8,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/trustbloc/edge-core/pkg/log"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 
 	"github.com/trustbloc/orb/pkg/activitypub/client"
@@ -29,6 +31,8 @@ import (
 	"github.com/trustbloc/orb/pkg/pubsub/redelivery"
 )
 
+var logger = log.New("activitypub_service")
+
 const (
 	inboxActivitiesTopic  = "orb.activity.inbox"
 	outboxActivitiesTopic = "orb.activity.outbox"
@@ -54,6 +58,10 @@ type Config struct {
 
 	IRICacheSize       int
 	IRICacheExpiration time.Duration
+
+	// PublicKey is the current public key of the service. If the key differs from the key in the
+	// previously stored actor then an 'Update' activity is posted so that other servers learn about the new key.
+	PublicKey *vocab.PublicKeyType
 }
 
 // Service implements an ActivityPub service which has an inbox, outbox, and
@@ -64,6 +72,9 @@ type Service struct {
 	inbox           *inbox.Inbox
 	outbox          *outbox.Outbox
 	activityHandler *activityhandler.Inbox
+	activityStore   store.Store
+	serviceIRI      *url.URL
+	publicKey       *vocab.PublicKeyType
 }
 
 type httpTransport interface {
@@ -79,6 +90,7 @@ type activityPubClient interface {
 	GetActor(iri *url.URL) (*vocab.ActorType, error)
 	GetReferences(iri *url.URL) (client.ReferenceIterator, error)
 	GetActivities(iri *url.URL, order client.Order) (client.ActivityIterator, error)
+	ClearActor(iri *url.URL)
 }
 
 type resourceResolver interface {
@@ -107,7 +119,7 @@ func New(cfg *Config, activityStore store.Store, t httpTransport, sigVerifier si
 			BufferSize:  cfg.ActivityHandlerBufferSize,
 			ServiceIRI:  cfg.ServiceIRI,
 		},
-		activityStore, activityPubClient)
+		activityStore, activityPubClient, handlerOpts...)
 
 	ob, err := outbox.New(
 		&outbox.Config{
@@ -137,12 +149,13 @@ func New(cfg *Config, activityStore store.Store, t httpTransport, sigVerifier si
 	ib, err := inbox.New(
 		&inbox.Config{
 			ServiceEndpoint:        cfg.ServiceEndpoint + resthandler.InboxPath,
+			SharedInboxEndpoint:    cfg.ServiceEndpoint + resthandler.SharedInboxPath,
 			ServiceIRI:             cfg.ServiceIRI,
 			Topic:                  inboxActivitiesTopic,
 			VerifyActorInSignature: cfg.VerifyActorInSignature,
 		},
 		activityStore, pubSub,
-		inboxHandler, sigVerifier, tm, m,
+		inboxHandler, sigVerifier, tm, m, handlerOpts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create inbox failed: %w", err)
@@ -152,6 +165,9 @@ func New(cfg *Config, activityStore store.Store, t httpTransport, sigVerifier si
 		inbox:           ib,
 		outbox:          ob,
 		activityHandler: inboxHandler,
+		activityStore:   activityStore,
+		serviceIRI:      cfg.ServiceIRI,
+		publicKey:       cfg.PublicKey,
 	}
 
 	s.Lifecycle = lifecycle.New(cfg.ServiceEndpoint,
@@ -166,6 +182,10 @@ func (s *Service) start() {
 	s.activityHandler.Start()
 	s.outbox.Start()
 	s.inbox.Start()
+
+	if s.publicKey != nil {
+		go s.publishActorUpdate()
+	}
 }
 
 func (s *Service) stop() {
@@ -174,6 +194,69 @@ func (s *Service) stop() {
 	s.activityHandler.Stop()
 }
 
+// publishActorUpdate compares the current service actor with the actor that was stored on the previous
+// startup. If the public key has changed then an 'Update' activity is posted to all servers that we
+// have a relationship with so that they replace any cached copy of our actor.
+func (s *Service) publishActorUpdate() {
+	actor, err := resthandler.NewServiceActor(s.serviceIRI, s.publicKey)
+	if err != nil {
+		logger.Errorf("Unable to create service actor [%s]: %s", s.serviceIRI, err)
+
+		return
+	}
+
+	existing, err := s.activityStore.GetActor(s.serviceIRI)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			logger.Errorf("Unable to retrieve service actor [%s]: %s", s.serviceIRI, err)
+
+			return
+		}
+
+		logger.Debugf("Storing service actor [%s]", s.serviceIRI)
+
+		if err := s.activityStore.PutActor(actor); err != nil {
+			logger.Errorf("Unable to store service actor [%s]: %s", s.serviceIRI, err)
+		}
+
+		return
+	}
+
+	if samePublicKey(existing.PublicKey(), s.publicKey) {
+		logger.Debugf("Public key for service actor [%s] has not changed", s.serviceIRI)
+
+		return
+	}
+
+	logger.Infof("Public key for service actor [%s] has changed to [%s]. Posting 'Update' activity.",
+		s.serviceIRI, s.publicKey.ID)
+
+	published := time.Now()
+
+	update := vocab.NewUpdateActivity(
+		vocab.NewObjectProperty(vocab.WithActorObject(actor)),
+		vocab.WithTo(actor.Followers(), actor.Following(), actor.Witnesses(), actor.Witnessing(), vocab.PublicIRI),
+		vocab.WithPublishedTime(&published),
+	)
+
+	activityID, err := s.outbox.Post(update)
+	if err != nil {
+		logger.Errorf("Unable to post 'Update' activity for service actor [%s]: %s", s.serviceIRI, err)
+
+		return
+	}
+
+	logger.Debugf("Posted 'Update' activity [%s] for service actor [%s]", activityID, s.serviceIRI)
+}
+
+func samePublicKey(k1, k2 *vocab.PublicKeyType) bool {
+	if k1 == nil || k2 == nil {
+		return k1 == k2
+	}
+
+	return k1.ID.String() == k2.ID.String() && k1.PublicKeyPem == k2.PublicKeyPem
+}
+
 // Outbox returns the outbox, which allows clients to post activities.
 func (s *Service) Outbox() spi.Outbox {
 	return s.outbox
@@ -190,6 +273,12 @@ func (s *Service) InboxHTTPHandler() common.HTTPHandler {
 	return s.inbox.HTTPHandler()
 }
 
+// SharedInboxHTTPHandler returns the HTTP handler for the shared inbox which is invoked by the HTTP server.
+// This handler must be registered with an HTTP server.
+func (s *Service) SharedInboxHTTPHandler() common.HTTPHandler {
+	return s.inbox.SharedInboxHTTPHandler()
+}
+
 // Subscribe allows a client to receive published activities.
 func (s *Service) Subscribe() <-chan *vocab.ActivityType {
 	return s.activityHandler.Subscribe()
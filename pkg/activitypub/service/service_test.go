@@ -77,6 +77,98 @@ func TestNewService(t *testing.T) {
 	require.Equal(t, lifecycle.StateStopped, service1.State())
 }
 
+func TestService_ActorUpdate(t *testing.T) {
+	serviceIRI := testutil.MustParseURL("http://localhost:8312/services/service1")
+
+	newKey := vocab.NewPublicKey(
+		vocab.WithID(testutil.NewMockID(serviceIRI, "/keys/main-key")),
+		vocab.WithOwner(serviceIRI),
+		vocab.WithPublicKeyPem("-----BEGIN PUBLIC KEY-----\nnew....."),
+	)
+
+	newService := func(t *testing.T, activityStore spi.Store) *Service {
+		t.Helper()
+
+		cfg := &Config{
+			ServiceEndpoint: "/services/service1",
+			ServiceIRI:      serviceIRI,
+			PublicKey:       newKey,
+		}
+
+		s, err := New(cfg, activityStore, transport.Default(), &mocks.SignatureVerifier{}, mocks.NewPubSub(),
+			mocks.NewActivitPubClient(), &mocks.WebFingerResolver{}, &apmocks.AuthTokenMgr{},
+			&orbmocks.MetricsProvider{})
+		require.NoError(t, err)
+
+		return s
+	}
+
+	queryUpdates := func(t *testing.T, activityStore spi.Store) []*vocab.ActivityType {
+		t.Helper()
+
+		it, err := activityStore.QueryActivities(spi.NewCriteria(spi.WithType(vocab.TypeUpdate)))
+		require.NoError(t, err)
+
+		activities, err := storeutil.ReadActivities(it, -1)
+		require.NoError(t, err)
+
+		return activities
+	}
+
+	t.Run("First startup -> actor stored", func(t *testing.T) {
+		activityStore := memstore.New("service1")
+
+		s := newService(t, activityStore)
+
+		s.Start()
+		defer s.Stop()
+
+		time.Sleep(100 * time.Millisecond)
+
+		actor, err := activityStore.GetActor(serviceIRI)
+		require.NoError(t, err)
+		require.Equal(t, newKey.PublicKeyPem, actor.PublicKey().PublicKeyPem)
+		require.Empty(t, queryUpdates(t, activityStore))
+	})
+
+	t.Run("Key unchanged -> no update", func(t *testing.T) {
+		activityStore := memstore.New("service1")
+		require.NoError(t, activityStore.PutActor(aptestutil.NewMockService(serviceIRI, aptestutil.WithPublicKey(newKey))))
+
+		s := newService(t, activityStore)
+
+		s.Start()
+		defer s.Stop()
+
+		time.Sleep(100 * time.Millisecond)
+
+		require.Empty(t, queryUpdates(t, activityStore))
+	})
+
+	t.Run("Key changed -> update posted", func(t *testing.T) {
+		activityStore := memstore.New("service1")
+		require.NoError(t, activityStore.PutActor(aptestutil.NewMockService(serviceIRI)))
+
+		s := newService(t, activityStore)
+
+		s.Start()
+		defer s.Stop()
+
+		time.Sleep(100 * time.Millisecond)
+
+		updates := queryUpdates(t, activityStore)
+		require.Len(t, updates, 1)
+
+		actor := updates[0].Object().Actor()
+		require.NotNil(t, actor)
+		require.Equal(t, newKey.PublicKeyPem, actor.PublicKey().PublicKeyPem)
+
+		storedActor, err := activityStore.GetActor(serviceIRI)
+		require.NoError(t, err)
+		require.Equal(t, newKey.PublicKeyPem, storedActor.PublicKey().PublicKeyPem)
+	})
+}
+
 func TestService_Create(t *testing.T) {
 	log.SetLevel(wmlogger.Module, log.WARNING)
 
@@ -982,10 +1074,16 @@ func newServiceWithMocks(t *testing.T, endpoint string,
 	serverAuthTokenMgr := &apmocks.AuthTokenMgr{}
 	serverAuthTokenMgr.RequiredAuthTokensReturns([]string{"admin"}, nil)
 
+	getSigner, err := httpsig.NewSigner(httpsig.DefaultGetSignerConfig(), cr, km, kmsKey1)
+	require.NoError(t, err)
+
+	postSigner, err := httpsig.NewSigner(httpsig.DefaultPostSignerConfig(), cr, km, kmsKey1)
+	require.NoError(t, err)
+
 	trnspt := transport.New(http.DefaultClient,
 		publicKey.ID.URL(),
-		httpsig.NewSigner(httpsig.DefaultGetSignerConfig(), cr, km, kmsKey1),
-		httpsig.NewSigner(httpsig.DefaultPostSignerConfig(), cr, km, kmsKey1),
+		getSigner,
+		postSigner,
 		clientAuthTokenMgr,
 	)
 
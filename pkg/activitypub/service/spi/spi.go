@@ -31,6 +31,9 @@ type Outbox interface {
 
 	// Post posts an activity to the outbox and returns the ID of the activity.
 	Post(activity *vocab.ActivityType, exclude ...*url.URL) (*url.URL, error)
+
+	// Deliver delivers a previously posted activity to the given inbox.
+	Deliver(activity *vocab.ActivityType, inboxIRI *url.URL) error
 }
 
 // Inbox defines the functions for an ActivityPub inbox.
@@ -56,6 +59,43 @@ type ActorAuth interface {
 	AuthorizeActor(actor *vocab.ActorType) (bool, error)
 }
 
+// BlockList maintains the list of actors that are blocked by this service. Activities from blocked
+// actors are dropped.
+type BlockList interface {
+	IsBlocked(actorIRI *url.URL) (bool, error)
+	Block(actorIRI *url.URL) error
+}
+
+// MoveRegistry records actors that have moved to a new IRI (for example, after a server domain migration)
+// so that references to the old location may still be resolved.
+type MoveRegistry interface {
+	Add(oldIRI, newIRI *url.URL) error
+}
+
+// CircuitBreaker tracks the health of destination hosts so that deliveries to a failing host may be suspended.
+type CircuitBreaker interface {
+	Allow(host string) error
+	Success(host string)
+	Failure(host string)
+}
+
+// RateLimiter limits the rate at which actors may post activities to the inbox.
+type RateLimiter interface {
+	// Allow returns true if the actor may post an activity of the given type. If false is returned then
+	// the returned duration indicates how long the actor should wait before retrying.
+	Allow(actor, activityType string) (bool, time.Duration)
+}
+
+// ActivitySigner adds a linked-data proof to an activity.
+type ActivitySigner interface {
+	Sign(activity *vocab.ActivityType) (*vocab.ActivityType, error)
+}
+
+// ActivityVerifier verifies the linked-data proofs embedded in an activity.
+type ActivityVerifier interface {
+	Verify(activity *vocab.ActivityType) error
+}
+
 // WitnessHandler is a handler that witnesses an anchor credential.
 type WitnessHandler interface {
 	Witness(anchorCred []byte) ([]byte, error)
@@ -101,6 +141,12 @@ type Handlers struct {
 	Witness               WitnessHandler
 	ProofHandler          ProofHandler
 	AnchorEventAckHandler AnchorEventAcknowledgementHandler
+	BlockList             BlockList
+	MoveRegistry          MoveRegistry
+	CircuitBreaker        CircuitBreaker
+	ActivitySigner        ActivitySigner
+	ActivityVerifier      ActivityVerifier
+	RateLimiter           RateLimiter
 }
 
 // HandlerOpt sets a specific handler.
@@ -156,6 +202,49 @@ func WithAnchorEventAcknowledgementHandler(handler AnchorEventAcknowledgementHan
 	}
 }
 
+// WithBlockList sets the list of blocked actors.
+func WithBlockList(blockList BlockList) HandlerOpt {
+	return func(options *Handlers) {
+		options.BlockList = blockList
+	}
+}
+
+// WithMoveRegistry sets the registry of actors that have moved to a new IRI.
+func WithMoveRegistry(registry MoveRegistry) HandlerOpt {
+	return func(options *Handlers) {
+		options.MoveRegistry = registry
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker that's used by the outbox when delivering activities.
+func WithCircuitBreaker(cb CircuitBreaker) HandlerOpt {
+	return func(options *Handlers) {
+		options.CircuitBreaker = cb
+	}
+}
+
+// WithActivitySigner sets the signer that's used by the outbox to add a linked-data proof to each posted activity.
+func WithActivitySigner(signer ActivitySigner) HandlerOpt {
+	return func(options *Handlers) {
+		options.ActivitySigner = signer
+	}
+}
+
+// WithActivityVerifier sets the verifier that's used by the inbox to verify the linked-data proofs
+// embedded in announced activities.
+func WithActivityVerifier(verifier ActivityVerifier) HandlerOpt {
+	return func(options *Handlers) {
+		options.ActivityVerifier = verifier
+	}
+}
+
+// WithRateLimiter sets the rate limiter that's used by the inbox to limit the rate of incoming activities.
+func WithRateLimiter(rl RateLimiter) HandlerOpt {
+	return func(options *Handlers) {
+		options.RateLimiter = rl
+	}
+}
+
 // AcceptList contains the URIs that are to be accepted by an authorization handler
 // for the given type. Known types are "follow" and "invite-witness".
 type AcceptList struct {
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+const storeName = "activity-archive"
+
+var logger = log.New("activity-archive")
+
+type casWriter interface {
+	Write(content []byte) (string, error)
+}
+
+// CAS archives activities to content-addressable storage. The CAS address of each archived activity is
+// saved (by activity ID) so that the activity may be retrieved after it's pruned from the ActivityPub store.
+type CAS struct {
+	cas   casWriter
+	store storage.Store
+}
+
+// NewCAS returns a new CAS archiver.
+func NewCAS(cas casWriter, provider storage.Provider) (*CAS, error) {
+	s, err := provider.OpenStore(storeName)
+	if err != nil {
+		return nil, fmt.Errorf("open store [%s]: %w", storeName, err)
+	}
+
+	return &CAS{
+		cas:   cas,
+		store: s,
+	}, nil
+}
+
+// Archive writes each of the given activities to CAS.
+func (a *CAS) Archive(activities ...*vocab.ActivityType) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	operations := make([]storage.Operation, len(activities))
+
+	for i, activity := range activities {
+		activityBytes, err := json.Marshal(activity)
+		if err != nil {
+			return fmt.Errorf("marshal activity [%s]: %w", activity.ID(), err)
+		}
+
+		cid, err := a.cas.Write(activityBytes)
+		if err != nil {
+			return orberrors.NewTransient(fmt.Errorf("write activity [%s] to CAS: %w", activity.ID(), err))
+		}
+
+		logger.Debugf("Archived activity [%s] to CAS: %s", activity.ID(), cid)
+
+		operations[i] = storage.Operation{Key: activity.ID().String(), Value: []byte(cid)}
+	}
+
+	if err := a.store.Batch(operations); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store archived activity addresses: %w", err))
+	}
+
+	return nil
+}
+
+// Get returns the CAS address of the archived activity with the given ID. An spi.ErrNotFound error
+// is returned if the activity wasn't archived.
+func (a *CAS) Get(activityID *url.URL) (string, error) {
+	cid, err := a.store.Get(activityID.String())
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return "", spi.ErrNotFound
+		}
+
+		return "", orberrors.NewTransient(fmt.Errorf("get archived activity [%s]: %w", activityID, err))
+	}
+
+	return string(cid), nil
+}
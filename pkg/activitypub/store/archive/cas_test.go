@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/cas/resolver/mocks"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+func TestCAS_Archive(t *testing.T) {
+	activityID1 := testutil.MustParseURL("https://example.com/activities/activity1")
+	activityID2 := testutil.MustParseURL("https://example.com/activities/activity2")
+
+	object := vocab.NewObjectProperty(vocab.WithIRI(testutil.MustParseURL("https://example.com/objects/object1")))
+
+	activity1 := vocab.NewCreateActivity(object, vocab.WithID(activityID1))
+	activity2 := vocab.NewAnnounceActivity(object, vocab.WithID(activityID2))
+
+	t.Run("Success", func(t *testing.T) {
+		casClient := &mocks.CASClient{}
+		casClient.WriteReturnsOnCall(0, "cid1", nil)
+		casClient.WriteReturnsOnCall(1, "cid2", nil)
+
+		a, err := NewCAS(casClient, mem.NewProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, a.Archive())
+		require.NoError(t, a.Archive(activity1, activity2))
+		require.Equal(t, 2, casClient.WriteCallCount())
+
+		cid, err := a.Get(activityID1)
+		require.NoError(t, err)
+		require.Equal(t, "cid1", cid)
+
+		cid, err = a.Get(activityID2)
+		require.NoError(t, err)
+		require.Equal(t, "cid2", cid)
+
+		_, err = a.Get(testutil.MustParseURL("https://example.com/activities/activity3"))
+		require.ErrorIs(t, err, spi.ErrNotFound)
+	})
+
+	t.Run("Open store error", func(t *testing.T) {
+		errExpected := errors.New("injected open store error")
+
+		_, err := NewCAS(&mocks.CASClient{}, &mock.Provider{ErrOpenStore: errExpected})
+		require.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("CAS write error", func(t *testing.T) {
+		errExpected := errors.New("injected write error")
+
+		casClient := &mocks.CASClient{}
+		casClient.WriteReturns("", errExpected)
+
+		a, err := NewCAS(casClient, mem.NewProvider())
+		require.NoError(t, err)
+
+		err = a.Archive(activity1)
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		casClient := &mocks.CASClient{}
+		casClient.WriteReturns("cid1", nil)
+
+		a, err := NewCAS(casClient, &mock.Provider{
+			OpenStoreReturn: &mock.Store{ErrBatch: errExpected, ErrGet: errExpected},
+		})
+		require.NoError(t, err)
+
+		err = a.Archive(activity1)
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+
+		_, err = a.Get(activityID1)
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+}
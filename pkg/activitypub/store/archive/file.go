@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+)
+
+const (
+	dirPermissions  = 0o700
+	filePermissions = 0o600
+
+	fileNameFormat = "activities-%s.jsonl.gz"
+	dateFormat     = "2006-01-02"
+)
+
+// File archives activities to compressed export files in a directory. A new file is started each day
+// (in UTC) and holds one JSON-encoded activity per line. Each call to Archive appends a gzip member to the
+// file so the file may be read with any gzip reader (for example, 'zcat').
+type File struct {
+	dir   string
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+// NewFile returns a new file archiver that writes to the given directory. The directory is created
+// if it doesn't exist.
+func NewFile(dir string) (*File, error) {
+	if dir == "" {
+		return nil, errors.New("archive directory is required")
+	}
+
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return nil, fmt.Errorf("create archive directory [%s]: %w", dir, err)
+	}
+
+	return &File{
+		dir: dir,
+		now: time.Now,
+	}, nil
+}
+
+// Archive appends the given activities to the current export file.
+func (a *File) Archive(activities ...*vocab.ActivityType) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	fileName := filepath.Join(a.dir, fmt.Sprintf(fileNameFormat, a.now().UTC().Format(dateFormat)))
+
+	f, err := os.OpenFile(filepath.Clean(fileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+	if err != nil {
+		return fmt.Errorf("open archive file [%s]: %w", fileName, err)
+	}
+
+	defer func() {
+		if errClose := f.Close(); errClose != nil {
+			logger.Warnf("Error closing archive file [%s]: %s", fileName, errClose)
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+
+	encoder := json.NewEncoder(zw)
+
+	for _, activity := range activities {
+		if err := encoder.Encode(activity); err != nil {
+			return fmt.Errorf("write activity [%s] to archive file [%s]: %w", activity.ID(), fileName, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("flush archive file [%s]: %w", fileName, err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync archive file [%s]: %w", fileName, err)
+	}
+
+	logger.Debugf("Archived %d activities to file [%s]", len(activities), fileName)
+
+	return nil
+}
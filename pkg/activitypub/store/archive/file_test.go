@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+func TestFile_Archive(t *testing.T) {
+	activityID1 := testutil.MustParseURL("https://example.com/activities/activity1")
+	activityID2 := testutil.MustParseURL("https://example.com/activities/activity2")
+	activityID3 := testutil.MustParseURL("https://example.com/activities/activity3")
+
+	object := vocab.NewObjectProperty(vocab.WithIRI(testutil.MustParseURL("https://example.com/objects/object1")))
+
+	activity1 := vocab.NewCreateActivity(object, vocab.WithID(activityID1))
+	activity2 := vocab.NewAnnounceActivity(object, vocab.WithID(activityID2))
+	activity3 := vocab.NewLikeActivity(object, vocab.WithID(activityID3))
+
+	t.Run("Success", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "archive")
+
+		a, err := NewFile(dir)
+		require.NoError(t, err)
+
+		a.now = func() time.Time { return time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC) }
+
+		require.NoError(t, a.Archive())
+		require.NoError(t, a.Archive(activity1, activity2))
+		require.NoError(t, a.Archive(activity3))
+
+		activities := readArchive(t, filepath.Join(dir, "activities-2021-12-01.jsonl.gz"))
+		require.Len(t, activities, 3)
+		require.Equal(t, activityID1.String(), activities[0].ID().String())
+		require.Equal(t, activityID2.String(), activities[1].ID().String())
+		require.Equal(t, activityID3.String(), activities[2].ID().String())
+
+		a.now = func() time.Time { return time.Date(2021, 12, 2, 10, 0, 0, 0, time.UTC) }
+
+		require.NoError(t, a.Archive(activity1))
+
+		activities = readArchive(t, filepath.Join(dir, "activities-2021-12-02.jsonl.gz"))
+		require.Len(t, activities, 1)
+	})
+
+	t.Run("No directory", func(t *testing.T) {
+		_, err := NewFile("")
+		require.EqualError(t, err, "archive directory is required")
+	})
+
+	t.Run("Open file error", func(t *testing.T) {
+		dir := t.TempDir()
+
+		a, err := NewFile(dir)
+		require.NoError(t, err)
+
+		require.NoError(t, os.RemoveAll(dir))
+
+		err = a.Archive(activity1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open archive file")
+	})
+}
+
+func readArchive(t *testing.T, fileName string) []*vocab.ActivityType {
+	t.Helper()
+
+	f, err := os.Open(filepath.Clean(fileName))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var activities []*vocab.ActivityType
+
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		activity := &vocab.ActivityType{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), activity))
+
+		activities = append(activities, activity)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return activities
+}
@@ -44,25 +44,39 @@ type Provider struct {
 	referenceStore          ariesstorage.Store
 	actorStore              ariesstorage.Store
 	multipleTagQueryCapable bool
+	retention               *RetentionConfig
 }
 
 // New returns a new ActivityPub storage provider.
 // If multipleTagQueryCapable is set to true, then reference queries can be done using both the object IRI and activity
 // type tags at the same time. NodeInfo uses this to optimize memory usage. Right now only the MongoDB provider
 // supports this setting.
-func New(serviceName string, provider ariesstorage.Provider, multipleTagQueryCapable bool) (*Provider, error) {
+func New(serviceName string, provider ariesstorage.Provider, multipleTagQueryCapable bool,
+	opts ...Option) (*Provider, error) {
 	stores, err := openStores(provider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open stores: %w", err)
 	}
 
-	return &Provider{
+	p := &Provider{
 		serviceName:             serviceName,
 		activityStore:           stores.activities,
 		referenceStore:          stores.reference,
 		actorStore:              stores.actor,
 		multipleTagQueryCapable: multipleTagQueryCapable,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.retention != nil {
+		if err := p.enableRetention(); err != nil {
+			return nil, fmt.Errorf("enable retention: %w", err)
+		}
+	}
+
+	return p, nil
 }
 
 // PutActor stores the given actor.
@@ -167,18 +181,23 @@ func (s *Provider) QueryActivities(query *spi.Criteria, opts ...spi.QueryOpt) (s
 	}
 
 	if len(query.ActivityIRIs) == 0 && len(query.Types) == 0 { // Get all activities
-		iterator, err := s.activityStore.Query(activityTag,
+		start, err := decodeCursor(options.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		iterator, err := s.activityStore.Query(activityTag+start.expression(options.SortOrder),
 			ariesstorage.WithSortOrder(&ariesstorage.SortOptions{
 				Order:   ariesstorage.SortOrder(options.SortOrder),
 				TagName: timeAddedTagName,
 			}),
 			ariesstorage.WithPageSize(options.PageSize),
-			ariesstorage.WithInitialPageNum(options.PageNumber))
+			ariesstorage.WithInitialPageNum(getInitialPageNum(options)))
 		if err != nil {
 			return nil, orberrors.NewTransient(fmt.Errorf("failed to query store: %w", err))
 		}
 
-		return &activityIterator{ariesIterator: iterator}, nil
+		return &activityIterator{ariesIterator: newCursorIterator(iterator, start, options.SortOrder)}, nil
 	}
 
 	return nil, errors.New("unsupported query criteria")
@@ -196,6 +215,7 @@ func (s *Provider) AddReference(referenceType spi.ReferenceType, objectIRI *url.
 	}
 
 	tags := determineTags(referenceType, objectIRI, refMetaDataOpts)
+	tags = append(tags, s.retentionTags(referenceType, referenceIRI, refMetaDataOpts)...)
 
 	err = s.referenceStore.Put(getRefKey(referenceType, objectIRI, referenceIRI), valueBytes, tags...)
 	if err != nil {
@@ -236,20 +256,25 @@ func (s *Provider) QueryReferences(referenceType spi.ReferenceType, query *spi.C
 			return nil, err
 		}
 
+		start, err := decodeCursor(options.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
 		iterator, errQuery := s.referenceStore.Query(
-			queryExpression,
+			queryExpression+start.expression(options.SortOrder),
 			ariesstorage.WithSortOrder(&ariesstorage.SortOptions{
 				Order:   ariesstorage.SortOrder(options.SortOrder),
 				TagName: timeAddedTagName,
 			}),
 			ariesstorage.WithPageSize(options.PageSize),
-			ariesstorage.WithInitialPageNum(options.PageNumber),
+			ariesstorage.WithInitialPageNum(getInitialPageNum(options)),
 		)
 		if errQuery != nil {
 			return nil, orberrors.NewTransient(fmt.Errorf("failed to query store: %w", errQuery))
 		}
 
-		return &referenceIterator{ariesIterator: iterator}, nil
+		return &referenceIterator{ariesIterator: newCursorIterator(iterator, start, options.SortOrder)}, nil
 	}
 
 	// Otherwise, if there is a reference IRI,
@@ -287,7 +312,7 @@ func (s *Provider) queryActivitiesByRef(refType spi.ReferenceType, query *spi.Cr
 
 	options := storeutil.GetQueryOptions(opts...)
 
-	refs, err := storeutil.ReadReferences(iterator, options.PageSize)
+	refs, refCursors, err := storeutil.ReadReferencesWithCursors(iterator, options.PageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -317,7 +342,9 @@ func (s *Provider) queryActivitiesByRef(refType spi.ReferenceType, query *spi.Cr
 
 	var activities []*vocab.ActivityType
 
-	for _, activityBytes := range activitiesBytes {
+	var cursors []string
+
+	for i, activityBytes := range activitiesBytes {
 		if activityBytes != nil {
 			var activity vocab.ActivityType
 
@@ -327,14 +354,15 @@ func (s *Provider) queryActivitiesByRef(refType spi.ReferenceType, query *spi.Cr
 			}
 
 			activities = append(activities, &activity)
+			cursors = append(cursors, refCursors[i])
 		}
 	}
 
-	return memstore.NewActivityIterator(activities, totalItems), nil
+	return memstore.NewActivityIterator(activities, totalItems, cursors...), nil
 }
 
 type activityIterator struct {
-	ariesIterator ariesstorage.Iterator
+	ariesIterator *cursorIterator
 }
 
 func (a *activityIterator) TotalItems() (int, error) {
@@ -366,12 +394,16 @@ func (a *activityIterator) Next() (*vocab.ActivityType, error) {
 	return nil, spi.ErrNotFound
 }
 
+func (a *activityIterator) Cursor() string {
+	return a.ariesIterator.Cursor()
+}
+
 func (a *activityIterator) Close() error {
 	return a.ariesIterator.Close()
 }
 
 type referenceIterator struct {
-	ariesIterator ariesstorage.Iterator
+	ariesIterator *cursorIterator
 }
 
 func (r *referenceIterator) TotalItems() (int, error) {
@@ -408,6 +440,10 @@ func (r *referenceIterator) Next() (*url.URL, error) {
 	return nil, spi.ErrNotFound
 }
 
+func (r *referenceIterator) Cursor() string {
+	return r.ariesIterator.Cursor()
+}
+
 func (r *referenceIterator) Close() error {
 	return r.ariesIterator.Close()
 }
@@ -451,7 +487,10 @@ func openStores(provider ariesstorage.Provider) (stores, error) {
 
 func openReferenceStore(provider ariesstorage.Provider) (ariesstorage.Store, error) {
 	storeConfig := ariesstorage.StoreConfiguration{
-		TagNames: []string{refTypeTagName, objectIRITagName, timeAddedTagName, activityTypeTagName},
+		TagNames: []string{
+			refTypeTagName, objectIRITagName, timeAddedTagName, activityTypeTagName,
+			expiryTimeTagName, referenceIRITagName,
+		},
 	}
 
 	store, err := provider.OpenStore(storeName)
@@ -507,9 +546,27 @@ func (s *Provider) generateQueryExpression(referenceType spi.ReferenceType, quer
 		queryExpression += fmt.Sprintf("&&%s:%s", activityTypeTagName, query.Types[0])
 	}
 
+	if query.Since != nil {
+		queryExpression += fmt.Sprintf("&&%s>=%d", timeAddedTagName, query.Since.UnixNano())
+	}
+
+	if query.Until != nil {
+		queryExpression += fmt.Sprintf("&&%s<%d", timeAddedTagName, query.Until.UnixNano())
+	}
+
 	return queryExpression, nil
 }
 
+// getInitialPageNum returns the page number at which to start the query. A query with a cursor
+// always starts at the first page of the items following the cursor.
+func getInitialPageNum(options *spi.QueryOptions) int {
+	if options.Cursor != "" {
+		return 0
+	}
+
+	return options.PageNumber
+}
+
 func getRefKey(referenceType spi.ReferenceType, objectIRI, referenceIRI *url.URL) string {
 	return fmt.Sprintf("%s-%s-%s", strings.ToLower(string(referenceType)), objectIRI, referenceIRI)
 }
@@ -6,42 +6,310 @@ SPDX-License-Identifier: Apache-2.0
 package ariesstore
 
 import (
+	"encoding/base64"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	ariesstorage "github.com/hyperledger/aries-framework-go/spi/storage"
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
 )
 
 func TestIterators_FailureCases(t *testing.T) {
 	t.Run("Activity iterator", func(t *testing.T) {
-		iterator := activityIterator{ariesIterator: &mock.Iterator{ErrNext: errors.New("next error")}}
+		iterator := activityIterator{ariesIterator: newCursorIterator(
+			&mock.Iterator{ErrNext: errors.New("next error")}, nil, spi.SortAscending,
+		)}
 
 		activity, err := iterator.Next()
 		require.EqualError(t, err, "failed to determine if there are more results: next error")
 		require.Nil(t, activity)
 
-		iterator = activityIterator{ariesIterator: &mock.Iterator{
+		iterator = activityIterator{ariesIterator: newCursorIterator(&mock.Iterator{
 			NextReturn: true, ErrValue: errors.New("value error"),
-		}}
+		}, nil, spi.SortAscending)}
 
 		activity, err = iterator.Next()
-		require.EqualError(t, err, "failed to get value: value error")
+		require.EqualError(t, err,
+			"failed to determine if there are more results: failed to get value: value error")
 		require.Nil(t, activity)
 	})
 	t.Run("Reference iterator", func(t *testing.T) {
-		iterator := referenceIterator{ariesIterator: &mock.Iterator{ErrNext: errors.New("next error")}}
+		iterator := referenceIterator{ariesIterator: newCursorIterator(
+			&mock.Iterator{ErrNext: errors.New("next error")}, nil, spi.SortAscending,
+		)}
 
 		activity, err := iterator.Next()
 		require.EqualError(t, err, "failed to determine if there are more results: next error")
 		require.Nil(t, activity)
 
-		iterator = referenceIterator{ariesIterator: &mock.Iterator{
+		iterator = referenceIterator{ariesIterator: newCursorIterator(&mock.Iterator{
 			NextReturn: true, ErrValue: errors.New("value error"),
-		}}
+		}, nil, spi.SortAscending)}
 
 		activity, err = iterator.Next()
-		require.EqualError(t, err, "failed to get value: value error")
+		require.EqualError(t, err,
+			"failed to determine if there are more results: failed to get value: value error")
 		require.Nil(t, activity)
 	})
 }
+
+func TestCursorIterator(t *testing.T) {
+	// Items 2, 3, 4 and 5 were added at the same time.
+	entries := []*entry{
+		{key: "item1", timeAdded: 1000},
+		{key: "item2", timeAdded: 2000},
+		{key: "item3", timeAdded: 2000},
+		{key: "item4", timeAdded: 2000},
+		{key: "item5", timeAdded: 2000},
+		{key: "item6", timeAdded: 3000},
+	}
+
+	// readPage reads up to pageSize items from the given cursor and returns the keys along with
+	// the cursors of the first and last items.
+	readPage := func(t *testing.T, start *queryCursor, sortOrder spi.SortOrder,
+		pageSize int) ([]string, *queryCursor, *queryCursor) {
+		t.Helper()
+
+		it := newCursorIterator(newEntryIterator(entries, start, sortOrder), start, sortOrder)
+
+		var keys []string
+
+		var first, last *queryCursor
+
+		for len(keys) < pageSize {
+			ok, err := it.Next()
+			require.NoError(t, err)
+
+			if !ok {
+				break
+			}
+
+			key, err := it.Key()
+			require.NoError(t, err)
+
+			keys = append(keys, key)
+
+			c, err := decodeCursor(it.Cursor())
+			require.NoError(t, err)
+
+			if first == nil {
+				first = c
+			}
+
+			last = c
+		}
+
+		return keys, first, last
+	}
+
+	t.Run("Items with the same time are not skipped", func(t *testing.T) {
+		for _, sortOrder := range []spi.SortOrder{spi.SortAscending, spi.SortDescending} {
+			var keys []string
+
+			var start *queryCursor
+
+			// Read one item per query.
+			for {
+				page, _, last := readPage(t, start, sortOrder, 1)
+				if len(page) == 0 {
+					break
+				}
+
+				keys = append(keys, page...)
+				start = last
+			}
+
+			require.Len(t, keys, len(entries))
+
+			for _, e := range entries {
+				require.Contains(t, keys, e.key)
+			}
+		}
+	})
+
+	t.Run("Next and prev pages across items with the same time", func(t *testing.T) {
+		var pages [][]string
+
+		var lastCursors []*queryCursor
+
+		var start *queryCursor
+
+		// Page forward.
+		for {
+			page, _, last := readPage(t, start, spi.SortAscending, 2)
+			if len(page) == 0 {
+				break
+			}
+
+			pages = append(pages, page)
+			lastCursors = append(lastCursors, last)
+			start = last
+		}
+
+		require.Len(t, pages, 3)
+
+		var forward []string
+
+		for _, page := range pages {
+			forward = append(forward, page...)
+		}
+
+		require.Len(t, forward, len(entries))
+		require.Equal(t, "item1", forward[0])
+		require.Equal(t, "item6", forward[len(forward)-1])
+
+		// Page backward from the first item of the last page (which is the cursor of the previous page's last item).
+		// The previous pages must contain exactly the items that were seen before the cursor.
+		for i := len(pages) - 1; i > 0; i-- {
+			_, first, _ := readPage(t, lastCursors[i-1], spi.SortAscending, 1)
+
+			prev, _, _ := readPage(t, first, spi.SortDescending, 2)
+			require.Len(t, prev, 2)
+
+			require.Equal(t, pages[i-1][1], prev[0])
+			require.Equal(t, pages[i-1][0], prev[1])
+		}
+	})
+
+	t.Run("Total items", func(t *testing.T) {
+		_, _, start := readPage(t, nil, spi.SortAscending, 3)
+
+		it := newCursorIterator(newEntryIterator(entries, start, spi.SortAscending), start, spi.SortAscending)
+
+		total, err := it.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+
+		it = newCursorIterator(newEntryIterator(entries, start, spi.SortDescending), start, spi.SortDescending)
+
+		total, err = it.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+	})
+
+	t.Run("Expression", func(t *testing.T) {
+		var c *queryCursor
+
+		require.Empty(t, c.expression(spi.SortAscending))
+
+		c = &queryCursor{TimeAdded: 2000, KeyHash: hashKey("item2")}
+
+		require.Equal(t, "&&TimeAdded>=2000", c.expression(spi.SortAscending))
+		require.Equal(t, "&&TimeAdded<=2000", c.expression(spi.SortDescending))
+	})
+
+	t.Run("Cursor doesn't expose keys", func(t *testing.T) {
+		c1 := (&queryCursor{TimeAdded: 2000, KeyHash: hashKey("item2")}).encode()
+		c2 := (&queryCursor{TimeAdded: 2000, KeyHash: hashKey(strings.Repeat("item2", 100))}).encode()
+
+		require.Len(t, c2, len(c1))
+
+		cursorBytes, err := base64.RawURLEncoding.DecodeString(c1)
+		require.NoError(t, err)
+		require.NotContains(t, string(cursorBytes), "item2")
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		c, err := decodeCursor("")
+		require.NoError(t, err)
+		require.Nil(t, c)
+
+		_, err = decodeCursor("!!!")
+		require.True(t, orberrors.IsBadRequest(err))
+
+		_, err = decodeCursor((&queryCursor{TimeAdded: 2000}).encode())
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+
+	t.Run("Key error", func(t *testing.T) {
+		it := newCursorIterator(&mock.Iterator{NextReturn: true, ErrKey: errors.New("key error")}, nil,
+			spi.SortAscending)
+
+		_, err := it.Next()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key error")
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("Invalid time", func(t *testing.T) {
+		it := newCursorIterator(&mock.Iterator{
+			NextReturn: true,
+			TagsReturn: []ariesstorage.Tag{{Name: timeAddedTagName, Value: "xxx"}},
+		}, nil, spi.SortAscending)
+
+		_, err := it.Next()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid value for tag")
+	})
+}
+
+type entry struct {
+	key       string
+	timeAdded int64
+}
+
+// entryIterator iterates over the entries from the time of the given cursor in the given sort order. Entries
+// with the same time are returned in the order in which they were added.
+type entryIterator struct {
+	ariesstorage.Iterator
+
+	entries []*entry
+	current int
+}
+
+func newEntryIterator(entries []*entry, start *queryCursor, sortOrder spi.SortOrder) *entryIterator {
+	var selected []*entry
+
+	for _, e := range entries {
+		switch {
+		case start == nil:
+		case sortOrder == spi.SortDescending && e.timeAdded > start.TimeAdded:
+			continue
+		case sortOrder == spi.SortAscending && e.timeAdded < start.TimeAdded:
+			continue
+		}
+
+		selected = append(selected, e)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if sortOrder == spi.SortDescending {
+			return selected[i].timeAdded > selected[j].timeAdded
+		}
+
+		return selected[i].timeAdded < selected[j].timeAdded
+	})
+
+	return &entryIterator{entries: selected, current: -1}
+}
+
+func (it *entryIterator) Next() (bool, error) {
+	it.current++
+
+	return it.current < len(it.entries), nil
+}
+
+func (it *entryIterator) Key() (string, error) {
+	return it.entries[it.current].key, nil
+}
+
+func (it *entryIterator) Value() ([]byte, error) {
+	return []byte(it.entries[it.current].key), nil
+}
+
+func (it *entryIterator) Tags() ([]ariesstorage.Tag, error) {
+	return []ariesstorage.Tag{
+		{Name: timeAddedTagName, Value: strconv.FormatInt(it.entries[it.current].timeAdded, 10)},
+	}, nil
+}
+
+func (it *entryIterator) TotalItems() (int, error) {
+	return len(it.entries), nil
+}
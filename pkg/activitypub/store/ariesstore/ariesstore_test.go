@@ -10,6 +10,7 @@ import (
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	ariesmongodbstorage "github.com/hyperledger/aries-framework-go-ext/component/storage/mongodb"
@@ -21,6 +22,7 @@ import (
 	"github.com/trustbloc/orb/pkg/activitypub/store/ariesstore"
 	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
 	"github.com/trustbloc/orb/pkg/internal/testutil"
 	"github.com/trustbloc/orb/pkg/internal/testutil/mongodbtestutil"
 )
@@ -173,6 +175,19 @@ func TestFunctionalityUsingMongoDB(t *testing.T) {
 
 				checkActivityQueryResultsInOrder(t, it, 3, activityID3, activityID2, activityID1)
 			})
+			t.Run("With cursor", func(t *testing.T) {
+				it, err := s.QueryActivities(spi.NewCriteria(), spi.WithSortOrder(spi.SortDescending))
+				require.NoError(t, err)
+
+				_, err = it.Next()
+				require.NoError(t, err)
+
+				it, err = s.QueryActivities(spi.NewCriteria(), spi.WithSortOrder(spi.SortDescending),
+					spi.WithCursor(it.Cursor()))
+				require.NoError(t, err)
+
+				checkActivityQueryResultsInOrder(t, it, 2, activityID2, activityID1)
+			})
 		})
 
 		t.Run("Query by reference", func(t *testing.T) {
@@ -206,6 +221,22 @@ func TestFunctionalityUsingMongoDB(t *testing.T) {
 
 				checkActivityQueryResultsInOrder(t, it, 3, activityID3, activityID2, activityID1)
 			})
+			t.Run("With cursor", func(t *testing.T) {
+				criteria := spi.NewCriteria(spi.WithReferenceType(spi.Inbox), spi.WithObjectIRI(serviceID1))
+
+				it, err := s.QueryActivities(criteria, spi.WithPageSize(1))
+				require.NoError(t, err)
+
+				a, err := it.Next()
+				require.NoError(t, err)
+				require.Equal(t, activityID1.String(), a.ID().String())
+				require.NotEmpty(t, it.Cursor())
+
+				it, err = s.QueryActivities(criteria, spi.WithPageSize(2), spi.WithCursor(it.Cursor()))
+				require.NoError(t, err)
+
+				checkActivityQueryResultsInOrder(t, it, 2, activityID2, activityID3)
+			})
 			t.Run("Fail to get total items from reference iterator", func(t *testing.T) {
 				mockAriesStore, err := ariesstore.New(serviceName, &mock.Provider{
 					OpenStoreReturn: &mock.Store{
@@ -362,6 +393,23 @@ func TestFunctionalityUsingMongoDB(t *testing.T) {
 		require.NoError(t, err)
 
 		checkReferenceQueryResultsInOrder(t, it, 1, actor4)
+
+		// Query using a time range. Only the reference added after the 'since' time should be returned.
+		since := time.Now()
+
+		require.NoError(t, s.AddReference(spi.Follower, actor2, actor1, spi.WithActivityType(vocab.TypeCreate)))
+
+		it, err = s.QueryReferences(spi.Follower,
+			spi.NewCriteria(spi.WithObjectIRI(actor2), spi.WithSince(since)))
+		require.NoError(t, err)
+
+		checkReferenceQueryResultsInOrder(t, it, 1, actor1)
+
+		it, err = s.QueryReferences(spi.Follower,
+			spi.NewCriteria(spi.WithObjectIRI(actor2), spi.WithType(vocab.TypeCreate), spi.WithUntil(since)))
+		require.NoError(t, err)
+
+		checkReferenceQueryResultsInOrder(t, it, 1, actor4)
 	})
 }
 
@@ -404,6 +452,41 @@ func TestStore_Activity_Failures(t *testing.T) {
 		_, err = provider.QueryActivities(spi.NewCriteria())
 		require.EqualError(t, err, "failed to query store: query error")
 	})
+	t.Run("Invalid cursor", func(t *testing.T) {
+		provider, err := ariesstore.New("ServiceName", mem.NewProvider(), true)
+		require.NoError(t, err)
+
+		_, err = provider.QueryActivities(spi.NewCriteria(), spi.WithCursor("!!!"))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+
+		_, err = provider.QueryReferences(spi.Inbox,
+			spi.NewCriteria(spi.WithObjectIRI(testutil.MustParseURL("https://example.com/service1"))),
+			spi.WithCursor("!!!"))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+
+	t.Run("Fail to get tags", func(t *testing.T) {
+		provider, err := ariesstore.New("ServiceName", &mock.Provider{
+			OpenStoreReturn: &mock.Store{
+				QueryReturn: &mock.Iterator{
+					NextReturn:  true,
+					ValueReturn: []byte(`{"id":"https://example.com/activities/activity1","type":"Create"}`),
+					ErrTags:     errors.New("tags error"),
+				},
+			},
+		}, false)
+		require.NoError(t, err)
+
+		it, err := provider.QueryActivities(spi.NewCriteria())
+		require.NoError(t, err)
+
+		_, err = it.Next()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "tags error")
+	})
+
 	t.Run("Unsupported query criteria", func(t *testing.T) {
 		provider, err := ariesstore.New("ServiceName", mem.NewProvider(), false)
 		require.NoError(t, err)
@@ -0,0 +1,379 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ariesstore
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	ariesstorage "github.com/hyperledger/aries-framework-go/spi/storage"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+)
+
+// queryCursor identifies the position of an item in the results of a query. Items are sorted by the time that
+// they were added to the store, which isn't unique (two items may be added within the same clock tick or by
+// different server instances), so items with the same time are further sorted by the hash of their key. The
+// cursor holds the time and key hash of an item and a query that continues from the cursor selects the items
+// that strictly follow the (time, key hash) pair in the sort order of the query.
+type queryCursor struct {
+	TimeAdded int64  `json:"t"`
+	KeyHash   string `json:"h"`
+}
+
+// decodeCursor decodes the given cursor. Nil is returned if the cursor is empty and a bad request
+// error is returned if the cursor is invalid.
+func decodeCursor(cursor string) (*queryCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, orberrors.NewBadRequest(fmt.Errorf("invalid cursor [%s]: %w", cursor, err))
+	}
+
+	c := &queryCursor{}
+
+	err = json.Unmarshal(cursorBytes, c)
+	if err != nil {
+		return nil, orberrors.NewBadRequest(fmt.Errorf("invalid cursor [%s]: %w", cursor, err))
+	}
+
+	if c.KeyHash == "" {
+		return nil, orberrors.NewBadRequest(fmt.Errorf("invalid cursor [%s]: no key hash", cursor))
+	}
+
+	return c, nil
+}
+
+func (c *queryCursor) encode() string {
+	cursorBytes, err := json.Marshal(c)
+	if err != nil {
+		// Not possible since the cursor holds only an integer and a string.
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursorBytes)
+}
+
+// expression returns the query expression that selects the items from the time of the cursor onwards
+// in the given sort order. The items with the time of the cursor which don't follow the cursor are
+// skipped by the cursor iterator.
+func (c *queryCursor) expression(sortOrder spi.SortOrder) string {
+	if c == nil {
+		return ""
+	}
+
+	if sortOrder == spi.SortDescending {
+		return fmt.Sprintf("&&%s<=%d", timeAddedTagName, c.TimeAdded)
+	}
+
+	return fmt.Sprintf("&&%s>=%d", timeAddedTagName, c.TimeAdded)
+}
+
+// follows returns true if the given item strictly follows this cursor in the given sort order.
+func (c *queryCursor) follows(item *cursorItem, sortOrder spi.SortOrder) bool {
+	if c == nil {
+		return true
+	}
+
+	if item.timeAdded != c.TimeAdded {
+		if sortOrder == spi.SortDescending {
+			return item.timeAdded < c.TimeAdded
+		}
+
+		return item.timeAdded > c.TimeAdded
+	}
+
+	if sortOrder == spi.SortDescending {
+		return item.keyHash < c.KeyHash
+	}
+
+	return item.keyHash > c.KeyHash
+}
+
+type cursorItem struct {
+	key       string
+	keyHash   string
+	value     []byte
+	tags      []ariesstorage.Tag
+	timeAdded int64
+	hasTime   bool
+}
+
+func (i *cursorItem) cursor() *queryCursor {
+	if !i.hasTime {
+		// Items without a time can't be positioned, so no cursor is available for the item.
+		return nil
+	}
+
+	return &queryCursor{TimeAdded: i.timeAdded, KeyHash: i.keyHash}
+}
+
+// cursorIterator wraps an iterator of a query that's sorted by time. The items with the same time are read
+// together and sorted by key hash so that the order is deterministic, and the items that don't follow the
+// start cursor are skipped.
+type cursorIterator struct {
+	ariesstorage.Iterator
+
+	start     *queryCursor
+	sortOrder spi.SortOrder
+	pending   []*cursorItem
+	lookahead *cursorItem
+	current   *cursorItem
+	skipped   int
+	started   bool
+	done      bool
+}
+
+func newCursorIterator(it ariesstorage.Iterator, start *queryCursor, sortOrder spi.SortOrder) *cursorIterator {
+	return &cursorIterator{
+		Iterator:  it,
+		start:     start,
+		sortOrder: sortOrder,
+	}
+}
+
+// Next moves to the next item which follows the start cursor.
+func (it *cursorIterator) Next() (bool, error) {
+	if len(it.pending) == 0 {
+		if err := it.loadRun(); err != nil {
+			return false, err
+		}
+
+		if len(it.pending) == 0 {
+			it.current = nil
+
+			return false, nil
+		}
+	}
+
+	it.current = it.pending[0]
+	it.pending = it.pending[1:]
+
+	return true, nil
+}
+
+// Key returns the key of the current item.
+func (it *cursorIterator) Key() (string, error) {
+	if it.current == nil {
+		return it.Iterator.Key()
+	}
+
+	return it.current.key, nil
+}
+
+// Value returns the value of the current item.
+func (it *cursorIterator) Value() ([]byte, error) {
+	if it.current == nil {
+		return it.Iterator.Value()
+	}
+
+	return it.current.value, nil
+}
+
+// Tags returns the tags of the current item.
+func (it *cursorIterator) Tags() ([]ariesstorage.Tag, error) {
+	if it.current == nil {
+		return it.Iterator.Tags()
+	}
+
+	return it.current.tags, nil
+}
+
+// TotalItems returns the total number of items after the start cursor.
+func (it *cursorIterator) TotalItems() (int, error) {
+	total, err := it.Iterator.TotalItems()
+	if err != nil || it.start == nil {
+		return total, err
+	}
+
+	// The query also selects the items with the time of the start cursor which don't follow the cursor. These
+	// are at the start of the results, so they're known once the first run of items is loaded.
+	if !it.started {
+		if err := it.loadRun(); err != nil {
+			return 0, err
+		}
+	}
+
+	total -= it.skipped
+	if total < 0 {
+		total = 0
+	}
+
+	return total, nil
+}
+
+// Cursor returns the cursor of the current item.
+func (it *cursorIterator) Cursor() string {
+	if it.current == nil {
+		return ""
+	}
+
+	c := it.current.cursor()
+	if c == nil {
+		return ""
+	}
+
+	return c.encode()
+}
+
+// loadRun loads the next run of items that have the same time (and follow the start cursor) into the
+// pending items, sorted by key hash.
+func (it *cursorIterator) loadRun() error {
+	it.started = true
+
+	for {
+		first, err := it.read()
+		if err != nil || first == nil {
+			return err
+		}
+
+		run := []*cursorItem{first}
+
+		if first.hasTime {
+			run, err = it.readRun(first)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, item := range run {
+			if item.hasTime && !it.start.follows(item, it.sortOrder) {
+				it.skipped++
+
+				continue
+			}
+
+			it.pending = append(it.pending, item)
+		}
+
+		if len(it.pending) > 0 {
+			return nil
+		}
+	}
+}
+
+// readRun reads the items that have the same time as the given item and returns them sorted by key hash in the
+// sort order of the query.
+func (it *cursorIterator) readRun(first *cursorItem) ([]*cursorItem, error) {
+	run := []*cursorItem{first}
+
+	for {
+		item, err := it.read()
+		if err != nil {
+			return nil, err
+		}
+
+		if item == nil {
+			break
+		}
+
+		if !item.hasTime || item.timeAdded != first.timeAdded {
+			it.lookahead = item
+
+			break
+		}
+
+		run = append(run, item)
+	}
+
+	sort.Slice(run, func(i, j int) bool {
+		if it.sortOrder == spi.SortDescending {
+			return run[i].keyHash > run[j].keyHash
+		}
+
+		return run[i].keyHash < run[j].keyHash
+	})
+
+	return run, nil
+}
+
+// read returns the next item from the underlying iterator or nil if there are no more items.
+func (it *cursorIterator) read() (*cursorItem, error) {
+	if it.lookahead != nil {
+		item := it.lookahead
+		it.lookahead = nil
+
+		return item, nil
+	}
+
+	if it.done {
+		return nil, nil
+	}
+
+	ok, err := it.Iterator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		it.done = true
+
+		return nil, nil
+	}
+
+	key, err := it.Iterator.Key()
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("failed to get key: %w", err))
+	}
+
+	value, err := it.Iterator.Value()
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("failed to get value: %w", err))
+	}
+
+	tags, err := it.Iterator.Tags()
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("failed to get tags: %w", err))
+	}
+
+	timeAdded, hasTime, err := getTimeAdded(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursorItem{
+		key:       key,
+		keyHash:   hashKey(key),
+		value:     value,
+		tags:      tags,
+		timeAdded: timeAdded,
+		hasTime:   hasTime,
+	}, nil
+}
+
+// hashKey returns the hash of the given store key, which is used in cursors (instead of the key itself) to
+// order the items that were added at the same time.
+func hashKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+func getTimeAdded(tags []ariesstorage.Tag) (int64, bool, error) {
+	for _, tag := range tags {
+		if tag.Name != timeAddedTagName {
+			continue
+		}
+
+		timeAdded, err := strconv.ParseInt(tag.Value, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid value for tag [%s]: %w", timeAddedTagName, err)
+		}
+
+		return timeAdded, true, nil
+	}
+
+	return 0, false, nil
+}
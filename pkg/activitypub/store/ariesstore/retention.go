@@ -0,0 +1,324 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ariesstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	ariesstorage "github.com/hyperledger/aries-framework-go/spi/storage"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/store/storeutil"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/store/expiry"
+)
+
+const (
+	expiryTimeTagName   = "ExpiryTime"
+	referenceIRITagName = "ReferenceIRI"
+)
+
+// retainableRefTypes are the reference types for which a retention period may be configured. References
+// of any other type (for example, PUBLIC_OUTBOX, ANCHOR_EVENT and FOLLOWER) are kept forever, and so are
+// the activities to which they refer. Pruning the inbox and outbox doesn't affect the anchor sync task since
+// it keeps its position in a peer's inbox and outbox as a cursor page, which is positioned relative to an
+// activity rather than by page number.
+var retainableRefTypes = map[spi.ReferenceType]struct{}{
+	spi.Inbox:  {},
+	spi.Outbox: {},
+	spi.Like:   {},
+	spi.Share:  {},
+}
+
+// prunableActivityTypes are the types of activity that may be pruned. Other activities (such as Follow,
+// Invite and Accept) are needed for the lifetime of the relationship that they establish (for example,
+// in order to process an Undo) and are therefore never pruned.
+var prunableActivityTypes = []vocab.Type{vocab.TypeCreate, vocab.TypeAnnounce, vocab.TypeLike, vocab.TypeOffer}
+
+type expiryService interface {
+	Register(store ariesstorage.Store, expiryTagName, storeName string, opts ...expiry.Option)
+}
+
+// Archiver archives activities before they are pruned from the store.
+type Archiver interface {
+	Archive(activities ...*vocab.ActivityType) error
+}
+
+// RetentionConfig holds the settings for pruning old activities from the store.
+type RetentionConfig struct {
+	// Periods holds the retention period for each reference type. A reference is removed once its
+	// retention period has elapsed, and the activity to which it refers is removed along with its last
+	// reference. Only the INBOX, OUTBOX, LIKE and SHARE reference types may be configured.
+	Periods map[spi.ReferenceType]time.Duration
+	// ExpiryService periodically removes the expired references.
+	ExpiryService expiryService
+	// Archiver (optional) archives each activity before it's removed.
+	Archiver Archiver
+}
+
+// Option is a Provider option.
+type Option func(p *Provider)
+
+// WithRetention enables the pruning of old Create, Announce, Like and Offer activities according to the
+// given configuration. Only references that are added after retention is enabled are pruned. Also, since an
+// activity is kept for as long as it's referenced, references that were added before retention was enabled
+// aren't taken into account when determining whether an activity may be pruned.
+func WithRetention(cfg *RetentionConfig) Option {
+	return func(p *Provider) {
+		p.retention = cfg
+	}
+}
+
+// ValidateRetentionPeriods returns an error if a retention period is configured for a reference type that
+// doesn't support retention or if a retention period isn't greater than 0.
+func ValidateRetentionPeriods(periods map[spi.ReferenceType]time.Duration) error {
+	for refType, period := range periods {
+		if _, ok := retainableRefTypes[refType]; !ok {
+			return fmt.Errorf("retention is not supported for reference type [%s]", refType)
+		}
+
+		if period <= 0 {
+			return fmt.Errorf("retention period for reference type [%s] must be greater than 0", refType)
+		}
+	}
+
+	return nil
+}
+
+func (s *Provider) enableRetention() error {
+	if err := ValidateRetentionPeriods(s.retention.Periods); err != nil {
+		return err
+	}
+
+	if len(s.retention.Periods) == 0 {
+		return nil
+	}
+
+	if s.retention.ExpiryService == nil {
+		return errors.New("expiry service is required for retention")
+	}
+
+	logger.Infof("[%s] Enabling activity retention - Periods: %v, Archive: %t",
+		s.serviceName, s.retention.Periods, s.retention.Archiver != nil)
+
+	s.retention.ExpiryService.Register(s.referenceStore, expiryTimeTagName, storeName,
+		expiry.WithExpiryHandler(&retentionHandler{
+			serviceName:    s.serviceName,
+			activityStore:  s.activityStore,
+			referenceStore: s.referenceStore,
+			archiver:       s.retention.Archiver,
+		}))
+
+	return nil
+}
+
+// retentionTags returns the tags that are needed for retention. When retention is enabled, every reference is
+// tagged with the reference IRI so that an activity is only pruned once it's no longer referenced. References
+// for which a retention period is configured are also tagged with an expiry time.
+func (s *Provider) retentionTags(referenceType spi.ReferenceType, referenceIRI *url.URL,
+	refMetaDataOpts []spi.RefMetadataOpt) []ariesstorage.Tag {
+	if s.retention == nil || len(s.retention.Periods) == 0 {
+		return nil
+	}
+
+	tags := []ariesstorage.Tag{
+		{
+			Name:  referenceIRITagName,
+			Value: base64.RawStdEncoding.EncodeToString([]byte(referenceIRI.String())),
+		},
+	}
+
+	period, ok := s.retention.Periods[referenceType]
+	if !ok {
+		return tags
+	}
+
+	refMetadata := storeutil.GetRefMetadata(refMetaDataOpts...)
+
+	if refMetadata.ActivityType == "" ||
+		!vocab.NewTypeProperty(refMetadata.ActivityType).IsAny(prunableActivityTypes...) {
+		return tags
+	}
+
+	return append(tags, ariesstorage.Tag{
+		Name:  expiryTimeTagName,
+		Value: strconv.FormatInt(time.Now().Add(period).Unix(), 10),
+	})
+}
+
+// retentionHandler is invoked by the expiry service with the keys of the expired references. It removes
+// (and optionally archives) the activities that are no longer referenced once the expired references are
+// deleted.
+type retentionHandler struct {
+	serviceName    string
+	activityStore  ariesstorage.Store
+	referenceStore ariesstorage.Store
+	archiver       Archiver
+}
+
+// HandleExpiredKeys prunes the activities referred to by the given (expired) reference keys. If an error
+// is returned then the expiry service doesn't delete the references and they are handled again in the
+// next round.
+func (h *retentionHandler) HandleExpiredKeys(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	expiredKeys := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		expiredKeys[key] = struct{}{}
+	}
+
+	activityIRIs, err := h.getReferenceIRIs(keys)
+	if err != nil {
+		return err
+	}
+
+	var activityIRIsToPrune []string
+
+	for _, activityIRI := range activityIRIs {
+		referenced, e := h.isReferenced(activityIRI, expiredKeys)
+		if e != nil {
+			return e
+		}
+
+		if referenced {
+			logger.Debugf("[%s] Not pruning activity [%s] since it is still referenced", h.serviceName, activityIRI)
+
+			continue
+		}
+
+		activityIRIsToPrune = append(activityIRIsToPrune, activityIRI)
+	}
+
+	if len(activityIRIsToPrune) == 0 {
+		return nil
+	}
+
+	return h.prune(activityIRIsToPrune)
+}
+
+func (h *retentionHandler) prune(activityIRIs []string) error {
+	activitiesBytes, err := h.activityStore.GetBulk(activityIRIs...)
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("get activities: %w", err))
+	}
+
+	var activities []*vocab.ActivityType
+
+	var operations []ariesstorage.Operation
+
+	for i, activityBytes := range activitiesBytes {
+		if activityBytes == nil {
+			continue
+		}
+
+		activity := &vocab.ActivityType{}
+
+		if err := json.Unmarshal(activityBytes, activity); err != nil {
+			return fmt.Errorf("unmarshal activity [%s]: %w", activityIRIs[i], err)
+		}
+
+		activities = append(activities, activity)
+		operations = append(operations, ariesstorage.Operation{Key: activityIRIs[i]})
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if h.archiver != nil {
+		if err := h.archiver.Archive(activities...); err != nil {
+			return fmt.Errorf("archive activities: %w", err)
+		}
+	}
+
+	if err := h.activityStore.Batch(operations); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("delete activities: %w", err))
+	}
+
+	logger.Infof("[%s] Pruned %d activities", h.serviceName, len(operations))
+
+	return nil
+}
+
+// getReferenceIRIs returns the (unique) reference IRIs stored under the given reference keys.
+func (h *retentionHandler) getReferenceIRIs(keys []string) ([]string, error) {
+	valuesBytes, err := h.referenceStore.GetBulk(keys...)
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("get references: %w", err))
+	}
+
+	var iris []string
+
+	added := make(map[string]struct{})
+
+	for i, valueBytes := range valuesBytes {
+		if valueBytes == nil {
+			continue
+		}
+
+		var iri string
+
+		if err := json.Unmarshal(valueBytes, &iri); err != nil {
+			return nil, fmt.Errorf("unmarshal reference [%s]: %w", keys[i], err)
+		}
+
+		if _, ok := added[iri]; ok {
+			continue
+		}
+
+		added[iri] = struct{}{}
+
+		iris = append(iris, iri)
+	}
+
+	return iris, nil
+}
+
+// isReferenced returns true if the given IRI is referenced by any reference other than the expired ones.
+func (h *retentionHandler) isReferenced(iri string, expiredKeys map[string]struct{}) (bool, error) {
+	it, err := h.referenceStore.Query(fmt.Sprintf("%s:%s", referenceIRITagName,
+		base64.RawStdEncoding.EncodeToString([]byte(iri))))
+	if err != nil {
+		return false, orberrors.NewTransient(fmt.Errorf("query references: %w", err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("[%s] Error closing iterator: %s", h.serviceName, errClose)
+		}
+	}()
+
+	for {
+		more, err := it.Next()
+		if err != nil {
+			return false, orberrors.NewTransient(fmt.Errorf("next reference: %w", err))
+		}
+
+		if !more {
+			return false, nil
+		}
+
+		key, err := it.Key()
+		if err != nil {
+			return false, orberrors.NewTransient(fmt.Errorf("get reference key: %w", err))
+		}
+
+		if _, ok := expiredKeys[key]; !ok {
+			return true, nil
+		}
+	}
+}
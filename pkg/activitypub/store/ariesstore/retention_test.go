@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ariesstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	ariesstorage "github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/expiry"
+)
+
+func TestNew_WithRetention(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		expirySvc := &mockExpiryService{}
+
+		_, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			Periods:       map[spi.ReferenceType]time.Duration{spi.Inbox: time.Hour},
+			ExpiryService: expirySvc,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, storeName, expirySvc.storeName)
+		require.Equal(t, expiryTimeTagName, expirySvc.tagName)
+	})
+
+	t.Run("No periods", func(t *testing.T) {
+		expirySvc := &mockExpiryService{}
+
+		_, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			ExpiryService: expirySvc,
+		}))
+		require.NoError(t, err)
+		require.Empty(t, expirySvc.storeName)
+	})
+
+	t.Run("Unsupported reference type", func(t *testing.T) {
+		_, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			Periods:       map[spi.ReferenceType]time.Duration{spi.PublicOutbox: time.Hour},
+			ExpiryService: &mockExpiryService{},
+		}))
+		require.EqualError(t, err,
+			"enable retention: retention is not supported for reference type [PUBLIC_OUTBOX]")
+	})
+
+	t.Run("Invalid period", func(t *testing.T) {
+		_, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			Periods:       map[spi.ReferenceType]time.Duration{spi.Outbox: 0},
+			ExpiryService: &mockExpiryService{},
+		}))
+		require.EqualError(t, err,
+			"enable retention: retention period for reference type [OUTBOX] must be greater than 0")
+	})
+
+	t.Run("No expiry service", func(t *testing.T) {
+		_, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			Periods: map[spi.ReferenceType]time.Duration{spi.Outbox: time.Hour},
+		}))
+		require.EqualError(t, err, "enable retention: expiry service is required for retention")
+	})
+}
+
+func TestProvider_RetentionTags(t *testing.T) {
+	serviceIRI := testutil.MustParseURL("https://example.com/services/service1")
+	activityID := testutil.MustParseURL("https://example.com/activities/activity1")
+
+	s, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+		Periods:       map[spi.ReferenceType]time.Duration{spi.Inbox: time.Hour},
+		ExpiryService: &mockExpiryService{},
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, s.AddReference(spi.Inbox, serviceIRI, activityID, spi.WithActivityType(vocab.TypeCreate)))
+
+	tags, err := s.referenceStore.GetTags(getRefKey(spi.Inbox, serviceIRI, activityID))
+	require.NoError(t, err)
+	require.True(t, hasTag(tags, referenceIRITagName))
+	require.True(t, hasTag(tags, expiryTimeTagName))
+
+	// Follow activities are never pruned.
+	require.NoError(t, s.AddReference(spi.Inbox, serviceIRI, activityID, spi.WithActivityType(vocab.TypeFollow)))
+
+	tags, err = s.referenceStore.GetTags(getRefKey(spi.Inbox, serviceIRI, activityID))
+	require.NoError(t, err)
+	require.True(t, hasTag(tags, referenceIRITagName))
+	require.False(t, hasTag(tags, expiryTimeTagName))
+
+	// No retention period for the public outbox.
+	require.NoError(t, s.AddReference(spi.PublicOutbox, serviceIRI, activityID,
+		spi.WithActivityType(vocab.TypeCreate)))
+
+	tags, err = s.referenceStore.GetTags(getRefKey(spi.PublicOutbox, serviceIRI, activityID))
+	require.NoError(t, err)
+	require.True(t, hasTag(tags, referenceIRITagName))
+	require.False(t, hasTag(tags, expiryTimeTagName))
+}
+
+func TestRetentionHandler_HandleExpiredKeys(t *testing.T) {
+	serviceIRI := testutil.MustParseURL("https://example.com/services/service1")
+	activityID1 := testutil.MustParseURL("https://example.com/activities/activity1")
+	activityID2 := testutil.MustParseURL("https://example.com/activities/activity2")
+
+	object := vocab.NewObjectProperty(vocab.WithIRI(testutil.MustParseURL("https://example.com/objects/object1")))
+
+	activity1 := vocab.NewCreateActivity(object, vocab.WithID(activityID1))
+	activity2 := vocab.NewCreateActivity(object, vocab.WithID(activityID2))
+
+	newStore := func(t *testing.T, archiver Archiver) (*Provider, *retentionHandler) {
+		t.Helper()
+
+		s, err := New("ServiceName", mem.NewProvider(), false, WithRetention(&RetentionConfig{
+			Periods:       map[spi.ReferenceType]time.Duration{spi.Outbox: time.Hour},
+			ExpiryService: &mockExpiryService{},
+			Archiver:      archiver,
+		}))
+		require.NoError(t, err)
+
+		require.NoError(t, s.AddActivity(activity1))
+		require.NoError(t, s.AddActivity(activity2))
+
+		require.NoError(t, s.AddReference(spi.Outbox, serviceIRI, activityID1,
+			spi.WithActivityType(vocab.TypeCreate)))
+		require.NoError(t, s.AddReference(spi.Outbox, serviceIRI, activityID2,
+			spi.WithActivityType(vocab.TypeCreate)))
+		require.NoError(t, s.AddReference(spi.PublicOutbox, serviceIRI, activityID2,
+			spi.WithActivityType(vocab.TypeCreate)))
+
+		return s, &retentionHandler{
+			serviceName:    s.serviceName,
+			activityStore:  s.activityStore,
+			referenceStore: s.referenceStore,
+			archiver:       s.retention.Archiver,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		archiver := &mockArchiver{}
+
+		s, h := newStore(t, archiver)
+
+		require.NoError(t, h.HandleExpiredKeys())
+		require.NoError(t, h.HandleExpiredKeys(
+			getRefKey(spi.Outbox, serviceIRI, activityID1),
+			getRefKey(spi.Outbox, serviceIRI, activityID2),
+			"unknown-key",
+		))
+
+		// Activity 1 is no longer referenced and should be pruned.
+		_, err := s.GetActivity(activityID1)
+		require.ErrorIs(t, err, spi.ErrNotFound)
+
+		// Activity 2 is still referenced by the public outbox.
+		_, err = s.GetActivity(activityID2)
+		require.NoError(t, err)
+
+		require.Len(t, archiver.activities, 1)
+		require.Equal(t, activityID1.String(), archiver.activities[0].ID().String())
+
+		// The activity was already pruned.
+		require.NoError(t, h.HandleExpiredKeys(getRefKey(spi.Outbox, serviceIRI, activityID1)))
+		require.Len(t, archiver.activities, 1)
+	})
+
+	t.Run("Archive error", func(t *testing.T) {
+		errExpected := errors.New("injected archive error")
+
+		s, h := newStore(t, &mockArchiver{err: errExpected})
+
+		err := h.HandleExpiredKeys(getRefKey(spi.Outbox, serviceIRI, activityID1))
+		require.ErrorIs(t, err, errExpected)
+
+		// The activity must not be deleted if it couldn't be archived.
+		_, err = s.GetActivity(activityID1)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reference store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		h := &retentionHandler{referenceStore: &mock.Store{ErrGetBulk: errExpected}}
+
+		require.ErrorIs(t, h.HandleExpiredKeys("key"), errExpected)
+
+		h = &retentionHandler{referenceStore: &mock.Store{
+			GetBulkReturn: [][]byte{[]byte(`"https://example.com/activities/activity1"`)},
+			ErrQuery:      errExpected,
+		}}
+
+		require.ErrorIs(t, h.HandleExpiredKeys("key"), errExpected)
+	})
+
+	t.Run("Activity store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		_, h := newStore(t, nil)
+
+		h.activityStore = &mock.Store{ErrGetBulk: errExpected}
+
+		require.ErrorIs(t, h.HandleExpiredKeys(getRefKey(spi.Outbox, serviceIRI, activityID1)), errExpected)
+
+		h.activityStore = &mock.Store{
+			GetBulkReturn: [][]byte{[]byte(`{"id":"https://example.com/activities/activity1","type":"Create"}`)},
+			ErrBatch:      errExpected,
+		}
+
+		require.ErrorIs(t, h.HandleExpiredKeys(getRefKey(spi.Outbox, serviceIRI, activityID1)), errExpected)
+	})
+}
+
+func hasTag(tags []ariesstorage.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type mockExpiryService struct {
+	storeName string
+	tagName   string
+}
+
+func (m *mockExpiryService) Register(_ ariesstorage.Store, expiryTagName, storeName string, _ ...expiry.Option) {
+	m.tagName = expiryTagName
+	m.storeName = storeName
+}
+
+type mockArchiver struct {
+	activities []*vocab.ActivityType
+	err        error
+}
+
+func (m *mockArchiver) Archive(activities ...*vocab.ActivityType) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.activities = append(m.activities, activities...)
+
+	return nil
+}
@@ -16,12 +16,14 @@ import (
 type iterator struct {
 	current    int
 	totalItems int
+	cursors    []string
 }
 
-func newIterator(totalItems int) *iterator {
+func newIterator(totalItems int, cursors []string) *iterator {
 	return &iterator{
 		totalItems: totalItems,
 		current:    -1,
+		cursors:    cursors,
 	}
 }
 
@@ -29,6 +31,16 @@ func (it *iterator) TotalItems() (int, error) {
 	return it.totalItems, nil
 }
 
+// Cursor returns the cursor of the item most recently returned by Next or an
+// empty string if no cursor is available.
+func (it *iterator) Cursor() string {
+	if it.current < 0 || it.current >= len(it.cursors) {
+		return ""
+	}
+
+	return it.cursors[it.current]
+}
+
 func (it *iterator) Close() error {
 	return nil
 }
@@ -39,10 +51,10 @@ type ActivityIterator struct {
 	results []*vocab.ActivityType
 }
 
-// NewActivityIterator creates a new ActivityIterator.
-func NewActivityIterator(results []*vocab.ActivityType, totalItems int) *ActivityIterator {
+// NewActivityIterator creates a new ActivityIterator. The optional cursors correspond to the given results.
+func NewActivityIterator(results []*vocab.ActivityType, totalItems int, cursors ...string) *ActivityIterator {
 	return &ActivityIterator{
-		iterator: newIterator(totalItems),
+		iterator: newIterator(totalItems, cursors),
 		results:  results,
 	}
 }
@@ -64,10 +76,10 @@ type ReferenceIterator struct {
 	results []*url.URL
 }
 
-// NewReferenceIterator creates a new ReferenceIterator.
-func NewReferenceIterator(results []*url.URL, totalItems int) *ReferenceIterator {
+// NewReferenceIterator creates a new ReferenceIterator. The optional cursors correspond to the given results.
+func NewReferenceIterator(results []*url.URL, totalItems int, cursors ...string) *ReferenceIterator {
 	return &ReferenceIterator{
-		iterator: newIterator(totalItems),
+		iterator: newIterator(totalItems, cursors),
 		results:  results,
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/trustbloc/edge-core/pkg/log"
 
@@ -104,7 +105,12 @@ func (s *Store) QueryActivities(query *spi.Criteria, opts ...spi.QueryOpt) (spi.
 		return s.queryActivitiesByRef(query.ReferenceType, query, opts...)
 	}
 
-	return s.activityStore.query(query, opts...), nil
+	it, err := s.activityStore.query(query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return it, nil
 }
 
 // AddReference adds the reference of the given type to the given object.
@@ -121,7 +127,8 @@ func (s *Store) AddReference(referenceType spi.ReferenceType, objectIRI *url.URL
 		return fmt.Errorf("nil reference IRI")
 	}
 
-	return s.referenceStores[referenceType].add(objectIRI, referenceIRI)
+	return s.referenceStores[referenceType].add(objectIRI, referenceIRI,
+		storeutil.GetRefMetadata(refMetaDataOpts...))
 }
 
 // DeleteReference deletes the reference of the given type from the given actor.
@@ -157,7 +164,7 @@ func (s *Store) queryActivitiesByRef(refType spi.ReferenceType, query *spi.Crite
 
 	options := storeutil.GetQueryOptions(opts...)
 
-	refs, err := storeutil.ReadReferences(it, options.PageSize)
+	refs, refCursors, err := storeutil.ReadReferencesWithCursors(it, options.PageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -171,13 +178,29 @@ func (s *Store) queryActivitiesByRef(refType spi.ReferenceType, query *spi.Crite
 		return NewActivityIterator(nil, totalItems), nil
 	}
 
-	ait := s.activityStore.query(
+	ait, err := s.activityStore.query(
 		spi.NewCriteria(spi.WithActivityIRIs(refs...)),
 		spi.WithSortOrder(options.SortOrder))
+	if err != nil {
+		return nil, err
+	}
 
 	// Set 'totalItems' to the 'totalItems' returned in the original reference query, which may be based on paging.
 	ait.totalItems = totalItems
 
+	// The cursor of each activity is the cursor of the reference that points to it.
+	cursorByIRI := make(map[string]string, len(refs))
+
+	for i, ref := range refs {
+		cursorByIRI[ref.String()] = refCursors[i]
+	}
+
+	ait.cursors = make([]string, len(ait.results))
+
+	for i, activity := range ait.results {
+		ait.cursors[i] = cursorByIRI[activity.ID().String()]
+	}
+
 	return ait, nil
 }
 
@@ -185,11 +208,14 @@ type activityStore struct {
 	mutex        sync.RWMutex
 	activities   []*vocab.ActivityType
 	activityByID map[string]*vocab.ActivityType
+	keyByID      map[string]int64
+	lastKey      int64
 }
 
 func newActivitiesStore() *activityStore {
 	return &activityStore{
 		activityByID: make(map[string]*vocab.ActivityType),
+		keyByID:      make(map[string]int64),
 	}
 }
 
@@ -197,8 +223,11 @@ func (s *activityStore) add(activity *vocab.ActivityType) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	s.lastKey++
+
 	s.activities = append(s.activities, activity)
 	s.activityByID[activity.ID().String()] = activity
+	s.keyByID[activity.ID().String()] = s.lastKey
 
 	return nil
 }
@@ -215,31 +244,89 @@ func (s *activityStore) get(activityID string) (*vocab.ActivityType, error) {
 	return a, nil
 }
 
-func (s *activityStore) query(query *spi.Criteria, opts ...spi.QueryOpt) *ActivityIterator {
+func (s *activityStore) query(query *spi.Criteria, opts ...spi.QueryOpt) (*ActivityIterator, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	return NewActivityIterator(activityQueryResults(s.activities).filter(query, opts...))
+	options := storeutil.GetQueryOptions(opts...)
+
+	activities := s.activities
+
+	if options.Cursor != "" {
+		key, err := storeutil.DecodeCursor(options.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		activities = nil
+
+		for _, a := range s.activities {
+			if isAfterCursor(s.keyByID[a.ID().String()], key, options.SortOrder) {
+				activities = append(activities, a)
+			}
+		}
+	}
+
+	results, totalItems := activityQueryResults(activities).filter(query, opts...)
+
+	cursors := make([]string, len(results))
+
+	for i, a := range results {
+		cursors[i] = storeutil.EncodeCursor(s.keyByID[a.ID().String()])
+	}
+
+	return NewActivityIterator(results, totalItems, cursors...), nil
+}
+
+type reference struct {
+	key          int64
+	iri          *url.URL
+	activityType vocab.Type
+	timeAdded    time.Time
+}
+
+func (r *reference) matches(query *spi.Criteria) bool {
+	if len(query.Types) > 0 && !containsType(query.Types, r.activityType) {
+		return false
+	}
+
+	if query.Since != nil && r.timeAdded.Before(*query.Since) {
+		return false
+	}
+
+	if query.Until != nil && !r.timeAdded.Before(*query.Until) {
+		return false
+	}
+
+	return true
 }
 
 type referenceStore struct {
-	irisByObject map[string][]*url.URL
+	refsByObject map[string][]*reference
+	lastKey      int64
 	mutex        sync.RWMutex
 }
 
 func newReferenceStore() *referenceStore {
 	return &referenceStore{
-		irisByObject: make(map[string][]*url.URL),
+		refsByObject: make(map[string][]*reference),
 	}
 }
 
-func (s *referenceStore) add(actor fmt.Stringer, iri *url.URL) error {
+func (s *referenceStore) add(actor fmt.Stringer, iri *url.URL, metadata *spi.RefMetadata) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	actorID := actor.String()
 
-	s.irisByObject[actorID] = append(s.irisByObject[actorID], iri)
+	s.lastKey++
+
+	s.refsByObject[actorID] = append(s.refsByObject[actorID], &reference{
+		key:          s.lastKey,
+		iri:          iri,
+		activityType: metadata.ActivityType,
+		timeAdded:    time.Now(),
+	})
 
 	return nil
 }
@@ -248,11 +335,11 @@ func (s *referenceStore) delete(actor, iri fmt.Stringer) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	irisForActor := s.irisByObject[actor.String()]
+	refsForActor := s.refsByObject[actor.String()]
 
-	for actorIRI, i := range irisForActor {
-		if i.String() == iri.String() {
-			s.irisByObject[actor.String()] = append(irisForActor[0:actorIRI], irisForActor[actorIRI+1:]...)
+	for i, ref := range refsForActor {
+		if ref.iri.String() == iri.String() {
+			s.refsByObject[actor.String()] = append(refsForActor[0:i], refsForActor[i+1:]...)
 
 			return nil
 		}
@@ -269,7 +356,43 @@ func (s *referenceStore) query(query *spi.Criteria, opts ...spi.QueryOpt) (spi.R
 		return nil, fmt.Errorf("object IRI is required")
 	}
 
-	return NewReferenceIterator(refQueryResults(s.irisByObject[query.ObjectIRI.String()]).filter(query, opts...)), nil
+	options := storeutil.GetQueryOptions(opts...)
+
+	var cursorKey int64
+
+	if options.Cursor != "" {
+		key, err := storeutil.DecodeCursor(options.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		cursorKey = key
+	}
+
+	var iris []*url.URL
+
+	keyByIRI := make(map[string]int64)
+
+	for _, ref := range s.refsByObject[query.ObjectIRI.String()] {
+		if options.Cursor != "" && !isAfterCursor(ref.key, cursorKey, options.SortOrder) {
+			continue
+		}
+
+		if ref.matches(query) {
+			iris = append(iris, ref.iri)
+			keyByIRI[ref.iri.String()] = ref.key
+		}
+	}
+
+	results, totalItems := refQueryResults(iris).filter(query, opts...)
+
+	cursors := make([]string, len(results))
+
+	for i, iri := range results {
+		cursors[i] = storeutil.EncodeCursor(keyByIRI[iri.String()])
+	}
+
+	return NewReferenceIterator(results, totalItems, cursors...), nil
 }
 
 type activityQueryFilter struct {
@@ -386,7 +509,7 @@ func getStartIndex(totalItems int, options *spi.QueryOptions) int {
 }
 
 func startIndex(totalItems int, options *spi.QueryOptions) int {
-	if options.PageNumber < 0 {
+	if options.PageNumber < 0 || options.Cursor != "" {
 		return 0
 	}
 
@@ -410,3 +533,22 @@ func containsIRI(iris []*url.URL, id fmt.Stringer) bool {
 
 	return false
 }
+
+func containsType(types []vocab.Type, t vocab.Type) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAfterCursor returns true if the given key comes after the cursor key in the given sort order.
+func isAfterCursor(key, cursorKey int64, sortOrder spi.SortOrder) bool {
+	if sortOrder == spi.SortDescending {
+		return key < cursorKey
+	}
+
+	return key > cursorKey
+}
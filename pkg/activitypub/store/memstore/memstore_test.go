@@ -11,11 +11,14 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
+	"github.com/trustbloc/orb/pkg/activitypub/store/storeutil"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
 	"github.com/trustbloc/orb/pkg/internal/testutil"
 )
 
@@ -136,6 +139,146 @@ func TestStore_Reference(t *testing.T) {
 	checkRefQueryResults(t, it, actor3)
 }
 
+func TestStore_ReferenceCriteria(t *testing.T) {
+	s := New("service1")
+	require.NotNil(t, s)
+
+	var (
+		serviceID1  = testutil.MustParseURL("https://example.com/services/service1")
+		activityID1 = testutil.MustParseURL("https://example.com/activities/activity1")
+		activityID2 = testutil.MustParseURL("https://example.com/activities/activity2")
+		activityID3 = testutil.MustParseURL("https://example.com/activities/activity3")
+	)
+
+	require.NoError(t, s.AddReference(spi.Inbox, serviceID1, activityID1, spi.WithActivityType(vocab.TypeCreate)))
+	require.NoError(t, s.AddReference(spi.Inbox, serviceID1, activityID2, spi.WithActivityType(vocab.TypeAnnounce)))
+
+	time.Sleep(time.Millisecond)
+
+	since := time.Now()
+
+	time.Sleep(time.Millisecond)
+
+	require.NoError(t, s.AddReference(spi.Inbox, serviceID1, activityID3, spi.WithActivityType(vocab.TypeCreate)))
+
+	t.Run("Query by type", func(t *testing.T) {
+		it, err := s.QueryReferences(spi.Inbox,
+			spi.NewCriteria(spi.WithObjectIRI(serviceID1), spi.WithType(vocab.TypeCreate)))
+		require.NoError(t, err)
+
+		checkRefQueryResults(t, it, activityID1, activityID3)
+	})
+
+	t.Run("Query since", func(t *testing.T) {
+		it, err := s.QueryReferences(spi.Inbox,
+			spi.NewCriteria(spi.WithObjectIRI(serviceID1), spi.WithSince(since)))
+		require.NoError(t, err)
+
+		checkRefQueryResults(t, it, activityID3)
+	})
+
+	t.Run("Query until", func(t *testing.T) {
+		it, err := s.QueryReferences(spi.Inbox,
+			spi.NewCriteria(spi.WithObjectIRI(serviceID1), spi.WithUntil(since)))
+		require.NoError(t, err)
+
+		checkRefQueryResults(t, it, activityID1, activityID2)
+	})
+
+	t.Run("Query by type and until", func(t *testing.T) {
+		it, err := s.QueryReferences(spi.Inbox,
+			spi.NewCriteria(spi.WithObjectIRI(serviceID1), spi.WithType(vocab.TypeCreate), spi.WithUntil(since)))
+		require.NoError(t, err)
+
+		checkRefQueryResults(t, it, activityID1)
+	})
+}
+
+func TestStore_Cursor(t *testing.T) {
+	s := New("service1")
+	require.NotNil(t, s)
+
+	serviceID1 := testutil.MustParseURL("https://example.com/services/service1")
+
+	activities := newMockActivities(vocab.TypeCreate, 5)
+
+	for _, a := range activities {
+		require.NoError(t, s.AddActivity(a))
+		require.NoError(t, s.AddReference(spi.Inbox, serviceID1, a.ID().URL()))
+	}
+
+	t.Run("References - ascending", func(t *testing.T) {
+		it, err := s.QueryReferences(spi.Inbox, spi.NewCriteria(spi.WithObjectIRI(serviceID1)),
+			spi.WithPageSize(2))
+		require.NoError(t, err)
+
+		refs, cursors, err := storeutil.ReadReferencesWithCursors(it, 2)
+		require.NoError(t, err)
+		require.Len(t, refs, 2)
+		require.Equal(t, activities[0].ID().String(), refs[0].String())
+		require.Equal(t, activities[1].ID().String(), refs[1].String())
+
+		it, err = s.QueryReferences(spi.Inbox, spi.NewCriteria(spi.WithObjectIRI(serviceID1)),
+			spi.WithPageSize(2), spi.WithCursor(cursors[1]))
+		require.NoError(t, err)
+
+		refs, _, err = storeutil.ReadReferencesWithCursors(it, 2)
+		require.NoError(t, err)
+		require.Len(t, refs, 2)
+		require.Equal(t, activities[2].ID().String(), refs[0].String())
+		require.Equal(t, activities[3].ID().String(), refs[1].String())
+	})
+
+	t.Run("Activities by reference - descending", func(t *testing.T) {
+		criteria := spi.NewCriteria(spi.WithReferenceType(spi.Inbox), spi.WithObjectIRI(serviceID1))
+
+		it, err := s.QueryActivities(criteria, spi.WithPageSize(3), spi.WithSortOrder(spi.SortDescending))
+		require.NoError(t, err)
+
+		var cursor string
+
+		for i := 4; i >= 2; i-- {
+			a, e := it.Next()
+			require.NoError(t, e)
+			require.Equal(t, activities[i].ID().String(), a.ID().String())
+
+			cursor = it.Cursor()
+			require.NotEmpty(t, cursor)
+		}
+
+		it, err = s.QueryActivities(criteria, spi.WithPageSize(3), spi.WithSortOrder(spi.SortDescending),
+			spi.WithCursor(cursor))
+		require.NoError(t, err)
+
+		checkQueryResults(t, it, activities[1].ID().URL(), activities[0].ID().URL())
+	})
+
+	t.Run("Activities - ascending", func(t *testing.T) {
+		it, err := s.QueryActivities(spi.NewCriteria())
+		require.NoError(t, err)
+
+		_, err = it.Next()
+		require.NoError(t, err)
+
+		it, err = s.QueryActivities(spi.NewCriteria(), spi.WithCursor(it.Cursor()))
+		require.NoError(t, err)
+
+		checkQueryResults(t, it, activities[1].ID().URL(), activities[2].ID().URL(),
+			activities[3].ID().URL(), activities[4].ID().URL())
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		_, err := s.QueryActivities(spi.NewCriteria(), spi.WithCursor("!!!"))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+
+		_, err = s.QueryReferences(spi.Inbox, spi.NewCriteria(spi.WithObjectIRI(serviceID1)),
+			spi.WithCursor("!!!"))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+}
+
 func TestStore_ReferenceError(t *testing.T) {
 	s := New("service1")
 	require.NotNil(t, s)
@@ -9,6 +9,7 @@ package spi
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
 )
@@ -87,6 +88,7 @@ type QueryOptions struct {
 	PageNumber int
 	PageSize   int
 	SortOrder  SortOrder
+	Cursor     string
 }
 
 // QueryOpt sets a query option.
@@ -113,6 +115,16 @@ func WithSortOrder(sortOrder SortOrder) QueryOpt {
 	}
 }
 
+// WithCursor sets the cursor (returned from an iterator of a previous query) after which the results
+// should start. The cursor is based on the time that the item was added to the store, so paging is stable
+// while new items are being added. If a cursor is provided then the page number is ignored and the total
+// number of items returned by the iterator includes only the items after the cursor.
+func WithCursor(cursor string) QueryOpt {
+	return func(options *QueryOptions) {
+		options.Cursor = cursor
+	}
+}
+
 // RefMetadata holds additional metadata to be stored in a reference entry.
 type RefMetadata struct {
 	ActivityType vocab.Type
@@ -135,6 +147,8 @@ type Criteria struct {
 	ObjectIRI     *url.URL
 	ReferenceIRI  *url.URL
 	ActivityIRIs  []*url.URL
+	Since         *time.Time
+	Until         *time.Time
 }
 
 // CriteriaOpt sets a Criteria option.
@@ -186,12 +200,29 @@ func WithActivityIRIs(iris ...*url.URL) CriteriaOpt {
 	}
 }
 
+// WithSince restricts a reference query to references that were added at or after the given time.
+func WithSince(t time.Time) CriteriaOpt {
+	return func(query *Criteria) {
+		query.Since = &t
+	}
+}
+
+// WithUntil restricts a reference query to references that were added before the given time.
+func WithUntil(t time.Time) CriteriaOpt {
+	return func(query *Criteria) {
+		query.Until = &t
+	}
+}
+
 // ActivityIterator defines the query results iterator for activity queries.
 type ActivityIterator interface {
 	// TotalItems returns the total number of items as a result of the query.
 	TotalItems() (int, error)
 	// Next returns the next activity or an ErrNotFound error if there are no more items.
 	Next() (*vocab.ActivityType, error)
+	// Cursor returns an opaque cursor for the item most recently returned by Next. The cursor may be passed
+	// to a subsequent query (see WithCursor) in order to continue from that item.
+	Cursor() string
 	// Close closes the iterator.
 	Close() error
 }
@@ -202,6 +233,9 @@ type ReferenceIterator interface {
 	TotalItems() (int, error)
 	// Next returns the next reference or an ErrNotFound error if there are no more items.
 	Next() (*url.URL, error)
+	// Cursor returns an opaque cursor for the item most recently returned by Next. The cursor may be passed
+	// to a subsequent query (see WithCursor) in order to continue from that item.
+	Cursor() string
 	// Close closes the iterator.
 	Close() error
 }
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package storeutil
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/url"
+	"strconv"
 
 	store "github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
@@ -29,6 +32,27 @@ func GetQueryOptions(opts ...store.QueryOpt) *store.QueryOptions {
 	return options
 }
 
+// EncodeCursor returns an opaque query cursor for the given sort key.
+func EncodeCursor(key int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(key, 10)))
+}
+
+// DecodeCursor returns the sort key encoded in the given cursor. A bad request error is returned
+// if the cursor is invalid.
+func DecodeCursor(cursor string) (int64, error) {
+	keyBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, orberrors.NewBadRequest(fmt.Errorf("invalid cursor [%s]: %w", cursor, err))
+	}
+
+	key, err := strconv.ParseInt(string(keyBytes), 10, 64)
+	if err != nil {
+		return 0, orberrors.NewBadRequest(fmt.Errorf("invalid cursor [%s]: %w", cursor, err))
+	}
+
+	return key, nil
+}
+
 // GetRefMetadata populates and returns the RefMetadata struct with the given metadata.
 func GetRefMetadata(refMetadataOpts ...store.RefMetadataOpt) *store.RefMetadata {
 	refMetadata := &store.RefMetadata{}
@@ -61,6 +85,31 @@ func ReadReferences(it store.ReferenceIterator, maxItems int) ([]*url.URL, error
 	return refs, nil
 }
 
+// ReadReferencesWithCursors returns all of the references, along with the cursor of each reference, resulting
+// from iterating over the given iterator, up to the given maximum number of references. If maxItems is <=0 then
+// all items are read.
+func ReadReferencesWithCursors(it store.ReferenceIterator, maxItems int) ([]*url.URL, []string, error) {
+	var refs []*url.URL
+
+	var cursors []string
+
+	for i := 0; maxItems <= 0 || i < maxItems; i++ {
+		ref, err := it.Next()
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				break
+			}
+
+			return nil, nil, orberrors.NewTransient(err)
+		}
+
+		refs = append(refs, ref)
+		cursors = append(cursors, it.Cursor())
+	}
+
+	return refs, cursors, nil
+}
+
 // ReadActivities returns all of the activities resulting from iterating over the given iterator,
 // up to the given maximum number of activities. If maxItems is <=0 then all items are read.
 func ReadActivities(it store.ActivityIterator, maxItems int) ([]*vocab.ActivityType, error) {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package storeutil
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/url"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/trustbloc/orb/pkg/activitypub/store/mocks"
 	"github.com/trustbloc/orb/pkg/activitypub/store/spi"
 	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
 )
 
 //go:generate counterfeiter -o ../mocks/referenceiterator.gen.go --fake-name ReferenceIterator ../spi ReferenceIterator
@@ -25,11 +27,36 @@ func TestGetQueryOptions(t *testing.T) {
 		spi.WithPageNum(1),
 		spi.WithSortOrder(spi.SortDescending),
 		spi.WithPageSize(10),
+		spi.WithCursor("cursor"),
 	)
 	require.NotNil(t, options)
 	require.Equal(t, 1, options.PageNumber)
 	require.Equal(t, 10, options.PageSize)
 	require.Equal(t, spi.SortDescending, options.SortOrder)
+	require.Equal(t, "cursor", options.Cursor)
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cursor := EncodeCursor(1639523434123456789)
+		require.NotEmpty(t, cursor)
+
+		key, err := DecodeCursor(cursor)
+		require.NoError(t, err)
+		require.Equal(t, int64(1639523434123456789), key)
+	})
+
+	t.Run("Invalid encoding", func(t *testing.T) {
+		_, err := DecodeCursor("!!!")
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		_, err := DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte("abc")))
+		require.Error(t, err)
+		require.True(t, orberrors.IsBadRequest(err))
+	})
 }
 
 func TestGetRefMetadata(t *testing.T) {
@@ -90,3 +117,42 @@ func TestReadReferences(t *testing.T) {
 		require.Empty(t, refs)
 	})
 }
+
+func TestReadReferencesWithCursors(t *testing.T) {
+	url1, err := url.Parse("https://url1")
+	require.NoError(t, err)
+
+	url2, err := url.Parse("https://url2")
+	require.NoError(t, err)
+
+	t.Run("All items", func(t *testing.T) {
+		it := &mocks.ReferenceIterator{}
+
+		it.NextReturnsOnCall(0, url1, nil)
+		it.NextReturnsOnCall(1, url2, nil)
+		it.NextReturnsOnCall(2, nil, spi.ErrNotFound)
+
+		it.CursorReturnsOnCall(0, "cursor1")
+		it.CursorReturnsOnCall(1, "cursor2")
+
+		refs, cursors, err := ReadReferencesWithCursors(it, 5)
+		require.NoError(t, err)
+		require.Len(t, refs, 2)
+		require.Equal(t, url1.String(), refs[0].String())
+		require.Equal(t, url2.String(), refs[1].String())
+		require.Equal(t, []string{"cursor1", "cursor2"}, cursors)
+	})
+
+	t.Run("Iterator error", func(t *testing.T) {
+		errExpected := fmt.Errorf("injected iterator error")
+
+		it := &mocks.ReferenceIterator{}
+
+		it.NextReturns(nil, errExpected)
+
+		refs, cursors, err := ReadReferencesWithCursors(it, 1)
+		require.EqualError(t, err, errExpected.Error())
+		require.Empty(t, refs)
+		require.Empty(t, cursors)
+	})
+}
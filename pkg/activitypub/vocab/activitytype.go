@@ -249,3 +249,62 @@ func NewUndoActivity(obj *ObjectProperty, opts ...Opt) *ActivityType {
 		},
 	}
 }
+
+// NewUpdateActivity returns a new 'Update' activity.
+func NewUpdateActivity(obj *ObjectProperty, opts ...Opt) *ActivityType {
+	options := NewOptions(opts...)
+
+	return &ActivityType{
+		ObjectType: NewObject(
+			WithContext(getContexts(options, ContextActivityStreams)...),
+			WithID(options.ID),
+			WithType(TypeUpdate),
+			WithTo(options.To...),
+			WithPublishedTime(options.Published),
+		),
+		activity: &activityType{
+			Actor:  NewURLProperty(options.Actor),
+			Object: obj,
+		},
+	}
+}
+
+// NewBlockActivity returns a new 'Block' activity.
+func NewBlockActivity(obj *ObjectProperty, opts ...Opt) *ActivityType {
+	options := NewOptions(opts...)
+
+	return &ActivityType{
+		ObjectType: NewObject(
+			WithContext(getContexts(options, ContextActivityStreams)...),
+			WithID(options.ID),
+			WithType(TypeBlock),
+			WithTo(options.To...),
+			WithPublishedTime(options.Published),
+		),
+		activity: &activityType{
+			Actor:  NewURLProperty(options.Actor),
+			Object: obj,
+		},
+	}
+}
+
+// NewMoveActivity returns a new 'Move' activity. The object is the IRI of the actor that moved and the
+// target is the IRI of the actor's new location.
+func NewMoveActivity(obj *ObjectProperty, opts ...Opt) *ActivityType {
+	options := NewOptions(opts...)
+
+	return &ActivityType{
+		ObjectType: NewObject(
+			WithContext(getContexts(options, ContextActivityStreams)...),
+			WithID(options.ID),
+			WithType(TypeMove),
+			WithTo(options.To...),
+			WithPublishedTime(options.Published),
+		),
+		activity: &activityType{
+			Actor:  NewURLProperty(options.Actor),
+			Target: options.Target,
+			Object: obj,
+		},
+	}
+}
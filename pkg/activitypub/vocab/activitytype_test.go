@@ -30,6 +30,9 @@ var (
 	rejectActivityID  = newMockID(service1, "/activities/75b3d005-abb6-473d-a879-18bc1ee84979")
 	offerActivityID   = newMockID(service1, "/activities/65b3d005-6bb6-673d-6879-18bc1ee84976")
 	undoActivityID    = newMockID(service1, "/activities/77bcd005-abb6-433d-a889-18bc1ce64981")
+	updateActivityID  = newMockID(service1, "/activities/a7bcd005-abb6-433d-a889-18bc1ce64983")
+	blockActivityID   = newMockID(service1, "/activities/b7bcd005-abb6-433d-a889-18bc1ce64984")
+	moveActivityID    = newMockID(service1, "/activities/c7bcd005-abb6-433d-a889-18bc1ce64985")
 	likeActivityID    = newMockID(witness1, "/likes/87bcd005-abb6-433d-a889-18bc1ce84988")
 
 	public           = testutil.MustParseURL("https://www.w3.org/ns/activitystreams#Public")
@@ -971,6 +974,123 @@ func TestUndoTypeMarshal(t *testing.T) {
 	})
 }
 
+func TestUpdateTypeMarshal(t *testing.T) {
+	org1Service := testutil.MustParseURL("https://org1.com/services/service1")
+	keyID := testutil.MustParseURL("https://org1.com/services/service1/keys/main-key")
+	followers := testutil.MustParseURL("https://org1.com/services/service1/followers")
+
+	published := getStaticTime()
+
+	t.Run("Marshal", func(t *testing.T) {
+		actor := NewService(org1Service,
+			WithPublicKey(NewPublicKey(
+				WithID(keyID),
+				WithOwner(org1Service),
+				WithPublicKeyPem("-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZI...\n-----END PUBLIC KEY-----"),
+			)),
+		)
+
+		update := NewUpdateActivity(
+			NewObjectProperty(WithActorObject(actor)),
+			WithID(updateActivityID),
+			WithActor(org1Service),
+			WithTo(followers),
+			WithPublishedTime(&published),
+		)
+
+		bytes, err := canonicalizer.MarshalCanonical(update)
+		require.NoError(t, err)
+		t.Log(string(bytes))
+
+		require.Equal(t, testutil.GetCanonical(t, jsonUpdate), string(bytes))
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		a := &ActivityType{}
+		require.NoError(t, json.Unmarshal([]byte(jsonUpdate), a))
+		require.NotNil(t, a.Type())
+		require.True(t, a.Type().Is(TypeUpdate))
+		require.Equal(t, updateActivityID.String(), a.ID().String())
+		require.Equal(t, org1Service.String(), a.Actor().String())
+
+		to := a.To()
+		require.Len(t, to, 1)
+		require.Equal(t, followers.String(), to[0].String())
+
+		actor := a.Object().Actor()
+		require.NotNil(t, actor)
+		require.True(t, a.Object().Type().Is(TypeService))
+		require.Equal(t, org1Service.String(), actor.ID().String())
+		require.NotNil(t, actor.PublicKey())
+		require.Equal(t, keyID.String(), actor.PublicKey().ID.String())
+		require.Equal(t, org1Service.String(), actor.PublicKey().Owner.String())
+	})
+}
+
+func TestBlockTypeMarshal(t *testing.T) {
+	org1Service := testutil.MustParseURL("https://org1.com/services/service1")
+	org2Service := testutil.MustParseURL("https://org1.com/services/service2")
+
+	t.Run("Marshal", func(t *testing.T) {
+		block := NewBlockActivity(
+			NewObjectProperty(WithIRI(org2Service)),
+			WithID(blockActivityID),
+			WithActor(org1Service),
+			WithTo(org2Service),
+		)
+
+		bytes, err := canonicalizer.MarshalCanonical(block)
+		require.NoError(t, err)
+		t.Log(string(bytes))
+
+		require.Equal(t, testutil.GetCanonical(t, jsonBlock), string(bytes))
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		a := &ActivityType{}
+		require.NoError(t, json.Unmarshal([]byte(jsonBlock), a))
+		require.NotNil(t, a.Type())
+		require.True(t, a.Type().Is(TypeBlock))
+		require.Equal(t, blockActivityID.String(), a.ID().String())
+		require.Equal(t, org1Service.String(), a.Actor().String())
+		require.Equal(t, org2Service.String(), a.Object().IRI().String())
+	})
+}
+
+func TestMoveTypeMarshal(t *testing.T) {
+	oldService := testutil.MustParseURL("https://org1.com/services/service1")
+	newService := testutil.MustParseURL("https://org2.com/services/service1")
+	followers := testutil.MustParseURL("https://org1.com/services/service1/followers")
+
+	t.Run("Marshal", func(t *testing.T) {
+		move := NewMoveActivity(
+			NewObjectProperty(WithIRI(oldService)),
+			WithID(moveActivityID),
+			WithActor(oldService),
+			WithTarget(NewObjectProperty(WithIRI(newService))),
+			WithTo(followers),
+		)
+
+		bytes, err := canonicalizer.MarshalCanonical(move)
+		require.NoError(t, err)
+		t.Log(string(bytes))
+
+		require.Equal(t, testutil.GetCanonical(t, jsonMove), string(bytes))
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		a := &ActivityType{}
+		require.NoError(t, json.Unmarshal([]byte(jsonMove), a))
+		require.NotNil(t, a.Type())
+		require.True(t, a.Type().Is(TypeMove))
+		require.True(t, a.Type().IsActivity())
+		require.Equal(t, moveActivityID.String(), a.ID().String())
+		require.Equal(t, oldService.String(), a.Actor().String())
+		require.Equal(t, oldService.String(), a.Object().IRI().String())
+		require.Equal(t, newService.String(), a.Target().IRI().String())
+	})
+}
+
 func TestActivityType_Accessors(t *testing.T) {
 	a := &ActivityType{}
 
@@ -1360,6 +1480,48 @@ const (
   "type": "Undo"
 }`
 
+	jsonUpdate = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "https://org1.com/services/service1",
+  "id": "https://sally.example.com/services/orb/activities/a7bcd005-abb6-433d-a889-18bc1ce64983",
+  "object": {
+    "@context": [
+      "https://www.w3.org/ns/activitystreams",
+      "https://w3id.org/security/v1",
+      "https://w3id.org/activityanchors/v1"
+    ],
+    "id": "https://org1.com/services/service1",
+    "publicKey": {
+      "id": "https://org1.com/services/service1/keys/main-key",
+      "owner": "https://org1.com/services/service1",
+      "publicKeyPem": "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZI...\n-----END PUBLIC KEY-----"
+    },
+    "type": "Service"
+  },
+  "published": "2021-01-27T09:30:10Z",
+  "to": "https://org1.com/services/service1/followers",
+  "type": "Update"
+}`
+
+	jsonBlock = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "https://org1.com/services/service1",
+  "id": "https://sally.example.com/services/orb/activities/b7bcd005-abb6-433d-a889-18bc1ce64984",
+  "object": "https://org1.com/services/service2",
+  "to": "https://org1.com/services/service2",
+  "type": "Block"
+}`
+
+	jsonMove = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "https://org1.com/services/service1",
+  "id": "https://sally.example.com/services/orb/activities/c7bcd005-abb6-433d-a889-18bc1ce64985",
+  "object": "https://org1.com/services/service1",
+  "target": "https://org2.com/services/service1",
+  "to": "https://org1.com/services/service1/followers",
+  "type": "Move"
+}`
+
 	jsonInviteWitness = `{
   "@context": [
     "https://www.w3.org/ns/activitystreams",
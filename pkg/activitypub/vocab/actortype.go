@@ -28,6 +28,11 @@ func NewPublicKey(opts ...Opt) *PublicKeyType {
 	}
 }
 
+// EndpointsType defines the 'endpoints' of an actor which are shared by other actors on the same server.
+type EndpointsType struct {
+	SharedInbox *URLProperty `json:"sharedInbox,omitempty"`
+}
+
 // ActorType defines an 'actor'.
 type ActorType struct {
 	*ObjectType
@@ -36,16 +41,19 @@ type ActorType struct {
 }
 
 type actorType struct {
-	PublicKey  *PublicKeyType `json:"publicKey"`
-	Inbox      *URLProperty   `json:"inbox"`
-	Outbox     *URLProperty   `json:"outbox"`
-	Followers  *URLProperty   `json:"followers"`
-	Following  *URLProperty   `json:"following"`
-	Witnesses  *URLProperty   `json:"witnesses"`
-	Witnessing *URLProperty   `json:"witnessing"`
-	Liked      *URLProperty   `json:"liked"`
-	Likes      *URLProperty   `json:"likes"`
-	Shares     *URLProperty   `json:"shares"`
+	PublicKey  *PublicKeyType `json:"publicKey,omitempty"`
+	Inbox      *URLProperty   `json:"inbox,omitempty"`
+	Outbox     *URLProperty   `json:"outbox,omitempty"`
+	Followers  *URLProperty   `json:"followers,omitempty"`
+	Following  *URLProperty   `json:"following,omitempty"`
+	Witnesses  *URLProperty   `json:"witnesses,omitempty"`
+	Witnessing *URLProperty   `json:"witnessing,omitempty"`
+	Liked      *URLProperty   `json:"liked,omitempty"`
+	Likes      *URLProperty   `json:"likes,omitempty"`
+	Shares     *URLProperty   `json:"shares,omitempty"`
+
+	AlsoKnownAs *URLCollectionProperty `json:"alsoKnownAs,omitempty"`
+	Endpoints   *EndpointsType         `json:"endpoints,omitempty"`
 }
 
 // PublicKey returns the actor's public key.
@@ -116,6 +124,22 @@ func (t *ActorType) Liked() *url.URL {
 	return t.actor.Liked.URL()
 }
 
+// SharedInbox returns the URL of the inbox that is shared by all actors on the actor's server.
+// Nil is returned if the actor does not advertise a shared inbox.
+func (t *ActorType) SharedInbox() *url.URL {
+	if t.actor.Endpoints == nil || t.actor.Endpoints.SharedInbox == nil {
+		return nil
+	}
+
+	return t.actor.Endpoints.SharedInbox.URL()
+}
+
+// AlsoKnownAs returns the other IRIs by which the actor is known, for example, the IRI
+// of the actor before it moved to a new domain.
+func (t *ActorType) AlsoKnownAs() Urls {
+	return t.actor.AlsoKnownAs.URLs()
+}
+
 // MarshalJSON mmarshals the object to JSON.
 func (t *ActorType) MarshalJSON() ([]byte, error) {
 	return MarshalJSON(t.ObjectType, t.actor)
@@ -150,6 +174,19 @@ func NewService(id *url.URL, opts ...Opt) *ActorType {
 			Liked:      NewURLProperty(options.Liked),
 			Likes:      NewURLProperty(options.Likes),
 			Shares:     NewURLProperty(options.Shares),
+
+			AlsoKnownAs: NewURLCollectionProperty(options.AlsoKnownAs...),
+			Endpoints:   newEndpoints(options),
 		},
 	}
 }
+
+func newEndpoints(options *Options) *EndpointsType {
+	if options.SharedInbox == nil {
+		return nil
+	}
+
+	return &EndpointsType{
+		SharedInbox: NewURLProperty(options.SharedInbox),
+	}
+}
@@ -30,6 +30,7 @@ func TestActor(t *testing.T) {
 	liked := testutil.MustParseURL("https://alice.example.com/services/orb/liked")
 	likes := testutil.MustParseURL("https://alice.example.com/services/orb/likes")
 	shares := testutil.MustParseURL("https://alice.example.com/services/orb/shares")
+	sharedInbox := testutil.MustParseURL("https://alice.example.com/services/orb/inbox")
 
 	publicKey := NewPublicKey(
 		WithID(keyID),
@@ -49,6 +50,7 @@ func TestActor(t *testing.T) {
 			WithLiked(liked),
 			WithShares(shares),
 			WithLikes(likes),
+			WithSharedInbox(sharedInbox),
 		)
 
 		bytes, err := canonicalizer.MarshalCanonical(service)
@@ -105,6 +107,10 @@ func TestActor(t *testing.T) {
 		lkd := a.Liked()
 		require.NotNil(t, lkd)
 		require.Equal(t, liked.String(), lkd.String())
+
+		si := a.SharedInbox()
+		require.NotNil(t, si)
+		require.Equal(t, sharedInbox.String(), si.String())
 	})
 
 	t.Run("Empty actor", func(t *testing.T) {
@@ -123,6 +129,23 @@ func TestActor(t *testing.T) {
 		require.Nil(t, a.Witnesses())
 		require.Nil(t, a.Witnessing())
 		require.Nil(t, a.Liked())
+		require.Nil(t, a.SharedInbox())
+		require.Empty(t, a.AlsoKnownAs())
+	})
+
+	t.Run("Also known as", func(t *testing.T) {
+		oldServiceIRI := testutil.MustParseURL("https://old.example.com/services/orb")
+
+		service := NewService(serviceIRI, WithAlsoKnownAs(oldServiceIRI))
+
+		bytes, err := json.Marshal(service)
+		require.NoError(t, err)
+		require.Contains(t, string(bytes), `"alsoKnownAs":"https://old.example.com/services/orb"`)
+
+		a := &ActorType{}
+		require.NoError(t, json.Unmarshal(bytes, a))
+		require.Len(t, a.AlsoKnownAs(), 1)
+		require.True(t, a.AlsoKnownAs().Contains(oldServiceIRI))
 	})
 }
 
@@ -147,5 +170,8 @@ const jsonService = `{
   "witnessing": "https://alice.example.com/services/orb/witnessing",
   "liked": "https://alice.example.com/services/orb/liked",
   "likes": "https://alice.example.com/services/orb/likes",
-  "shares": "https://alice.example.com/services/orb/shares"
+  "shares": "https://alice.example.com/services/orb/shares",
+  "endpoints": {
+    "sharedInbox": "https://alice.example.com/services/orb/inbox"
+  }
 }`
@@ -22,6 +22,7 @@ type ObjectProperty struct {
 	activity     *ActivityType
 	anchorObject *AnchorObjectType
 	anchorEvent  *AnchorEventType
+	actor        *ActorType
 }
 
 // NewObjectProperty returns a new 'object' property with the given options.
@@ -36,6 +37,7 @@ func NewObjectProperty(opts ...Opt) *ObjectProperty {
 		activity:     options.Activity,
 		anchorObject: options.AnchorObject,
 		anchorEvent:  options.AnchorEvent,
+		actor:        options.ActorObject,
 	}
 }
 
@@ -70,6 +72,10 @@ func (p *ObjectProperty) Type() *TypeProperty {
 		return p.anchorEvent.Type()
 	}
 
+	if p.actor != nil {
+		return p.actor.Type()
+	}
+
 	return nil
 }
 
@@ -138,6 +144,15 @@ func (p *ObjectProperty) AnchorEvent() *AnchorEventType {
 	return p.anchorEvent
 }
 
+// Actor returns the actor or nil if the actor is not set.
+func (p *ObjectProperty) Actor() *ActorType {
+	if p == nil {
+		return nil
+	}
+
+	return p.actor
+}
+
 // MarshalJSON marshals the 'object' property.
 func (p *ObjectProperty) MarshalJSON() ([]byte, error) {
 	if p.iri != nil {
@@ -168,6 +183,10 @@ func (p *ObjectProperty) MarshalJSON() ([]byte, error) {
 		return json.Marshal(p.anchorEvent)
 	}
 
+	if p.actor != nil {
+		return json.Marshal(p.actor)
+	}
+
 	return nil, fmt.Errorf("nil object property")
 }
 
@@ -211,6 +230,9 @@ func (p *ObjectProperty) UnmarshalJSON(bytes []byte) error {
 	case obj.object.Type.Is(TypeAnchorEvent):
 		err = p.unmarshalAnchorEvent(bytes)
 
+	case obj.object.Type.Is(TypeService):
+		err = p.unmarshalActor(bytes)
+
 	default:
 		p.obj = obj
 	}
@@ -277,3 +299,15 @@ func (p *ObjectProperty) unmarshalAnchorEvent(bytes []byte) error {
 
 	return nil
 }
+
+func (p *ObjectProperty) unmarshalActor(bytes []byte) error {
+	a := &ActorType{}
+
+	if err := json.Unmarshal(bytes, &a); err != nil {
+		return err
+	}
+
+	p.actor = a
+
+	return nil
+}
@@ -46,6 +46,7 @@ func TestNewObjectProperty(t *testing.T) {
 		require.Nil(t, p.Collection())
 		require.Nil(t, p.OrderedCollection())
 		require.Nil(t, p.Activity())
+		require.Nil(t, p.Actor())
 	})
 
 	t.Run("WithIRI", func(t *testing.T) {
@@ -121,6 +122,29 @@ func TestNewObjectProperty(t *testing.T) {
 		require.NotNil(t, collContext)
 		require.True(t, collContext.Contains(ContextActivityStreams))
 	})
+
+	t.Run("WithActorObject", func(t *testing.T) {
+		p := NewObjectProperty(WithActorObject(NewService(objectPropertyID)))
+		require.NotNil(t, p)
+
+		typeProp := p.Type()
+		require.Nil(t, p.IRI())
+		require.Nil(t, p.Object())
+		require.NotNil(t, typeProp)
+		require.True(t, typeProp.Is(TypeService))
+
+		actor := p.Actor()
+		require.NotNil(t, actor)
+		require.Equal(t, objectPropertyID.String(), actor.ID().String())
+
+		bytes, err := json.Marshal(p)
+		require.NoError(t, err)
+
+		p2 := NewObjectProperty()
+		require.NoError(t, json.Unmarshal(bytes, p2))
+		require.NotNil(t, p2.Actor())
+		require.Equal(t, objectPropertyID.String(), p2.Actor().ID().String())
+	})
 }
 
 func TestObjectProperty_MarshalJSON(t *testing.T) {
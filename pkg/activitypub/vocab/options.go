@@ -225,6 +225,7 @@ type ObjectPropertyOptions struct {
 	Collection        *CollectionType
 	OrderedCollection *OrderedCollectionType
 	Activity          *ActivityType
+	ActorObject       *ActorType
 }
 
 // WithIRI sets the 'object' property to an IRI.
@@ -262,6 +263,13 @@ func WithActivity(activity *ActivityType) Opt {
 	}
 }
 
+// WithActorObject sets the 'object' property to an embedded actor.
+func WithActorObject(actor *ActorType) Opt {
+	return func(opts *Options) {
+		opts.ActorObject = actor
+	}
+}
+
 // ActivityOptions holds the options for an Activity.
 type ActivityOptions struct {
 	Result *ObjectProperty
@@ -302,6 +310,9 @@ type ActorOptions struct {
 	Liked      *url.URL
 	Likes      *url.URL
 	Shares     *url.URL
+
+	AlsoKnownAs []*url.URL
+	SharedInbox *url.URL
 }
 
 // WithPublicKey sets the 'publicKey' property on the actor.
@@ -374,6 +385,20 @@ func WithShares(shares *url.URL) Opt {
 	}
 }
 
+// WithAlsoKnownAs sets the 'alsoKnownAs' property on the actor.
+func WithAlsoKnownAs(iri ...*url.URL) Opt {
+	return func(opts *Options) {
+		opts.AlsoKnownAs = append(opts.AlsoKnownAs, iri...)
+	}
+}
+
+// WithSharedInbox sets the 'sharedInbox' endpoint on the actor.
+func WithSharedInbox(sharedInbox *url.URL) Opt {
+	return func(opts *Options) {
+		opts.SharedInbox = sharedInbox
+	}
+}
+
 // PublicKeyOptions holds the options for a Public Key.
 type PublicKeyOptions struct {
 	Owner        *url.URL
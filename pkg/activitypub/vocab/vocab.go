@@ -18,6 +18,9 @@ const (
 	ContextCredentials Context = "https://www.w3.org/2018/credentials/v1"
 	// ContextActivityAnchors is the Activity Anchors context.
 	ContextActivityAnchors Context = "https://w3id.org/activityanchors/v1"
+	// ContextActivityAnchorsExt defines the Activity Anchors terms that were added after the v1 context was
+	// published (the v1 context can't be changed without changing how existing documents are canonicalized).
+	ContextActivityAnchorsExt Context = "https://w3id.org/activityanchors/ext/v1"
 )
 
 //nolint:gochecknoglobals
@@ -77,6 +80,12 @@ const (
 	TypeOffer Type = "Offer"
 	// TypeUndo specifies the "Undo" activity type.
 	TypeUndo Type = "Undo"
+	// TypeUpdate specifies the "Update" activity type.
+	TypeUpdate Type = "Update"
+	// TypeBlock specifies the "Block" activity type.
+	TypeBlock Type = "Block"
+	// TypeMove specifies the "Move" activity type.
+	TypeMove Type = "Move"
 )
 
 const (
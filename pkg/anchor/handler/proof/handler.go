@@ -41,12 +41,45 @@ type metricsProvider interface {
 	WitnessAnchorCredentialTime(duration time.Duration)
 }
 
+type healthRecorder interface {
+	InvalidProof(anchorID string, witness *url.URL) error
+	Timeout(anchorID string, witness *url.URL) error
+}
+
+type noopHealthRecorder struct{}
+
+func (r *noopHealthRecorder) InvalidProof(string, *url.URL) error {
+	return nil
+}
+
+func (r *noopHealthRecorder) Timeout(string, *url.URL) error {
+	return nil
+}
+
+// Option is an option for the proof handler.
+type Option func(opts *WitnessProofHandler)
+
+// WithHealthRecorder sets an optional recorder that's notified when a witness provides an invalid proof
+// or provides a proof after the offer has expired.
+func WithHealthRecorder(recorder healthRecorder) Option {
+	return func(opts *WitnessProofHandler) {
+		opts.healthRecorder = recorder
+	}
+}
+
 // New creates new proof handler.
-func New(providers *Providers, pubSub pubSub) *WitnessProofHandler {
-	return &WitnessProofHandler{
-		Providers: providers,
-		publisher: vcpubsub.NewPublisher(pubSub),
+func New(providers *Providers, pubSub pubSub, opts ...Option) *WitnessProofHandler {
+	h := &WitnessProofHandler{
+		Providers:      providers,
+		publisher:      vcpubsub.NewPublisher(pubSub),
+		healthRecorder: &noopHealthRecorder{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
 	}
+
+	return h
 }
 
 // Providers contains all of the providers required by the handler.
@@ -63,7 +96,8 @@ type Providers struct {
 // WitnessProofHandler handles an anchor credential witness proof.
 type WitnessProofHandler struct {
 	*Providers
-	publisher anchorEventPublisher
+	publisher      anchorEventPublisher
+	healthRecorder healthRecorder
 }
 
 type witnessStore interface {
@@ -99,6 +133,10 @@ func (h *WitnessProofHandler) HandleProof(witness *url.URL, anchors string, endT
 		// proof came after expiry time so nothing to do here
 		// clean up process for witness store and Sidetree batch files will have to be initiated differently
 		// since we can have scenario that proof never shows up
+		if e := h.healthRecorder.Timeout(anchors, witness); e != nil {
+			logger.Warnf("Failed to record timeout for anchor event [%s] from witness[%s]: %s", anchors, witness, e)
+		}
+
 		return nil
 	}
 
@@ -115,10 +153,21 @@ func (h *WitnessProofHandler) HandleProof(witness *url.URL, anchors string, endT
 		return nil
 	}
 
+	if status == proofapi.AnchorIndexStatusFailed {
+		logger.Infof("Received proof from [%s] but witness policy was not satisfied in time for anchor event[%s]",
+			witness, anchors)
+
+		return nil
+	}
+
 	var witnessProof vct.Proof
 
 	err = json.Unmarshal(proof, &witnessProof)
 	if err != nil {
+		if e := h.healthRecorder.InvalidProof(anchors, witness); e != nil {
+			logger.Warnf("Failed to record invalid proof for anchor event [%s] from witness[%s]: %s", anchors, witness, e)
+		}
+
 		return fmt.Errorf("failed to unmarshal incoming witness proof for anchor event [%s]: %w", anchors, err)
 	}
 
@@ -211,6 +260,12 @@ func (h *WitnessProofHandler) handleWitnessPolicy(anchorEvent *vocab.AnchorEvent
 		return nil
 	}
 
+	if status == proofapi.AnchorIndexStatusFailed {
+		logger.Infof("VC status has already been marked as failed for [%s]", anchorID)
+
+		return nil
+	}
+
 	// Publish the VC before setting the status to completed since, if the publisher returns a transient error,
 	// then this handler would be invoked on another server instance. So, we want the status to remain in-process,
 	// otherwise the handler on the other instance would not publish the VC because it would think that is has
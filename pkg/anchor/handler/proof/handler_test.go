@@ -106,12 +106,15 @@ func TestWitnessProofHandler(t *testing.T) {
 	})
 
 	t.Run("success - proof expired", func(t *testing.T) {
-		proofHandler := New(&Providers{}, ps)
+		recorder := &mockHealthRecorder{err: fmt.Errorf("injected recorder error")}
+
+		proofHandler := New(&Providers{}, ps, WithHealthRecorder(recorder))
 
 		expiredTime := time.Now().Add(-60 * time.Second)
 
 		err := proofHandler.HandleProof(witnessIRI, anchorID, expiredTime, nil)
 		require.NoError(t, err)
+		require.Equal(t, 1, recorder.timeouts)
 	})
 
 	t.Run("success - witness policy satisfied", func(t *testing.T) {
@@ -191,6 +194,42 @@ func TestWitnessProofHandler(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("success - status is failed", func(t *testing.T) {
+		aeStore, err := anchoreventstore.New(mem.NewProvider(), testutil.GetLoader(t))
+		require.NoError(t, err)
+
+		ae := &vocab.AnchorEventType{}
+		require.NoError(t, json.Unmarshal([]byte(anchorEventTwoProofs), ae))
+
+		err = aeStore.Put(ae)
+		require.NoError(t, err)
+
+		statusStore, err := anchoreventstatus.New(mem.NewProvider(), testutil.GetExpiryService(t), time.Minute)
+		require.NoError(t, err)
+
+		err = statusStore.AddStatus(ae.Index().String(), proofapi.AnchorIndexStatusFailed)
+		require.NoError(t, err)
+
+		// The proof shouldn't be added since the anchor event has failed.
+		witnessStore := &mockWitnessStore{AddProofErr: fmt.Errorf("unexpected call to AddProof")}
+
+		providers := &Providers{
+			AnchorEventStore: aeStore,
+			StatusStore:      statusStore,
+			MonitoringSvc:    &mocks.MonitoringService{},
+			WitnessStore:     witnessStore,
+			WitnessPolicy:    &mockWitnessPolicy{eval: true},
+			Metrics:          &orbmocks.MetricsProvider{},
+			DocLoader:        testutil.GetLoader(t),
+		}
+
+		proofHandler := New(providers, ps)
+
+		err = proofHandler.HandleProof(witnessIRI, ae.Index().String(),
+			expiryTime, []byte(witnessProof))
+		require.NoError(t, err)
+	})
+
 	t.Run("success - policy satisfied but some witness proofs are empty", func(t *testing.T) {
 		aeStore, err := anchoreventstore.New(mem.NewProvider(), testutil.GetLoader(t))
 		require.NoError(t, err)
@@ -443,6 +482,50 @@ func TestWitnessProofHandler(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("status already failed", func(t *testing.T) {
+		aeStore, err := anchoreventstore.New(mem.NewProvider(), testutil.GetLoader(t))
+		require.NoError(t, err)
+
+		ae := &vocab.AnchorEventType{}
+		require.NoError(t, json.Unmarshal([]byte(anchorEventTwoProofs), ae))
+
+		err = aeStore.Put(ae)
+		require.NoError(t, err)
+
+		witnessStore, err := witness.New(mem.NewProvider(), testutil.GetExpiryService(t), time.Minute)
+		require.NoError(t, err)
+
+		// prepare witness store
+		witnesses := []*proofapi.Witness{{Type: proofapi.WitnessTypeSystem, URI: witnessIRI}}
+		err = witnessStore.Put(ae.Index().String(), witnesses)
+		require.NoError(t, err)
+
+		witnessPolicy, err := policy.New(configStore, defaultPolicyCacheExpiry)
+		require.NoError(t, err)
+
+		mockStatusStore := &mocks.AnchorIndexStatusStore{}
+		mockStatusStore.GetStatusReturnsOnCall(0, proofapi.AnchorIndexStatusInProcess, nil)
+		mockStatusStore.GetStatusReturnsOnCall(1, proofapi.AnchorIndexStatusFailed, nil)
+		// The status shouldn't be changed to completed since the anchor event has failed.
+		mockStatusStore.AddStatusReturns(fmt.Errorf("unexpected call to AddStatus"))
+
+		providers := &Providers{
+			AnchorEventStore: aeStore,
+			StatusStore:      mockStatusStore,
+			MonitoringSvc:    &mocks.MonitoringService{},
+			WitnessStore:     witnessStore,
+			WitnessPolicy:    witnessPolicy,
+			Metrics:          &orbmocks.MetricsProvider{},
+			DocLoader:        testutil.GetLoader(t),
+		}
+
+		proofHandler := New(providers, ps)
+
+		err = proofHandler.HandleProof(witnessIRI, ae.Index().String(),
+			expiryTime, []byte(witnessProof))
+		require.NoError(t, err)
+	})
+
 	t.Run("error - witness policy error", func(t *testing.T) {
 		aeStore, err := anchoreventstore.New(mem.NewProvider(), testutil.GetLoader(t))
 		require.NoError(t, err)
@@ -626,11 +709,14 @@ func TestWitnessProofHandler(t *testing.T) {
 			Metrics:          &orbmocks.MetricsProvider{},
 		}
 
-		proofHandler := New(providers, ps)
+		recorder := &mockHealthRecorder{}
+
+		proofHandler := New(providers, ps, WithHealthRecorder(recorder))
 
 		err = proofHandler.HandleProof(witnessIRI, anchorID, expiryTime, []byte(""))
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to unmarshal incoming witness proof for anchor event")
+		require.Equal(t, 1, recorder.invalidProofs)
 	})
 
 	t.Run("error - monitoring error", func(t *testing.T) {
@@ -811,3 +897,21 @@ const duplicateWitnessProof = `{
     "verificationMethod": "did:web:orb.domain1.com#orb1key"
   }
 }`
+
+type mockHealthRecorder struct {
+	invalidProofs int
+	timeouts      int
+	err           error
+}
+
+func (r *mockHealthRecorder) InvalidProof(string, *url.URL) error {
+	r.invalidProofs++
+
+	return r.err
+}
+
+func (r *mockHealthRecorder) Timeout(string, *url.URL) error {
+	r.timeouts++
+
+	return r.err
+}
@@ -0,0 +1,306 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/store/anchoreventstatus"
+)
+
+const (
+	endpoint       = "/anchor/status"
+	idPathVariable = "id"
+)
+
+const (
+	statusNotFoundResponse      = "Content Not Found."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("anchor-status-rest-handler")
+
+type statusStore interface {
+	GetInProcess() ([]*anchoreventstatus.AnchorStatus, error)
+	GetStatusInfo(anchorID string) (*anchoreventstatus.AnchorStatus, error)
+}
+
+type witnessStore interface {
+	Get(anchorID string) ([]*proof.WitnessProof, error)
+}
+
+type witnessPolicy interface {
+	Evaluate(witnesses []*proof.WitnessProof) (bool, error)
+}
+
+// AnchorStatus contains the status of an anchor event. The status is "failed" if the witness policy
+// was not satisfied within the maximum witness delay. Age and TimeRemaining (the time until the
+// maximum witness delay elapses) are only set for anchor events that are in process.
+type AnchorStatus struct {
+	AnchorID        string     `json:"anchorId"`
+	Status          string     `json:"status"`
+	Time            *time.Time `json:"time,omitempty"`
+	Age             string     `json:"age,omitempty"`
+	TimeRemaining   string     `json:"timeRemaining,omitempty"`
+	PolicySatisfied *bool      `json:"policySatisfied,omitempty"`
+	Witnesses       []*Witness `json:"witnesses,omitempty"`
+}
+
+// Witness contains a witness that was considered for an anchor event and whether or not a proof was
+// received from the witness.
+type Witness struct {
+	URI           string          `json:"uri"`
+	Type          string          `json:"type,omitempty"`
+	HasLog        bool            `json:"hasLog"`
+	Selected      bool            `json:"selected"`
+	ProofReceived bool            `json:"proofReceived"`
+	Metadata      *proof.Metadata `json:"metadata,omitempty"`
+}
+
+type handler struct {
+	statusStore     statusStore
+	maxWitnessDelay time.Duration
+	marshal         func(interface{}) ([]byte, error)
+	now             func() time.Time
+}
+
+// InProcessRetriever retrieves the anchor events for which proofs are still being collected.
+type InProcessRetriever struct {
+	*handler
+}
+
+// NewInProcessRetriever returns a new InProcessRetriever.
+func NewInProcessRetriever(statusStore statusStore, maxWitnessDelay time.Duration) *InProcessRetriever {
+	return &InProcessRetriever{
+		handler: newHandler(statusStore, maxWitnessDelay),
+	}
+}
+
+// Path returns the HTTP REST endpoint for the in-process anchor retriever.
+func (h *InProcessRetriever) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the in-process anchor retriever.
+func (h *InProcessRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the in-process anchor retriever.
+func (h *InProcessRetriever) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *InProcessRetriever) handle(w http.ResponseWriter, _ *http.Request) {
+	statuses, err := h.statusStore.GetInProcess()
+	if err != nil {
+		logger.Errorf("[%s] Error retrieving in-process anchor events: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Time.Before(statuses[j].Time)
+	})
+
+	now := h.now()
+
+	result := make([]*AnchorStatus, len(statuses))
+
+	for i, s := range statuses {
+		result[i] = h.newAnchorStatus(s, now)
+	}
+
+	h.writeJSON(w, result)
+}
+
+// StatusRetriever retrieves the status of an anchor event along with its witnesses, the proofs that
+// were received and the result of evaluating the witness policy.
+type StatusRetriever struct {
+	*handler
+
+	witnessStore  witnessStore
+	witnessPolicy witnessPolicy
+}
+
+// NewStatusRetriever returns a new StatusRetriever.
+func NewStatusRetriever(statusStore statusStore, witnessStore witnessStore, witnessPolicy witnessPolicy,
+	maxWitnessDelay time.Duration) *StatusRetriever {
+	return &StatusRetriever{
+		handler:       newHandler(statusStore, maxWitnessDelay),
+		witnessStore:  witnessStore,
+		witnessPolicy: witnessPolicy,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the anchor status retriever.
+func (h *StatusRetriever) Path() string {
+	return fmt.Sprintf("%s/{%s}", endpoint, idPathVariable)
+}
+
+// Method returns the HTTP REST method for the anchor status retriever.
+func (h *StatusRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the anchor status retriever.
+func (h *StatusRetriever) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *StatusRetriever) handle(w http.ResponseWriter, req *http.Request) {
+	anchorID := mux.Vars(req)[idPathVariable]
+
+	status, err := h.statusStore.GetStatusInfo(anchorID)
+	if err != nil {
+		if errors.Is(err, orberrors.ErrContentNotFound) {
+			logger.Debugf("[%s] Status not found for anchor event [%s]", endpoint, anchorID)
+
+			writeResponse(w, http.StatusNotFound, []byte(statusNotFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error retrieving status for anchor event [%s]: %s", endpoint, anchorID, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	result := h.newAnchorStatus(status, h.now())
+
+	witnesses, err := h.witnessStore.Get(anchorID)
+	if err != nil {
+		if !errors.Is(err, orberrors.ErrContentNotFound) {
+			logger.Errorf("[%s] Error retrieving witnesses for anchor event [%s]: %s", endpoint, anchorID, err)
+
+			writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+			return
+		}
+
+		// The witnesses are deleted after the anchor event is completed.
+		logger.Debugf("[%s] Witnesses not found for anchor event [%s]", endpoint, anchorID)
+
+		h.writeJSON(w, result)
+
+		return
+	}
+
+	satisfied, err := h.witnessPolicy.Evaluate(witnesses)
+	if err != nil {
+		logger.Errorf("[%s] Error evaluating witness policy for anchor event [%s]: %s", endpoint, anchorID, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	result.PolicySatisfied = &satisfied
+	result.Witnesses = newWitnesses(witnesses)
+
+	h.writeJSON(w, result)
+}
+
+func newHandler(statusStore statusStore, maxWitnessDelay time.Duration) *handler {
+	return &handler{
+		statusStore:     statusStore,
+		maxWitnessDelay: maxWitnessDelay,
+		marshal:         json.Marshal,
+		now:             time.Now,
+	}
+}
+
+func (h *handler) newAnchorStatus(s *anchoreventstatus.AnchorStatus, now time.Time) *AnchorStatus {
+	status := &AnchorStatus{
+		AnchorID: s.AnchorID,
+		Status:   string(s.Status),
+	}
+
+	if s.Time.IsZero() {
+		return status
+	}
+
+	t := s.Time
+	status.Time = &t
+
+	if s.Status == proof.AnchorIndexStatusInProcess {
+		age := now.Sub(s.Time).Truncate(time.Second)
+
+		remaining := h.maxWitnessDelay - age
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		status.Age = age.String()
+		status.TimeRemaining = remaining.String()
+	}
+
+	return status
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, v interface{}) {
+	respBytes, err := h.marshal(v)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling anchor status: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(w, http.StatusOK, respBytes)
+}
+
+func newWitnesses(witnesses []*proof.WitnessProof) []*Witness {
+	result := make([]*Witness, len(witnesses))
+
+	for i, w := range witnesses {
+		result[i] = &Witness{
+			URI:           w.URI.String(),
+			Type:          string(w.Type),
+			HasLog:        w.HasLog,
+			Selected:      w.Selected,
+			ProofReceived: w.Proof != nil,
+			Metadata:      w.Metadata,
+		}
+	}
+
+	return result
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
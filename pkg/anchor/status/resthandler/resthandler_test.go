@@ -0,0 +1,278 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/anchoreventstatus"
+)
+
+const (
+	anchor1 = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+	anchor2 = "hl:uEiAk0CUuIIVOxlalYH6JU7gsIwvo5zGNcM_zYo2jXwzBzw"
+	anchor3 = "hl:uEiDWrnyK7Rts7MklKGtcbaqG1o2hFH9XDGYlZ7gGnsTCYw"
+
+	maxWitnessDelay = 10 * time.Minute
+)
+
+func TestInProcessRetriever(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("success", func(t *testing.T) {
+		store := &mockStatusStore{
+			inProcess: []*anchoreventstatus.AnchorStatus{
+				{AnchorID: anchor1, Status: proof.AnchorIndexStatusInProcess, Time: now.Add(-time.Minute)},
+				{AnchorID: anchor2, Status: proof.AnchorIndexStatusInProcess, Time: now.Add(-time.Hour)},
+			},
+		}
+
+		h := NewInProcessRetriever(store, maxWitnessDelay)
+		require.Equal(t, endpoint, h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+		require.NotNil(t, h.Handler())
+
+		h.now = func() time.Time { return now }
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		var statuses []*AnchorStatus
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &statuses))
+		require.Len(t, statuses, 2)
+
+		require.Equal(t, anchor2, statuses[0].AnchorID)
+		require.Equal(t, "in-process", statuses[0].Status)
+		require.Equal(t, "1h0m0s", statuses[0].Age)
+		require.Equal(t, "0s", statuses[0].TimeRemaining)
+
+		require.Equal(t, anchor1, statuses[1].AnchorID)
+		require.Equal(t, "1m0s", statuses[1].Age)
+		require.Equal(t, "9m0s", statuses[1].TimeRemaining)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := NewInProcessRetriever(&mockStatusStore{err: errors.New("injected store error")}, maxWitnessDelay)
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := NewInProcessRetriever(&mockStatusStore{}, maxWitnessDelay)
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
+
+func TestStatusRetriever(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	statusStore := &mockStatusStore{
+		statuses: map[string]*anchoreventstatus.AnchorStatus{
+			anchor1: {AnchorID: anchor1, Status: proof.AnchorIndexStatusInProcess, Time: now.Add(-time.Minute)},
+			anchor2: {AnchorID: anchor2, Status: proof.AnchorIndexStatusCompleted, Time: now},
+			anchor3: {AnchorID: anchor3, Status: proof.AnchorIndexStatusFailed, Time: now},
+		},
+	}
+
+	witnessStore := &mockWitnessStore{
+		witnesses: map[string][]*proof.WitnessProof{
+			anchor1: {
+				{
+					Type: proof.WitnessTypeSystem, URI: witness1, Selected: true, Proof: []byte("proof"),
+					Metadata: &proof.Metadata{Operator: "operator-a"},
+				},
+				{Type: proof.WitnessTypeSystem, URI: witness2, Selected: true},
+			},
+			anchor3: {
+				{Type: proof.WitnessTypeSystem, URI: witness2, Selected: true},
+			},
+		},
+	}
+
+	t.Run("success - in process", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, witnessStore, &mockWitnessPolicy{}, maxWitnessDelay)
+		require.Equal(t, "/anchor/status/{id}", h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+		require.NotNil(t, h.Handler())
+
+		h.now = func() time.Time { return now }
+
+		status := getStatus(t, h, anchor1, http.StatusOK)
+		require.Equal(t, anchor1, status.AnchorID)
+		require.Equal(t, "in-process", status.Status)
+		require.Equal(t, "1m0s", status.Age)
+		require.Equal(t, "9m0s", status.TimeRemaining)
+		require.NotNil(t, status.PolicySatisfied)
+		require.False(t, *status.PolicySatisfied)
+		require.Len(t, status.Witnesses, 2)
+		require.Equal(t, witness1.String(), status.Witnesses[0].URI)
+		require.True(t, status.Witnesses[0].ProofReceived)
+		require.Equal(t, "operator-a", status.Witnesses[0].Metadata.Operator)
+		require.False(t, status.Witnesses[1].ProofReceived)
+	})
+
+	t.Run("success - completed", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, witnessStore, &mockWitnessPolicy{}, maxWitnessDelay)
+
+		status := getStatus(t, h, anchor2, http.StatusOK)
+		require.Equal(t, "completed", status.Status)
+		require.Equal(t, now.Unix(), status.Time.Unix())
+		require.Empty(t, status.Age)
+		require.Nil(t, status.PolicySatisfied)
+		require.Empty(t, status.Witnesses)
+	})
+
+	t.Run("success - failed", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, witnessStore, &mockWitnessPolicy{}, maxWitnessDelay)
+
+		status := getStatus(t, h, anchor3, http.StatusOK)
+		require.Equal(t, "failed", status.Status)
+		require.Equal(t, now.Unix(), status.Time.Unix())
+		require.Empty(t, status.Age)
+		require.Empty(t, status.TimeRemaining)
+		require.NotNil(t, status.PolicySatisfied)
+		require.False(t, *status.PolicySatisfied)
+		require.Len(t, status.Witnesses, 1)
+		require.False(t, status.Witnesses[0].ProofReceived)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, witnessStore, &mockWitnessPolicy{}, maxWitnessDelay)
+
+		getStatus(t, h, "hl:unknown", http.StatusNotFound)
+	})
+
+	t.Run("status store error", func(t *testing.T) {
+		h := NewStatusRetriever(&mockStatusStore{err: errors.New("injected store error")}, witnessStore,
+			&mockWitnessPolicy{}, maxWitnessDelay)
+
+		getStatus(t, h, anchor1, http.StatusInternalServerError)
+	})
+
+	t.Run("witness store error", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, &mockWitnessStore{err: errors.New("injected store error")},
+			&mockWitnessPolicy{}, maxWitnessDelay)
+
+		getStatus(t, h, anchor1, http.StatusInternalServerError)
+	})
+
+	t.Run("witness policy error", func(t *testing.T) {
+		h := NewStatusRetriever(statusStore, witnessStore,
+			&mockWitnessPolicy{err: errors.New("injected policy error")}, maxWitnessDelay)
+
+		getStatus(t, h, anchor1, http.StatusInternalServerError)
+	})
+}
+
+func getStatus(t *testing.T, h *StatusRetriever, anchorID string, expectedStatus int) *AnchorStatus {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, endpoint, nil), map[string]string{
+		idPathVariable: anchorID,
+	})
+
+	h.handle(rw, req)
+
+	result := rw.Result()
+	require.Equal(t, expectedStatus, result.StatusCode)
+	require.NoError(t, result.Body.Close())
+
+	if expectedStatus != http.StatusOK {
+		return nil
+	}
+
+	status := &AnchorStatus{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), status))
+
+	return status
+}
+
+type mockStatusStore struct {
+	inProcess []*anchoreventstatus.AnchorStatus
+	statuses  map[string]*anchoreventstatus.AnchorStatus
+	err       error
+}
+
+func (m *mockStatusStore) GetInProcess() ([]*anchoreventstatus.AnchorStatus, error) {
+	return m.inProcess, m.err
+}
+
+func (m *mockStatusStore) GetStatusInfo(anchorID string) (*anchoreventstatus.AnchorStatus, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	status, ok := m.statuses[anchorID]
+	if !ok {
+		return nil, fmt.Errorf("status not found: %w", orberrors.ErrContentNotFound)
+	}
+
+	return status, nil
+}
+
+type mockWitnessStore struct {
+	witnesses map[string][]*proof.WitnessProof
+	err       error
+}
+
+func (m *mockWitnessStore) Get(anchorID string) ([]*proof.WitnessProof, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	witnesses, ok := m.witnesses[anchorID]
+	if !ok {
+		return nil, fmt.Errorf("witnesses not found: %w", orberrors.ErrContentNotFound)
+	}
+
+	return witnesses, nil
+}
+
+type mockWitnessPolicy struct {
+	satisfied bool
+	err       error
+}
+
+func (m *mockWitnessPolicy) Evaluate([]*proof.WitnessProof) (bool, error) {
+	return m.satisfied, m.err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/store/witnessstats"
+)
+
+const endpoint = "/witness/health"
+
+const internalServerErrorResponse = "Internal Server Error."
+
+var logger = log.New("witness-health-rest-handler")
+
+type statsStore interface {
+	GetAll() (map[string]*witnessstats.Stats, error)
+}
+
+// WitnessHealth contains the health of a witness.
+type WitnessHealth struct {
+	Witness           string     `json:"witness"`
+	Healthy           bool       `json:"healthy"`
+	Score             float64    `json:"score"`
+	Successes         int        `json:"successes"`
+	Timeouts          int        `json:"timeouts"`
+	InvalidProofs     int        `json:"invalidProofs"`
+	OutstandingOffers int        `json:"outstandingOffers"`
+	Latency           string     `json:"latency,omitempty"`
+	LastSelected      *time.Time `json:"lastSelected,omitempty"`
+	RetryAfter        *time.Time `json:"retryAfter,omitempty"`
+}
+
+// Handler retrieves the health of all witnesses that were sent an offer.
+type Handler struct {
+	store   statsStore
+	marshal func(interface{}) ([]byte, error)
+	now     func() time.Time
+}
+
+// Path returns the HTTP REST endpoint for the witness health handler.
+func (h *Handler) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the witness health handler.
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the witness health handler.
+func (h *Handler) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+// New returns a new witness health handler.
+func New(store statsStore) *Handler {
+	return &Handler{
+		store:   store,
+		marshal: json.Marshal,
+		now:     time.Now,
+	}
+}
+
+func (h *Handler) handle(w http.ResponseWriter, _ *http.Request) {
+	stats, err := h.store.GetAll()
+	if err != nil {
+		logger.Errorf("[%s] Error retrieving witness stats: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	now := h.now()
+
+	health := make([]*WitnessHealth, 0, len(stats))
+
+	for witness, s := range stats {
+		health = append(health, newWitnessHealth(witness, s, now))
+	}
+
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].Witness < health[j].Witness
+	})
+
+	healthBytes, err := h.marshal(health)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling witness health: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Retrieved witness health: %s", endpoint, healthBytes)
+
+	writeResponse(w, http.StatusOK, healthBytes)
+}
+
+func newWitnessHealth(witness string, s *witnessstats.Stats, now time.Time) *WitnessHealth {
+	health := &WitnessHealth{
+		Witness:           witness,
+		Healthy:           s.RetryAfter == nil || !now.Before(*s.RetryAfter),
+		Score:             s.Score(),
+		Successes:         s.Successes,
+		Timeouts:          s.Timeouts,
+		InvalidProofs:     s.InvalidProofs,
+		OutstandingOffers: s.OutstandingOffers,
+	}
+
+	if s.Latency > 0 {
+		health.Latency = s.Latency.String()
+	}
+
+	if !s.LastSelected.IsZero() {
+		lastSelected := s.LastSelected
+		health.LastSelected = &lastSelected
+	}
+
+	if !health.Healthy {
+		health.RetryAfter = s.RetryAfter
+	}
+
+	return health
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/store/witnessstats"
+)
+
+const (
+	witness1 = "https://domain1.com/services/orb"
+	witness2 = "https://domain2.com/services/orb"
+)
+
+func TestNew(t *testing.T) {
+	h := New(&mockStatsStore{})
+	require.NotNil(t, h)
+	require.Equal(t, endpoint, h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.NotNil(t, h.Handler())
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Now()
+	retryAfter := now.Add(time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		h := New(&mockStatsStore{
+			stats: map[string]*witnessstats.Stats{
+				witness2: {
+					LastSelected: now,
+					Timeouts:     4,
+					FailureRate:  0.6,
+					Backoff:      time.Minute,
+					RetryAfter:   &retryAfter,
+				},
+				witness1: {
+					LastSelected:      now,
+					Latency:           2 * time.Second,
+					Successes:         5,
+					OutstandingOffers: 1,
+				},
+			},
+		})
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		defer func() {
+			require.NoError(t, result.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		var health []*WitnessHealth
+
+		require.NoError(t, json.NewDecoder(result.Body).Decode(&health))
+		require.Len(t, health, 2)
+
+		require.Equal(t, witness1, health[0].Witness)
+		require.True(t, health[0].Healthy)
+		require.Equal(t, 1.0, health[0].Score)
+		require.Equal(t, 5, health[0].Successes)
+		require.Equal(t, 1, health[0].OutstandingOffers)
+		require.Equal(t, "2s", health[0].Latency)
+		require.NotNil(t, health[0].LastSelected)
+		require.Nil(t, health[0].RetryAfter)
+
+		require.Equal(t, witness2, health[1].Witness)
+		require.False(t, health[1].Healthy)
+		require.InDelta(t, 0.4, health[1].Score, 0.001)
+		require.Equal(t, 4, health[1].Timeouts)
+		require.Empty(t, health[1].Latency)
+		require.NotNil(t, health[1].RetryAfter)
+	})
+
+	t.Run("no witnesses", func(t *testing.T) {
+		h := New(&mockStatsStore{})
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.NoError(t, result.Body.Close())
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "[]", rw.Body.String())
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := New(&mockStatsStore{err: errors.New("injected store error")})
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.NoError(t, result.Body.Close())
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := New(&mockStatsStore{})
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.NoError(t, result.Body.Close())
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	})
+}
+
+type mockStatsStore struct {
+	stats map[string]*witnessstats.Stats
+	err   error
+}
+
+func (m *mockStatsStore) GetAll() (map[string]*witnessstats.Stats, error) {
+	return m.stats, m.err
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bluele/gcache"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+)
+
+// WitnessMetadataKey is the witness metadata key in config store.
+const WitnessMetadataKey = "witness-metadata"
+
+var logger = log.New("witness-metadata")
+
+// Provider provides the metadata (operator, jurisdiction, hosting provider) of witnesses. The metadata
+// is kept in the config store and is cached for the given expiry period.
+type Provider struct {
+	configStore storage.Store
+	cache       gcache.Cache
+	cacheExpiry time.Duration
+}
+
+// New returns a new witness metadata provider.
+func New(configStore storage.Store, cacheExpiry time.Duration) *Provider {
+	p := &Provider{
+		configStore: configStore,
+		cacheExpiry: cacheExpiry,
+	}
+
+	p.cache = gcache.New(1).ARC().LoaderExpireFunc(p.load).Build()
+
+	return p
+}
+
+// Get returns the metadata of the given witnesses. Witnesses without metadata aren't included in the result.
+func (p *Provider) Get(witnesses ...string) (map[string]*proof.Metadata, error) {
+	value, err := p.cache.Get(WitnessMetadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve witness metadata from cache: %w", err)
+	}
+
+	all, ok := value.(map[string]*proof.Metadata)
+	if !ok {
+		return nil, fmt.Errorf("unexpected interface '%T' for witness metadata value in cache", value)
+	}
+
+	result := make(map[string]*proof.Metadata)
+
+	for _, w := range witnesses {
+		if m, ok := all[w]; ok {
+			result[w] = m
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Provider) load(key interface{}) (interface{}, *time.Duration, error) {
+	metadataBytes, err := p.configStore.Get(key.(string))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil, err
+	}
+
+	metadata := make(map[string]*proof.Metadata)
+
+	if len(metadataBytes) != 0 {
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal witness metadata: %w", err)
+		}
+	}
+
+	logger.Debugf("loaded metadata for %d witnesses from store", len(metadata))
+
+	return metadata, &p.cacheExpiry, nil
+}
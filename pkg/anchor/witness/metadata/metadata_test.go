@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metadata
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/store/mocks"
+)
+
+const (
+	witness1 = "https://domain1.com/services/orb"
+	witness2 = "https://domain2.com/services/orb"
+)
+
+func TestProvider_Get(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore("config")
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessMetadataKey,
+			[]byte(`{"`+witness1+`":{"operator":"operator-a","jurisdiction":"ca","hosting":"provider-a"}}`)))
+
+		p := New(configStore, time.Minute)
+
+		metadata, err := p.Get(witness1, witness2)
+		require.NoError(t, err)
+		require.Len(t, metadata, 1)
+		require.Equal(t, "operator-a", metadata[witness1].Operator)
+		require.Equal(t, "ca", metadata[witness1].Jurisdiction)
+		require.Equal(t, "provider-a", metadata[witness1].Hosting)
+	})
+
+	t.Run("success - not found", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore("config")
+		require.NoError(t, err)
+
+		metadata, err := New(configStore, time.Minute).Get(witness1)
+		require.NoError(t, err)
+		require.Empty(t, metadata)
+	})
+
+	t.Run("error - store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		configStore := &mocks.Store{}
+		configStore.GetReturns(nil, errExpected)
+
+		_, err := New(configStore, time.Minute).Get(witness1)
+		require.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("error - unmarshal error", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore("config")
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessMetadataKey, []byte("{")))
+
+		_, err = New(configStore, time.Minute).Get(witness1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal witness metadata")
+	})
+}
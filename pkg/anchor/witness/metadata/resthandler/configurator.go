@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/metadata"
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+)
+
+const endpoint = "/witness/metadata"
+
+const (
+	badRequestResponse          = "Bad Request."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("witness-metadata-rest-handler")
+
+// MetadataConfigurator updates the witness metadata in config store. The request body is a JSON object
+// that maps witness IRIs to their metadata, e.g. {"https://a.com/services/orb":{"operator":"a"}}.
+type MetadataConfigurator struct {
+	configStore storage.Store
+	marshal     func(interface{}) ([]byte, error)
+}
+
+// Path returns the HTTP REST endpoint for the MetadataConfigurator service.
+func (mc *MetadataConfigurator) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the configure witness metadata service.
+func (mc *MetadataConfigurator) Method() string {
+	return http.MethodPost
+}
+
+// Handler returns the HTTP REST handle for the MetadataConfigurator service.
+func (mc *MetadataConfigurator) Handler() common.HTTPRequestHandler {
+	return mc.handle
+}
+
+// New returns a new MetadataConfigurator.
+func New(cfgStore storage.Store) *MetadataConfigurator {
+	return &MetadataConfigurator{
+		configStore: cfgStore,
+		marshal:     json.Marshal,
+	}
+}
+
+func (mc *MetadataConfigurator) handle(w http.ResponseWriter, req *http.Request) {
+	reqBytes, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		logger.Errorf("[%s] Error reading request body: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(badRequestResponse))
+
+		return
+	}
+
+	witnessMetadata := make(map[string]*proof.Metadata)
+
+	err = json.Unmarshal(reqBytes, &witnessMetadata)
+	if err != nil {
+		logger.Errorf("[%s] Invalid witness metadata: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(badRequestResponse))
+
+		return
+	}
+
+	err = validate(witnessMetadata)
+	if err != nil {
+		logger.Errorf("[%s] Invalid witness metadata: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(badRequestResponse))
+
+		return
+	}
+
+	valueBytes, err := mc.marshal(witnessMetadata)
+	if err != nil {
+		logger.Errorf("[%s] Marshal witness metadata error: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	err = mc.configStore.Put(metadata.WitnessMetadataKey, valueBytes)
+	if err != nil {
+		logger.Errorf("[%s] Error storing witness metadata: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Stored witness metadata %s", endpoint, valueBytes)
+
+	writeResponse(w, http.StatusOK, nil)
+}
+
+func validate(witnessMetadata map[string]*proof.Metadata) error {
+	for witness, m := range witnessMetadata {
+		u, err := url.Parse(witness)
+		if err != nil {
+			return fmt.Errorf("invalid witness IRI [%s]: %w", witness, err)
+		}
+
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("witness IRI [%s] must be an absolute URL", witness)
+		}
+
+		if m == nil {
+			return fmt.Errorf("metadata for witness [%s] is null", witness)
+		}
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if len(body) > 0 {
+		if status == http.StatusOK {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/plain")
+		}
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
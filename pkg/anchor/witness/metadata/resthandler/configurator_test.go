@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/metadata"
+	storemocks "github.com/trustbloc/orb/pkg/store/mocks"
+)
+
+const (
+	configStoreName = "orb-config"
+
+	testMetadata = `{"https://a.com/services/orb":{"operator":"operator-a","jurisdiction":"ca"},` +
+		`"https://b.com/services/orb":{"operator":"operator-b","hosting":"provider-b"}}`
+)
+
+func TestNew(t *testing.T) {
+	configStore, err := mem.NewProvider().OpenStore(configStoreName)
+	require.NoError(t, err)
+
+	configurator := New(configStore)
+	require.NotNil(t, configurator)
+	require.Equal(t, endpoint, configurator.Path())
+	require.Equal(t, http.MethodPost, configurator.Method())
+	require.NotNil(t, configurator.Handler())
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer([]byte(testMetadata)))
+
+		New(configStore).handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		m, err := metadata.New(configStore, 0).Get("https://a.com/services/orb")
+		require.NoError(t, err)
+		require.Equal(t, "operator-a", m["https://a.com/services/orb"].Operator)
+	})
+
+	t.Run("error - invalid metadata", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		for _, body := range []string{
+			`{`,
+			`{"https://a.com/services/orb":"operator-a"}`,
+			`{"a.com":{"operator":"operator-a"}}`,
+			`{"https://a.com/services/orb":null}`,
+			`{":":{"operator":"operator-a"}}`,
+		} {
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer([]byte(body)))
+
+			New(configStore).handle(rw, req)
+
+			result := rw.Result()
+			require.Equalf(t, http.StatusBadRequest, result.StatusCode, "expecting bad request for %s", body)
+			require.NoError(t, result.Body.Close())
+		}
+	})
+
+	t.Run("error - marshal error", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		configurator := New(configStore)
+		configurator.marshal = func(interface{}) ([]byte, error) {
+			return nil, fmt.Errorf("injected marshal error")
+		}
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer([]byte(testMetadata)))
+
+		configurator.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("error - store error", func(t *testing.T) {
+		configStore := &storemocks.Store{}
+		configStore.PutReturns(errors.New("injected store error"))
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer([]byte(testMetadata)))
+
+		New(configStore).handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/metadata"
+)
+
+// MetadataRetriever retrieves the current witness metadata.
+type MetadataRetriever struct {
+	configStore storage.Store
+}
+
+// Path returns the HTTP REST endpoint for the witness metadata retriever.
+func (mr *MetadataRetriever) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the witness metadata retriever.
+func (mr *MetadataRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the MetadataRetriever service.
+func (mr *MetadataRetriever) Handler() common.HTTPRequestHandler {
+	return mr.handle
+}
+
+// NewRetriever returns a new MetadataRetriever.
+func NewRetriever(cfgStore storage.Store) *MetadataRetriever {
+	return &MetadataRetriever{
+		configStore: cfgStore,
+	}
+}
+
+func (mr *MetadataRetriever) handle(w http.ResponseWriter, _ *http.Request) {
+	metadataBytes, err := mr.configStore.Get(metadata.WitnessMetadataKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			logger.Debugf("[%s] Witness metadata not found", endpoint)
+
+			writeResponse(w, http.StatusNotFound, nil)
+
+			return
+		}
+
+		logger.Errorf("[%s] Error retrieving witness metadata: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Debugf("[%s] Retrieved witness metadata %s", endpoint, metadataBytes)
+
+	writeResponse(w, http.StatusOK, metadataBytes)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/metadata"
+	storemocks "github.com/trustbloc/orb/pkg/store/mocks"
+)
+
+func TestNewRetriever(t *testing.T) {
+	configStore, err := mem.NewProvider().OpenStore(configStoreName)
+	require.NoError(t, err)
+
+	retriever := NewRetriever(configStore)
+	require.NotNil(t, retriever)
+	require.Equal(t, endpoint, retriever.Path())
+	require.Equal(t, http.MethodGet, retriever.Method())
+	require.NotNil(t, retriever.Handler())
+}
+
+func TestRetriever(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(metadata.WitnessMetadataKey, []byte(testMetadata)))
+
+		rw := httptest.NewRecorder()
+
+		NewRetriever(configStore).handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "application/json", result.Header.Get("Content-Type"))
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.Equal(t, testMetadata, string(respBytes))
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+
+		NewRetriever(configStore).handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusNotFound, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		configStore := &storemocks.Store{}
+		configStore.GetReturns(nil, errors.New("injected store error"))
+
+		rw := httptest.NewRecorder()
+
+		NewRetriever(configStore).handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+}
@@ -0,0 +1,592 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+)
+
+// Declaration values.
+const (
+	// Group declares a named group of witnesses, e.g. Group(regulators,{https://a.com/orb,https://b.com/orb}).
+	Group = "Group"
+	// Weight declares the weight of a witness, e.g. Weight(https://a.com/orb,3). The default weight is 1.
+	Weight = "Weight"
+)
+
+const punctuation = "(){},"
+
+// Expression is a node in a witness policy expression. The node is either a rule or an operator (AND, OR)
+// that is applied to two or more operands.
+type Expression struct {
+	Rule     *Rule
+	Operator string
+	Operands []*Expression
+}
+
+// Rule applies a gate (OutOf, MinPercent or Distinct) to a set of witnesses. Attribute is the witness
+// metadata attribute (operator, jurisdiction or hosting) for the Distinct gate.
+type Rule struct {
+	Gate      string
+	Value     int
+	Attribute string
+	Target    Target
+}
+
+// Target is the set of witnesses that a rule applies to. The target is either a role (batch, system)
+// or a group of witness IRIs. Named groups are resolved to their members when the policy is parsed.
+// A Distinct rule without a target applies to all witnesses.
+type Target struct {
+	Role    string
+	Group   string
+	Members []string
+}
+
+// Evaluate evaluates the expression. The given function is invoked to evaluate each rule.
+func (e *Expression) Evaluate(evaluateRule func(r *Rule) bool) bool {
+	if e.Rule != nil {
+		return evaluateRule(e.Rule)
+	}
+
+	for _, operand := range e.Operands {
+		satisfied := operand.Evaluate(evaluateRule)
+
+		if e.Operator == OR && satisfied {
+			return true
+		}
+
+		if e.Operator == AND && !satisfied {
+			return false
+		}
+	}
+
+	return e.Operator == AND
+}
+
+func (e *Expression) String() string {
+	if e.Rule != nil {
+		return e.Rule.String()
+	}
+
+	operands := make([]string, len(e.Operands))
+
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+	}
+
+	return "(" + strings.Join(operands, " "+e.Operator+" ") + ")"
+}
+
+func (r *Rule) String() string {
+	if r.Gate == Distinct {
+		if r.Target.IsAll() {
+			return fmt.Sprintf("%s(%d,%s)", r.Gate, r.Value, r.Attribute)
+		}
+
+		return fmt.Sprintf("%s(%d,%s,%s)", r.Gate, r.Value, r.Attribute, r.Target)
+	}
+
+	return fmt.Sprintf("%s(%d,%s)", r.Gate, r.Value, r.Target)
+}
+
+// IsAll returns true if the target applies to all witnesses.
+func (t Target) IsAll() bool {
+	return t.Role == "" && t.Group == "" && len(t.Members) == 0
+}
+
+func (t Target) String() string {
+	switch {
+	case t.Role != "":
+		return t.Role
+	case t.Group != "":
+		return t.Group
+	default:
+		return "{" + strings.Join(t.Members, ",") + "}"
+	}
+}
+
+// isExtended returns true if the given policy tokens use any of the features that are not supported by
+// the original policy syntax, i.e. groups, weights, diversity rules or nested expressions.
+func isExtended(tokens []string) bool {
+	for i, t := range tokens {
+		switch t {
+		case "{", Group, Weight, Distinct:
+			return true
+		case "(":
+			if i == 0 || !isIdent(tokens[i-1]) || isOperator(tokens[i-1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// tokenize splits the policy into identifiers and punctuation. White space is ignored.
+func tokenize(policy string) []string {
+	var tokens []string
+
+	start := -1
+
+	for i, r := range policy {
+		switch {
+		case unicode.IsSpace(r) || strings.ContainsRune(punctuation, r):
+			if start >= 0 {
+				tokens = append(tokens, policy[start:i])
+				start = -1
+			}
+
+			if !unicode.IsSpace(r) {
+				tokens = append(tokens, string(r))
+			}
+		case start < 0:
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, policy[start:])
+	}
+
+	return tokens
+}
+
+// expressionParser parses the extended policy syntax:
+//
+//	policy      := { declaration | LogRequired | expression }
+//	declaration := Group(name,{iri,...}) | Weight(iri,n)
+//	expression  := term { OR term }
+//	term        := factor { AND factor }
+//	factor      := ( expression ) | rule
+//	rule        := OutOf(n,target) | MinPercent(n,target) | Distinct(n,attribute[,target])
+//	target      := batch | system | name | {iri,...}
+//	attribute   := operator | jurisdiction | hosting
+//
+// AND takes precedence over OR. Only one expression is allowed in the policy.
+type expressionParser struct {
+	tokens []string
+	pos    int
+	wp     *WitnessPolicyConfig
+}
+
+func parseExtended(tokens []string) (*WitnessPolicyConfig, error) {
+	wp := &WitnessPolicyConfig{}
+
+	p := &expressionParser{tokens: tokens, wp: wp}
+
+	for p.pos < len(p.tokens) {
+		switch t := p.peek(); t {
+		case LogRequired:
+			p.pos++
+
+			wp.LogRequired = true
+		case Group:
+			if err := p.parseGroup(); err != nil {
+				return nil, err
+			}
+		case Weight:
+			if err := p.parseWeight(); err != nil {
+				return nil, err
+			}
+		default:
+			if wp.Expression != nil {
+				return nil, fmt.Errorf("unexpected token '%s': only one policy expression is allowed", t)
+			}
+
+			expr, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			wp.Expression = expr
+		}
+	}
+
+	if wp.Expression == nil {
+		return nil, errors.New("policy expression not found")
+	}
+
+	if err := wp.resolveGroups(wp.Expression); err != nil {
+		return nil, err
+	}
+
+	return wp, nil
+}
+
+func (p *expressionParser) parseOr() (*Expression, error) {
+	return p.parseOperator(OR, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (*Expression, error) {
+	return p.parseOperator(AND, p.parseFactor)
+}
+
+func (p *expressionParser) parseOperator(operator string,
+	parseOperand func() (*Expression, error)) (*Expression, error) {
+	operand, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*Expression{operand}
+
+	for p.peek() == operator {
+		p.pos++
+
+		operand, err = parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return operand, nil
+	}
+
+	return &Expression{Operator: operator, Operands: operands}, nil
+}
+
+func (p *expressionParser) parseFactor() (*Expression, error) {
+	if p.peek() != "(" {
+		return p.parseRule()
+	}
+
+	p.pos++
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+func (p *expressionParser) parseRule() (*Expression, error) {
+	gate, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+
+	if gate != OutOf && gate != MinPercent && gate != Distinct {
+		return nil, fmt.Errorf("rule not supported: %s", gate)
+	}
+
+	if gate == Distinct {
+		return p.parseDistinct()
+	}
+
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+
+	value, err := p.integer()
+	if err != nil {
+		return nil, fmt.Errorf("first argument for %s policy must be an integer: %w", gate, err)
+	}
+
+	if gate == OutOf && value < 0 {
+		return nil, fmt.Errorf("first argument[%d] for OutOf policy rule must be 0 or positive integer", value)
+	}
+
+	if gate == MinPercent && (value < 0 || value > maxPercent) {
+		return nil, fmt.Errorf("first argument[%d] for MinPercent policy rule must be an integer between 0 and 100",
+			value)
+	}
+
+	if err = p.expect(","); err != nil {
+		return nil, err
+	}
+
+	target, err := p.parseTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return &Expression{Rule: &Rule{Gate: gate, Value: value, Target: target}}, nil
+}
+
+// parseDistinct parses the arguments of a Distinct rule, e.g. Distinct(3,operator) or Distinct(2,jurisdiction,system).
+func (p *expressionParser) parseDistinct() (*Expression, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	value, err := p.integer()
+	if err != nil {
+		return nil, fmt.Errorf("first argument for Distinct policy must be an integer: %w", err)
+	}
+
+	if value <= 0 {
+		return nil, fmt.Errorf("first argument[%d] for Distinct policy rule must be a positive integer", value)
+	}
+
+	if err = p.expect(","); err != nil {
+		return nil, err
+	}
+
+	attribute, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+
+	if !proof.IsAttribute(attribute) {
+		return nil, fmt.Errorf("attribute '%s' not supported for Distinct policy", attribute)
+	}
+
+	rule := &Rule{Gate: Distinct, Value: value, Attribute: attribute}
+
+	if p.peek() == "," {
+		p.pos++
+
+		rule.Target, err = p.parseTarget()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return &Expression{Rule: rule}, nil
+}
+
+func (p *expressionParser) parseTarget() (Target, error) {
+	if p.peek() == "{" {
+		members, err := p.parseMembers()
+		if err != nil {
+			return Target{}, err
+		}
+
+		return Target{Members: members}, nil
+	}
+
+	name, err := p.ident()
+	if err != nil {
+		return Target{}, err
+	}
+
+	if name == RoleBatch || name == RoleSystem {
+		return Target{Role: name}, nil
+	}
+
+	return Target{Group: name}, nil
+}
+
+// parseGroup parses a group declaration, e.g. Group(regulators,{https://a.com/orb,https://b.com/orb}).
+func (p *expressionParser) parseGroup() error {
+	p.pos++
+
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+
+	if name == RoleBatch || name == RoleSystem {
+		return fmt.Errorf("group name '%s' is reserved", name)
+	}
+
+	if _, exists := p.wp.Groups[name]; exists {
+		return fmt.Errorf("group '%s' is already defined", name)
+	}
+
+	if err = p.expect(","); err != nil {
+		return err
+	}
+
+	members, err := p.parseMembers()
+	if err != nil {
+		return err
+	}
+
+	if err = p.expect(")"); err != nil {
+		return err
+	}
+
+	if p.wp.Groups == nil {
+		p.wp.Groups = make(map[string][]string)
+	}
+
+	p.wp.Groups[name] = members
+
+	return nil
+}
+
+// parseWeight parses a weight declaration, e.g. Weight(https://a.com/orb,3).
+func (p *expressionParser) parseWeight() error {
+	p.pos++
+
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	witness, err := p.ident()
+	if err != nil {
+		return err
+	}
+
+	if err = p.expect(","); err != nil {
+		return err
+	}
+
+	weight, err := p.integer()
+	if err != nil {
+		return fmt.Errorf("second argument for Weight must be an integer: %w", err)
+	}
+
+	if weight <= 0 {
+		return fmt.Errorf("second argument[%d] for Weight must be a positive integer", weight)
+	}
+
+	if err = p.expect(")"); err != nil {
+		return err
+	}
+
+	if p.wp.Weights == nil {
+		p.wp.Weights = make(map[string]int)
+	}
+
+	p.wp.Weights[witness] = weight
+
+	return nil
+}
+
+func (p *expressionParser) parseMembers() ([]string, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var members []string
+
+	for {
+		member, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+
+		if p.peek() != "," {
+			break
+		}
+
+		p.pos++
+	}
+
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", errors.New("unexpected end of policy")
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	return t, nil
+}
+
+func (p *expressionParser) expect(expected string) error {
+	t, err := p.next()
+	if err != nil {
+		return fmt.Errorf("expecting '%s': %w", expected, err)
+	}
+
+	if t != expected {
+		return fmt.Errorf("expecting '%s' but got '%s'", expected, t)
+	}
+
+	return nil
+}
+
+func (p *expressionParser) ident() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	if !isIdent(t) || isOperator(t) {
+		return "", fmt.Errorf("unexpected token '%s'", t)
+	}
+
+	return t, nil
+}
+
+func (p *expressionParser) integer() (int, error) {
+	t, err := p.ident()
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(t)
+}
+
+func (wp *WitnessPolicyConfig) resolveGroups(expr *Expression) error {
+	if expr.Rule == nil {
+		for _, operand := range expr.Operands {
+			if err := wp.resolveGroups(operand); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if expr.Rule.Target.Group == "" {
+		return nil
+	}
+
+	members, ok := wp.Groups[expr.Rule.Target.Group]
+	if !ok {
+		return fmt.Errorf("group '%s' is not defined", expr.Rule.Target.Group)
+	}
+
+	expr.Rule.Target.Members = members
+
+	return nil
+}
+
+func isIdent(token string) bool {
+	return len(token) > 1 || !strings.Contains(punctuation, token)
+}
+
+func isOperator(token string) bool {
+	return token == AND || token == OR
+}
@@ -24,6 +24,12 @@ type WitnessPolicyConfig struct {
 	Operator    string
 
 	LogRequired bool
+
+	// Expression is set if the policy uses the extended syntax (groups, weights, diversity or nested expressions),
+	// in which case the batch/system fields above are not used.
+	Expression *Expression
+	Groups     map[string][]string
+	Weights    map[string]int
 }
 
 // Gate values.
@@ -31,6 +37,9 @@ const (
 	OutOf       = "OutOf"
 	MinPercent  = "MinPercent"
 	LogRequired = "LogRequired"
+	// Distinct requires proofs from witnesses with at least n distinct values of a metadata attribute,
+	// e.g. Distinct(3,operator) or Distinct(2,jurisdiction,system).
+	Distinct = "Distinct"
 
 	AND = "AND"
 	OR  = "OR"
@@ -46,7 +55,8 @@ const maxPercent = 100
 
 type operatorFnc func(a, b bool) bool
 
-// Parse parses witness policy from policy string.
+// Parse parses witness policy from policy string. Policies that use groups, weights or nested expressions
+// are parsed into an Expression; all other policies are parsed into the batch/system fields.
 func Parse(policy string) (*WitnessPolicyConfig, error) {
 	// default policy is 100% batch and 100% system witnesses
 	wp := &WitnessPolicyConfig{
@@ -60,6 +70,10 @@ func Parse(policy string) (*WitnessPolicyConfig, error) {
 		return wp, nil
 	}
 
+	if tokens := tokenize(policy); isExtended(tokens) {
+		return parseExtended(tokens)
+	}
+
 	tokens := strings.Split(policy, " ")
 
 	for _, token := range tokens {
@@ -176,7 +190,20 @@ func (wp *WitnessPolicyConfig) processMinPercent(token string) error {
 	return nil
 }
 
+// WeightOf returns the weight of the given witness. The default weight is 1.
+func (wp *WitnessPolicyConfig) WeightOf(witness string) int {
+	if weight, ok := wp.Weights[witness]; ok {
+		return weight
+	}
+
+	return 1
+}
+
 func (wp *WitnessPolicyConfig) String() string {
+	if wp.Expression != nil {
+		return fmt.Sprintf("expression:%s, weights:%v, log:%t", wp.Expression, wp.Weights, wp.LogRequired)
+	}
+
 	return fmt.Sprintf("minBatch:%d, minSystem:%d, percentBatch:%d, percentSystem:%d, operator: %s, log:%t",
 		wp.MinNumberBatch, wp.MinNumberSystem, wp.MinPercentBatch, wp.MinPercentSystem, wp.Operator, wp.LogRequired)
 }
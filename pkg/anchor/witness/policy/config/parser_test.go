@@ -170,3 +170,139 @@ func TestParse_LogRequired(t *testing.T) {
 		require.Equal(t, and(true, false), wp.OperatorFnc(true, false))
 	})
 }
+
+func TestParse_Extended(t *testing.T) {
+	const (
+		regulatorA = "https://regulator-a.com/services/orb"
+		regulatorB = "https://regulator-b.com/services/orb"
+	)
+
+	t.Run("success - named group", func(t *testing.T) {
+		wp, err := Parse("Group(regulators,{" + regulatorA + ", " + regulatorB + "}) " +
+			"OutOf(2,regulators) AND MinPercent(60,system)")
+		require.NoError(t, err)
+		require.NotNil(t, wp)
+
+		require.Equal(t, []string{regulatorA, regulatorB}, wp.Groups["regulators"])
+		require.Equal(t, AND, wp.Expression.Operator)
+		require.Len(t, wp.Expression.Operands, 2)
+
+		rule := wp.Expression.Operands[0].Rule
+		require.Equal(t, OutOf, rule.Gate)
+		require.Equal(t, 2, rule.Value)
+		require.Equal(t, "regulators", rule.Target.Group)
+		require.Equal(t, []string{regulatorA, regulatorB}, rule.Target.Members)
+
+		rule = wp.Expression.Operands[1].Rule
+		require.Equal(t, MinPercent, rule.Gate)
+		require.Equal(t, 60, rule.Value)
+		require.Equal(t, RoleSystem, rule.Target.Role)
+
+		require.Equal(t, "(OutOf(2,regulators) AND MinPercent(60,system))", wp.Expression.String())
+		require.NotEmpty(t, wp.String())
+	})
+
+	t.Run("success - inline group, weights and nested expression", func(t *testing.T) {
+		wp, err := Parse("Weight(" + regulatorA + ",3) LogRequired " +
+			"(OutOf(3,{" + regulatorA + "," + regulatorB + "}) OR OutOf(1,batch)) AND MinPercent(50,system)")
+		require.NoError(t, err)
+		require.NotNil(t, wp)
+
+		require.True(t, wp.LogRequired)
+		require.Equal(t, 3, wp.WeightOf(regulatorA))
+		require.Equal(t, 1, wp.WeightOf(regulatorB))
+		require.Equal(t, "((OutOf(3,{"+regulatorA+","+regulatorB+"}) OR OutOf(1,batch)) AND MinPercent(50,system))",
+			wp.Expression.String())
+	})
+
+	t.Run("success - AND takes precedence over OR", func(t *testing.T) {
+		wp, err := Parse("(OutOf(1,batch) OR OutOf(1,system) AND MinPercent(50,system))")
+		require.NoError(t, err)
+		require.NotNil(t, wp)
+
+		require.Equal(t, "(OutOf(1,batch) OR (OutOf(1,system) AND MinPercent(50,system)))", wp.Expression.String())
+	})
+
+	t.Run("success - distinct", func(t *testing.T) {
+		wp, err := Parse("OutOf(3,system) AND Distinct(3,operator) AND Distinct(2,jurisdiction,{" + regulatorA + "})")
+		require.NoError(t, err)
+		require.NotNil(t, wp)
+
+		require.Len(t, wp.Expression.Operands, 3)
+
+		rule := wp.Expression.Operands[1].Rule
+		require.Equal(t, Distinct, rule.Gate)
+		require.Equal(t, 3, rule.Value)
+		require.Equal(t, "operator", rule.Attribute)
+		require.True(t, rule.Target.IsAll())
+
+		rule = wp.Expression.Operands[2].Rule
+		require.Equal(t, "jurisdiction", rule.Attribute)
+		require.Equal(t, []string{regulatorA}, rule.Target.Members)
+
+		require.Equal(t, "(OutOf(3,system) AND Distinct(3,operator) AND Distinct(2,jurisdiction,{"+regulatorA+"}))",
+			wp.Expression.String())
+
+		wp, err = Parse("Distinct(2,hosting)")
+		require.NoError(t, err)
+		require.Equal(t, "Distinct(2,hosting)", wp.Expression.String())
+	})
+
+	t.Run("success - evaluate", func(t *testing.T) {
+		wp, err := Parse("(OutOf(1,batch) OR OutOf(1,system)) AND MinPercent(50,system)")
+		require.NoError(t, err)
+
+		evaluate := func(satisfied ...string) bool {
+			return wp.Expression.Evaluate(func(r *Rule) bool {
+				for _, s := range satisfied {
+					if s == r.String() {
+						return true
+					}
+				}
+
+				return false
+			})
+		}
+
+		require.True(t, evaluate("OutOf(1,batch)", "MinPercent(50,system)"))
+		require.True(t, evaluate("OutOf(1,system)", "MinPercent(50,system)"))
+		require.False(t, evaluate("OutOf(1,batch)", "OutOf(1,system)"))
+		require.False(t, evaluate("MinPercent(50,system)"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := []struct {
+			policy string
+			err    string
+		}{
+			{policy: "(OutOf(1,batch)", err: "expecting ')': unexpected end of policy"},
+			{policy: "(OutOf(1,batch)))", err: "unexpected token ')'"},
+			{policy: "(Test(1,batch))", err: "rule not supported: Test"},
+			{policy: "(OutOf(a,batch))", err: "first argument for OutOf policy must be an integer"},
+			{policy: "(OutOf(-1,batch))", err: "first argument[-1] for OutOf policy rule must be 0 or positive integer"},
+			{policy: "(MinPercent(101,batch))", err: "must be an integer between 0 and 100"},
+			{policy: "(OutOf(1 batch))", err: "expecting ',' but got 'batch'"},
+			{policy: "(OutOf(1,regulators))", err: "group 'regulators' is not defined"},
+			{policy: "OutOf(1,{})", err: "unexpected token '}'"},
+			{policy: "Group(system,{a})", err: "group name 'system' is reserved"},
+			{policy: "Group(g,{a}) Group(g,{b})", err: "group 'g' is already defined"},
+			{policy: "Group(g,{a})", err: "policy expression not found"},
+			{policy: "Weight(a,0) OutOf(1,{a})", err: "second argument[0] for Weight must be a positive integer"},
+			{policy: "Weight(a,b) OutOf(1,{a})", err: "second argument for Weight must be an integer"},
+			{policy: "(OutOf(1,batch)) (OutOf(1,system))", err: "only one policy expression is allowed"},
+			{policy: "(OutOf(1,AND))", err: "unexpected token 'AND'"},
+			{policy: "Distinct(0,operator)", err: "first argument[0] for Distinct policy rule must be a positive integer"},
+			{policy: "Distinct(a,operator)", err: "first argument for Distinct policy must be an integer"},
+			{policy: "Distinct(2,color)", err: "attribute 'color' not supported for Distinct policy"},
+			{policy: "Distinct(2,operator,regulators)", err: "group 'regulators' is not defined"},
+			{policy: "Distinct(2,operator", err: "expecting ')': unexpected end of policy"},
+		}
+
+		for _, test := range tests {
+			wp, err := Parse(test.policy)
+			require.Errorf(t, err, "expecting error for policy %s", test.policy)
+			require.Nil(t, wp)
+			require.Contains(t, err.Error(), test.err)
+		}
+	})
+}
@@ -0,0 +1,409 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/policy/config"
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+)
+
+// evaluateExpression evaluates a policy that uses the extended syntax (groups, weights, diversity rules
+// or nested expressions).
+func evaluateExpression(cfg *config.WitnessPolicyConfig, witnesses []*proof.WitnessProof) bool {
+	return cfg.Expression.Evaluate(func(rule *config.Rule) bool {
+		if rule.Gate == config.Distinct {
+			return evaluateDistinct(cfg, rule, witnesses)
+		}
+
+		// A witness may be listed more than once (e.g. as both a batch and a system witness)
+		// so only count it once.
+		collected := make(map[string]bool)
+
+		for _, w := range witnesses {
+			if !inTarget(rule.Target, w.Type, w.URI.String()) {
+				continue
+			}
+
+			collected[w.URI.String()] = collected[w.URI.String()] ||
+				(checkLog(cfg.LogRequired, w.HasLog) && w.Proof != nil)
+		}
+
+		collectedWeight := 0
+		totalWeight := 0
+
+		for uri, ok := range collected {
+			totalWeight += cfg.WeightOf(uri)
+
+			if ok {
+				collectedWeight += cfg.WeightOf(uri)
+			}
+		}
+
+		if rule.Target.Role == "" {
+			// The members of a group are required even if they weren't chosen as witnesses for the anchor.
+			totalWeight = totalGroupWeight(cfg, rule.Target)
+		}
+
+		satisfied := evaluateRule(rule, collectedWeight, totalWeight)
+
+		logger.Debugf("witness policy rule[%s] evaluated to[%t] with collected weight[%d] and total weight[%d]",
+			rule, satisfied, collectedWeight, totalWeight)
+
+		return satisfied
+	})
+}
+
+// evaluateDistinct returns true if proofs were collected from witnesses with at least the required number
+// of distinct values for the rule's metadata attribute. Witnesses without a value for the attribute aren't counted.
+func evaluateDistinct(cfg *config.WitnessPolicyConfig, rule *config.Rule, witnesses []*proof.WitnessProof) bool {
+	values := make(map[string]bool)
+
+	for _, w := range witnesses {
+		if !inTarget(rule.Target, w.Type, w.URI.String()) || !checkLog(cfg.LogRequired, w.HasLog) || w.Proof == nil {
+			continue
+		}
+
+		if value := w.Metadata.Attribute(rule.Attribute); value != "" {
+			values[value] = true
+		}
+	}
+
+	satisfied := len(values) >= rule.Value
+
+	logger.Debugf("witness policy rule[%s] evaluated to[%t] with %d distinct %s values",
+		rule, satisfied, len(values), rule.Attribute)
+
+	return satisfied
+}
+
+func evaluateRule(rule *config.Rule, collected, total int) bool {
+	if rule.Gate == config.OutOf {
+		if rule.Value == 0 {
+			return true
+		}
+
+		return evaluate(collected, total, rule.Value, maxPercent)
+	}
+
+	return evaluate(collected, total, 0, rule.Value)
+}
+
+// expressionSelector selects the witnesses that are required to fulfill a policy that uses the extended syntax.
+type expressionSelector struct {
+	selector  selector
+	cfg       *config.WitnessPolicyConfig
+	witnesses []*proof.Witness
+	exclude   []*proof.Witness
+}
+
+// selectWitnesses selects witnesses for the given expression. Preferred witnesses (i.e. witnesses that were
+// already selected for other parts of the expression) are selected first. For AND, the witnesses selected for
+// each operand are combined. Distinct rules are selected first so that the remaining operands may reuse the
+// diverse set of witnesses. For OR, the selection that adds the fewest witnesses is chosen.
+func (s *expressionSelector) selectWitnesses(expr *config.Expression,
+	preferred []*proof.Witness) ([]*proof.Witness, error) {
+	if expr.Rule != nil {
+		if expr.Rule.Gate == config.Distinct {
+			return s.selectForDistinct(expr.Rule, preferred)
+		}
+
+		return s.selectForRule(expr.Rule, preferred)
+	}
+
+	if expr.Operator == config.AND {
+		var selected []*proof.Witness
+
+		for _, operand := range distinctFirst(expr.Operands) {
+			selection, err := s.selectWitnesses(operand, union(preferred, selected))
+			if err != nil {
+				return nil, err
+			}
+
+			selected = union(selected, selection)
+		}
+
+		return selected, nil
+	}
+
+	var selected []*proof.Witness
+
+	var lastErr error
+
+	found := false
+
+	for _, operand := range expr.Operands {
+		selection, err := s.selectWitnesses(operand, preferred)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		if !found || len(difference(selection, preferred)) < len(difference(selected, preferred)) {
+			selected = selection
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, lastErr
+	}
+
+	return selected, nil
+}
+
+func (s *expressionSelector) selectForRule(rule *config.Rule, preferred []*proof.Witness) ([]*proof.Witness, error) {
+	var eligible []*proof.Witness
+
+	totalWeight := 0
+	included := make(map[string]bool)
+
+	for _, w := range s.witnesses {
+		uri := w.URI.String()
+
+		if !inTarget(rule.Target, w.Type, uri) || included[uri] {
+			continue
+		}
+
+		included[uri] = true
+		totalWeight += s.cfg.WeightOf(uri)
+
+		if checkLog(s.cfg.LogRequired, w.HasLog) && !isExcluded(w, s.exclude...) {
+			eligible = append(eligible, w)
+		}
+	}
+
+	if rule.Target.Role == "" {
+		totalWeight = totalGroupWeight(s.cfg, rule.Target)
+	}
+
+	requiredWeight := rule.Value
+
+	if rule.Gate == config.MinPercent {
+		requiredWeight = int(math.Ceil(float64(rule.Value) / maxPercent * float64(totalWeight)))
+	}
+
+	var selected, others []*proof.Witness
+
+	weight := 0
+
+	for _, w := range eligible {
+		if weight < requiredWeight && containsWitness(preferred, w) {
+			selected = append(selected, w)
+			weight += s.cfg.WeightOf(w.URI.String())
+		} else {
+			others = append(others, w)
+		}
+	}
+
+	selection, err := s.selectWeighted(others, requiredWeight-weight)
+	if err != nil {
+		return nil, fmt.Errorf("select witnesses for rule[%s] based on witnesses%s, eligible%s, exclude%s: %w",
+			rule, s.witnesses, eligible, s.exclude, err)
+	}
+
+	selected = append(selected, selection...)
+
+	logger.Debugf("selected %d witnesses for rule[%s]: %v", len(selected), rule, selected)
+
+	return selected, nil
+}
+
+// selectForDistinct selects witnesses with the required number of distinct values for the rule's metadata
+// attribute. Preferred witnesses are used to cover as many values as possible. One witness is chosen by
+// the selector for each of the remaining values and then the selector chooses among those witnesses.
+func (s *expressionSelector) selectForDistinct(rule *config.Rule, preferred []*proof.Witness) ([]*proof.Witness, error) {
+	var eligible, selected []*proof.Witness
+
+	covered := make(map[string]bool)
+
+	for _, w := range s.witnesses {
+		if !inTarget(rule.Target, w.Type, w.URI.String()) || !checkLog(s.cfg.LogRequired, w.HasLog) ||
+			isExcluded(w, s.exclude...) || w.Metadata.Attribute(rule.Attribute) == "" {
+			continue
+		}
+
+		value := w.Metadata.Attribute(rule.Attribute)
+
+		if containsWitness(preferred, w) && !covered[value] && len(covered) < rule.Value {
+			covered[value] = true
+
+			selected = append(selected, w)
+		}
+
+		eligible = append(eligible, w)
+	}
+
+	required := rule.Value - len(covered)
+	if required <= 0 {
+		return selected, nil
+	}
+
+	var values []string
+
+	candidates := make(map[string][]*proof.Witness)
+
+	for _, w := range eligible {
+		value := w.Metadata.Attribute(rule.Attribute)
+
+		if covered[value] || containsWitness(candidates[value], w) {
+			continue
+		}
+
+		if _, ok := candidates[value]; !ok {
+			values = append(values, value)
+		}
+
+		candidates[value] = append(candidates[value], w)
+	}
+
+	if len(values) < required {
+		return nil, fmt.Errorf("select witnesses for rule[%s]: %d distinct %s values are required "+
+			"but only %d are available from witnesses%s, exclude%s",
+			rule, rule.Value, rule.Attribute, len(covered)+len(values), s.witnesses, s.exclude)
+	}
+
+	sort.Strings(values)
+
+	representatives := make([]*proof.Witness, 0, len(values))
+
+	for _, value := range values {
+		representative, err := s.selector.Select(candidates[value], 1)
+		if err != nil {
+			return nil, fmt.Errorf("select witness with %s[%s]: %w", rule.Attribute, value, err)
+		}
+
+		representatives = append(representatives, representative...)
+	}
+
+	selection, err := s.selector.Select(representatives, required)
+	if err != nil {
+		return nil, fmt.Errorf("select witnesses for rule[%s]: %w", rule, err)
+	}
+
+	selected = append(selected, selection...)
+
+	logger.Debugf("selected %d witnesses for rule[%s]: %v", len(selected), rule, selected)
+
+	return selected, nil
+}
+
+// selectWeighted selects witnesses with a combined weight of at least the required weight. If all of the
+// witnesses have the default weight then the selector is used, otherwise the witnesses with the highest
+// weights are selected.
+func (s *expressionSelector) selectWeighted(eligible []*proof.Witness, requiredWeight int) ([]*proof.Witness, error) {
+	if requiredWeight <= 0 {
+		return nil, nil
+	}
+
+	if !hasWeights(s.cfg, eligible) {
+		return s.selector.Select(eligible, requiredWeight)
+	}
+
+	sorted := make([]*proof.Witness, len(eligible))
+	copy(sorted, eligible)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return s.cfg.WeightOf(sorted[i].URI.String()) > s.cfg.WeightOf(sorted[j].URI.String())
+	})
+
+	var selected []*proof.Witness
+
+	weight := 0
+
+	for _, w := range sorted {
+		if weight >= requiredWeight {
+			break
+		}
+
+		selected = append(selected, w)
+		weight += s.cfg.WeightOf(w.URI.String())
+	}
+
+	if weight < requiredWeight {
+		return nil, fmt.Errorf("unable to select witnesses with a combined weight of %d from %d witnesses",
+			requiredWeight, len(eligible))
+	}
+
+	return selected, nil
+}
+
+// distinctFirst returns a copy of the operands with the Distinct rules moved to the front.
+func distinctFirst(operands []*config.Expression) []*config.Expression {
+	sorted := make([]*config.Expression, len(operands))
+	copy(sorted, operands)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return isDistinct(sorted[i]) && !isDistinct(sorted[j])
+	})
+
+	return sorted
+}
+
+func isDistinct(expr *config.Expression) bool {
+	return expr.Rule != nil && expr.Rule.Gate == config.Distinct
+}
+
+func containsWitness(witnesses []*proof.Witness, witness *proof.Witness) bool {
+	for _, w := range witnesses {
+		if w.URI.String() == witness.URI.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func union(a, b []*proof.Witness) []*proof.Witness {
+	result := make([]*proof.Witness, 0, len(a)+len(b))
+
+	result = append(result, a...)
+
+	return append(result, difference(b, a)...)
+}
+
+func inTarget(target config.Target, witnessType proof.WitnessType, uri string) bool {
+	if target.IsAll() {
+		return true
+	}
+
+	if target.Role != "" {
+		return string(witnessType) == target.Role
+	}
+
+	for _, member := range target.Members {
+		if member == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+func totalGroupWeight(cfg *config.WitnessPolicyConfig, target config.Target) int {
+	total := 0
+
+	for _, member := range target.Members {
+		total += cfg.WeightOf(member)
+	}
+
+	return total
+}
+
+func hasWeights(cfg *config.WitnessPolicyConfig, witnesses []*proof.Witness) bool {
+	for _, w := range witnesses {
+		if cfg.WeightOf(w.URI.String()) != 1 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -25,6 +25,7 @@ type Inspector struct {
 	*Providers
 
 	maxWitnessDelay time.Duration
+	healthRecorder  healthRecorder
 }
 
 type anchorEventStore interface {
@@ -55,11 +56,37 @@ type Outbox interface {
 
 type outboxProvider func() Outbox
 
+type healthRecorder interface {
+	Timeout(anchorID string, witness *url.URL) error
+}
+
+type noopHealthRecorder struct{}
+
+func (r *noopHealthRecorder) Timeout(string, *url.URL) error {
+	return nil
+}
+
+// Option is an option for the policy inspector.
+type Option func(opts *Inspector)
+
+// WithHealthRecorder sets an optional recorder that's notified when a selected witness
+// did not provide a proof within the 'in-process' grace period.
+func WithHealthRecorder(recorder healthRecorder) Option {
+	return func(opts *Inspector) {
+		opts.healthRecorder = recorder
+	}
+}
+
 // New returns a new anchor inspector.
-func New(providers *Providers, maxWitnessDelay time.Duration) (*Inspector, error) {
+func New(providers *Providers, maxWitnessDelay time.Duration, opts ...Option) (*Inspector, error) {
 	w := &Inspector{
 		Providers:       providers,
 		maxWitnessDelay: maxWitnessDelay,
+		healthRecorder:  &noopHealthRecorder{},
+	}
+
+	for _, opt := range opts {
+		opt(w)
 	}
 
 	return w, nil
@@ -139,22 +166,29 @@ func (c *Inspector) getAdditionalWitnesses(anchorID string) ([]*url.URL, error)
 				logger.Debugf("witness[%s] did not return proof within 'in-process' grace period, "+
 					"this witness will be ignored during re-selecting witnesses.", w.URI.String())
 
+				if e := c.healthRecorder.Timeout(anchorID, w.URI); e != nil {
+					logger.Warnf("Failed to record timeout for anchorID[%s] from witness[%s]: %s", anchorID, w.URI, e)
+				}
+
 				excludeWitness := &proof.Witness{
 					Type:     w.Type,
 					URI:      w.URI,
 					HasLog:   w.HasLog,
 					Selected: w.Selected,
+					Metadata: w.Metadata,
 				}
 
 				excludeWitnesses = append(excludeWitnesses, excludeWitness)
 			}
 		}
 
+		// the witness metadata is kept so that the diversity rules of the policy are applied when re-selecting
 		witness := &proof.Witness{
 			Type:     w.Type,
 			URI:      w.URI,
 			HasLog:   w.HasLog,
 			Selected: w.Selected,
+			Metadata: w.Metadata,
 		}
 
 		allWitnesses = append(allWitnesses, witness)
@@ -74,22 +74,43 @@ func TestInspector_CheckPolicy(t *testing.T) {
 
 		err = witnessStore.Put(anchorEvent.Index().String(), []*proof.Witness{
 			{URI: selectedWitnessURL, Selected: true},
-			{URI: notSelectedWitnessURL, Selected: false},
+			{URI: notSelectedWitnessURL, Selected: false, Metadata: &proof.Metadata{Operator: "operator-a"}},
 		})
 		require.NoError(t, err)
 
+		witnessPolicy := &mockWitnessPolicy{}
+
 		providers := &Providers{
 			AnchorEventStore: anchorEventStore,
 			Outbox:           func() Outbox { return &mockOutbox{} },
 			WitnessStore:     witnessStore,
-			WitnessPolicy:    &mockWitnessPolicy{},
+			WitnessPolicy:    witnessPolicy,
 		}
 
-		c, err := New(providers, testMaxWitnessDelay)
+		recorder := &mockHealthRecorder{err: fmt.Errorf("injected recorder error")}
+
+		c, err := New(providers, testMaxWitnessDelay, WithHealthRecorder(recorder))
 		require.NoError(t, err)
 
 		err = c.CheckPolicy(anchorEvent.Index().String())
 		require.NoError(t, err)
+		require.Equal(t, []string{selectedWitnessURL.String()}, recorder.timeouts)
+
+		// The witness metadata is passed to the witness policy when re-selecting witnesses. The witnesses
+		// aren't returned in any particular order, so they're looked up by URI.
+		require.Len(t, witnessPolicy.received, 2)
+
+		received := make(map[string]*proof.Witness)
+
+		for _, w := range witnessPolicy.received {
+			received[w.URI.String()] = w
+		}
+
+		require.Contains(t, received, selectedWitnessURL.String())
+		require.Nil(t, received[selectedWitnessURL.String()].Metadata)
+		require.Contains(t, received, notSelectedWitnessURL.String())
+		require.NotNil(t, received[notSelectedWitnessURL.String()].Metadata)
+		require.Equal(t, "operator-a", received[notSelectedWitnessURL.String()].Metadata.Operator)
 	})
 
 	t.Run("error - get anchor event error", func(t *testing.T) {
@@ -329,9 +350,13 @@ func (w *mockWitnessStore) UpdateWitnessSelection(anchorID string, witnesses []*
 type mockWitnessPolicy struct {
 	Witnesses []*proof.Witness
 	Err       error
+
+	received []*proof.Witness
 }
 
 func (wp *mockWitnessPolicy) Select(witnesses []*proof.Witness, _ ...*proof.Witness) ([]*proof.Witness, error) {
+	wp.received = witnesses
+
 	if wp.Err != nil {
 		return nil, wp.Err
 	}
@@ -377,3 +402,14 @@ const jsonAnchorEvent = `{
   "published": "2022-02-10T18:50:48.681998572Z",
   "type": "AnchorEvent"
 }`
+
+type mockHealthRecorder struct {
+	timeouts []string
+	err      error
+}
+
+func (r *mockHealthRecorder) Timeout(_ string, witness *url.URL) error {
+	r.timeouts = append(r.timeouts, witness.String())
+
+	return r.err
+}
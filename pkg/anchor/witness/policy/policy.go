@@ -28,7 +28,8 @@ type WitnessPolicy struct {
 	cache       gCache
 	cacheExpiry time.Duration
 
-	selector selector
+	selector      selector
+	healthChecker healthChecker
 }
 
 const (
@@ -51,14 +52,39 @@ type selector interface {
 	Select(witnesses []*proof.Witness, n int) ([]*proof.Witness, error)
 }
 
+type healthChecker interface {
+	Unhealthy(witnesses ...string) ([]string, error)
+}
+
+// Option is an option for the witness policy evaluator.
+type Option func(opts *WitnessPolicy)
+
+// WithSelector sets the selector that's used to select a subset of witnesses. The default is random selection.
+func WithSelector(s selector) Option {
+	return func(opts *WitnessPolicy) {
+		opts.selector = s
+	}
+}
+
+// WithHealthChecker sets the health checker that's used to skip unhealthy witnesses during selection.
+func WithHealthChecker(hc healthChecker) Option {
+	return func(opts *WitnessPolicy) {
+		opts.healthChecker = hc
+	}
+}
+
 // New will create new witness policy evaluator.
-func New(configStore storage.Store, policyCacheExpiry time.Duration) (*WitnessPolicy, error) {
+func New(configStore storage.Store, policyCacheExpiry time.Duration, opts ...Option) (*WitnessPolicy, error) {
 	wp := &WitnessPolicy{
 		configStore: configStore,
 		cacheExpiry: policyCacheExpiry,
 		selector:    random.New(),
 	}
 
+	for _, opt := range opts {
+		opt(wp)
+	}
+
 	wp.cache = gcache.New(defaultCacheSize).ARC().LoaderExpireFunc(wp.loadWitnessPolicy).Build()
 
 	policy, _, err := wp.loadWitnessPolicy(WitnessPolicyKey)
@@ -84,6 +110,14 @@ func (wp *WitnessPolicy) Evaluate(witnesses []*proof.WitnessProof) (bool, error)
 		return false, err
 	}
 
+	if cfg.Expression != nil {
+		evaluated := evaluateExpression(cfg, witnesses)
+
+		logger.Debugf("witness policy[%s] evaluated to[%t] for witnesses: %s", cfg, evaluated, witnesses)
+
+		return evaluated, nil
+	}
+
 	totalSystemWitnesses := 0
 	collectedSystemWitnesses := 0
 
@@ -182,13 +216,37 @@ func checkLog(logRequired, hasLog bool) bool {
 	return true
 }
 
-// Select selects min number of witnesses required based on witness policy.
+// Select selects min number of witnesses required based on witness policy. Unhealthy witnesses are skipped
+// unless the policy can't be satisfied without them.
 func (wp *WitnessPolicy) Select(witnesses []*proof.Witness, exclude ...*proof.Witness) ([]*proof.Witness, error) {
 	cfg, err := wp.getWitnessPolicyConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	unhealthy := wp.getUnhealthy(witnesses, exclude)
+
+	if len(unhealthy) > 0 {
+		selected, e := wp.selectWitnesses(cfg, witnesses, append(unhealthy, exclude...)...)
+		if e == nil {
+			return selected, nil
+		}
+
+		logger.Warnf("Unable to select witnesses without unhealthy witnesses %s. Unhealthy witnesses "+
+			"will be considered for selection: %s", unhealthy, e)
+	}
+
+	return wp.selectWitnesses(cfg, witnesses, exclude...)
+}
+
+func (wp *WitnessPolicy) selectWitnesses(cfg *config.WitnessPolicyConfig, witnesses []*proof.Witness,
+	exclude ...*proof.Witness) ([]*proof.Witness, error) {
+	if cfg.Expression != nil {
+		s := &expressionSelector{selector: wp.selector, cfg: cfg, witnesses: witnesses, exclude: exclude}
+
+		return s.selectWitnesses(cfg.Expression, nil)
+	}
+
 	selectedBatchWitnesses, selectedSystemWitnesses, err := wp.selectBatchAndSystemWitnesses(witnesses, cfg, exclude...)
 	if err != nil {
 		return nil, err
@@ -205,6 +263,49 @@ func (wp *WitnessPolicy) Select(witnesses []*proof.Witness, exclude ...*proof.Wi
 	return selectedBatchWitnesses, nil
 }
 
+// getUnhealthy returns the witnesses which are unhealthy and not already excluded. Health is best-effort
+// so an error from the health checker is only logged.
+func (wp *WitnessPolicy) getUnhealthy(witnesses, exclude []*proof.Witness) []*proof.Witness {
+	if wp.healthChecker == nil {
+		return nil
+	}
+
+	var uris []string
+
+	for _, w := range difference(witnesses, exclude) {
+		uris = append(uris, w.URI.String())
+	}
+
+	if len(uris) == 0 {
+		return nil
+	}
+
+	unhealthyURIs, err := wp.healthChecker.Unhealthy(uris...)
+	if err != nil {
+		logger.Warnf("Unable to determine the health of witnesses %s: %s", uris, err)
+
+		return nil
+	}
+
+	var unhealthy []*proof.Witness
+
+	for _, w := range witnesses {
+		for _, uri := range unhealthyURIs {
+			if w.URI.String() == uri {
+				unhealthy = append(unhealthy, w)
+
+				break
+			}
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		logger.Debugf("Skipping unhealthy witnesses: %s", unhealthy)
+	}
+
+	return unhealthy
+}
+
 // selects min number of batch and system witnesses that are required to fulfill witness policy.
 func (wp *WitnessPolicy) selectBatchAndSystemWitnesses(witnesses []*proof.Witness, // nolint:funlen,gocyclo,cyclop
 	cfg *config.WitnessPolicyConfig, exclude ...*proof.Witness) ([]*proof.Witness, []*proof.Witness, error) {
@@ -34,6 +34,18 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, wp)
 	})
 
+	t.Run("success - with selector", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		s := &mockSelector{}
+
+		wp, err := New(configStore, defaultPolicyCacheExpiry, WithSelector(s))
+		require.NoError(t, err)
+		require.NotNil(t, wp)
+		require.Equal(t, s, wp.selector)
+	})
+
 	t.Run("success - call to cache loader function", func(t *testing.T) {
 		configStore, err := mem.NewProvider().OpenStore(configStoreName)
 		require.NoError(t, err)
@@ -1331,3 +1343,426 @@ func (mc *mockCache) SetWithExpire(interface{}, interface{}, time.Duration) erro
 
 	return nil
 }
+
+func TestEvaluate_Expression(t *testing.T) {
+	regulatorAURL, err := url.Parse("https://regulator-a.com/services/orb")
+	require.NoError(t, err)
+
+	regulatorBURL, err := url.Parse("https://regulator-b.com/services/orb")
+	require.NoError(t, err)
+
+	systemWitnessURL, err := url.Parse("https://system.com/services/orb")
+	require.NoError(t, err)
+
+	systemWitness2URL, err := url.Parse("https://other.system.com/services/orb")
+	require.NoError(t, err)
+
+	newPolicy := func(t *testing.T, policy string) *WitnessPolicy {
+		t.Helper()
+
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessPolicyKey, []byte(fmt.Sprintf("%q", policy))))
+
+		wp, err := New(configStore, defaultPolicyCacheExpiry)
+		require.NoError(t, err)
+
+		return wp
+	}
+
+	const groupPolicy = "Group(regulators,{https://regulator-a.com/services/orb,https://regulator-b.com/services/orb}) " +
+		"OutOf(2,regulators) AND MinPercent(60,system)"
+
+	t.Run("success - group and system satisfied", func(t *testing.T) {
+		wp := newPolicy(t, groupPolicy)
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: regulatorAURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: regulatorBURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitness2URL},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("success - group not satisfied", func(t *testing.T) {
+		wp := newPolicy(t, groupPolicy)
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: regulatorAURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof")},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("success - system not satisfied", func(t *testing.T) {
+		wp := newPolicy(t, groupPolicy)
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: regulatorAURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeBatch, URI: regulatorBURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitness2URL},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("success - weights", func(t *testing.T) {
+		wp := newPolicy(t, "Weight(https://regulator-a.com/services/orb,2) OutOf(2,system)")
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeSystem, URI: regulatorAURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL},
+			{Type: proof.WitnessTypeSystem, URI: systemWitness2URL},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeSystem, URI: regulatorAURL},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitness2URL},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("success - nested expression with log required", func(t *testing.T) {
+		wp := newPolicy(t, "LogRequired (OutOf(1,{https://regulator-a.com/services/orb}) OR OutOf(1,batch)) "+
+			"AND OutOf(1,system)")
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: regulatorAURL, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof"), HasLog: true},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: regulatorAURL, Proof: []byte("proof"), HasLog: true},
+			{Type: proof.WitnessTypeSystem, URI: systemWitnessURL, Proof: []byte("proof"), HasLog: true},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
+
+func TestSelect_Expression(t *testing.T) {
+	regulatorAURL, err := url.Parse("https://regulator-a.com/services/orb")
+	require.NoError(t, err)
+
+	regulatorBURL, err := url.Parse("https://regulator-b.com/services/orb")
+	require.NoError(t, err)
+
+	batchWitnessURL, err := url.Parse("https://batch.com/services/orb")
+	require.NoError(t, err)
+
+	systemWitnessURL, err := url.Parse("https://system.com/services/orb")
+	require.NoError(t, err)
+
+	systemWitness2URL, err := url.Parse("https://other.system.com/services/orb")
+	require.NoError(t, err)
+
+	witnesses := []*proof.Witness{
+		{Type: proof.WitnessTypeBatch, URI: batchWitnessURL},
+		{Type: proof.WitnessTypeBatch, URI: regulatorAURL},
+		{Type: proof.WitnessTypeSystem, URI: regulatorBURL},
+		{Type: proof.WitnessTypeSystem, URI: systemWitnessURL},
+		{Type: proof.WitnessTypeSystem, URI: systemWitness2URL},
+	}
+
+	newPolicy := func(t *testing.T, policy string) *WitnessPolicy {
+		t.Helper()
+
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessPolicyKey, []byte(fmt.Sprintf("%q", policy))))
+
+		wp, err := New(configStore, defaultPolicyCacheExpiry)
+		require.NoError(t, err)
+
+		return wp
+	}
+
+	t.Run("success - AND", func(t *testing.T) {
+		wp := newPolicy(t, "Group(regulators,{https://regulator-a.com/services/orb,"+
+			"https://regulator-b.com/services/orb}) OutOf(2,regulators) AND OutOf(1,batch)")
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, regulatorAURL.String(), selected[0].URI.String())
+		require.Equal(t, regulatorBURL.String(), selected[1].URI.String())
+	})
+
+	t.Run("success - OR selects the smallest set", func(t *testing.T) {
+		wp := newPolicy(t, "(MinPercent(100,system) OR OutOf(1,{https://regulator-a.com/services/orb}))")
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		require.Equal(t, regulatorAURL.String(), selected[0].URI.String())
+
+		selected, err = wp.Select(witnesses, &proof.Witness{URI: regulatorAURL})
+		require.NoError(t, err)
+		require.Len(t, selected, 3)
+	})
+
+	t.Run("success - AND prefers witnesses selected by other operands", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(1,{https://regulator-b.com/services/orb}) AND OutOf(1,system)")
+
+		// The second operand may be satisfied by any system witness but the regulator that was selected
+		// for the first operand is also a system witness, so no additional witness should be selected.
+		for i := 0; i < 20; i++ {
+			selected, err := wp.Select(witnesses)
+			require.NoError(t, err)
+			require.Len(t, selected, 1)
+			require.Equal(t, regulatorBURL.String(), selected[0].URI.String())
+		}
+	})
+
+	t.Run("success - OR prefers witnesses selected by other operands", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(1,{https://regulator-b.com/services/orb}) AND (OutOf(2,batch) OR OutOf(2,system))")
+
+		// Both OR operands select two witnesses, but the system operand only adds one witness to the regulator
+		// that was already selected, whereas the batch operand adds two.
+		for i := 0; i < 20; i++ {
+			selected, err := wp.Select(witnesses)
+			require.NoError(t, err)
+			require.Len(t, selected, 2)
+			require.Equal(t, regulatorBURL.String(), selected[0].URI.String())
+			require.Equal(t, proof.WitnessTypeSystem, selected[1].Type)
+		}
+	})
+
+	t.Run("success - weights", func(t *testing.T) {
+		wp := newPolicy(t, "Weight(https://system.com/services/orb,3) MinPercent(60,system)")
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		require.Equal(t, systemWitnessURL.String(), selected[0].URI.String())
+	})
+
+	t.Run("error - AND", func(t *testing.T) {
+		wp := newPolicy(t, "(OutOf(2,batch) AND OutOf(4,system))")
+
+		_, err := wp.Select(witnesses)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unable to select 4 witnesses from witness array of length 3")
+	})
+
+	t.Run("error - OR", func(t *testing.T) {
+		wp := newPolicy(t, "Weight(https://system.com/services/orb,3) (OutOf(3,batch) OR OutOf(6,system))")
+
+		_, err := wp.Select(witnesses)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unable to select witnesses with a combined weight of 6 from 3 witnesses")
+	})
+}
+
+func TestSelect_Health(t *testing.T) {
+	witness1URL, err := url.Parse("https://domain1.com/services/orb")
+	require.NoError(t, err)
+
+	witness2URL, err := url.Parse("https://domain2.com/services/orb")
+	require.NoError(t, err)
+
+	witness3URL, err := url.Parse("https://domain3.com/services/orb")
+	require.NoError(t, err)
+
+	witnesses := []*proof.Witness{
+		{Type: proof.WitnessTypeSystem, URI: witness1URL},
+		{Type: proof.WitnessTypeSystem, URI: witness2URL},
+		{Type: proof.WitnessTypeSystem, URI: witness3URL},
+	}
+
+	newPolicy := func(t *testing.T, policy string, hc healthChecker) *WitnessPolicy {
+		t.Helper()
+
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessPolicyKey, []byte(fmt.Sprintf("%q", policy))))
+
+		wp, err := New(configStore, defaultPolicyCacheExpiry, WithSelector(&mockSelector{}), WithHealthChecker(hc))
+		require.NoError(t, err)
+
+		return wp
+	}
+
+	t.Run("unhealthy witnesses are skipped", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(2,system)", &mockHealthChecker{unhealthy: []string{witness1URL.String()}})
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, witness2URL.String(), selected[0].URI.String())
+		require.Equal(t, witness3URL.String(), selected[1].URI.String())
+	})
+
+	t.Run("unhealthy witnesses are selected if required", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(2,system)",
+			&mockHealthChecker{unhealthy: []string{witness1URL.String(), witness2URL.String()}})
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, witness1URL.String(), selected[0].URI.String())
+		require.Equal(t, witness2URL.String(), selected[1].URI.String())
+	})
+
+	t.Run("excluded witnesses remain excluded", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(2,system)", &mockHealthChecker{unhealthy: []string{witness2URL.String()}})
+
+		selected, err := wp.Select(witnesses, witnesses[0])
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, witness2URL.String(), selected[0].URI.String())
+		require.Equal(t, witness3URL.String(), selected[1].URI.String())
+	})
+
+	t.Run("health checker error", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(1,system)", &mockHealthChecker{err: fmt.Errorf("injected health error")})
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		require.Equal(t, witness1URL.String(), selected[0].URI.String())
+	})
+}
+
+func TestDiversity(t *testing.T) {
+	witness1URL, err := url.Parse("https://domain1.com/services/orb")
+	require.NoError(t, err)
+
+	witness2URL, err := url.Parse("https://domain2.com/services/orb")
+	require.NoError(t, err)
+
+	witness3URL, err := url.Parse("https://domain3.com/services/orb")
+	require.NoError(t, err)
+
+	witness4URL, err := url.Parse("https://domain4.com/services/orb")
+	require.NoError(t, err)
+
+	witness5URL, err := url.Parse("https://domain5.com/services/orb")
+	require.NoError(t, err)
+
+	metadata1 := &proof.Metadata{Operator: "operator-a", Jurisdiction: "ca"}
+	metadata2 := &proof.Metadata{Operator: "operator-a", Jurisdiction: "us"}
+	metadata3 := &proof.Metadata{Operator: "operator-b", Jurisdiction: "ca"}
+	metadata4 := &proof.Metadata{Operator: "operator-c"}
+
+	witnesses := []*proof.Witness{
+		{Type: proof.WitnessTypeSystem, URI: witness1URL, Metadata: metadata1},
+		{Type: proof.WitnessTypeSystem, URI: witness2URL, Metadata: metadata2},
+		{Type: proof.WitnessTypeSystem, URI: witness3URL, Metadata: metadata3},
+		{Type: proof.WitnessTypeSystem, URI: witness4URL, Metadata: metadata4},
+		{Type: proof.WitnessTypeSystem, URI: witness5URL},
+	}
+
+	newPolicy := func(t *testing.T, policy string) *WitnessPolicy {
+		t.Helper()
+
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, configStore.Put(WitnessPolicyKey, []byte(fmt.Sprintf("%q", policy))))
+
+		wp, err := New(configStore, defaultPolicyCacheExpiry, WithSelector(&mockSelector{}))
+		require.NoError(t, err)
+
+		return wp
+	}
+
+	t.Run("evaluate", func(t *testing.T) {
+		wp := newPolicy(t, "Distinct(2,operator)")
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeSystem, URI: witness1URL, Metadata: metadata1, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: witness2URL, Metadata: metadata2, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: witness3URL, Metadata: metadata3},
+			{Type: proof.WitnessTypeSystem, URI: witness5URL, Proof: []byte("proof")},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeSystem, URI: witness1URL, Metadata: metadata1, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: witness3URL, Metadata: metadata3, Proof: []byte("proof")},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("evaluate - target", func(t *testing.T) {
+		wp := newPolicy(t, "Distinct(2,jurisdiction,batch)")
+
+		ok, err := wp.Evaluate([]*proof.WitnessProof{
+			{Type: proof.WitnessTypeBatch, URI: witness1URL, Metadata: metadata1, Proof: []byte("proof")},
+			{Type: proof.WitnessTypeSystem, URI: witness2URL, Metadata: metadata2, Proof: []byte("proof")},
+		})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("select - distinct rules are selected first", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(3,system) AND Distinct(3,operator)")
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 3)
+		require.Equal(t, witness1URL.String(), selected[0].URI.String())
+		require.Equal(t, witness3URL.String(), selected[1].URI.String())
+		require.Equal(t, witness4URL.String(), selected[2].URI.String())
+	})
+
+	t.Run("select - preferred witnesses cover distinct values", func(t *testing.T) {
+		wp := newPolicy(t, "OutOf(1,{https://domain2.com/services/orb}) AND "+
+			"(Distinct(2,operator) OR OutOf(5,system))")
+
+		selected, err := wp.Select(witnesses)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, witness2URL.String(), selected[0].URI.String())
+		require.Equal(t, witness3URL.String(), selected[1].URI.String())
+	})
+
+	t.Run("select - excluded witnesses", func(t *testing.T) {
+		wp := newPolicy(t, "Distinct(2,jurisdiction)")
+
+		selected, err := wp.Select(witnesses, witnesses[0])
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, witness3URL.String(), selected[0].URI.String())
+		require.Equal(t, witness2URL.String(), selected[1].URI.String())
+
+		_, err = wp.Select(witnesses, witnesses[1])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "2 distinct jurisdiction values are required but only 1 are available")
+	})
+}
+
+type mockSelector struct{}
+
+func (s *mockSelector) Select(witnesses []*proof.Witness, n int) ([]*proof.Witness, error) {
+	if n > len(witnesses) {
+		return nil, fmt.Errorf("unable to select %d witnesses from witness array of length %d", n, len(witnesses))
+	}
+
+	return witnesses[:n], nil
+}
+
+type mockHealthChecker struct {
+	unhealthy []string
+	err       error
+}
+
+func (m *mockHealthChecker) Unhealthy(...string) ([]string, error) {
+	return m.unhealthy, m.err
+}
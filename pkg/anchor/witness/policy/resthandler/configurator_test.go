@@ -24,6 +24,9 @@ import (
 const (
 	testPolicy      = "MinPercent(50,system) AND MinPercent(50,batch)"
 	configStoreName = "orb-config"
+
+	testExtendedPolicy = "Group(regulators,{https://a.com/services/orb,https://b.com/services/orb}) " +
+		"OutOf(2,regulators) AND (MinPercent(60,system) OR OutOf(1,batch))"
 )
 
 func TestNew(t *testing.T) {
@@ -60,6 +63,53 @@ func TestHandler(t *testing.T) {
 		require.NoError(t, result.Body.Close())
 	})
 
+	t.Run("success - extended policy", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		policyConfigurator := New(configStore)
+		require.NotNil(t, policyConfigurator)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer([]byte(testExtendedPolicy)))
+
+		policyConfigurator.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		rw = httptest.NewRecorder()
+
+		NewRetriever(configStore).handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result = rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+
+		respBytes, err := ioutil.ReadAll(result.Body)
+		require.NoError(t, err)
+		require.Equal(t, testExtendedPolicy, string(respBytes))
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("error - invalid extended policy", func(t *testing.T) {
+		configStore, err := mem.NewProvider().OpenStore(configStoreName)
+		require.NoError(t, err)
+
+		policyConfigurator := New(configStore)
+		require.NotNil(t, policyConfigurator)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, endpoint,
+			bytes.NewBuffer([]byte("OutOf(2,regulators) AND MinPercent(60,system)")))
+
+		policyConfigurator.handle(rw, req)
+
+		result := rw.Result()
+		require.Equal(t, http.StatusBadRequest, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
 	t.Run("error - reader error", func(t *testing.T) {
 		configStore, err := mem.NewProvider().OpenStore(configStoreName)
 		require.NoError(t, err)
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	"github.com/trustbloc/orb/pkg/store/witnessstats"
+)
+
+var logger = log.New("witness-selector")
+
+type statsStore interface {
+	Get(witnesses ...string) (map[string]*witnessstats.Stats, error)
+	NextRoundRobin(n int) (int, error)
+}
+
+type lessFunc func(a, b *witnessstats.Stats) bool
+
+// Selector selects the n highest ranked witnesses according to the statistics in the witness stats store.
+type Selector struct {
+	name  string
+	store statsStore
+	less  lessFunc
+}
+
+// NewLeastRecentlyUsed returns a selector that selects the witnesses which were least recently sent an offer.
+func NewLeastRecentlyUsed(store statsStore) *Selector {
+	return newSelector("least-recently-used", store, func(a, b *witnessstats.Stats) bool {
+		return a.LastSelected.Before(b.LastSelected)
+	})
+}
+
+// NewLowestLatency returns a selector that selects the witnesses with the lowest average latency between
+// sending an offer and receiving a proof. Witnesses that have not yet provided a proof are selected first
+// so that their latency may be measured.
+func NewLowestLatency(store statsStore) *Selector {
+	return newSelector("lowest-latency", store, func(a, b *witnessstats.Stats) bool {
+		return a.Latency < b.Latency
+	})
+}
+
+// NewFewestOutstandingOffers returns a selector that selects the witnesses with the fewest offers for which
+// a proof has not yet been received.
+func NewFewestOutstandingOffers(store statsStore) *Selector {
+	return newSelector("fewest-outstanding-offers", store, func(a, b *witnessstats.Stats) bool {
+		return a.OutstandingOffers < b.OutstandingOffers
+	})
+}
+
+func newSelector(name string, store statsStore, less lessFunc) *Selector {
+	return &Selector{
+		name:  name,
+		store: store,
+		less:  less,
+	}
+}
+
+// Select selects n witnesses out of provided list of witnesses.
+func (s *Selector) Select(witnesses []*proof.Witness, n int) ([]*proof.Witness, error) {
+	if err := validate(witnesses, n); err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, nil
+	}
+
+	stats, err := s.store.Get(uris(witnesses)...)
+	if err != nil {
+		return nil, fmt.Errorf("get witness stats: %w", err)
+	}
+
+	ranked := sortByURI(witnesses)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return s.less(stats[ranked[i].URI.String()], stats[ranked[j].URI.String()])
+	})
+
+	logger.Debugf("Selected %d %s witnesses from %s", n, s.name, ranked)
+
+	return ranked[:n], nil
+}
+
+// RoundRobinSelector selects witnesses in turn. The position is kept in the witness stats store
+// so that it's shared by all server instances (see witnessstats.Store.NextRoundRobin).
+type RoundRobinSelector struct {
+	store statsStore
+}
+
+// NewRoundRobin returns a new round-robin selector.
+func NewRoundRobin(store statsStore) *RoundRobinSelector {
+	return &RoundRobinSelector{store: store}
+}
+
+// Select selects n witnesses out of provided list of witnesses.
+func (s *RoundRobinSelector) Select(witnesses []*proof.Witness, n int) ([]*proof.Witness, error) {
+	if err := validate(witnesses, n); err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, nil
+	}
+
+	position, err := s.store.NextRoundRobin(n)
+	if err != nil {
+		return nil, fmt.Errorf("get round-robin position: %w", err)
+	}
+
+	sorted := sortByURI(witnesses)
+
+	selected := make([]*proof.Witness, n)
+
+	for i := 0; i < n; i++ {
+		selected[i] = sorted[(position+i)%len(sorted)]
+	}
+
+	logger.Debugf("Selected %d round-robin witnesses at position %d from %s", n, position, sorted)
+
+	return selected, nil
+}
+
+func validate(witnesses []*proof.Witness, n int) error {
+	if n > len(witnesses) {
+		return fmt.Errorf("unable to select %d witnesses from witness array of length %d", n, len(witnesses))
+	}
+
+	return nil
+}
+
+// sortByURI returns a copy of the witnesses sorted by URI so that all server instances see the same order.
+func sortByURI(witnesses []*proof.Witness) []*proof.Witness {
+	sorted := make([]*proof.Witness, len(witnesses))
+	copy(sorted, witnesses)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].URI.String() < sorted[j].URI.String()
+	})
+
+	return sorted
+}
+
+func uris(witnesses []*proof.Witness) []string {
+	result := make([]string, len(witnesses))
+
+	for i, w := range witnesses {
+		result[i] = w.URI.String()
+	}
+
+	return result
+}
@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stats
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/witnessstats"
+)
+
+const (
+	witness1 = "https://domain1.com/services/orb"
+	witness2 = "https://domain2.com/services/orb"
+	witness3 = "https://domain3.com/services/orb"
+)
+
+func TestSelector(t *testing.T) {
+	now := time.Now()
+
+	store := &mockStatsStore{
+		stats: map[string]*witnessstats.Stats{
+			witness1: {LastSelected: now, Latency: 3 * time.Second, OutstandingOffers: 0},
+			witness2: {LastSelected: now.Add(-time.Minute), Latency: time.Second, OutstandingOffers: 5},
+			witness3: {LastSelected: now.Add(-time.Hour), Latency: 2 * time.Second, OutstandingOffers: 2},
+		},
+	}
+
+	witnesses := newWitnesses(witness1, witness2, witness3)
+
+	t.Run("least recently used", func(t *testing.T) {
+		selected, err := NewLeastRecentlyUsed(store).Select(witnesses, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{witness3, witness2}, uris(selected))
+	})
+
+	t.Run("lowest latency", func(t *testing.T) {
+		selected, err := NewLowestLatency(store).Select(witnesses, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{witness2, witness3}, uris(selected))
+	})
+
+	t.Run("fewest outstanding offers", func(t *testing.T) {
+		selected, err := NewFewestOutstandingOffers(store).Select(witnesses, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{witness1, witness3}, uris(selected))
+	})
+
+	t.Run("no stats", func(t *testing.T) {
+		selected, err := NewFewestOutstandingOffers(&mockStatsStore{}).Select(newWitnesses(witness3, witness1), 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{witness1}, uris(selected))
+	})
+
+	t.Run("select none", func(t *testing.T) {
+		selected, err := NewLowestLatency(store).Select(witnesses, 0)
+		require.NoError(t, err)
+		require.Empty(t, selected)
+	})
+
+	t.Run("error - too many", func(t *testing.T) {
+		selected, err := NewLowestLatency(store).Select(witnesses, 4)
+		require.EqualError(t, err, "unable to select 4 witnesses from witness array of length 3")
+		require.Empty(t, selected)
+	})
+
+	t.Run("error - stats store", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		_, err := NewLowestLatency(&mockStatsStore{err: errExpected}).Select(witnesses, 1)
+		require.ErrorIs(t, err, errExpected)
+	})
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	store := &mockStatsStore{}
+
+	s := NewRoundRobin(store)
+
+	witnesses := newWitnesses(witness3, witness1, witness2)
+
+	selected, err := s.Select(witnesses, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{witness1, witness2}, uris(selected))
+
+	selected, err = s.Select(witnesses, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{witness3, witness1}, uris(selected))
+
+	selected, err = s.Select(witnesses, 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{witness2, witness3, witness1}, uris(selected))
+
+	selected, err = s.Select(witnesses, 0)
+	require.NoError(t, err)
+	require.Empty(t, selected)
+
+	_, err = s.Select(witnesses, 4)
+	require.EqualError(t, err, "unable to select 4 witnesses from witness array of length 3")
+
+	errExpected := errors.New("injected store error")
+
+	_, err = NewRoundRobin(&mockStatsStore{err: errExpected}).Select(witnesses, 1)
+	require.ErrorIs(t, err, errExpected)
+}
+
+func newWitnesses(uris ...string) []*proof.Witness {
+	witnesses := make([]*proof.Witness, len(uris))
+
+	for i, uri := range uris {
+		witnesses[i] = &proof.Witness{Type: proof.WitnessTypeSystem, URI: testutil.MustParseURL(uri)}
+	}
+
+	return witnesses
+}
+
+type mockStatsStore struct {
+	stats    map[string]*witnessstats.Stats
+	position int
+	err      error
+}
+
+func (m *mockStatsStore) Get(witnesses ...string) (map[string]*witnessstats.Stats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	result := make(map[string]*witnessstats.Stats)
+
+	for _, w := range witnesses {
+		if s, ok := m.stats[w]; ok {
+			result[w] = s
+		} else {
+			result[w] = &witnessstats.Stats{}
+		}
+	}
+
+	return result, nil
+}
+
+func (m *mockStatsStore) NextRoundRobin(n int) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	position := m.position
+	m.position += n
+
+	return position, nil
+}
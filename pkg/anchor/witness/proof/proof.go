@@ -17,6 +17,7 @@ type Witness struct {
 	URI      *url.URL
 	HasLog   bool
 	Selected bool
+	Metadata *Metadata
 }
 
 func (wf *Witness) String() string {
@@ -30,12 +31,51 @@ type WitnessProof struct {
 	HasLog   bool
 	Selected bool
 	Proof    []byte
+	Metadata *Metadata
 }
 
 func (wf *WitnessProof) String() string {
 	return fmt.Sprintf("{type:%s, witness:%s, log:%t, proof:%s}", wf.Type, wf.URI, wf.HasLog, string(wf.Proof))
 }
 
+// Metadata contains information about a witness that is used to ensure that the witnesses for an anchor
+// are independent of one another.
+type Metadata struct {
+	Operator     string `json:"operator,omitempty"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	Hosting      string `json:"hosting,omitempty"`
+}
+
+// Attribute values.
+const (
+	AttributeOperator     = "operator"
+	AttributeJurisdiction = "jurisdiction"
+	AttributeHosting      = "hosting"
+)
+
+// Attribute returns the value of the given attribute or an empty string if the attribute isn't set.
+func (m *Metadata) Attribute(name string) string {
+	if m == nil {
+		return ""
+	}
+
+	switch name {
+	case AttributeOperator:
+		return m.Operator
+	case AttributeJurisdiction:
+		return m.Jurisdiction
+	case AttributeHosting:
+		return m.Hosting
+	default:
+		return ""
+	}
+}
+
+// IsAttribute returns true if the given name is a supported metadata attribute.
+func IsAttribute(name string) bool {
+	return name == AttributeOperator || name == AttributeJurisdiction || name == AttributeHosting
+}
+
 // WitnessType defines valid values for witness type.
 type WitnessType string
 
@@ -58,4 +98,8 @@ const (
 
 	// AnchorIndexStatusCompleted defines "completed" status.
 	AnchorIndexStatusCompleted AnchorIndexStatus = "completed"
+
+	// AnchorIndexStatusFailed defines "failed" status. The witness policy was not satisfied within
+	// the maximum witness delay.
+	AnchorIndexStatusFailed AnchorIndexStatus = "failed"
 )
@@ -29,4 +29,20 @@ func Test(t *testing.T) {
 		wp := &WitnessProof{Type: WitnessTypeBatch, URI: testURI, HasLog: true, Proof: []byte("proof")}
 		require.Equal(t, wp.String(), "{type:batch, witness:http://domain.com/service, log:true, proof:proof}")
 	})
+
+	t.Run("metadata", func(t *testing.T) {
+		m := &Metadata{Operator: "operator1", Jurisdiction: "ca", Hosting: "provider1"}
+
+		require.Equal(t, "operator1", m.Attribute(AttributeOperator))
+		require.Equal(t, "ca", m.Attribute(AttributeJurisdiction))
+		require.Equal(t, "provider1", m.Attribute(AttributeHosting))
+		require.Empty(t, m.Attribute("unknown"))
+
+		var nilMetadata *Metadata
+
+		require.Empty(t, nilMetadata.Attribute(AttributeOperator))
+
+		require.True(t, IsAttribute(AttributeOperator))
+		require.False(t, IsAttribute("unknown"))
+	})
 }
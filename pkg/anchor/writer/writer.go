@@ -97,6 +97,18 @@ type Providers struct {
 	WFClient               webfingerClient
 	DocumentLoader         ld.DocumentLoader
 	VCStore                storage.Store
+	WitnessMetadata        witnessMetadataProvider
+	OperationStatusStore   operationStatusStore
+}
+
+type operationStatusStore interface {
+	Anchored(anchorID string, suffixes ...string) error
+	AnchorFailed(anchorID, reason string, suffixes ...string) error
+	Published(anchorID, anchorHashlink string) error
+}
+
+type witnessMetadataProvider interface {
+	Get(witnesses ...string) (map[string]*proof.Metadata, error)
 }
 
 type webfingerClient interface {
@@ -198,11 +210,21 @@ func New(namespace string, apServiceIRI, casURL *url.URL, anchorAttachmentMediaT
 
 // WriteAnchor writes Sidetree anchor string to Orb anchor.
 func (c *Writer) WriteAnchor(anchor string, attachments []*protocol.AnchorDocument,
-	refs []*operation.Reference, version uint64) error {
+	refs []*operation.Reference, version uint64) (err error) {
 	startTime := time.Now()
 
 	defer func() { c.metrics.WriteAnchorTime(time.Since(startTime)) }()
 
+	var anchorID string
+
+	defer func() {
+		if err != nil {
+			c.updateOperationStatus(func(s operationStatusStore) error {
+				return s.AnchorFailed(anchorID, err.Error(), getSuffixes(refs)...)
+			})
+		}
+	}()
+
 	// get previous anchors for each did that is referenced in this anchor
 	previousAnchors, err := c.getPreviousAnchors(refs)
 	if err != nil {
@@ -249,6 +271,13 @@ func (c *Writer) WriteAnchor(anchor string, attachments []*protocol.AnchorDocume
 
 	logger.Debugf("signed and stored anchor event %s for anchor: %s", anchorEvent.Index(), anchor)
 
+	anchorID = anchorEvent.Index().String()
+
+	// The status is updated before the offer is posted since the anchor event may be witnessed immediately.
+	c.updateOperationStatus(func(s operationStatusStore) error {
+		return s.Anchored(anchorID, getSuffixes(refs)...)
+	})
+
 	// send an offer activity to witnesses (request witnessing anchor credential from non-local witness logs)
 	err = c.postOfferActivity(anchorEvent, vc, batchWitnesses)
 	if err != nil {
@@ -483,6 +512,11 @@ func (c *Writer) handle(anchorEvent *vocab.AnchorEventType) error {
 		return fmt.Errorf("add witnessed anchor event[%s] to anchor graph: %w", anchorEvent.Index(), err)
 	}
 
+	// The status is updated before the anchor event is published so that the observer may find the operations.
+	c.updateOperationStatus(func(s operationStatusStore) error {
+		return s.Published(anchorEvent.Index().String(), anchorEventRef)
+	})
+
 	logger.Debugf("Publishing anchor event[%s] ref[%s]", anchorEvent.Index(), anchorEventRef)
 
 	err = c.anchorPublisher.PublishAnchor(&anchorinfo.AnchorInfo{Hashlink: anchorEventRef})
@@ -748,6 +782,11 @@ func (c *Writer) getWitnesses(anchorID string, batchOpsWitnesses []string) ([]*u
 	witnesses = append(witnesses, batchWitnesses...)
 	witnesses = append(witnesses, systemWitnesses...)
 
+	err = c.setWitnessMetadata(witnesses)
+	if err != nil {
+		return nil, err
+	}
+
 	selectedWitnesses, err := c.WitnessPolicy.Select(witnesses)
 	if err != nil {
 		return nil, fmt.Errorf("select witnesses: %w", err)
@@ -785,6 +824,43 @@ func (c *Writer) getWitnesses(anchorID string, batchOpsWitnesses []string) ([]*u
 	return selectedWitnessesIRI, nil
 }
 
+// setWitnessMetadata sets the metadata (operator, jurisdiction, hosting provider) of the given witnesses so that
+// the metadata may be used by the witness policy and is stored along with the witnesses.
+func (c *Writer) setWitnessMetadata(witnesses []*proof.Witness) error {
+	if c.WitnessMetadata == nil || len(witnesses) == 0 {
+		return nil
+	}
+
+	uris := make([]string, len(witnesses))
+
+	for i, w := range witnesses {
+		uris[i] = w.URI.String()
+	}
+
+	metadata, err := c.WitnessMetadata.Get(uris...)
+	if err != nil {
+		return fmt.Errorf("get witness metadata: %w", err)
+	}
+
+	for _, w := range witnesses {
+		w.Metadata = metadata[w.URI.String()]
+	}
+
+	return nil
+}
+
+// updateOperationStatus updates the lifecycle status of the operations in an anchor event. The status is
+// informational so an error is logged but otherwise ignored.
+func (c *Writer) updateOperationStatus(update func(s operationStatusStore) error) {
+	if c.OperationStatusStore == nil {
+		return
+	}
+
+	if err := update(c.OperationStatusStore); err != nil {
+		logger.Warnf("failed to update operation status: %s", err)
+	}
+}
+
 func updateWitnessSelectionFlag(witnesses []*proof.Witness, selectedWitnesses map[string]bool) []*proof.Witness {
 	for _, w := range witnesses {
 		if _, ok := selectedWitnesses[w.URI.String()]; ok {
@@ -153,6 +153,8 @@ func TestWriter_WriteAnchor(t *testing.T) {
 		statusStore, err := anchoreventstatus.New(mem.NewProvider(), testutil.GetExpiryService(t), time.Minute)
 		require.NoError(t, err)
 
+		opStatusStore := &mockOperationStatusStore{}
+
 		providers := &Providers{
 			AnchorGraph:            anchorGraph,
 			DidAnchors:             memdidanchor.New(),
@@ -167,6 +169,7 @@ func TestWriter_WriteAnchor(t *testing.T) {
 			AnchorEventStore:       anchorEventStore,
 			AnchorEventStatusStore: statusStore,
 			WFClient:               wfClient,
+			OperationStatusStore:   opStatusStore,
 		}
 
 		c, err := New(namespace, apServiceIRI, casIRI, vocab.JSONMediaType, providers,
@@ -196,6 +199,9 @@ func TestWriter_WriteAnchor(t *testing.T) {
 
 		err = c.WriteAnchor("1.anchor", nil, opRefs, 0)
 		require.NoError(t, err)
+
+		require.NotEmpty(t, opStatusStore.anchorID)
+		require.Equal(t, []string{"did-1"}, opStatusStore.anchored)
 	})
 
 	t.Run("success - witness needs to be resolved via IPNS", func(t *testing.T) {
@@ -363,6 +369,8 @@ func TestWriter_WriteAnchor(t *testing.T) {
 
 		wit := &mockWitness{proofBytes: []byte(`{"proof": {"domain":"domain","created": "2021-02-23T19:36:07Z"}}`)}
 
+		opStatusStore := &mockOperationStatusStore{}
+
 		providers := &Providers{
 			AnchorGraph:            anchorGraph,
 			DidAnchors:             memdidanchor.New(),
@@ -378,6 +386,7 @@ func TestWriter_WriteAnchor(t *testing.T) {
 			AnchorEventStore:       anchorEventStore,
 			AnchorEventStatusStore: &mockstatusStore{Err: fmt.Errorf("status error")},
 			WFClient:               wfClient,
+			OperationStatusStore:   opStatusStore,
 		}
 
 		c, err := New(namespace, apServiceIRI, casIRI, vocab.JSONMediaType, providers,
@@ -407,6 +416,10 @@ func TestWriter_WriteAnchor(t *testing.T) {
 		err = c.WriteAnchor("1.anchor", nil, opRefs, 0)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to set 'in-process' status")
+
+		require.NotEmpty(t, opStatusStore.failedAnchorID)
+		require.Equal(t, opStatusStore.anchorID, opStatusStore.failedAnchorID)
+		require.Contains(t, opStatusStore.reason, "failed to set 'in-process' status")
 	})
 
 	t.Run("Parse created time (error)", func(t *testing.T) {
@@ -1020,16 +1033,20 @@ func TestWriter_handle(t *testing.T) {
 		vcStore, err := mem.NewProvider().OpenStore("verifiable")
 		require.NoError(t, err)
 
+		// An error updating the operation status is only logged.
+		opStatusStore := &mockOperationStatusStore{err: errors.New("injected status error")}
+
 		providers := &Providers{
-			AnchorGraph:      anchorGraph,
-			DidAnchors:       memdidanchor.New(),
-			AnchorBuilder:    &mockTxnBuilder{},
-			Outbox:           &mockOutbox{},
-			Signer:           &mockSigner{},
-			AnchorEventStore: anchorEventStore,
-			WitnessStore:     &mockWitnessStore{},
-			VCStore:          vcStore,
-			DocumentLoader:   testutil.GetLoader(t),
+			AnchorGraph:          anchorGraph,
+			DidAnchors:           memdidanchor.New(),
+			AnchorBuilder:        &mockTxnBuilder{},
+			Outbox:               &mockOutbox{},
+			Signer:               &mockSigner{},
+			AnchorEventStore:     anchorEventStore,
+			WitnessStore:         &mockWitnessStore{},
+			VCStore:              vcStore,
+			DocumentLoader:       testutil.GetLoader(t),
+			OperationStatusStore: opStatusStore,
 		}
 
 		c, err := New(namespace, apServiceIRI, casIRI, vocab.JSONMediaType, providers, &anchormocks.AnchorPublisher{}, ps,
@@ -1040,6 +1057,8 @@ func TestWriter_handle(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(jsonAnchorEvent), anchorEvent))
 
 		require.NoError(t, c.handle(anchorEvent))
+
+		require.Equal(t, anchorEvent.Index().String(), opStatusStore.publishedAnchorID)
 	})
 
 	t.Run("error - add anchor credential to txn graph error", func(t *testing.T) {
@@ -1590,6 +1609,48 @@ func TestWriter_getWitnesses(t *testing.T) {
 	})
 }
 
+func TestWriter_setWitnessMetadata(t *testing.T) {
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	t.Run("success", func(t *testing.T) {
+		metadataProvider := &mockWitnessMetadata{
+			metadata: map[string]*proof.Metadata{
+				witness1.String(): {Operator: "operator-a", Jurisdiction: "ca"},
+			},
+		}
+
+		c := &Writer{Providers: &Providers{WitnessMetadata: metadataProvider}}
+
+		witnesses := []*proof.Witness{
+			{Type: proof.WitnessTypeBatch, URI: witness1},
+			{Type: proof.WitnessTypeSystem, URI: witness2},
+		}
+
+		require.NoError(t, c.setWitnessMetadata(witnesses))
+		require.Equal(t, "operator-a", witnesses[0].Metadata.Operator)
+		require.Nil(t, witnesses[1].Metadata)
+	})
+
+	t.Run("no metadata provider", func(t *testing.T) {
+		c := &Writer{Providers: &Providers{}}
+
+		witnesses := []*proof.Witness{{Type: proof.WitnessTypeBatch, URI: witness1}}
+
+		require.NoError(t, c.setWitnessMetadata(witnesses))
+		require.Nil(t, witnesses[0].Metadata)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errExpected := errors.New("injected metadata error")
+
+		c := &Writer{Providers: &Providers{WitnessMetadata: &mockWitnessMetadata{err: errExpected}}}
+
+		err := c.setWitnessMetadata([]*proof.Witness{{Type: proof.WitnessTypeBatch, URI: witness1}})
+		require.ErrorIs(t, err, errExpected)
+	})
+}
+
 func TestWriter_getBatchWitnessesIRI(t *testing.T) {
 	ps := mempubsub.New(mempubsub.Config{})
 	defer ps.Stop()
@@ -1889,6 +1950,15 @@ func (wp *mockWitnessPolicy) Select(witnesses []*proof.Witness, _ ...*proof.Witn
 	return witnesses, nil
 }
 
+type mockWitnessMetadata struct {
+	metadata map[string]*proof.Metadata
+	err      error
+}
+
+func (m *mockWitnessMetadata) Get(...string) (map[string]*proof.Metadata, error) {
+	return m.metadata, m.err
+}
+
 //nolint: lll
 const jsonAnchorEvent = `{
   "@context": [
@@ -1964,3 +2034,32 @@ const jsonAnchorEventInvalidWitness = `{
   "published": "2022-02-10T18:50:48.681998572Z",
   "type": "AnchorEvent"
 }`
+
+type mockOperationStatusStore struct {
+	anchorID          string
+	anchored          []string
+	failedAnchorID    string
+	reason            string
+	publishedAnchorID string
+	err               error
+}
+
+func (m *mockOperationStatusStore) Anchored(anchorID string, suffixes ...string) error {
+	m.anchorID = anchorID
+	m.anchored = suffixes
+
+	return m.err
+}
+
+func (m *mockOperationStatusStore) AnchorFailed(anchorID, reason string, _ ...string) error {
+	m.failedAnchorID = anchorID
+	m.reason = reason
+
+	return m.err
+}
+
+func (m *mockOperationStatusStore) Published(anchorID, _ string) error {
+	m.publishedAnchorID = anchorID
+
+	return m.err
+}
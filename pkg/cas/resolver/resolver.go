@@ -47,6 +47,10 @@ type metricsProvider interface {
 	CASResolveTime(value time.Duration)
 }
 
+type urlMapper interface {
+	MapURL(u *url.URL) (*url.URL, error)
+}
+
 // Resolver represents a resolver that can resolve data in a CAS based on a CID (with possible hint) and a WebCAS URL.
 type Resolver struct {
 	localCAS       extendedcasclient.Client
@@ -54,6 +58,18 @@ type Resolver struct {
 	webCASResolver WebCASResolver
 	metrics        metricsProvider
 	hl             *hashlink.HashLink
+	urlMapper      urlMapper
+}
+
+// Option is a resolver option.
+type Option func(opts *Resolver)
+
+// WithURLMapper sets the mapper that's used to map WebCAS links and domains to a new location when the data can't
+// be retrieved from the original location, for example, after the remote server has moved to a new domain.
+func WithURLMapper(mapper urlMapper) Option {
+	return func(opts *Resolver) {
+		opts.urlMapper = mapper
+	}
 }
 
 type ipfsReader interface {
@@ -63,14 +79,20 @@ type ipfsReader interface {
 // New returns a new Resolver.
 // ipfsReader is optional. If not provided (is nil), CIDs with IPFS hints won't be resolvable.
 func New(casClient extendedcasclient.Client, ipfsReader ipfsReader, webCASResolver WebCASResolver,
-	metrics metricsProvider) *Resolver {
-	return &Resolver{
+	metrics metricsProvider, opts ...Option) *Resolver {
+	r := &Resolver{
 		localCAS:       casClient,
 		ipfsReader:     ipfsReader,
 		webCASResolver: webCASResolver,
 		metrics:        metrics,
 		hl:             hashlink.New(),
 	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
 // Resolve does the following:
@@ -216,7 +238,20 @@ func separateLinks(links []string) ([]string, []string) {
 func (h *Resolver) getAndStoreDataFromDomain(domain, resourceHash string) ([]byte, string, error) {
 	dataFromRemote, err := h.webCASResolver.Resolve(domain, resourceHash)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to resolve domain and resource hash via WebCAS: %w", err)
+		movedDomain, ok := h.mapDomain(domain)
+		if !ok {
+			return nil, "", fmt.Errorf("failed to resolve domain and resource hash via WebCAS: %w", err)
+		}
+
+		logger.Debugf("failed to resolve resource hash[%s] from domain[%s]: %s. Trying domain[%s] to which it has moved",
+			resourceHash, domain, err, movedDomain)
+
+		dataFromRemote, err = h.webCASResolver.Resolve(movedDomain, resourceHash)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to resolve moved domain and resource hash via WebCAS: %w", err)
+		}
+
+		domain = movedDomain
 	}
 
 	localHL, errStoreLocallyAndVerifyHash := h.storeLocallyAndVerifyHash(dataFromRemote, resourceHash)
@@ -231,6 +266,24 @@ func (h *Resolver) getAndStoreDataFromDomain(domain, resourceHash string) ([]byt
 }
 
 func (h *Resolver) getAndStoreDataFromWebCASEndpoints(webCASEndpoints []string, cid string) ([]byte, string, error) {
+	data, localHL, err := h.getAndStoreDataFromFirstAvailableEndpoint(webCASEndpoints, cid)
+	if err == nil {
+		return data, localHL, nil
+	}
+
+	movedEndpoints := h.mapEndpoints(webCASEndpoints)
+	if len(movedEndpoints) == 0 {
+		return nil, "", err
+	}
+
+	logger.Debugf("failed to get data for cid[%s] from WebCAS endpoints%s: %s. Trying moved endpoints%s",
+		cid, webCASEndpoints, err, movedEndpoints)
+
+	return h.getAndStoreDataFromFirstAvailableEndpoint(movedEndpoints, cid)
+}
+
+func (h *Resolver) getAndStoreDataFromFirstAvailableEndpoint(webCASEndpoints []string,
+	cid string) ([]byte, string, error) {
 	if len(webCASEndpoints) == 0 {
 		return nil, "", fmt.Errorf("must provide at least one cas endpoint in order to retrieve data")
 	}
@@ -282,6 +335,55 @@ func (h *Resolver) getAndStoreDataFromWebCASEndpoint(webCASEndpoint, cid string)
 	return dataFromRemote, localHL, nil
 }
 
+// mapDomain returns the domain to which the given domain has moved, if any.
+func (h *Resolver) mapDomain(domain string) (string, bool) {
+	if h.urlMapper == nil {
+		return "", false
+	}
+
+	mappedURL, err := h.urlMapper.MapURL(&url.URL{Host: domain})
+	if err != nil {
+		logger.Warnf("failed to map domain[%s]: %s", domain, err)
+
+		return "", false
+	}
+
+	if mappedURL == nil {
+		return "", false
+	}
+
+	return mappedURL.Host, true
+}
+
+// mapEndpoints returns the endpoints that point to a domain that has moved, mapped to the new domain.
+func (h *Resolver) mapEndpoints(webCASEndpoints []string) []string {
+	if h.urlMapper == nil {
+		return nil
+	}
+
+	var mappedEndpoints []string
+
+	for _, endpoint := range webCASEndpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			continue
+		}
+
+		mappedURL, err := h.urlMapper.MapURL(endpointURL)
+		if err != nil {
+			logger.Warnf("failed to map WebCAS endpoint[%s]: %s", endpoint, err)
+
+			continue
+		}
+
+		if mappedURL != nil {
+			mappedEndpoints = append(mappedEndpoints, mappedURL.String())
+		}
+	}
+
+	return mappedEndpoints
+}
+
 func (h *Resolver) getAndStoreDataFromIPFS(cid, resourceHash string) ([]byte, string, error) {
 	resp, err := h.ipfsReader.Read(cid)
 	if err != nil {
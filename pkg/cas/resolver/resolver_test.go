@@ -28,6 +28,7 @@ import (
 	apmocks "github.com/trustbloc/orb/pkg/activitypub/mocks"
 	"github.com/trustbloc/orb/pkg/activitypub/resthandler"
 	"github.com/trustbloc/orb/pkg/activitypub/service/mocks"
+	"github.com/trustbloc/orb/pkg/activitypub/service/moveregistry"
 	"github.com/trustbloc/orb/pkg/activitypub/store/memstore"
 	"github.com/trustbloc/orb/pkg/cas/extendedcasclient"
 	"github.com/trustbloc/orb/pkg/cas/ipfs"
@@ -209,6 +210,60 @@ func TestResolver_Resolve(t *testing.T) {
 		require.NotEmpty(t, localHL)
 	})
 
+	t.Run("Had to retrieve data from remote server that moved to a new domain", func(t *testing.T) {
+		casClient := createInMemoryCAS(t)
+
+		hl, err := casClient.Write([]byte(sampleData))
+		require.NoError(t, err)
+		require.NotEmpty(t, hl)
+
+		webCAS := webcas.New(&resthandler.Config{}, memstore.New(""), &mocks.SignatureVerifier{},
+			casClient, &apmocks.AuthTokenMgr{})
+		require.NotNil(t, webCAS)
+
+		router := mux.NewRouter()
+
+		router.HandleFunc(webCAS.Path(), webCAS.Handler())
+
+		// This test server is the new location of the "remote Orb server".
+		testServer := httptest.NewServer(router)
+		defer testServer.Close()
+
+		rh, err := hashlink.New().CreateResourceHash([]byte(sampleData))
+		require.NoError(t, err)
+
+		// The hashlink points to the old domain of the remote server, which is no longer available.
+		md, err := hashlink.New().CreateMetadataFromLinks([]string{"http://127.0.0.1:1/cas/" + rh})
+		require.NoError(t, err)
+
+		hl = hashlink.GetHashLink(rh, md)
+
+		t.Run("No mapping -> error", func(t *testing.T) {
+			resolver := createNewResolver(t, createInMemoryCAS(t), nil)
+
+			_, _, err := resolver.Resolve(nil, hl, nil)
+			require.Error(t, err)
+		})
+
+		t.Run("Success", func(t *testing.T) {
+			store, err := mem.NewProvider().OpenStore("moved")
+			require.NoError(t, err)
+
+			registry := moveregistry.New(store)
+			require.NoError(t, registry.Add(
+				testutil.MustParseURL("http://127.0.0.1:1/services/orb"),
+				testutil.MustParseURL(testServer.URL+"/services/orb"),
+			))
+
+			resolver := createNewResolver(t, createInMemoryCAS(t), nil, WithURLMapper(registry))
+
+			data, localHL, err := resolver.Resolve(nil, hl, nil)
+			require.NoError(t, err)
+			require.Equal(t, sampleData, string(data))
+			require.NotEmpty(t, localHL)
+		})
+	})
+
 	t.Run("Had to retrieve data from remote server via hint", func(t *testing.T) {
 		hlUtil := hashlink.New()
 		hl, err := hlUtil.CreateHashLink([]byte(sampleData), nil)
@@ -779,7 +834,45 @@ func TestResolver_Resolve(t *testing.T) {
 	})
 }
 
-func createNewResolver(t *testing.T, casClient extendedcasclient.Client, ipfsReader ipfsReader) *Resolver {
+func TestResolver_MapDomain(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore("moved")
+	require.NoError(t, err)
+
+	registry := moveregistry.New(store)
+	require.NoError(t, registry.Add(
+		testutil.MustParseURL("https://old.domain.com/services/orb"),
+		testutil.MustParseURL("https://new.domain.com/services/orb"),
+	))
+
+	t.Run("No mapper", func(t *testing.T) {
+		resolver := createNewResolver(t, createInMemoryCAS(t), nil)
+
+		_, ok := resolver.mapDomain("old.domain.com")
+		require.False(t, ok)
+		require.Empty(t, resolver.mapEndpoints([]string{"https://old.domain.com/cas/xxx"}))
+	})
+
+	t.Run("With mapper", func(t *testing.T) {
+		resolver := createNewResolver(t, createInMemoryCAS(t), nil, WithURLMapper(registry))
+
+		domain, ok := resolver.mapDomain("old.domain.com")
+		require.True(t, ok)
+		require.Equal(t, "new.domain.com", domain)
+
+		_, ok = resolver.mapDomain("other.domain.com")
+		require.False(t, ok)
+
+		endpoints := resolver.mapEndpoints([]string{
+			"https://old.domain.com/cas/xxx",
+			"https://other.domain.com/cas/xxx",
+			":invalid",
+		})
+		require.Equal(t, []string{"https://new.domain.com/cas/xxx"}, endpoints)
+	})
+}
+
+func createNewResolver(t *testing.T, casClient extendedcasclient.Client, ipfsReader ipfsReader,
+	opts ...Option) *Resolver {
 	t.Helper()
 
 	webFingerResolver := webfingerclient.New()
@@ -791,7 +884,7 @@ func createNewResolver(t *testing.T, casClient extendedcasclient.Client, ipfsRea
 		webFingerResolver,
 		"http")
 
-	casResolver := New(casClient, ipfsReader, webCASResolver, &orbmocks.MetricsProvider{})
+	casResolver := New(casClient, ipfsReader, webCASResolver, &orbmocks.MetricsProvider{}, opts...)
 	require.NotNil(t, casResolver)
 
 	return casResolver
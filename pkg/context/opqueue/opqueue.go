@@ -23,6 +23,7 @@ import (
 	"github.com/trustbloc/orb/pkg/lifecycle"
 	"github.com/trustbloc/orb/pkg/pubsub/spi"
 	"github.com/trustbloc/orb/pkg/store/expiry"
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
 )
 
 var logger = log.New("sidetree_context")
@@ -76,6 +77,30 @@ type dataExpiryService interface {
 	Register(store storage.Store, expiryTagName, storeName string, opts ...expiry.Option)
 }
 
+type operationStatusStore interface {
+	Queued(id, suffix string) error
+	Batched(ids ...string) error
+	Failed(id, reason string) error
+}
+
+type noopOperationStatusStore struct{}
+
+func (s *noopOperationStatusStore) Queued(string, string) error { return nil }
+
+func (s *noopOperationStatusStore) Batched(...string) error { return nil }
+
+func (s *noopOperationStatusStore) Failed(string, string) error { return nil }
+
+// Option is an option for the operation queue.
+type Option func(q *Queue)
+
+// WithOperationStatusStore sets the store that tracks the lifecycle status of operations.
+func WithOperationStatusStore(store operationStatusStore) Option {
+	return func(q *Queue) {
+		q.opStatusStore = store
+	}
+}
+
 type opQueueTask struct {
 	// ServerID is the unique ID of the server instance.
 	ServerID string `json:"serverID"`
@@ -121,11 +146,12 @@ type Queue struct {
 	taskMgr             taskManager
 	expiryService       dataExpiryService
 	maxRetries          int
+	opStatusStore       operationStatusStore
 }
 
 // New returns a new operation queue.
 func New(cfg Config, pubSub pubSub, p storage.Provider, taskMgr taskManager,
-	expiryService dataExpiryService, metrics metricsProvider) (*Queue, error) {
+	expiryService dataExpiryService, metrics metricsProvider, opts ...Option) (*Queue, error) {
 	msgChan, err := pubSub.SubscribeWithOpts(context.Background(), topic, spi.WithPool(cfg.PoolSize))
 	if err != nil {
 		return nil, fmt.Errorf("subscribe to topic [%s]: %w", topic, err)
@@ -161,6 +187,11 @@ func New(cfg Config, pubSub pubSub, p storage.Provider, taskMgr taskManager,
 		taskMgr:             taskMgr,
 		expiryService:       expiryService,
 		maxRetries:          cfg.MaxRetries,
+		opStatusStore:       &noopOperationStatusStore{},
+	}
+
+	for _, opt := range opts {
+		opt(q)
 	}
 
 	q.Lifecycle = lifecycle.New("operation-queue", lifecycle.WithStart(q.start))
@@ -177,15 +208,30 @@ func New(cfg Config, pubSub pubSub, p storage.Provider, taskMgr taskManager,
 
 // Add publishes the given operation.
 func (q *Queue) Add(op *operation.QueuedOperation, protocolVersion uint64) (uint, error) {
-	return q.post(
-		&operationMessage{
-			ID: uuid.New().String(),
-			Operation: &operation.QueuedOperationAtTime{
-				QueuedOperation: *op,
-				ProtocolVersion: protocolVersion,
-			},
+	opMsg := &operationMessage{
+		ID: uuid.New().String(),
+		Operation: &operation.QueuedOperationAtTime{
+			QueuedOperation: *op,
+			ProtocolVersion: protocolVersion,
 		},
-	)
+	}
+
+	// The status is recorded before the operation is posted since the operation may be batched
+	// (and its status updated) before the post returns.
+	q.updateStatus(opMsg, func(id string) error {
+		return q.opStatusStore.Queued(id, op.UniqueSuffix)
+	})
+
+	n, err := q.post(opMsg)
+	if err != nil {
+		q.updateStatus(opMsg, func(id string) error {
+			return q.opStatusStore.Failed(id, fmt.Sprintf("add to operation queue: %s", err))
+		})
+
+		return 0, err
+	}
+
+	return n, nil
 }
 
 func (q *Queue) post(op *operationMessage) (uint, error) {
@@ -245,6 +291,24 @@ func (q *Queue) Remove(num uint) (ops operation.QueuedOperationsAtTime, ack func
 		return nil, nil, nil, lifecycle.ErrNotStarted
 	}
 
+	items := q.removeItems(num)
+
+	if len(items) == 0 {
+		return nil,
+			func() uint { return 0 },
+			func() {}, nil
+	}
+
+	for _, item := range items {
+		q.updateStatus(item.operationMessage, func(id string) error {
+			return q.opStatusStore.Batched(id)
+		})
+	}
+
+	return q.asQueuedOperations(items), q.newAckFunc(items), q.newNackFunc(items), nil
+}
+
+func (q *Queue) removeItems(num uint) []*queuedOperation {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
@@ -253,18 +317,12 @@ func (q *Queue) Remove(num uint) (ops operation.QueuedOperationsAtTime, ack func
 		n = len(q.pending)
 	}
 
-	if n == 0 {
-		return nil,
-			func() uint { return 0 },
-			func() {}, nil
-	}
-
 	items := q.pending[0:n]
 	q.pending = q.pending[n:]
 
 	logger.Debugf("[%s] Removed %d operations", q.serverInstanceID, len(items))
 
-	return q.asQueuedOperations(items), q.newAckFunc(items), q.newNackFunc(items), nil
+	return items
 }
 
 // Len returns the length of the pending queue.
@@ -395,6 +453,10 @@ func (q *Queue) newNackFunc(items []*queuedOperation) func() {
 				logger.Warnf("... not re-posting operation [%s] for suffix [%s] since the retry count [%d] has reached the limit.",
 					op.ID, op.Operation.UniqueSuffix, op.Retries)
 
+				q.updateStatus(op.operationMessage, func(id string) error {
+					return q.opStatusStore.Failed(id, fmt.Sprintf("retry count [%d] has reached the limit", op.Retries))
+				})
+
 				continue
 			}
 
@@ -403,6 +465,10 @@ func (q *Queue) newNackFunc(items []*queuedOperation) func() {
 			logger.Infof("... re-posting operation [%s] for suffix [%s], retries [%d]",
 				op.ID, op.Operation.UniqueSuffix, op.Retries)
 
+			q.updateStatus(op.operationMessage, func(id string) error {
+				return q.opStatusStore.Queued(id, op.Operation.UniqueSuffix)
+			})
+
 			if _, err := q.post(op.operationMessage); err != nil {
 				logger.Errorf("Error re-posting operation [%s]", op.ID)
 
@@ -549,6 +615,10 @@ func (q *Queue) repostOperations(serverID string) error {
 		logger.Debugf("[%s] Re-posting operation [%s] for suffix [%s]",
 			q.serverInstanceID, op.ID, op.Operation.UniqueSuffix)
 
+		q.updateStatus(op, func(id string) error {
+			return q.opStatusStore.Queued(id, op.Operation.UniqueSuffix)
+		})
+
 		_, e = q.post(op)
 		if e != nil {
 			return fmt.Errorf("unmarshal operation [%s]: %w", op.ID, e)
@@ -632,6 +702,23 @@ func (q *Queue) nextOperation(it storage.Iterator) (string, *operationMessage, b
 	return key, op, true, nil
 }
 
+// updateStatus updates the lifecycle status of the given operation. The status is informational
+// so an error is logged but otherwise ignored.
+func (q *Queue) updateStatus(op *operationMessage, update func(id string) error) {
+	id, err := operationstatus.TrackingID(op.Operation.OperationRequest)
+	if err != nil {
+		logger.Warnf("Error calculating tracking ID of operation [%s] for suffix [%s]: %s",
+			op.ID, op.Operation.UniqueSuffix, err)
+
+		return
+	}
+
+	if err := update(id); err != nil {
+		logger.Warnf("Error updating status of operation [%s] for suffix [%s]: %s",
+			op.ID, op.Operation.UniqueSuffix, err)
+	}
+}
+
 func resolveConfig(cfg Config) Config {
 	if cfg.TaskMonitorInterval == 0 {
 		cfg.TaskMonitorInterval = defaultInterval
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -190,17 +191,25 @@ func TestQueue_Error(t *testing.T) {
 	expirySvc := expiry.NewService(taskMgr, 750*time.Millisecond)
 
 	t.Run("Not started error", func(t *testing.T) {
+		statusStore := &mockOperationStatusStore{}
+
 		q, err := New(Config{}, ps, storage.NewMockStoreProvider(),
-			taskMgr, expirySvc, &mocks.MetricsProvider{})
+			taskMgr, expirySvc, &mocks.MetricsProvider{}, WithOperationStatusStore(statusStore))
 		require.NoError(t, err)
 		require.NotNil(t, q)
 
 		q.Stop()
 
-		_, err = q.Add(op1, 100)
+		op := &operation.QueuedOperation{UniqueSuffix: "op1", OperationRequest: []byte(`{"type":"create"}`)}
+
+		_, err = q.Add(op, 100)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), lifecycle.ErrNotStarted.Error())
 
+		// The operation is recorded as queued before it's posted and then as failed.
+		require.Equal(t, 1, statusStore.queued)
+		require.Equal(t, 1, statusStore.failed)
+
 		_, err = q.Peek(1)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), lifecycle.ErrNotStarted.Error())
@@ -399,10 +408,13 @@ func TestRepostWithMaxRetries(t *testing.T) {
 
 	defer ps.Stop()
 
+	statusStore := &mockOperationStatusStore{}
+
 	q, err := New(Config{PoolSize: 8, TaskMonitorInterval: time.Second, MaxRetries: 1},
 		ps, storageProvider, taskMgr,
 		expiry.NewService(taskMgr, 750*time.Millisecond),
 		&mocks.MetricsProvider{},
+		WithOperationStatusStore(statusStore),
 	)
 	require.NoError(t, err)
 	require.NotNil(t, q)
@@ -434,6 +446,13 @@ func TestRepostWithMaxRetries(t *testing.T) {
 	removedOps, _, _, err = q.Remove(5)
 	require.NoError(t, err)
 	require.Emptyf(t, removedOps, "no operations should have been remaining since the max retry count was reached")
+
+	statusStore.mutex.Lock()
+	defer statusStore.mutex.Unlock()
+
+	require.Equal(t, 10, statusStore.queued)
+	require.Equal(t, 10, statusStore.batched)
+	require.Equal(t, 5, statusStore.failed)
 }
 
 func TestMain(m *testing.M) {
@@ -501,3 +520,37 @@ func newProcessedOperations(n int) processedOperations {
 
 	return ops
 }
+
+type mockOperationStatusStore struct {
+	mutex   sync.Mutex
+	queued  int
+	batched int
+	failed  int
+}
+
+func (m *mockOperationStatusStore) Queued(string, string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.queued++
+
+	return nil
+}
+
+func (m *mockOperationStatusStore) Batched(ids ...string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.batched += len(ids)
+
+	return nil
+}
+
+func (m *mockOperationStatusStore) Failed(string, string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.failed++
+
+	return nil
+}
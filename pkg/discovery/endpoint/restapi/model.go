@@ -21,6 +21,7 @@ type WellKnownResponse struct {
 // and https://datatracker.ietf.org/doc/html/rfc7033#section-4.4.
 type JRD struct {
 	Subject    string                 `json:"subject,omitempty"`
+	Aliases    []string               `json:"aliases,omitempty"`
 	Properties map[string]interface{} `json:"properties,omitempty"`
 	Links      []Link                 `json:"links,omitempty"`
 }
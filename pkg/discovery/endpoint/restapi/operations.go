@@ -49,6 +49,10 @@ const (
 	// ActivityJSONType represents a link type that points to an ActivityPub endpoint.
 	ActivityJSONType = "application/activity+json"
 
+	// activityPubServiceName is the name of the ActivityPub service, which is also the user name
+	// in the service's 'acct' URI, e.g. acct:orb@orb.domain1.com.
+	activityPubServiceName = "orb"
+
 	nodeInfoV2_0Schema = "http://nodeinfo.diaspora.software/ns/schema/2.0"
 	nodeInfoV2_1Schema = "http://nodeinfo.diaspora.software/ns/schema/2.1"
 )
@@ -286,6 +290,10 @@ func (o *Operation) writeResponseForResourceRequest(rw http.ResponseWriter, reso
 		o.handleVCTQuery(rw, resource)
 	case strings.HasPrefix(resource, "did:orb:"):
 		o.handleDIDOrbQuery(rw, resource)
+	case strings.HasPrefix(resource, model.AcctScheme):
+		o.handleAcctQuery(rw, resource)
+	case resource == constructActivityPubURL(o.baseURL):
+		o.writeServiceActorResponse(rw)
 	// TODO (#536): Support resources other than did:orb.
 	default:
 		writeErrorResponse(rw, http.StatusNotFound, fmt.Sprintf("resource %s not found,", resource))
@@ -340,6 +348,37 @@ func (o *Operation) handleDIDOrbQuery(rw http.ResponseWriter, resource string) {
 	writeResponse(rw, resp, http.StatusOK)
 }
 
+// handleAcctQuery resolves an 'acct' URI (e.g. acct:orb@orb.domain1.com) to the ActivityPub service actor
+// so that generic ActivityPub software is able to discover the service.
+func (o *Operation) handleAcctQuery(rw http.ResponseWriter, resource string) {
+	user, host, err := model.ParseAcct(resource)
+	if err != nil {
+		writeErrorResponse(rw, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if user != activityPubServiceName || host != strings.ToLower(o.host) {
+		writeErrorResponse(rw, http.StatusNotFound, fmt.Sprintf("resource %s not found", resource))
+
+		return
+	}
+
+	o.writeServiceActorResponse(rw)
+}
+
+func (o *Operation) writeServiceActorResponse(rw http.ResponseWriter) {
+	serviceIRI := constructActivityPubURL(o.baseURL)
+
+	writeResponse(rw, &JRD{
+		Subject: model.NewAcct(activityPubServiceName, o.host),
+		Aliases: []string{serviceIRI},
+		Links: []Link{
+			{Rel: selfRelation, Type: ActivityJSONType, Href: serviceIRI},
+		},
+	}, http.StatusOK)
+}
+
 func (o *Operation) handleVCTQuery(rw http.ResponseWriter, resource string) {
 	resp := &JRD{
 		Subject: resource,
@@ -625,7 +664,7 @@ func (h *httpHandler) Handler() common.HTTPRequestHandler {
 }
 
 func constructActivityPubURL(baseURL string) string {
-	return fmt.Sprintf("%s%s", baseURL, "/services/orb")
+	return fmt.Sprintf("%s/services/%s", baseURL, activityPubServiceName)
 }
 
 func contains(strs []string, str string) bool {
@@ -156,6 +156,59 @@ func TestWebFinger(t *testing.T) {
 		require.Contains(t, rr.Body.String(), "resource wrong not found")
 	})
 
+	t.Run("test acct resource", func(t *testing.T) {
+		c, err := restapi.New(&restapi.Config{
+			OperationPath:  "/op",
+			ResolutionPath: "/resolve",
+			WebCASPath:     "/cas",
+			BaseURL:        "https://orb.domain1.com",
+		}, &restapi.Providers{})
+		require.NoError(t, err)
+
+		handler := getHandler(t, c, restapi.WebFingerEndpoint)
+
+		t.Run("Success", func(t *testing.T) {
+			for _, resource := range []string{
+				"acct:orb@orb.domain1.com",
+				"acct:orb@ORB.domain1.com",
+				"https://orb.domain1.com/services/orb",
+			} {
+				rr := serveHTTP(t, handler.Handler(), http.MethodGet,
+					restapi.WebFingerEndpoint+"?resource="+resource, nil, nil, false)
+
+				require.Equal(t, http.StatusOK, rr.Code)
+
+				var w restapi.JRD
+
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &w))
+				require.Equal(t, "acct:orb@orb.domain1.com", w.Subject)
+				require.Equal(t, []string{"https://orb.domain1.com/services/orb"}, w.Aliases)
+				require.Len(t, w.Links, 1)
+				require.Equal(t, "self", w.Links[0].Rel)
+				require.Equal(t, restapi.ActivityJSONType, w.Links[0].Type)
+				require.Equal(t, "https://orb.domain1.com/services/orb", w.Links[0].Href)
+			}
+		})
+
+		t.Run("Unknown user or domain", func(t *testing.T) {
+			for _, resource := range []string{"acct:alice@orb.domain1.com", "acct:orb@orb.domain2.com"} {
+				rr := serveHTTP(t, handler.Handler(), http.MethodGet,
+					restapi.WebFingerEndpoint+"?resource="+resource, nil, nil, false)
+
+				require.Equal(t, http.StatusNotFound, rr.Code)
+				require.Contains(t, rr.Body.String(), "resource "+resource+" not found")
+			}
+		})
+
+		t.Run("Invalid acct URI", func(t *testing.T) {
+			rr := serveHTTP(t, handler.Handler(), http.MethodGet,
+				restapi.WebFingerEndpoint+"?resource=acct:orb.domain1.com", nil, nil, false)
+
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+			require.Contains(t, rr.Body.String(), "invalid acct URI")
+		})
+	})
+
 	t.Run("test resolution resource", func(t *testing.T) {
 		c, err := restapi.New(&restapi.Config{
 			OperationPath:             "/op",
@@ -0,0 +1,393 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/piprate/json-gold/ld"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	txnapi "github.com/trustbloc/sidetree-core-go/pkg/api/txn"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/vct/pkg/client/vct"
+
+	"github.com/trustbloc/orb/pkg/anchor/anchorevent"
+	"github.com/trustbloc/orb/pkg/anchor/graph"
+	"github.com/trustbloc/orb/pkg/anchor/util"
+	"github.com/trustbloc/orb/pkg/didanchor"
+	"github.com/trustbloc/orb/pkg/hashlink"
+)
+
+const (
+	endpoint       = "/did/history"
+	idPathVariable = "id"
+)
+
+const (
+	notFoundResponse            = "Content Not Found."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("did-history-rest-handler")
+
+type didAnchors interface {
+	Get(suffix string) (string, error)
+}
+
+type anchorGraph interface {
+	GetDidAnchors(hl, suffix string) ([]graph.Anchor, error)
+}
+
+type anchorLinkStore interface {
+	GetLinks(anchorHash string) ([]*url.URL, error)
+}
+
+// Providers contains the providers required by the HistoryRetriever.
+type Providers struct {
+	DidAnchors             didAnchors
+	AnchorGraph            anchorGraph
+	AnchorLinkStore        anchorLinkStore
+	ProtocolClientProvider protocol.ClientProvider
+	DocLoader              ld.DocumentLoader
+}
+
+// History contains the chain of anchors for a DID, from the anchor that created the DID to the latest anchor.
+type History struct {
+	Suffix  string    `json:"didSuffix"`
+	Anchors []*Anchor `json:"anchors"`
+}
+
+// Anchor contains the details of an anchor event in the history of a DID.
+type Anchor struct {
+	// Hashlink is the hashlink of the anchor event.
+	Hashlink string `json:"hashlink"`
+	// AnchorTime is the time that the anchor event was issued.
+	AnchorTime *time.Time `json:"anchorTime,omitempty"`
+	// AnchorOrigin is the origin of the anchor event.
+	AnchorOrigin interface{} `json:"anchorOrigin,omitempty"`
+	// Proofs contains the proofs on the anchor credential, including the witness proofs.
+	Proofs []verifiable.Proof `json:"proofs,omitempty"`
+	// LogEntries contains the VCT log entries for the witness proofs that were added by a VCT log.
+	LogEntries []*LogEntry `json:"logEntries,omitempty"`
+	// Operations contains the operations for the DID that were included in the anchor event.
+	Operations []*Operation `json:"operations,omitempty"`
+	// AlternateLinks contains the alternate CAS locations from which the anchor event may be retrieved.
+	AlternateLinks []string `json:"alternateLinks,omitempty"`
+}
+
+// LogEntry identifies the entry in a VCT log that was created when an anchor credential was witnessed.
+type LogEntry struct {
+	// Log is the URL of the VCT log.
+	Log string `json:"log"`
+	// Created is the timestamp of the log entry.
+	Created time.Time `json:"created"`
+	// LeafHash is the Merkle tree leaf hash of the log entry, which may be used to retrieve
+	// an inclusion proof (and the leaf index) from the VCT log.
+	LeafHash string `json:"leafHash"`
+}
+
+// Operation is a DID operation that was included in an anchor event.
+type Operation struct {
+	Type            operation.Type  `json:"type"`
+	ProtocolVersion uint64          `json:"protocolVersion"`
+	Request         json.RawMessage `json:"request,omitempty"`
+}
+
+// HistoryRetriever returns the full chain of anchors for a DID by walking the anchor graph back from
+// the latest anchor of the DID. The DID may be specified as a full DID or as a unique suffix.
+type HistoryRetriever struct {
+	*Providers
+
+	hl      *hashlink.HashLink
+	marshal func(interface{}) ([]byte, error)
+}
+
+// NewHistoryRetriever returns a new HistoryRetriever.
+func NewHistoryRetriever(providers *Providers) *HistoryRetriever {
+	return &HistoryRetriever{
+		Providers: providers,
+		hl:        hashlink.New(),
+		marshal:   json.Marshal,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the DID history retriever.
+func (h *HistoryRetriever) Path() string {
+	return fmt.Sprintf("%s/{%s}", endpoint, idPathVariable)
+}
+
+// Method returns the HTTP REST method for the DID history retriever.
+func (h *HistoryRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the DID history retriever.
+func (h *HistoryRetriever) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *HistoryRetriever) handle(w http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)[idPathVariable]
+
+	suffix := id[strings.LastIndex(id, ":")+1:]
+
+	history, err := h.getHistory(suffix)
+	if err != nil {
+		if errors.Is(err, didanchor.ErrDataNotFound) {
+			logger.Debugf("[%s] Anchor not found for DID [%s]", endpoint, id)
+
+			writeResponse(w, http.StatusNotFound, []byte(notFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error retrieving history for DID [%s]: %s", endpoint, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	respBytes, err := h.marshal(history)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling history for DID [%s]: %s", endpoint, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(w, http.StatusOK, respBytes)
+}
+
+func (h *HistoryRetriever) getHistory(suffix string) (*History, error) {
+	latestAnchor, err := h.DidAnchors.Get(suffix)
+	if err != nil {
+		return nil, fmt.Errorf("get latest anchor: %w", err)
+	}
+
+	anchors, err := h.AnchorGraph.GetDidAnchors(latestAnchor, suffix)
+	if err != nil {
+		return nil, fmt.Errorf("get anchors from anchor graph: %w", err)
+	}
+
+	history := &History{Suffix: suffix}
+
+	for _, a := range anchors {
+		anchor, err := h.getAnchor(a, suffix)
+		if err != nil {
+			return nil, fmt.Errorf("anchor [%s]: %w", a.CID, err)
+		}
+
+		history.Anchors = append(history.Anchors, anchor)
+	}
+
+	return history, nil
+}
+
+func (h *HistoryRetriever) getAnchor(a graph.Anchor, suffix string) (*Anchor, error) {
+	payload, err := anchorevent.GetPayloadFromAnchorEvent(a.Info)
+	if err != nil {
+		return nil, fmt.Errorf("get payload from anchor event: %w", err)
+	}
+
+	vc, err := util.VerifiableCredentialFromAnchorEvent(a.Info,
+		verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(h.DocLoader),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get verifiable credential from anchor event: %w", err)
+	}
+
+	canonicalID, err := hashlink.GetResourceHashFromHashLink(a.CID)
+	if err != nil {
+		return nil, fmt.Errorf("get canonical ID from hashlink: %w", err)
+	}
+
+	anchor := &Anchor{
+		Hashlink:     a.CID,
+		AnchorOrigin: payload.AnchorOrigin,
+		Proofs:       vc.Proofs,
+		LogEntries:   getLogEntries(vc),
+	}
+
+	var transactionTime uint64
+
+	if vc.Issued != nil {
+		anchor.AnchorTime = &vc.Issued.Time
+		transactionTime = uint64(vc.Issued.Unix())
+	}
+
+	ad := &util.AnchorData{OperationCount: payload.OperationCount, CoreIndexFileURI: payload.CoreIndex}
+
+	anchor.Operations, err = h.getOperations(&txnapi.SidetreeTxn{
+		TransactionTime:    transactionTime,
+		AnchorString:       ad.GetAnchorString(),
+		Namespace:          payload.Namespace,
+		ProtocolVersion:    payload.Version,
+		CanonicalReference: canonicalID,
+	}, suffix)
+	if err != nil {
+		return nil, err
+	}
+
+	anchor.AlternateLinks = h.getAlternateLinks(a.CID, canonicalID)
+
+	return anchor, nil
+}
+
+func (h *HistoryRetriever) getOperations(sidetreeTxn *txnapi.SidetreeTxn, suffix string) ([]*Operation, error) {
+	pc, err := h.ProtocolClientProvider.ForNamespace(sidetreeTxn.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("get protocol client for namespace [%s]: %w", sidetreeTxn.Namespace, err)
+	}
+
+	v, err := pc.Get(sidetreeTxn.ProtocolVersion)
+	if err != nil {
+		return nil, fmt.Errorf("get protocol version [%d]: %w", sidetreeTxn.ProtocolVersion, err)
+	}
+
+	txnOps, err := v.OperationProvider().GetTxnOperations(sidetreeTxn)
+	if err != nil {
+		return nil, fmt.Errorf("get operations: %w", err)
+	}
+
+	var ops []*Operation
+
+	for _, op := range txnOps {
+		if op.UniqueSuffix != suffix {
+			continue
+		}
+
+		o := &Operation{
+			Type:            op.Type,
+			ProtocolVersion: sidetreeTxn.ProtocolVersion,
+		}
+
+		if json.Valid(op.OperationRequest) {
+			o.Request = op.OperationRequest
+		}
+
+		ops = append(ops, o)
+	}
+
+	return ops, nil
+}
+
+// getAlternateLinks returns the links in the given hashlink along with the links from the anchor link store.
+func (h *HistoryRetriever) getAlternateLinks(anchorHL, anchorHash string) []string {
+	hashLinks := []string{anchorHL}
+
+	links, err := h.AnchorLinkStore.GetLinks(anchorHash)
+	if err != nil {
+		// Not fatal.
+		logger.Warnf("Error retrieving alternate links for anchor [%s]: %s", anchorHL, err)
+	}
+
+	for _, l := range links {
+		hashLinks = append(hashLinks, l.String())
+	}
+
+	var alternates []string
+
+	for _, hl := range hashLinks {
+		hlInfo, err := h.hl.ParseHashLink(hl)
+		if err != nil {
+			logger.Warnf("Error parsing hashlink [%s]: %s", hl, err)
+
+			continue
+		}
+
+		for _, l := range hlInfo.Links {
+			if !contains(alternates, l) {
+				alternates = append(alternates, l)
+			}
+		}
+	}
+
+	return alternates
+}
+
+// getLogEntries returns a log entry for each proof that contains a domain. The domain of a witness
+// proof is the URL of the VCT log that added the credential and the created time is the timestamp of the entry.
+// The leaf hash is calculated from the credential (without proofs) and the timestamp, in the same way as the
+// VCT log calculates it.
+func getLogEntries(vc *verifiable.Credential) []*LogEntry {
+	var entries []*LogEntry
+
+	for _, p := range vc.Proofs {
+		domain, ok := p["domain"].(string)
+		if !ok || domain == "" {
+			continue
+		}
+
+		created, ok := p["created"].(string)
+		if !ok {
+			continue
+		}
+
+		createdTime, err := time.Parse(time.RFC3339, created)
+		if err != nil {
+			logger.Warnf("Error parsing created time [%s] of proof from [%s]: %s", created, domain, err)
+
+			continue
+		}
+
+		leafHash, err := vct.CalculateLeafHash(uint64(createdTime.UnixNano()/int64(time.Millisecond)), vc)
+		if err != nil {
+			logger.Warnf("Error calculating leaf hash of proof from [%s]: %s", domain, err)
+
+			continue
+		}
+
+		entries = append(entries, &LogEntry{
+			Log:      domain,
+			Created:  createdTime,
+			LeafHash: leafHash,
+		})
+	}
+
+	return entries
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
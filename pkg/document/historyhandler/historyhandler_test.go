@@ -0,0 +1,328 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/vct/pkg/client/vct"
+
+	"github.com/trustbloc/orb/pkg/activitypub/client/transport"
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/anchor/anchorevent"
+	"github.com/trustbloc/orb/pkg/anchor/builder"
+	"github.com/trustbloc/orb/pkg/anchor/graph"
+	"github.com/trustbloc/orb/pkg/anchor/subject"
+	casresolver "github.com/trustbloc/orb/pkg/cas/resolver"
+	"github.com/trustbloc/orb/pkg/didanchor/memdidanchor"
+	"github.com/trustbloc/orb/pkg/hashlink"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	orbmocks "github.com/trustbloc/orb/pkg/mocks"
+	"github.com/trustbloc/orb/pkg/store/cas"
+	webfingerclient "github.com/trustbloc/orb/pkg/webfinger/client"
+)
+
+const (
+	casLink    = "https://domain.com/cas"
+	namespace1 = "did:orb"
+
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+
+	vctLog       = "https://vct.example.com/maple2021"
+	alternateCAS = "https://orb.domain2.com/cas/uEiBL1RVIr2DdyRE5h6b8bPys-PuVs5mMPPC778OtklPa-w"
+)
+
+func TestHistoryRetriever(t *testing.T) {
+	casClient, err := cas.New(mem.NewProvider(), casLink, nil, &orbmocks.MetricsProvider{}, 0)
+	require.NoError(t, err)
+
+	anchorGraph := graph.New(&graph.Providers{
+		CasWriter: casClient,
+		CasResolver: casresolver.New(casClient, nil,
+			casresolver.NewWebCASResolver(transport.Default(), webfingerclient.New(), "https"),
+			&orbmocks.MetricsProvider{}),
+		DocLoader: testutil.GetLoader(t),
+	})
+
+	created := time.Now().UTC().Truncate(time.Second)
+
+	hl1, err := anchorGraph.Add(newMockAnchorEvent(t, &subject.Payload{
+		Namespace:       namespace1,
+		CoreIndex:       "core1",
+		OperationCount:  2,
+		AnchorOrigin:    "https://orb.domain1.com/services/orb",
+		PreviousAnchors: []*subject.SuffixAnchor{{Suffix: suffix1}, {Suffix: suffix2}},
+	}, created))
+	require.NoError(t, err)
+
+	hl2, err := anchorGraph.Add(newMockAnchorEvent(t, &subject.Payload{
+		Namespace:       namespace1,
+		CoreIndex:       "core2",
+		OperationCount:  1,
+		AnchorOrigin:    "https://orb.domain1.com/services/orb",
+		PreviousAnchors: []*subject.SuffixAnchor{{Suffix: suffix1, Anchor: hl1}},
+	}, created))
+	require.NoError(t, err)
+
+	didAnchors := memdidanchor.New()
+	require.NoError(t, didAnchors.PutBulk([]string{suffix1}, []bool{false}, hl2))
+
+	opProvider := &mocks.OperationProvider{}
+	opProvider.GetTxnOperationsReturnsOnCall(0, []*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: suffix1, OperationRequest: []byte(`{"type":"create"}`)},
+		{Type: operation.TypeCreate, UniqueSuffix: suffix2, OperationRequest: []byte(`{"type":"create"}`)},
+	}, nil)
+	opProvider.GetTxnOperationsReturnsOnCall(1, []*operation.AnchoredOperation{
+		{Type: operation.TypeUpdate, UniqueSuffix: suffix1, OperationRequest: []byte(`{"type":"update"}`)},
+	}, nil)
+
+	pc := mocks.NewMockProtocolClient()
+	pc.Versions[0].OperationProviderReturns(opProvider)
+
+	metadata, err := hashlink.New().CreateMetadataFromLinks([]string{alternateCAS})
+	require.NoError(t, err)
+
+	resourceHash, err := hashlink.GetResourceHashFromHashLink(hl1)
+	require.NoError(t, err)
+
+	linkStore := &orbmocks.AnchorLinkStore{}
+	linkStore.GetLinksReturns([]*url.URL{testutil.MustParseURL(hashlink.GetHashLink(resourceHash, metadata))}, nil)
+
+	providers := &Providers{
+		DidAnchors:             didAnchors,
+		AnchorGraph:            anchorGraph,
+		AnchorLinkStore:        linkStore,
+		ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace1, pc),
+		DocLoader:              testutil.GetLoader(t),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		h := NewHistoryRetriever(providers)
+		require.Equal(t, "/did/history/{id}", h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, newRequest("did:orb:uAAA:"+suffix1))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "application/json", result.Header.Get("Content-Type"))
+		require.NoError(t, result.Body.Close())
+
+		history := &History{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), history))
+		require.Equal(t, suffix1, history.Suffix)
+		require.Len(t, history.Anchors, 2)
+
+		anchor := history.Anchors[0]
+		require.Equal(t, hl1, anchor.Hashlink)
+		require.NotNil(t, anchor.AnchorTime)
+		require.Equal(t, "https://orb.domain1.com/services/orb", anchor.AnchorOrigin)
+		require.Len(t, anchor.Proofs, 2)
+		require.Len(t, anchor.LogEntries, 1)
+		require.Equal(t, vctLog, anchor.LogEntries[0].Log)
+		require.True(t, created.Equal(anchor.LogEntries[0].Created))
+		require.NotEmpty(t, anchor.LogEntries[0].LeafHash)
+		require.Len(t, anchor.Operations, 1)
+		require.Equal(t, operation.TypeCreate, anchor.Operations[0].Type)
+		require.JSONEq(t, `{"type":"create"}`, string(anchor.Operations[0].Request))
+		require.Len(t, anchor.AlternateLinks, 2)
+		require.Contains(t, anchor.AlternateLinks, alternateCAS)
+
+		anchor = history.Anchors[1]
+		require.Equal(t, hl2, anchor.Hashlink)
+		require.Len(t, anchor.Operations, 1)
+		require.Equal(t, operation.TypeUpdate, anchor.Operations[0].Type)
+	})
+
+	t.Run("DID not found", func(t *testing.T) {
+		h := NewHistoryRetriever(providers)
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, newRequest(suffix2))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusNotFound, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+	})
+
+	t.Run("anchor graph error", func(t *testing.T) {
+		ag := &orbmocks.AnchorGraph{}
+		ag.GetDidAnchorsReturns(nil, errors.New("injected anchor graph error"))
+
+		h := NewHistoryRetriever(&Providers{
+			DidAnchors:  didAnchors,
+			AnchorGraph: ag,
+		})
+
+		requireStatus(t, h, suffix1, http.StatusInternalServerError)
+	})
+
+	t.Run("protocol client error", func(t *testing.T) {
+		h := NewHistoryRetriever(&Providers{
+			DidAnchors:             didAnchors,
+			AnchorGraph:            anchorGraph,
+			ProtocolClientProvider: mocks.NewMockProtocolClientProvider(),
+			DocLoader:              testutil.GetLoader(t),
+		})
+
+		requireStatus(t, h, suffix1, http.StatusInternalServerError)
+	})
+
+	t.Run("operation provider error", func(t *testing.T) {
+		errProvider := &mocks.OperationProvider{}
+		errProvider.GetTxnOperationsReturns(nil, errors.New("injected operation provider error"))
+
+		errClient := mocks.NewMockProtocolClient()
+		errClient.Versions[0].OperationProviderReturns(errProvider)
+
+		h := NewHistoryRetriever(&Providers{
+			DidAnchors:             didAnchors,
+			AnchorGraph:            anchorGraph,
+			ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace1, errClient),
+			DocLoader:              testutil.GetLoader(t),
+		})
+
+		requireStatus(t, h, suffix1, http.StatusInternalServerError)
+	})
+
+	t.Run("anchor link store error", func(t *testing.T) {
+		okProvider := &mocks.OperationProvider{}
+
+		okClient := mocks.NewMockProtocolClient()
+		okClient.Versions[0].OperationProviderReturns(okProvider)
+
+		errLinkStore := &orbmocks.AnchorLinkStore{}
+		errLinkStore.GetLinksReturns(nil, errors.New("injected link store error"))
+
+		h := NewHistoryRetriever(&Providers{
+			DidAnchors:             didAnchors,
+			AnchorGraph:            anchorGraph,
+			AnchorLinkStore:        errLinkStore,
+			ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace1, okClient),
+			DocLoader:              testutil.GetLoader(t),
+		})
+
+		// The alternate links are optional.
+		requireStatus(t, h, suffix1, http.StatusOK)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := NewHistoryRetriever(providers)
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		requireStatus(t, h, suffix1, http.StatusInternalServerError)
+	})
+}
+
+func TestGetLogEntries(t *testing.T) {
+	created, err := time.Parse(time.RFC3339, "2021-10-14T18:32:17.894314751Z")
+	require.NoError(t, err)
+
+	vc := &verifiable.Credential{
+		Types:   []string{"VerifiableCredential"},
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		Subject: &builder.CredentialSubject{ID: "hl:uEiBN4vd1lgKx_K93ltpdI32T6nIGlwXhJcSwbeVAg8NMxg"},
+		Issuer:  verifiable.Issuer{ID: "http://orb.domain.com"},
+		Issued:  &util.TimeWrapper{Time: created},
+	}
+
+	// The VCT log calculates the leaf hash from the credential without proofs.
+	expectedLeafHash, err := vct.CalculateLeafHash(uint64(created.UnixNano()/int64(time.Millisecond)), vc)
+	require.NoError(t, err)
+
+	vc.Proofs = []verifiable.Proof{
+		{"created": "2021-10-14T18:32:17.894314751Z"},
+		{"domain": vctLog, "created": "2021-10-14T18:32:17.894314751Z"},
+		{"domain": vctLog},
+		{"domain": vctLog, "created": "xxx"},
+	}
+
+	entries := getLogEntries(vc)
+	require.Len(t, entries, 1)
+	require.Equal(t, vctLog, entries[0].Log)
+	require.True(t, created.Equal(entries[0].Created))
+	require.Equal(t, expectedLeafHash, entries[0].LeafHash)
+	require.Len(t, vc.Proofs, 4)
+}
+
+func newRequest(id string) *http.Request {
+	return mux.SetURLVars(httptest.NewRequest(http.MethodGet, endpoint+"/"+id, nil), map[string]string{
+		idPathVariable: id,
+	})
+}
+
+func requireStatus(t *testing.T, h *HistoryRetriever, id string, expectedStatus int) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+
+	h.handle(rw, newRequest(id))
+
+	result := rw.Result()
+	require.Equal(t, expectedStatus, result.StatusCode)
+	require.NoError(t, result.Body.Close())
+}
+
+func newMockAnchorEvent(t *testing.T, payload *subject.Payload, created time.Time) *vocab.AnchorEventType {
+	t.Helper()
+
+	const defVCContext = "https://www.w3.org/2018/credentials/v1"
+
+	vc := &verifiable.Credential{
+		Types:   []string{"VerifiableCredential"},
+		Context: []string{defVCContext},
+		Subject: &builder.CredentialSubject{
+			ID: "hl:uEiBN4vd1lgKx_K93ltpdI32T6nIGlwXhJcSwbeVAg8NMxg:uoQ-BeEJpcGZzOi8vYmFma3JlaWNuNGwzeGxmcWN3aDZrNjU0dzNqb3NnN210NWp6YW5meWY0ZXM0am1kbjR2YWlocTJteXk", //nolint:lll
+		},
+		Issuer: verifiable.Issuer{
+			ID: "http://orb.domain.com",
+		},
+		Issued: &util.TimeWrapper{Time: created},
+		Proofs: []verifiable.Proof{
+			{
+				"type":               "Ed25519Signature2018",
+				"created":            created.Format(time.RFC3339),
+				"verificationMethod": "did:web:orb.domain1.com#key1",
+				"proofPurpose":       "assertionMethod",
+			},
+			{
+				"type":               "Ed25519Signature2018",
+				"created":            created.Format(time.RFC3339),
+				"domain":             vctLog,
+				"verificationMethod": "did:web:orb.domain2.com#key1",
+				"proofPurpose":       "assertionMethod",
+			},
+		},
+	}
+
+	contentObj, err := anchorevent.BuildContentObject(payload)
+	require.NoError(t, err)
+
+	act, err := anchorevent.BuildAnchorEvent(payload, contentObj.GeneratorID, contentObj.Payload,
+		vocab.MustMarshalToDoc(vc), vocab.GzipMediaType)
+	require.NoError(t, err)
+
+	return act
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statushandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/store/anchoreventstatus"
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
+)
+
+const (
+	endpoint       = "/operation/status"
+	idPathVariable = "id"
+)
+
+const (
+	statusNotFoundResponse      = "Content Not Found."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("operation-status-rest-handler")
+
+type operationStatusStore interface {
+	Get(id string) (*operationstatus.Status, error)
+}
+
+type anchorStatusStore interface {
+	GetStatusInfo(anchorID string) (*anchoreventstatus.AnchorStatus, error)
+}
+
+// StatusRetriever retrieves the lifecycle status of a Sidetree operation by its tracking ID. The status
+// recorded by the operation queue, anchor writer and observer is combined with the status of the anchor
+// event in order to report when the anchor event was witnessed.
+type StatusRetriever struct {
+	operationStatusStore operationStatusStore
+	anchorStatusStore    anchorStatusStore
+	marshal              func(interface{}) ([]byte, error)
+}
+
+// NewStatusRetriever returns a new StatusRetriever.
+func NewStatusRetriever(operationStatusStore operationStatusStore,
+	anchorStatusStore anchorStatusStore) *StatusRetriever {
+	return &StatusRetriever{
+		operationStatusStore: operationStatusStore,
+		anchorStatusStore:    anchorStatusStore,
+		marshal:              json.Marshal,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the operation status retriever.
+func (h *StatusRetriever) Path() string {
+	return fmt.Sprintf("%s/{%s}", endpoint, idPathVariable)
+}
+
+// Method returns the HTTP REST method for the operation status retriever.
+func (h *StatusRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the operation status retriever.
+func (h *StatusRetriever) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *StatusRetriever) handle(w http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)[idPathVariable]
+
+	status, err := h.operationStatusStore.Get(id)
+	if err != nil {
+		if errors.Is(err, orberrors.ErrContentNotFound) {
+			logger.Debugf("[%s] Status not found for operation [%s]", endpoint, id)
+
+			writeResponse(w, http.StatusNotFound, []byte(statusNotFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error retrieving status for operation [%s]: %s", endpoint, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	if err := h.resolveWitnessed(status); err != nil {
+		logger.Errorf("[%s] Error retrieving status of anchor event [%s] for operation [%s]: %s",
+			endpoint, status.AnchorID, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	respBytes, err := h.marshal(status)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling status for operation [%s]: %s", endpoint, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(w, http.StatusOK, respBytes)
+}
+
+// resolveWitnessed sets the time that the anchor event was witnessed from the anchor event status store.
+// The state is advanced to 'witnessed' if the anchor event was witnessed but not yet published.
+func (h *StatusRetriever) resolveWitnessed(status *operationstatus.Status) error {
+	if status.AnchorID == "" || status.State == operationstatus.StateFailed {
+		return nil
+	}
+
+	if _, ok := status.Timestamps[operationstatus.StateWitnessed]; ok {
+		return nil
+	}
+
+	anchorStatus, err := h.anchorStatusStore.GetStatusInfo(status.AnchorID)
+	if err != nil {
+		if errors.Is(err, orberrors.ErrContentNotFound) {
+			// The status of the anchor event may have expired.
+			return nil
+		}
+
+		return err
+	}
+
+	if anchorStatus.Status != proof.AnchorIndexStatusCompleted {
+		return nil
+	}
+
+	if !anchorStatus.Time.IsZero() {
+		status.Timestamps[operationstatus.StateWitnessed] = anchorStatus.Time
+	}
+
+	if status.State == operationstatus.StateAnchored {
+		status.State = operationstatus.StateWitnessed
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
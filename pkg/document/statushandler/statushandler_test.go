@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statushandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/anchoreventstatus"
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
+)
+
+const (
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+	suffix3 = "EiBQ4jWCqiSRgGHqrdo3wzJlyPObyWaOxuLX0SO7Oqaz8A"
+
+	anchor1 = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+	anchor2 = "hl:uEiAk0CUuIIVOxlalYH6JU7gsIwvo5zGNcM_zYo2jXwzBzw"
+)
+
+func TestStatusRetriever(t *testing.T) {
+	witnessedTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	opStatusStore, err := operationstatus.New(mem.NewProvider(), testutil.GetExpiryService(t), time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, opStatusStore.Queued("op1", suffix1))
+	require.NoError(t, opStatusStore.Queued("op2", suffix2))
+	require.NoError(t, opStatusStore.Queued("op3", suffix3))
+	require.NoError(t, opStatusStore.Batched("op1", "op2", "op3"))
+	require.NoError(t, opStatusStore.Anchored(anchor1, suffix1))
+	require.NoError(t, opStatusStore.Anchored(anchor2, suffix2))
+
+	anchorStatusStore := &mockAnchorStatusStore{
+		statuses: map[string]*anchoreventstatus.AnchorStatus{
+			anchor1: {AnchorID: anchor1, Status: proof.AnchorIndexStatusCompleted, Time: witnessedTime},
+			anchor2: {AnchorID: anchor2, Status: proof.AnchorIndexStatusInProcess, Time: witnessedTime},
+		},
+	}
+
+	t.Run("success - witnessed", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, anchorStatusStore)
+		require.Equal(t, "/operation/status/{id}", h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+		require.NotNil(t, h.Handler())
+
+		status := getStatus(t, h, "op1", http.StatusOK)
+		require.Equal(t, "op1", status.ID)
+		require.Equal(t, suffix1, status.Suffix)
+		require.Equal(t, operationstatus.StateWitnessed, status.State)
+		require.Equal(t, anchor1, status.AnchorID)
+		require.Equal(t, witnessedTime.Unix(), status.Timestamps[operationstatus.StateWitnessed].Unix())
+		require.Len(t, status.Timestamps, 4)
+	})
+
+	t.Run("success - anchored", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, anchorStatusStore)
+
+		status := getStatus(t, h, "op2", http.StatusOK)
+		require.Equal(t, operationstatus.StateAnchored, status.State)
+		require.Len(t, status.Timestamps, 3)
+	})
+
+	t.Run("success - batched", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, anchorStatusStore)
+
+		status := getStatus(t, h, "op3", http.StatusOK)
+		require.Equal(t, operationstatus.StateBatched, status.State)
+		require.Empty(t, status.AnchorID)
+	})
+
+	t.Run("success - anchor status not found", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, &mockAnchorStatusStore{})
+
+		status := getStatus(t, h, "op1", http.StatusOK)
+		require.Equal(t, operationstatus.StateAnchored, status.State)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, anchorStatusStore)
+
+		getStatus(t, h, "op4", http.StatusNotFound)
+	})
+
+	t.Run("operation status store error", func(t *testing.T) {
+		h := NewStatusRetriever(&mockOperationStatusStore{err: errors.New("injected store error")},
+			anchorStatusStore)
+
+		getStatus(t, h, "op1", http.StatusInternalServerError)
+	})
+
+	t.Run("anchor status store error", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, &mockAnchorStatusStore{err: errors.New("injected store error")})
+
+		getStatus(t, h, "op1", http.StatusInternalServerError)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := NewStatusRetriever(opStatusStore, anchorStatusStore)
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		getStatus(t, h, "op1", http.StatusInternalServerError)
+	})
+}
+
+func getStatus(t *testing.T, h *StatusRetriever, id string, expectedStatus int) *operationstatus.Status {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, endpoint, nil), map[string]string{
+		idPathVariable: id,
+	})
+
+	h.handle(rw, req)
+
+	result := rw.Result()
+	require.Equal(t, expectedStatus, result.StatusCode)
+	require.NoError(t, result.Body.Close())
+
+	if expectedStatus != http.StatusOK {
+		return nil
+	}
+
+	status := &operationstatus.Status{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), status))
+
+	return status
+}
+
+type mockOperationStatusStore struct {
+	err error
+}
+
+func (m *mockOperationStatusStore) Get(string) (*operationstatus.Status, error) {
+	return nil, m.err
+}
+
+type mockAnchorStatusStore struct {
+	statuses map[string]*anchoreventstatus.AnchorStatus
+	err      error
+}
+
+func (m *mockAnchorStatusStore) GetStatusInfo(anchorID string) (*anchoreventstatus.AnchorStatus, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	status, ok := m.statuses[anchorID]
+	if !ok {
+		return nil, fmt.Errorf("status not found: %w", orberrors.ErrContentNotFound)
+	}
+
+	return status, nil
+}
@@ -9,10 +9,19 @@ package updatehandler
 import (
 	"time"
 
+	"github.com/trustbloc/edge-core/pkg/log"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
 )
 
+var logger = log.New("orb-update-handler")
+
+// TrackingIDProperty is the document metadata property that contains the tracking ID of the operation. The
+// tracking ID may be used to retrieve the status of the operation.
+const TrackingIDProperty = "trackingId"
+
 type metricsProvider interface {
 	DocumentCreateUpdateTime(duration time.Duration)
 }
@@ -46,7 +55,8 @@ func (r *UpdateHandler) Namespace() string {
 	return r.coreProcessor.Namespace()
 }
 
-// ProcessOperation validates operation and adds it to the batch.
+// ProcessOperation validates operation and adds it to the batch. The tracking ID of the operation
+// is returned in the document metadata.
 func (r *UpdateHandler) ProcessOperation(operationBuffer []byte, protocolVersion uint64) (*document.ResolutionResult, error) { //nolint:lll
 	startTime := time.Now()
 
@@ -59,5 +69,24 @@ func (r *UpdateHandler) ProcessOperation(operationBuffer []byte, protocolVersion
 		return nil, err
 	}
 
+	trackingID, err := operationstatus.TrackingID(operationBuffer)
+	if err != nil {
+		// The operation was already added to the batch so don't return an error.
+		logger.Warnf("Unable to calculate tracking ID for operation: %s", err)
+
+		return doc, nil
+	}
+
+	if doc == nil {
+		// Only create operations return a document.
+		doc = &document.ResolutionResult{}
+	}
+
+	if doc.DocumentMetadata == nil {
+		doc.DocumentMetadata = make(document.Metadata)
+	}
+
+	doc.DocumentMetadata[TrackingIDProperty] = trackingID
+
 	return doc, nil
 }
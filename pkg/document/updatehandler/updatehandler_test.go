@@ -18,6 +18,7 @@ import (
 
 	"github.com/trustbloc/orb/pkg/document/updatehandler/mocks"
 	orbmocks "github.com/trustbloc/orb/pkg/mocks"
+	"github.com/trustbloc/orb/pkg/store/operationstatus"
 )
 
 const (
@@ -53,9 +54,26 @@ func TestUpdateHandler_ProcessOperation(t *testing.T) {
 
 		handler := New(coreProcessor, &orbmocks.MetricsProvider{})
 
-		response, err := handler.ProcessOperation(nil, 0)
+		response, err := handler.ProcessOperation([]byte(`{"type":"create"}`), 0)
 		require.NoError(t, err)
 		require.NotNil(t, response)
+		require.Equal(t, doc, response.Document)
+		require.NotEmpty(t, response.DocumentMetadata[TrackingIDProperty])
+	})
+
+	t.Run("success - no document", func(t *testing.T) {
+		coreProcessor := &mocks.Processor{}
+
+		handler := New(coreProcessor, &orbmocks.MetricsProvider{})
+
+		response, err := handler.ProcessOperation([]byte(`{"type":"update"}`), 0)
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		require.Nil(t, response.Document)
+
+		trackingID, err := operationstatus.TrackingID([]byte(`{"type":"update"}`))
+		require.NoError(t, err)
+		require.Equal(t, trackingID, response.DocumentMetadata[TrackingIDProperty])
 	})
 
 	t.Run("error - core processor error", func(t *testing.T) {
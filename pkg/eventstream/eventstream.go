@@ -0,0 +1,394 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventstream
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/lifecycle"
+)
+
+var logger = log.New("event-stream")
+
+const (
+	defaultBufferSize           = 1000
+	defaultSubscriberBufferSize = 100
+
+	idSeparator = ":"
+)
+
+// EventType is the type of a stream event.
+type EventType string
+
+const (
+	// EventTypeAnchor is published when an anchor event is received in a 'Create' or 'Announce' activity.
+	EventTypeAnchor EventType = "anchor"
+	// EventTypeProof is published when a witness proof is received in an 'Accept' of an 'Offer' activity.
+	EventTypeProof EventType = "proof"
+	// EventTypeDID is published when the observer processes the DID operations in an anchor event.
+	EventTypeDID EventType = "did"
+	// EventTypeReset is sent to a subscriber that attempts to resume the stream from an event that's no
+	// longer retained (for example, the server instance was restarted). The subscriber may have missed
+	// events and should resume from the ID of the reset event.
+	EventTypeReset EventType = "reset"
+)
+
+// Event is an event that is pushed to stream subscribers.
+type Event struct {
+	// ID consists of the epoch of the stream (which changes when the server instance is restarted) and the
+	// sequence number of the event. Subscribers may resume the stream from the last ID that they've seen.
+	ID string `json:"id"`
+	// Type is the type of event.
+	Type EventType `json:"type"`
+	// Actor is the actor of the activity from which the event was created.
+	Actor string `json:"actor,omitempty"`
+	// Anchor is the hashlink (or index) of the anchor event.
+	Anchor string `json:"anchor,omitempty"`
+	// Suffixes contains the unique suffixes of the DIDs that were updated by the anchor event.
+	Suffixes []string `json:"suffixes,omitempty"`
+	// Activity is the activity from which the event was created.
+	Activity *vocab.ActivityType `json:"activity,omitempty"`
+	// Time is the time that the event was published to the stream.
+	Time time.Time `json:"time"`
+
+	seq uint64
+}
+
+// Filter restricts the events that are delivered to a subscriber. An empty field matches any value.
+type Filter struct {
+	Types    []EventType
+	Actors   []string
+	Suffixes []string
+}
+
+// Option is an event stream option.
+type Option func(s *Stream)
+
+// WithBufferSize sets the number of recent events that are retained so that subscribers may resume the stream.
+func WithBufferSize(value int) Option {
+	return func(s *Stream) {
+		s.bufferSize = value
+	}
+}
+
+// WithSubscriberBufferSize sets the size of a subscriber's event channel. A subscriber that falls behind
+// by more than this number of events is disconnected.
+func WithSubscriberBufferSize(value int) Option {
+	return func(s *Stream) {
+		s.subscriberBufferSize = value
+	}
+}
+
+type subscriber struct {
+	filter *Filter
+	events chan *Event
+}
+
+// Stream publishes anchor events, witness proofs and observed DIDs to subscribers. Anchor events and proofs
+// are created from the activities that are processed by the ActivityPub inbox and DID events are published
+// by the observer. The most recent events are retained in memory so that a subscriber that reconnects may resume
+// from the last event that it received. Event IDs are local to this server instance, so they're prefixed with
+// an epoch that's generated when the stream is created.
+type Stream struct {
+	*lifecycle.Lifecycle
+
+	epoch                string
+	bufferSize           int
+	subscriberBufferSize int
+	mutex                sync.RWMutex
+	lastID               uint64
+	buffer               []*Event
+	subscribers          map[*subscriber]struct{}
+	stopped              bool
+	now                  func() time.Time
+}
+
+// New returns a new event stream.
+func New(opts ...Option) *Stream {
+	s := &Stream{
+		epoch:                uuid.New().String(),
+		bufferSize:           defaultBufferSize,
+		subscriberBufferSize: defaultSubscriberBufferSize,
+		subscribers:          make(map[*subscriber]struct{}),
+		now:                  time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Lifecycle = lifecycle.New("event-stream", lifecycle.WithStop(s.stop))
+
+	return s
+}
+
+// DIDsObserved publishes an event for the DIDs that were updated by the given anchor event.
+func (s *Stream) DIDsObserved(anchorHashlink string, suffixes []string) {
+	s.publish(&Event{
+		Type:     EventTypeDID,
+		Anchor:   anchorHashlink,
+		Suffixes: suffixes,
+	})
+}
+
+// Subscribe subscribes to events that match the given filter. The events after the given ID that are
+// still retained are returned first and subsequent events are sent to the returned channel. If the stream
+// can't be resumed from the given ID (the ID is from another epoch or the events after it are no longer
+// retained) then a single reset event is returned instead. The channel is closed if the subscriber falls
+// behind or the stream is stopped. The returned function must be invoked to unsubscribe.
+func (s *Stream) Subscribe(filter *Filter, lastEventID string) ([]*Event, <-chan *Event, func()) {
+	sub := &subscriber{
+		filter: filter,
+		events: make(chan *Event, s.subscriberBufferSize),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		logger.Debugf("Not adding subscriber since the stream is stopped")
+
+		close(sub.events)
+
+		return nil, sub.events, func() {}
+	}
+
+	var replay []*Event
+
+	if lastEventID != "" {
+		replay = s.getReplay(filter, lastEventID)
+	}
+
+	s.subscribers[sub] = struct{}{}
+
+	logger.Debugf("Added subscriber. Replaying %d events after event [%s]", len(replay), lastEventID)
+
+	return replay, sub.events, func() { s.unsubscribe(sub) }
+}
+
+func (s *Stream) getReplay(filter *Filter, lastEventID string) []*Event {
+	seq, ok := s.parseID(lastEventID)
+
+	// Events were missed if the event following the given event is no longer retained.
+	missed := seq < s.lastID && (len(s.buffer) == 0 || s.buffer[0].seq > seq+1)
+
+	if !ok || seq > s.lastID || missed {
+		logger.Infof("Unable to resume stream from event [%s]. Sending reset event.", lastEventID)
+
+		return []*Event{{
+			ID:   s.formatID(s.lastID),
+			Type: EventTypeReset,
+			Time: s.now(),
+			seq:  s.lastID,
+		}}
+	}
+
+	var replay []*Event
+
+	for _, e := range s.buffer {
+		if e.seq > seq && filter.matches(e) {
+			replay = append(replay, e)
+		}
+	}
+
+	return replay
+}
+
+// parseID returns the sequence number of the given event ID. False is returned if the ID is invalid or
+// if it's from another epoch.
+func (s *Stream) parseID(id string) (uint64, bool) {
+	i := strings.LastIndex(id, idSeparator)
+	if i < 0 || id[:i] != s.epoch {
+		return 0, false
+	}
+
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return seq, true
+}
+
+func (s *Stream) formatID(seq uint64) string {
+	return fmt.Sprintf("%s%s%d", s.epoch, idSeparator, seq)
+}
+
+func (s *Stream) unsubscribe(sub *subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
+
+func (s *Stream) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for sub := range s.subscribers {
+		close(sub.events)
+	}
+
+	s.subscribers = make(map[*subscriber]struct{})
+	s.stopped = true
+}
+
+// Listen publishes events for the activities received on the given channel. This function blocks until
+// the channel is closed.
+func (s *Stream) Listen(activityChan <-chan *vocab.ActivityType) {
+	logger.Debugf("Starting activity listener")
+
+	for activity := range activityChan {
+		if e := newActivityEvent(activity); e != nil {
+			s.publish(e)
+		}
+	}
+
+	logger.Debugf("Activity listener stopped")
+}
+
+func (s *Stream) publish(e *Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastID++
+
+	e.seq = s.lastID
+	e.ID = s.formatID(s.lastID)
+	e.Time = s.now()
+
+	s.buffer = append(s.buffer, e)
+
+	if len(s.buffer) > s.bufferSize {
+		s.buffer = s.buffer[len(s.buffer)-s.bufferSize:]
+	}
+
+	for sub := range s.subscribers {
+		if !sub.filter.matches(e) {
+			continue
+		}
+
+		select {
+		case sub.events <- e:
+		default:
+			// The subscriber isn't keeping up. Disconnect it so that it may resume from the last event it received.
+			logger.Warnf("Disconnecting subscriber since it has fallen behind by %d events", len(sub.events))
+
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func newActivityEvent(activity *vocab.ActivityType) *Event {
+	e := &Event{Activity: activity}
+
+	if activity.Actor() != nil {
+		e.Actor = activity.Actor().String()
+	}
+
+	switch {
+	case activity.Type().Is(vocab.TypeCreate):
+		e.Type = EventTypeAnchor
+
+		if anchorEvent := activity.Object().AnchorEvent(); anchorEvent != nil {
+			e.Anchor = anchorRef(anchorEvent)
+		}
+	case activity.Type().Is(vocab.TypeAnnounce):
+		e.Type = EventTypeAnchor
+	case activity.Type().Is(vocab.TypeAccept):
+		offer := activity.Object().Activity()
+		if offer == nil || !offer.Type().Is(vocab.TypeOffer) {
+			return nil
+		}
+
+		e.Type = EventTypeProof
+
+		if result := activity.Result().Object(); result != nil && result.InReplyTo() != nil {
+			e.Anchor = result.InReplyTo().String()
+		}
+	default:
+		return nil
+	}
+
+	return e
+}
+
+func anchorRef(anchorEvent *vocab.AnchorEventType) string {
+	if anchorEvent.Index() != nil {
+		return anchorEvent.Index().String()
+	}
+
+	if len(anchorEvent.URL()) > 0 {
+		return anchorEvent.URL()[0].String()
+	}
+
+	return ""
+}
+
+func (f *Filter) matches(e *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 && !containsType(f.Types, e.Type) {
+		return false
+	}
+
+	if len(f.Actors) > 0 && !contains(f.Actors, e.Actor) {
+		return false
+	}
+
+	if len(f.Suffixes) > 0 && !containsAny(f.Suffixes, e.Suffixes) {
+		return false
+	}
+
+	return true
+}
+
+func containsType(types []EventType, t EventType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(values, others []string) bool {
+	for _, v := range others {
+		if contains(values, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventstream
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/activitypub/vocab"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+)
+
+const (
+	anchor1 = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+	anchor2 = "hl:uEiAk0CUuIIVOxlalYH6JU7gsIwvo5zGNcM_zYo2jXwzBzw"
+
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+)
+
+var (
+	service1 = testutil.MustParseURL("https://orb.domain1.com/services/orb")
+	service2 = testutil.MustParseURL("https://orb.domain2.com/services/orb")
+)
+
+func TestStream(t *testing.T) {
+	activityChan := make(chan *vocab.ActivityType, 10)
+
+	s := New(WithBufferSize(3))
+	require.NotNil(t, s)
+
+	s.Start()
+	defer s.Stop()
+
+	go s.Listen(activityChan)
+
+	_, allEvents, unsubscribeAll := s.Subscribe(nil, "")
+	defer unsubscribeAll()
+
+	_, didEvents, unsubscribeDIDs := s.Subscribe(
+		&Filter{Types: []EventType{EventTypeDID}, Suffixes: []string{suffix2}}, "",
+	)
+	defer unsubscribeDIDs()
+
+	activityChan <- newCreateActivity(service1, anchor1)
+
+	e := requireEvent(t, allEvents)
+	require.Equal(t, s.formatID(1), e.ID)
+	require.Equal(t, EventTypeAnchor, e.Type)
+	require.Equal(t, service1.String(), e.Actor)
+	require.Equal(t, anchor1, e.Anchor)
+	require.NotNil(t, e.Activity)
+	require.False(t, e.Time.IsZero())
+
+	activityChan <- newAcceptOfferActivity(service2, anchor1)
+
+	e = requireEvent(t, allEvents)
+	require.Equal(t, s.formatID(2), e.ID)
+	require.Equal(t, EventTypeProof, e.Type)
+	require.Equal(t, service2.String(), e.Actor)
+	require.Equal(t, anchor1, e.Anchor)
+
+	// Activities that aren't anchor events or proofs are ignored.
+	activityChan <- vocab.NewLikeActivity(vocab.NewObjectProperty(), vocab.WithActor(service2))
+	activityChan <- vocab.NewAcceptActivity(
+		vocab.NewObjectProperty(vocab.WithActivity(vocab.NewLikeActivity(vocab.NewObjectProperty()))),
+		vocab.WithActor(service2),
+	)
+
+	activityChan <- vocab.NewAnnounceActivity(vocab.NewObjectProperty(), vocab.WithActor(service2))
+
+	e = requireEvent(t, allEvents)
+	require.Equal(t, s.formatID(3), e.ID)
+	require.Equal(t, EventTypeAnchor, e.Type)
+
+	s.DIDsObserved(anchor1, []string{suffix1})
+	s.DIDsObserved(anchor2, []string{suffix1, suffix2})
+
+	e = requireEvent(t, allEvents)
+	require.Equal(t, s.formatID(4), e.ID)
+	require.Equal(t, EventTypeDID, e.Type)
+	require.Equal(t, anchor1, e.Anchor)
+	require.Equal(t, []string{suffix1}, e.Suffixes)
+
+	require.Equal(t, s.formatID(5), requireEvent(t, allEvents).ID)
+
+	// Only the DID event for suffix2 matches the filter.
+	e = requireEvent(t, didEvents)
+	require.Equal(t, s.formatID(5), e.ID)
+	require.Equal(t, anchor2, e.Anchor)
+
+	select {
+	case e := <-didEvents:
+		t.Fatalf("unexpected event [%s]", e.ID)
+	default:
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		replay, _, unsubscribe := s.Subscribe(nil, s.formatID(2))
+		defer unsubscribe()
+
+		// Only the last three events are retained.
+		require.Len(t, replay, 3)
+		require.Equal(t, s.formatID(3), replay[0].ID)
+		require.Equal(t, s.formatID(5), replay[2].ID)
+
+		replay, _, unsubscribe2 := s.Subscribe(&Filter{Types: []EventType{EventTypeDID}}, s.formatID(4))
+		defer unsubscribe2()
+
+		require.Len(t, replay, 1)
+		require.Equal(t, s.formatID(5), replay[0].ID)
+
+		replay, _, unsubscribe3 := s.Subscribe(nil, "")
+		defer unsubscribe3()
+
+		require.Empty(t, replay)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		for _, lastEventID := range []string{
+			s.formatID(1), // The event after this event is no longer retained.
+			s.formatID(6), // The event hasn't been published yet.
+			"5",
+			"unknown-epoch:5",
+			s.epoch + ":x",
+		} {
+			replay, _, unsubscribe := s.Subscribe(&Filter{Types: []EventType{EventTypeDID}}, lastEventID)
+			unsubscribe()
+
+			require.Len(t, replay, 1)
+			require.Equal(t, EventTypeReset, replay[0].Type)
+			require.Equal(t, s.formatID(5), replay[0].ID)
+		}
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		_, events, unsubscribe := s.Subscribe(nil, "")
+
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-events
+		require.False(t, ok)
+	})
+}
+
+func TestStream_SlowSubscriber(t *testing.T) {
+	s := New(WithSubscriberBufferSize(1))
+
+	_, events, unsubscribe := s.Subscribe(nil, "")
+	defer unsubscribe()
+
+	s.DIDsObserved(anchor1, []string{suffix1})
+	s.DIDsObserved(anchor2, []string{suffix2})
+
+	require.Equal(t, s.formatID(1), requireEvent(t, events).ID)
+
+	// The subscriber was disconnected since it fell behind.
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestStream_Stop(t *testing.T) {
+	s := New()
+	s.Start()
+
+	_, events, unsubscribe := s.Subscribe(nil, "")
+	defer unsubscribe()
+
+	s.Stop()
+
+	_, ok := <-events
+	require.False(t, ok)
+
+	// The channel of a subscriber that subscribes after the stream is stopped is closed.
+	_, events, unsubscribe2 := s.Subscribe(nil, "")
+	defer unsubscribe2()
+
+	_, ok = <-events
+	require.False(t, ok)
+}
+
+func requireEvent(t *testing.T, events <-chan *Event) *Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		require.True(t, ok)
+
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	return nil
+}
+
+func newCreateActivity(actor *url.URL, anchor string) *vocab.ActivityType {
+	return vocab.NewCreateActivity(
+		vocab.NewObjectProperty(vocab.WithAnchorEvent(
+			vocab.NewAnchorEvent(vocab.WithURL(testutil.MustParseURL(anchor))),
+		)),
+		vocab.WithActor(actor),
+	)
+}
+
+func newAcceptOfferActivity(actor *url.URL, anchor string) *vocab.ActivityType {
+	offer := vocab.NewOfferActivity(vocab.NewObjectProperty(), vocab.WithActor(service1))
+
+	return vocab.NewAcceptActivity(
+		vocab.NewObjectProperty(vocab.WithActivity(offer)),
+		vocab.WithActor(actor),
+		vocab.WithResult(vocab.NewObjectProperty(vocab.WithObject(
+			vocab.NewObject(vocab.WithInReplyTo(testutil.MustParseURL(anchor))),
+		))),
+	)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	"github.com/trustbloc/orb/pkg/eventstream"
+)
+
+const (
+	endpoint = "/events"
+
+	typeParam        = "type"
+	actorParam       = "actor"
+	suffixParam      = "suffix"
+	lastEventIDParam = "lastEventId"
+
+	lastEventIDHeader = "Last-Event-ID"
+
+	defaultKeepAliveInterval = 30 * time.Second
+)
+
+const (
+	badRequestResponse          = "Bad Request."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("event-stream-rest-handler")
+
+type eventStream interface {
+	Subscribe(filter *eventstream.Filter, lastEventID string) ([]*eventstream.Event, <-chan *eventstream.Event, func())
+}
+
+// StreamHandler streams events to the client using Server-Sent Events. The events may be filtered with the
+// 'type', 'actor' and 'suffix' query parameters (each of which may be repeated or contain a comma-separated
+// list of values). A client that reconnects may resume the stream by specifying the ID of the last event that
+// it received in the 'Last-Event-ID' header or the 'lastEventId' query parameter. A 'reset' event is sent
+// if the stream can't be resumed from the given event (e.g. the server was restarted).
+type StreamHandler struct {
+	stream            eventStream
+	keepAliveInterval time.Duration
+	marshal           func(interface{}) ([]byte, error)
+}
+
+// New returns a new StreamHandler.
+func New(stream eventStream) *StreamHandler {
+	return &StreamHandler{
+		stream:            stream,
+		keepAliveInterval: defaultKeepAliveInterval,
+		marshal:           json.Marshal,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the StreamHandler service.
+func (h *StreamHandler) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the StreamHandler service.
+func (h *StreamHandler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the StreamHandler service.
+func (h *StreamHandler) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *StreamHandler) handle(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		logger.Errorf("[%s] Streaming is not supported by the response writer", endpoint)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	filter, err := getFilter(req)
+	if err != nil {
+		logger.Infof("[%s] Invalid filter: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(badRequestResponse))
+
+		return
+	}
+
+	lastEventID := getLastEventID(req)
+
+	replay, events, unsubscribe := h.stream.Subscribe(filter, lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range replay {
+		if err := h.writeEvent(w, e); err != nil {
+			logger.Debugf("[%s] Closing stream: %s", endpoint, err)
+
+			return
+		}
+	}
+
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			logger.Debugf("[%s] Client closed the stream", endpoint)
+
+			return
+		case e, ok := <-events:
+			if !ok {
+				logger.Debugf("[%s] Event stream closed", endpoint)
+
+				return
+			}
+
+			if err := h.writeEvent(w, e); err != nil {
+				logger.Debugf("[%s] Closing stream: %s", endpoint, err)
+
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				logger.Debugf("[%s] Closing stream: %s", endpoint, err)
+
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func (h *StreamHandler) writeEvent(w http.ResponseWriter, e *eventstream.Event) error {
+	eventBytes, err := h.marshal(e)
+	if err != nil {
+		// Skip the event rather than closing the stream.
+		logger.Errorf("[%s] Error marshalling event [%s]: %s", endpoint, e.ID, err)
+
+		return nil
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, eventBytes)
+	if err != nil {
+		return fmt.Errorf("write event [%s]: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+func getFilter(req *http.Request) (*eventstream.Filter, error) {
+	filter := &eventstream.Filter{
+		Actors:   getValues(req, actorParam),
+		Suffixes: getValues(req, suffixParam),
+	}
+
+	for _, t := range getValues(req, typeParam) {
+		switch eventType := eventstream.EventType(t); eventType {
+		case eventstream.EventTypeAnchor, eventstream.EventTypeProof, eventstream.EventTypeDID:
+			filter.Types = append(filter.Types, eventType)
+		default:
+			return nil, fmt.Errorf("invalid event type [%s]", t)
+		}
+	}
+
+	return filter, nil
+}
+
+func getLastEventID(req *http.Request) string {
+	if value := req.Header.Get(lastEventIDHeader); value != "" {
+		return value
+	}
+
+	return req.URL.Query().Get(lastEventIDParam)
+}
+
+func getValues(req *http.Request, param string) []string {
+	var values []string
+
+	for _, value := range req.URL.Query()[param] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return values
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/orb/pkg/eventstream"
+)
+
+const (
+	anchor1 = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+	anchor2 = "hl:uEiAk0CUuIIVOxlalYH6JU7gsIwvo5zGNcM_zYo2jXwzBzw"
+
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+)
+
+func TestStreamHandler(t *testing.T) {
+	stream := eventstream.New()
+
+	stream.Start()
+	defer stream.Stop()
+
+	h := New(stream)
+	require.Equal(t, endpoint, h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.NotNil(t, h.Handler())
+
+	server := httptest.NewServer(http.HandlerFunc(h.Handler()))
+	defer server.Close()
+
+	_, events, unsubscribe := stream.Subscribe(nil, "")
+	defer unsubscribe()
+
+	stream.DIDsObserved(anchor1, []string{suffix1})
+
+	// Event IDs are prefixed with the epoch of the stream.
+	epoch := strings.TrimSuffix((<-events).ID, ":1")
+
+	t.Run("success", func(t *testing.T) {
+		resp, lines := openStream(t, server.URL+"?type=did&suffix="+suffix2, "")
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		stream.DIDsObserved(anchor2, []string{suffix1})
+		stream.DIDsObserved(anchor2, []string{suffix2})
+
+		// Only the event for suffix2 matches the filter.
+		requireEvent(t, lines, epoch+":3", eventstream.EventTypeDID, anchor2)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		resp, lines := openStream(t, server.URL+"?type=did,anchor", epoch+":1")
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		requireEvent(t, lines, epoch+":2", eventstream.EventTypeDID, anchor2)
+		requireEvent(t, lines, epoch+":3", eventstream.EventTypeDID, anchor2)
+	})
+
+	t.Run("resume with query parameter", func(t *testing.T) {
+		resp, lines := openStream(t, server.URL+"?lastEventId="+epoch+":2", "")
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		requireEvent(t, lines, epoch+":3", eventstream.EventTypeDID, anchor2)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		// The stream can't be resumed from an event of another epoch.
+		resp, lines := openStream(t, server.URL, "xxx:2")
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		requireEvent(t, lines, epoch+":3", eventstream.EventTypeReset, "")
+	})
+
+	t.Run("invalid event type", func(t *testing.T) {
+		resp, _ := openStream(t, server.URL+"?type=activity", "")
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+}
+
+func TestStreamHandler_KeepAlive(t *testing.T) {
+	h := New(&mockStream{events: make(chan *eventstream.Event)})
+	h.keepAliveInterval = 10 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(h.Handler()))
+	defer server.Close()
+
+	resp, lines := openStream(t, server.URL, "")
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	require.Equal(t, ": keep-alive", nextLine(t, lines))
+}
+
+func TestStreamHandler_StreamClosed(t *testing.T) {
+	events := make(chan *eventstream.Event, 1)
+	events <- &eventstream.Event{ID: "epoch:1", Type: eventstream.EventTypeAnchor, Anchor: anchor1}
+
+	close(events)
+
+	h := New(&mockStream{events: events})
+
+	rw := httptest.NewRecorder()
+
+	h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), "id: epoch:1\nevent: anchor\n")
+}
+
+func TestStreamHandler_Error(t *testing.T) {
+	t.Run("streaming not supported", func(t *testing.T) {
+		h := New(&mockStream{})
+
+		rw := &responseWriter{header: make(http.Header)}
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		require.Equal(t, http.StatusInternalServerError, rw.status)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		events := make(chan *eventstream.Event, 2)
+		events <- &eventstream.Event{ID: "epoch:1", Type: eventstream.EventTypeAnchor, Anchor: anchor1}
+		events <- &eventstream.Event{ID: "epoch:2", Type: eventstream.EventTypeAnchor, Anchor: anchor2}
+
+		close(events)
+
+		h := New(&mockStream{events: events})
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Empty(t, rw.Body.String())
+	})
+
+	t.Run("client closed", func(t *testing.T) {
+		h := New(&mockStream{events: make(chan *eventstream.Event)})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx))
+
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func openStream(t *testing.T, u, lastEventID string) (*http.Response, <-chan string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, nil)
+	require.NoError(t, err)
+
+	if lastEventID != "" {
+		req.Header.Set(lastEventIDHeader, lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	lines := make(chan string, 100)
+
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				lines <- line
+			}
+		}
+	}()
+
+	return resp, lines
+}
+
+func nextLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	return ""
+}
+
+func requireEvent(t *testing.T, lines <-chan string, id string, eventType eventstream.EventType, anchor string) {
+	t.Helper()
+
+	require.Equal(t, "id: "+id, nextLine(t, lines))
+	require.Equal(t, "event: "+string(eventType), nextLine(t, lines))
+
+	data := nextLine(t, lines)
+	require.True(t, strings.HasPrefix(data, "data: "))
+	require.Contains(t, data, anchor)
+}
+
+type mockStream struct {
+	events chan *eventstream.Event
+}
+
+func (m *mockStream) Subscribe(*eventstream.Filter, string) ([]*eventstream.Event, <-chan *eventstream.Event, func()) {
+	return nil, m.events, func() {}
+}
+
+type responseWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+}
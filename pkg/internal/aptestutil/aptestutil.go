@@ -24,7 +24,8 @@ import (
 
 // ServiceOptions are options passed in to NewMockService.
 type ServiceOptions struct {
-	PublicKey *vocab.PublicKeyType
+	PublicKey   *vocab.PublicKeyType
+	SharedInbox *url.URL
 }
 
 // ServiceOpt is a mock service option.
@@ -37,6 +38,13 @@ func WithPublicKey(pubKey *vocab.PublicKeyType) ServiceOpt {
 	}
 }
 
+// WithSharedInbox sets the shared inbox on the mock service.
+func WithSharedInbox(sharedInbox *url.URL) ServiceOpt {
+	return func(options *ServiceOptions) {
+		options.SharedInbox = sharedInbox
+	}
+}
+
 // NewMockService returns a mock 'Service' type actor with the given IRI and options.
 func NewMockService(serviceIRI *url.URL, opts ...ServiceOpt) *vocab.ActorType {
 	options := &ServiceOptions{
@@ -64,6 +72,7 @@ func NewMockService(serviceIRI *url.URL, opts ...ServiceOpt) *vocab.ActorType {
 		vocab.WithWitnesses(witnesses),
 		vocab.WithWitnessing(witnessing),
 		vocab.WithLiked(liked),
+		vocab.WithSharedInbox(options.SharedInbox),
 	)
 }
 
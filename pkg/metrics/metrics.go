@@ -23,6 +23,8 @@ const (
 	apResolveInboxesTimeMetric    = "outbox_resolve_inboxes_seconds"
 	apInboxHandlerTimeMetric      = "inbox_handler_seconds"
 	apOutboxActivityCounterMetric = "outbox_count"
+	apCircuitBreakerStateMetric   = "outbox_circuit_breaker_state"
+	apInboxRateLimitedMetric      = "inbox_rate_limited_count"
 
 	// Anchor.
 	anchor                                         = "anchor"
@@ -41,6 +43,9 @@ const (
 	anchorWriteSignLocalWitnessLogTimeMetric       = "write_sign_local_witness_log_seconds"
 	anchorWriteSignLocalWatchTimeMetric            = "write_sign_local_watch_seconds"
 	anchorWriteResolveHostMetaLinkTimeMetric       = "write_resolve_host_meta_link_seconds"
+	anchorWitnessResponseCountMetric               = "witness_response_count"
+	anchorWitnessHealthScoreMetric                 = "witness_health_score"
+	anchorWitnessLatencyMetric                     = "witness_latency_seconds"
 
 	// Operation queue.
 	operationQueue                 = "opqueue"
@@ -148,6 +153,8 @@ type Metrics struct {
 	apOutboxResolveInboxesTime prometheus.Histogram
 	apInboxHandlerTimes        map[string]prometheus.Histogram
 	apOutboxActivityCounts     map[string]prometheus.Counter
+	apCircuitBreakerStates     *prometheus.GaugeVec
+	apInboxRateLimitedCounts   map[string]prometheus.Counter
 
 	anchorWriteTime                          prometheus.Histogram
 	anchorWitnessTime                        prometheus.Histogram
@@ -164,6 +171,9 @@ type Metrics struct {
 	anchorWriteStoreTime                     prometheus.Histogram
 	anchorWriteSignLocalWatchTime            prometheus.Histogram
 	anchorWriteResolveHostMetaLinkTime       prometheus.Histogram
+	anchorWitnessResponseCounts              *prometheus.CounterVec
+	anchorWitnessHealthScores                *prometheus.GaugeVec
+	anchorWitnessLatencies                   *prometheus.GaugeVec
 
 	opqueueAddOperationTime  prometheus.Histogram
 	opqueueBatchCutTime      prometheus.Histogram
@@ -275,6 +285,11 @@ func newMetrics() *Metrics { //nolint:funlen,gocyclo,cyclop
 		docResolveTime:                               newDocResolveTime(),
 		apInboxHandlerTimes:                          newInboxHandlerTimes(activityTypes),
 		apOutboxActivityCounts:                       newOutboxActivityCounts(activityTypes),
+		apCircuitBreakerStates:                       newOutboxCircuitBreakerStates(),
+		anchorWitnessResponseCounts:                  newAnchorWitnessResponseCounts(),
+		anchorWitnessHealthScores:                    newAnchorWitnessHealthScores(),
+		anchorWitnessLatencies:                       newAnchorWitnessLatencies(),
+		apInboxRateLimitedCounts:                     newInboxRateLimitedCounts(activityTypes),
 		dbPutTimes:                                   newDBPutTime(dbTypes),
 		dbGetTimes:                                   newDBGetTime(dbTypes),
 		dbGetTagsTimes:                               newDBGetTagsTime(dbTypes),
@@ -320,7 +335,7 @@ func newMetrics() *Metrics { //nolint:funlen,gocyclo,cyclop
 	}
 
 	prometheus.MustRegister(
-		m.apOutboxPostTime, m.apOutboxResolveInboxesTime,
+		m.apOutboxPostTime, m.apOutboxResolveInboxesTime, m.apCircuitBreakerStates,
 		m.anchorWriteTime, m.anchorWitnessTime, m.anchorProcessWitnessedTime, m.anchorWriteBuildCredTime,
 		m.anchorWriteGetWitnessesTime, m.anchorWriteSignCredTime, m.anchorWritePostOfferActivityTime,
 		m.anchorWriteGetPreviousAnchorsGetBulkTime, m.anchorWriteGetPreviousAnchorsTime,
@@ -334,6 +349,7 @@ func newMetrics() *Metrics { //nolint:funlen,gocyclo,cyclop
 		m.vctWitnessAddWebFingerTimes, m.vctWitnessVerifyVCTimes, m.vctAddProofParseCredentialTimes,
 		m.vctAddProofSignTimes, m.signerSignTimes, m.signerGetKeyTimes, m.signerAddLinkedDataProofTimes,
 		m.anchorWriteResolveHostMetaLinkTime,
+		m.anchorWitnessResponseCounts, m.anchorWitnessHealthScores, m.anchorWitnessLatencies,
 		m.resolverResolveDocumentLocallyTimes, m.resolverGetAnchorOriginEndpointTimes,
 		m.resolverResolveDocumentFromAnchorOriginTimes,
 		m.resolverResolveDocumentFromCreateStoreTimes, m.resolverDeleteDocumentFromCreateStoreTimes,
@@ -383,6 +399,10 @@ func newMetrics() *Metrics { //nolint:funlen,gocyclo,cyclop
 		prometheus.MustRegister(c)
 	}
 
+	for _, c := range m.apInboxRateLimitedCounts {
+		prometheus.MustRegister(c)
+	}
+
 	for _, c := range m.casReadTimes {
 		prometheus.MustRegister(c)
 	}
@@ -404,6 +424,14 @@ func (m *Metrics) OutboxResolveInboxesTime(value time.Duration) {
 	logger.Debugf("OutboxResolveInboxes time: %s", value)
 }
 
+// OutboxCircuitBreakerState records the state of the circuit breaker for the given destination host
+// (0 = closed, 1 = half-open, 2 = open).
+func (m *Metrics) OutboxCircuitBreakerState(host string, state int) {
+	m.apCircuitBreakerStates.WithLabelValues(host).Set(float64(state))
+
+	logger.Debugf("OutboxCircuitBreakerState for host [%s]: %d", host, state)
+}
+
 // InboxHandlerTime records the time it takes to handle an activity posted to the inbox.
 func (m *Metrics) InboxHandlerTime(activityType string, value time.Duration) {
 	if c, ok := m.apInboxHandlerTimes[activityType]; ok {
@@ -413,6 +441,16 @@ func (m *Metrics) InboxHandlerTime(activityType string, value time.Duration) {
 	logger.Debugf("InboxHandler time for activity [%s]: %s", activityType, value)
 }
 
+// InboxIncrementRateLimitedCount increments the number of activities of the given type that were rejected
+// by the inbox because the posting actor exceeded its rate limit.
+func (m *Metrics) InboxIncrementRateLimitedCount(activityType string) {
+	if c, ok := m.apInboxRateLimitedCounts[activityType]; ok {
+		c.Inc()
+	}
+
+	logger.Debugf("InboxRateLimited count for activity [%s]", activityType)
+}
+
 // OutboxIncrementActivityCount increments the number of activities of the given type posted to the outbox.
 func (m *Metrics) OutboxIncrementActivityCount(activityType string) {
 	if c, ok := m.apOutboxActivityCounts[activityType]; ok {
@@ -528,6 +566,29 @@ func (m *Metrics) ProcessWitnessedAnchorCredentialTime(value time.Duration) {
 	logger.Debugf("ProcessWitnessedAnchorCredential time: %s", value)
 }
 
+// WitnessResponse increments the number of responses from the given witness with the given outcome
+// (success, timeout or invalid-proof).
+func (m *Metrics) WitnessResponse(witness, outcome string) {
+	m.anchorWitnessResponseCounts.WithLabelValues(witness, outcome).Inc()
+
+	logger.Debugf("WitnessResponse for witness [%s]: %s", witness, outcome)
+}
+
+// WitnessHealthScore records the health score of the given witness (0 = unhealthy, 1 = healthy).
+func (m *Metrics) WitnessHealthScore(witness string, score float64) {
+	m.anchorWitnessHealthScores.WithLabelValues(witness).Set(score)
+
+	logger.Debugf("WitnessHealthScore for witness [%s]: %.2f", witness, score)
+}
+
+// WitnessLatency records the average time between sending an offer to the given witness
+// and receiving a proof.
+func (m *Metrics) WitnessLatency(witness string, value time.Duration) {
+	m.anchorWitnessLatencies.WithLabelValues(witness).Set(value.Seconds())
+
+	logger.Debugf("WitnessLatency for witness [%s]: %s", witness, value)
+}
+
 // AddOperationTime records the time it takes to add an operation to the queue.
 func (m *Metrics) AddOperationTime(value time.Duration) {
 	m.opqueueAddOperationTime.Observe(value.Seconds())
@@ -982,6 +1043,30 @@ func newOutboxActivityCounts(activityTypes []string) map[string]prometheus.Count
 	return counters
 }
 
+func newInboxRateLimitedCounts(activityTypes []string) map[string]prometheus.Counter {
+	counters := make(map[string]prometheus.Counter)
+
+	for _, activityType := range activityTypes {
+		counters[activityType] = newCounter(
+			activityPub, apInboxRateLimitedMetric,
+			"The number of activities rejected by the inbox because the actor exceeded its rate limit.",
+			prometheus.Labels{"type": activityType},
+		)
+	}
+
+	return counters
+}
+
+func newOutboxCircuitBreakerStates() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: activityPub,
+		Name:      apCircuitBreakerStateMetric,
+		Help: "The state of the circuit breaker for a destination host when delivering activities from the " +
+			"outbox (0 = closed, 1 = half-open, 2 = open).",
+	}, []string{"host"})
+}
+
 func newAnchorWriteTime() prometheus.Histogram {
 	return newHistogram(
 		anchor, anchorWriteTimeMetric,
@@ -1009,6 +1094,35 @@ func newAnchorProcessWitnessedTime() prometheus.Histogram {
 	)
 }
 
+func newAnchorWitnessResponseCounts() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: anchor,
+		Name:      anchorWitnessResponseCountMetric,
+		Help: "The number of responses to 'Offer' activities from a witness by outcome " +
+			"(success, timeout, invalid-proof).",
+	}, []string{"witness", "outcome"})
+}
+
+func newAnchorWitnessHealthScores() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: anchor,
+		Name:      anchorWitnessHealthScoreMetric,
+		Help: "The health score of a witness based on its recent responses (0 = unhealthy, 1 = healthy). " +
+			"Witnesses with a score below the health threshold are skipped during witness selection.",
+	}, []string{"witness"})
+}
+
+func newAnchorWitnessLatencies() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: anchor,
+		Name:      anchorWitnessLatencyMetric,
+		Help:      "The average time (in seconds) between sending an 'Offer' activity to a witness and receiving a proof.",
+	}, []string{"witness"})
+}
+
 func newAnchorWriteBuildCredTime() prometheus.Histogram {
 	return newHistogram(
 		anchor, anchorWriteBuildCredTimeMetric,
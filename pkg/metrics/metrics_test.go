@@ -23,6 +23,8 @@ func TestMetrics(t *testing.T) {
 		require.NotPanics(t, func() { m.InboxHandlerTime("Create", time.Second) })
 		require.NotPanics(t, func() { m.OutboxPostTime(time.Second) })
 		require.NotPanics(t, func() { m.OutboxResolveInboxesTime(time.Second) })
+		require.NotPanics(t, func() { m.OutboxCircuitBreakerState("orb.domain1.com", 2) })
+		require.NotPanics(t, func() { m.InboxIncrementRateLimitedCount("Create") })
 		require.NotPanics(t, func() { m.WriteAnchorTime(time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorBuildCredentialTime(time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorSignCredentialTime(time.Second) })
@@ -33,6 +35,9 @@ func TestMetrics(t *testing.T) {
 		require.NotPanics(t, func() { m.WriteAnchorSignWithLocalWitnessTime(time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorSignWithServerKeyTime(time.Second) })
 		require.NotPanics(t, func() { m.WitnessAnchorCredentialTime(time.Second) })
+		require.NotPanics(t, func() { m.WitnessResponse("https://orb.domain1.com/services/orb", "timeout") })
+		require.NotPanics(t, func() { m.WitnessHealthScore("https://orb.domain1.com/services/orb", 0.5) })
+		require.NotPanics(t, func() { m.WitnessLatency("https://orb.domain1.com/services/orb", time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorSignLocalWitnessLogTime(time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorStoreTime(time.Second) })
 		require.NotPanics(t, func() { m.WriteAnchorSignLocalWatchTime(time.Second) })
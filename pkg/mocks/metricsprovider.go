@@ -19,6 +19,26 @@ func (m *MetricsProvider) OutboxPostTime(value time.Duration) {
 func (m *MetricsProvider) OutboxResolveInboxesTime(value time.Duration) {
 }
 
+// OutboxCircuitBreakerState records the state of the circuit breaker for the given destination host.
+func (m *MetricsProvider) OutboxCircuitBreakerState(host string, state int) {
+}
+
+// InboxIncrementRateLimitedCount increments the number of activities rejected by the inbox due to rate limiting.
+func (m *MetricsProvider) InboxIncrementRateLimitedCount(activityType string) {
+}
+
+// WitnessResponse increments the number of responses from the given witness with the given outcome.
+func (m *MetricsProvider) WitnessResponse(witness, outcome string) {
+}
+
+// WitnessHealthScore records the health score of the given witness.
+func (m *MetricsProvider) WitnessHealthScore(witness string, score float64) {
+}
+
+// WitnessLatency records the average time between sending an offer to the given witness and receiving a proof.
+func (m *MetricsProvider) WitnessLatency(witness string, value time.Duration) {
+}
+
 // InboxHandlerTime records the time it takes to handle an activity posted to the inbox.
 func (m *MetricsProvider) InboxHandlerTime(activityType string, value time.Duration) {
 }
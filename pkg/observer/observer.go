@@ -100,6 +100,25 @@ type anchorLinkStore interface {
 	GetLinks(anchorHash string) ([]*url.URL, error)
 }
 
+type operationStatusStore interface {
+	Observed(anchorHashlink string) error
+	ObserveFailed(anchorHashlink, reason string) error
+}
+
+type webhookNotifier interface {
+	Notify(anchorHashlink, anchorOrigin string, ops []*operation.AnchoredOperation) error
+}
+
+// operationsProcessor is implemented by transaction processors that return the operations that they processed
+// so that the operations don't need to be retrieved again in order to notify webhook subscribers.
+type operationsProcessor interface {
+	ProcessOperations(sidetreeTxn txnapi.SidetreeTxn, suffixes ...string) ([]*operation.AnchoredOperation, int, error)
+}
+
+type eventStream interface {
+	DIDsObserved(anchorHashlink string, suffixes []string)
+}
+
 type outboxProvider func() Outbox
 
 type options struct {
@@ -128,15 +147,18 @@ func WithSubscriberPoolSize(value int) Option {
 type Providers struct {
 	ProtocolClientProvider protocol.ClientProvider
 	AnchorGraph
-	DidAnchors        didAnchors
-	PubSub            pubSub
-	Metrics           metricsProvider
-	Outbox            outboxProvider
-	WebFingerResolver resourceResolver
-	CASResolver       casResolver
-	DocLoader         documentLoader
-	Pkf               verifiable.PublicKeyFetcher
-	AnchorLinkStore   anchorLinkStore
+	DidAnchors           didAnchors
+	PubSub               pubSub
+	Metrics              metricsProvider
+	Outbox               outboxProvider
+	WebFingerResolver    resourceResolver
+	CASResolver          casResolver
+	DocLoader            documentLoader
+	Pkf                  verifiable.PublicKeyFetcher
+	AnchorLinkStore      anchorLinkStore
+	OperationStatusStore operationStatusStore
+	WebhookNotifier      webhookNotifier
+	EventStream          eventStream
 }
 
 // Observer receives transactions over a channel and processes them by storing them to an operation store.
@@ -206,6 +228,8 @@ func (o *Observer) handleAnchor(anchor *anchorinfo.AnchorInfo) error {
 	if err != nil {
 		logger.Warnf("Failed to get anchor event[%s] node from anchor graph: %s", anchor.Hashlink, err.Error())
 
+		o.updateOperationStatus(anchor.Hashlink, err)
+
 		return err
 	}
 
@@ -214,12 +238,36 @@ func (o *Observer) handleAnchor(anchor *anchorinfo.AnchorInfo) error {
 	if err := o.processAnchor(anchor, anchorEvent); err != nil {
 		logger.Warnf(err.Error())
 
+		o.updateOperationStatus(anchor.Hashlink, err)
+
 		return err
 	}
 
+	o.updateOperationStatus(anchor.Hashlink, nil)
+
 	return nil
 }
 
+// updateOperationStatus updates the status of the operations in the given anchor (if the operations were
+// submitted to this server). The status is informational so an error is logged but otherwise ignored.
+func (o *Observer) updateOperationStatus(hl string, processErr error) {
+	if o.OperationStatusStore == nil {
+		return
+	}
+
+	var err error
+
+	if processErr != nil {
+		err = o.OperationStatusStore.ObserveFailed(hl, processErr.Error())
+	} else {
+		err = o.OperationStatusStore.Observed(hl)
+	}
+
+	if err != nil {
+		logger.Warnf("Failed to update status of operations in anchor[%s]: %s", hl, err)
+	}
+}
+
 func (o *Observer) processDID(did string) error {
 	logger.Debugf("processing out-of-system did[%s]", did)
 
@@ -330,7 +378,7 @@ func (o *Observer) processAnchor(anchor *anchorinfo.AnchorInfo,
 
 	logger.Debugf("processing anchor[%s], core index[%s]", anchor.Hashlink, anchorPayload.CoreIndex)
 
-	numProcessed, err := v.TransactionProcessor().Process(sidetreeTxn, suffixes...)
+	txnOps, numProcessed, err := o.processTxn(v, &sidetreeTxn, suffixes)
 	if err != nil {
 		return fmt.Errorf("failed to process anchor[%s] core index[%s]: %w",
 			anchor.Hashlink, anchorPayload.CoreIndex, err)
@@ -368,6 +416,21 @@ func (o *Observer) processAnchor(anchor *anchorinfo.AnchorInfo,
 	logger.Infof("Successfully processed %d DIDs in anchor[%s], core index[%s]",
 		anchorPayload.OperationCount, anchor.Hashlink, anchorPayload.CoreIndex)
 
+	err = o.notifyWebhooks(anchor, anchorPayload.AnchorOrigin, &sidetreeTxn, txnOps)
+	if err != nil {
+		if errors.IsTransient(err) {
+			// The anchor link hasn't been saved yet so the anchor isn't considered to be processed. Return
+			// the error so that the anchor is redelivered and the notifications are published again.
+			return fmt.Errorf("notify webhooks for anchor[%s]: %w", anchor.Hashlink, err)
+		}
+
+		logger.Warnf("Unable to send webhook notifications for anchor[%s]: %s", anchor.Hashlink, err)
+	}
+
+	if o.EventStream != nil {
+		o.EventStream.DIDsObserved(anchor.Hashlink, acSuffixes)
+	}
+
 	// Post a 'Like' activity to the originator of the anchor credential.
 	err = o.saveAnchorLinkAndPostLikeActivity(anchor)
 	if err != nil {
@@ -379,6 +442,62 @@ func (o *Observer) processAnchor(anchor *anchorinfo.AnchorInfo,
 	return nil
 }
 
+// processTxn processes the given transaction and returns the operations of the transaction (for the given
+// suffixes, if any) along with the number of operations that were processed. The operations are retrieved
+// from the operation provider only if the transaction processor doesn't return them and webhook subscribers
+// need to be notified.
+func (o *Observer) processTxn(v protocol.Version, sidetreeTxn *txnapi.SidetreeTxn,
+	suffixes []string) ([]*operation.AnchoredOperation, int, error) {
+	if p, ok := v.TransactionProcessor().(operationsProcessor); ok {
+		return p.ProcessOperations(*sidetreeTxn, suffixes...)
+	}
+
+	numProcessed, err := v.TransactionProcessor().Process(*sidetreeTxn, suffixes...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if o.WebhookNotifier == nil {
+		return nil, numProcessed, nil
+	}
+
+	txnOps, err := v.OperationProvider().GetTxnOperations(sidetreeTxn)
+	if err != nil {
+		// The operations were processed so don't fail. Webhook subscribers won't be notified though.
+		logger.Warnf("Unable to retrieve operations for webhook notifications: %s", err)
+
+		return nil, numProcessed, nil
+	}
+
+	var ops []*operation.AnchoredOperation
+
+	for _, op := range txnOps {
+		if len(suffixes) == 0 || contains(suffixes, op.UniqueSuffix) {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops, numProcessed, nil
+}
+
+// notifyWebhooks notifies webhook subscribers of the given operations in the anchor. If a transient error is
+// returned then the anchor should be redelivered, in which case the same notification (with the same ID) may be
+// sent to some subscribers more than once.
+func (o *Observer) notifyWebhooks(anchor *anchorinfo.AnchorInfo, anchorOrigin string,
+	sidetreeTxn *txnapi.SidetreeTxn, ops []*operation.AnchoredOperation) error {
+	if o.WebhookNotifier == nil || len(ops) == 0 {
+		return nil
+	}
+
+	for _, op := range ops {
+		op.TransactionTime = sidetreeTxn.TransactionTime
+		op.ProtocolVersion = sidetreeTxn.ProtocolVersion
+		op.CanonicalReference = sidetreeTxn.CanonicalReference
+	}
+
+	return o.WebhookNotifier.Notify(anchor.Hashlink, anchorOrigin, ops)
+}
+
 func (o *Observer) saveAnchorLinkAndPostLikeActivity(anchor *anchorinfo.AnchorInfo) error {
 	refURL, err := url.Parse(anchor.Hashlink)
 	if err != nil {
@@ -551,3 +670,13 @@ func newLikeResult(hashLink string) (*vocab.ObjectProperty, error) {
 		vocab.NewAnchorEvent(vocab.WithURL(u))),
 	), nil
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
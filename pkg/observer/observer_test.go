@@ -20,6 +20,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	txnapi "github.com/trustbloc/sidetree-core-go/pkg/api/txn"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 
 	apclientmocks "github.com/trustbloc/orb/pkg/activitypub/client/mocks"
@@ -190,6 +192,117 @@ func TestStartObserver(t *testing.T) {
 		require.Equal(t, 2, tp.ProcessCallCount())
 	})
 
+	t.Run("success - webhook notification and DID event", func(t *testing.T) {
+		tp := &mocks.TxnProcessor{}
+		tp.ProcessReturns(2, nil)
+
+		opProvider := &mocks.OperationProvider{}
+		opProvider.GetTxnOperationsReturns([]*operation.AnchoredOperation{
+			{Type: operation.TypeCreate, UniqueSuffix: "did1"},
+			{Type: operation.TypeUpdate, UniqueSuffix: "did2"},
+		}, nil)
+
+		pc := mocks.NewMockProtocolClient()
+		pc.Versions[0].TransactionProcessorReturns(tp)
+		pc.Versions[0].OperationProviderReturns(opProvider)
+		pc.Versions[0].ProtocolReturns(pc.Protocol)
+
+		casClient, err := cas.New(mem.NewProvider(), casLink, nil, &orbmocks.MetricsProvider{}, 0)
+		require.NoError(t, err)
+
+		anchorGraph := graph.New(&graph.Providers{
+			CasWriter: casClient,
+			CasResolver: casresolver.New(casClient, nil,
+				casresolver.NewWebCASResolver(transport.Default(), webfingerclient.New(), "https"),
+				&orbmocks.MetricsProvider{}),
+			DocLoader: testutil.GetLoader(t),
+		})
+
+		payload := subject.Payload{
+			Namespace:       namespace1,
+			CoreIndex:       "core1",
+			AnchorOrigin:    "https://domain1.com/services/orb",
+			PreviousAnchors: []*subject.SuffixAnchor{{Suffix: "did1"}, {Suffix: "did2"}},
+		}
+
+		cid, err := anchorGraph.Add(newMockAnchorEvent(t, &payload))
+		require.NoError(t, err)
+
+		notifier := &mockWebhookNotifier{}
+		eventStream := &mockEventStream{}
+		anchorLinkStore := &orbmocks.AnchorLinkStore{}
+
+		providers := &Providers{
+			ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace1, pc),
+			AnchorGraph:            anchorGraph,
+			DidAnchors:             memdidanchor.New(),
+			PubSub:                 mempubsub.New(mempubsub.DefaultConfig()),
+			Metrics:                &orbmocks.MetricsProvider{},
+			Outbox:                 func() Outbox { return apmocks.NewOutbox() },
+			WebFingerResolver:      &apmocks.WebFingerResolver{},
+			DocLoader:              testutil.GetLoader(t),
+			Pkf:                    pubKeyFetcherFnc,
+			AnchorLinkStore:        anchorLinkStore,
+			WebhookNotifier:        notifier,
+			EventStream:            eventStream,
+		}
+
+		o, err := New(serviceIRI, providers)
+		require.NoError(t, err)
+
+		anchor := &anchorinfo.AnchorInfo{Hashlink: cid}
+
+		anchorEvent, err := anchorGraph.Read(cid)
+		require.NoError(t, err)
+
+		require.NoError(t, o.processAnchor(anchor, anchorEvent))
+		require.Equal(t, cid, notifier.anchorHashlink)
+		require.Equal(t, payload.AnchorOrigin, notifier.anchorOrigin)
+		require.Len(t, notifier.ops, 2)
+		require.Equal(t, uint64(0), notifier.ops[0].ProtocolVersion)
+		require.NotEmpty(t, notifier.ops[0].CanonicalReference)
+		require.Equal(t, cid, eventStream.anchorHashlink)
+		require.Equal(t, []string{"did1", "did2"}, eventStream.suffixes)
+
+		// Only the operations for the given suffixes are included.
+		require.NoError(t, o.processAnchor(anchor, anchorEvent, "did2"))
+		require.Len(t, notifier.ops, 1)
+		require.Equal(t, "did2", notifier.ops[0].UniqueSuffix)
+
+		// Persistent errors are logged but otherwise ignored.
+		notifier.err = errors.New("injected notifier error")
+		require.NoError(t, o.processAnchor(anchor, anchorEvent))
+
+		// A transient error is returned (before the anchor link is saved) so that the anchor is redelivered.
+		errTransient := orberrors.NewTransient(errors.New("injected transient notifier error"))
+		notifier.err = errTransient
+
+		putLinksCount := anchorLinkStore.PutLinksCallCount()
+
+		err = o.processAnchor(anchor, anchorEvent)
+		require.ErrorIs(t, err, errTransient)
+		require.True(t, orberrors.IsTransient(err))
+		require.Equal(t, putLinksCount, anchorLinkStore.PutLinksCallCount())
+
+		notifier.err = nil
+
+		opProvider.GetTxnOperationsReturns(nil, errors.New("injected operation provider error"))
+		require.NoError(t, o.processAnchor(anchor, anchorEvent))
+
+		// The operations returned by the transaction processor are used instead of retrieving them again.
+		pc.Versions[0].TransactionProcessorReturns(&mockOperationsProcessor{
+			ops: []*operation.AnchoredOperation{{Type: operation.TypeUpdate, UniqueSuffix: "did3"}},
+		})
+
+		getTxnOpsCount := opProvider.GetTxnOperationsCallCount()
+
+		require.NoError(t, o.processAnchor(anchor, anchorEvent))
+		require.Len(t, notifier.ops, 1)
+		require.Equal(t, "did3", notifier.ops[0].UniqueSuffix)
+		require.NotEmpty(t, notifier.ops[0].CanonicalReference)
+		require.Equal(t, getTxnOpsCount, opProvider.GetTxnOperationsCallCount())
+	})
+
 	t.Run("success - process did (multiple, just create)", func(t *testing.T) {
 		tp := &mocks.TxnProcessor{}
 
@@ -968,3 +1081,39 @@ const anchorEvent = `{
 const anchorEventInvalid = `{
   "@context": [
 `
+
+type mockWebhookNotifier struct {
+	anchorHashlink string
+	anchorOrigin   string
+	ops            []*operation.AnchoredOperation
+	err            error
+}
+
+func (m *mockWebhookNotifier) Notify(anchorHashlink, anchorOrigin string, ops []*operation.AnchoredOperation) error {
+	m.anchorHashlink = anchorHashlink
+	m.anchorOrigin = anchorOrigin
+	m.ops = ops
+
+	return m.err
+}
+
+type mockEventStream struct {
+	anchorHashlink string
+	suffixes       []string
+}
+
+func (m *mockEventStream) DIDsObserved(anchorHashlink string, suffixes []string) {
+	m.anchorHashlink = anchorHashlink
+	m.suffixes = suffixes
+}
+
+type mockOperationsProcessor struct {
+	mocks.TxnProcessor
+
+	ops []*operation.AnchoredOperation
+}
+
+func (m *mockOperationsProcessor) ProcessOperations(_ txnapi.SidetreeTxn,
+	_ ...string) ([]*operation.AnchoredOperation, int, error) {
+	return m.ops, len(m.ops), nil
+}
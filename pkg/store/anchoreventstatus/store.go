@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,6 +32,7 @@ const (
 
 	statusTagName          = "Status"
 	statusCheckTimeTagName = "StatusCheckTime"
+	statusTimeTagName      = "StatusTime"
 
 	// adding time in order to avoid possible errors due to differences in server times.
 	delta = 5 * time.Minute
@@ -76,7 +78,9 @@ func New(provider storage.Provider, expiryService *expiry.Service, maxWitnessDel
 	}
 
 	err = provider.SetStoreConfig(namespace,
-		storage.StoreConfiguration{TagNames: []string{index, expiryTimeTagName, statusTagName, statusCheckTimeTagName}},
+		storage.StoreConfiguration{TagNames: []string{
+			index, expiryTimeTagName, statusTagName, statusCheckTimeTagName, statusTimeTagName,
+		}},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
@@ -85,8 +89,9 @@ func New(provider storage.Provider, expiryService *expiry.Service, maxWitnessDel
 	expiryService.Register(store, expiryTimeTagName, namespace)
 
 	anchorEventStatusStore := &Store{
-		store:          store,
-		statusLifespan: maxWitnessDelay + delta,
+		store:           store,
+		statusLifespan:  maxWitnessDelay + delta,
+		maxWitnessDelay: maxWitnessDelay,
 
 		policyHandler:              &noopPolicyHandler{},
 		checkStatusAfterTimePeriod: defaultCheckStatusAfterTimePeriod,
@@ -102,10 +107,19 @@ func New(provider storage.Provider, expiryService *expiry.Service, maxWitnessDel
 	return anchorEventStatusStore, nil
 }
 
+// AnchorStatus contains the status of an anchor event and the time at which the status was added.
+// The time is zero for statuses that were added before the time was recorded.
+type AnchorStatus struct {
+	AnchorID string
+	Status   proof.AnchorIndexStatus
+	Time     time.Time
+}
+
 // Store is db implementation of anchor index status store.
 type Store struct {
-	store          storage.Store
-	statusLifespan time.Duration
+	store           storage.Store
+	statusLifespan  time.Duration
+	maxWitnessDelay time.Duration
 
 	policyHandler              policyHandler
 	checkStatusAfterTimePeriod time.Duration
@@ -133,9 +147,14 @@ func (s *Store) AddStatus(anchorID string, status proof.AnchorIndexStatus) error
 		Value: fmt.Sprintf("%d", time.Now().Add(s.statusLifespan).Unix()),
 	}
 
-	tags := []storage.Tag{indexTag, statusTag, expiryTag}
+	timeTag := storage.Tag{
+		Name:  statusTimeTagName,
+		Value: fmt.Sprintf("%d", time.Now().Unix()),
+	}
+
+	tags := []storage.Tag{indexTag, statusTag, expiryTag, timeTag}
 
-	if status != proof.AnchorIndexStatusCompleted {
+	if !isTerminal(status) {
 		statusCheckTime := time.Now().Add(s.checkStatusAfterTimePeriod).Unix()
 
 		logger.Debugf("Setting '%s' tag for anchorID[%s]: %d", statusCheckTimeTagName, anchorID, statusCheckTime)
@@ -157,11 +176,11 @@ func (s *Store) AddStatus(anchorID string, status proof.AnchorIndexStatus) error
 			anchorID, status, err))
 	}
 
-	if status == proof.AnchorIndexStatusCompleted {
+	if isTerminal(status) {
 		delErr := s.deleteInProcessStatus(anchorID)
 		if delErr != nil {
 			// no need to stop processing for this
-			logger.Debugf("failed to delete in-process statuses after receiving complete status: %s", err.Error())
+			logger.Debugf("failed to delete in-process statuses after receiving %s status: %s", status, delErr)
 		}
 	}
 
@@ -262,6 +281,8 @@ func (s *Store) GetStatus(anchorID string) (proof.AnchorIndexStatus, error) {
 
 	var status proof.AnchorIndexStatus
 
+	var failed bool
+
 	for ok {
 		value, err := iter.Value()
 		if err != nil {
@@ -278,17 +299,161 @@ func (s *Store) GetStatus(anchorID string) (proof.AnchorIndexStatus, error) {
 			return proof.AnchorIndexStatusCompleted, nil
 		}
 
+		failed = failed || status == proof.AnchorIndexStatusFailed
+
 		ok, err = iter.Next()
 		if err != nil {
 			return "", orberrors.NewTransient(fmt.Errorf("iterator error for anchor event[%s]: %w", anchorID, err))
 		}
 	}
 
+	if failed {
+		status = proof.AnchorIndexStatusFailed
+	}
+
 	logger.Debugf("status for anchor event[%s]: %s", anchorID, status)
 
 	return status, nil
 }
 
+// GetStatusInfo returns the current status of the given anchor event along with the time at which the
+// status was added. If the anchor event is still in process then the time at which it was first added
+// is returned. A completed status takes precedence over a failed status. orberrors.ErrContentNotFound
+// is returned if no status exists for the anchor event.
+func (s *Store) GetStatusInfo(anchorID string) (*AnchorStatus, error) {
+	anchorIDEncoded := base64.RawURLEncoding.EncodeToString([]byte(anchorID))
+
+	statuses, err := s.query(fmt.Sprintf("%s:%s", index, anchorIDEncoded))
+	if err != nil {
+		return nil, fmt.Errorf("get statuses for anchor event[%s]: %w", anchorID, err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("status not found for anchor event[%s]: %w", anchorID, orberrors.ErrContentNotFound)
+	}
+
+	var result, failed *AnchorStatus
+
+	for _, status := range statuses {
+		switch {
+		case status.Status == proof.AnchorIndexStatusCompleted:
+			return status, nil
+		case status.Status == proof.AnchorIndexStatusFailed:
+			failed = status
+		case result == nil || status.Time.Before(result.Time):
+			result = status
+		}
+	}
+
+	if failed != nil {
+		return failed, nil
+	}
+
+	return result, nil
+}
+
+// GetInProcess returns the anchor events for which proofs are still being collected, along with the
+// time at which each anchor event was added.
+func (s *Store) GetInProcess() ([]*AnchorStatus, error) {
+	statuses, err := s.query(fmt.Sprintf("%s:%s", statusTagName, proof.AnchorIndexStatusInProcess))
+	if err != nil {
+		return nil, fmt.Errorf("get in-process anchor events: %w", err)
+	}
+
+	var result []*AnchorStatus
+
+	added := make(map[string]*AnchorStatus)
+
+	for _, status := range statuses {
+		existing, ok := added[status.AnchorID]
+		if !ok {
+			added[status.AnchorID] = status
+			result = append(result, status)
+
+			continue
+		}
+
+		if status.Time.Before(existing.Time) {
+			existing.Time = status.Time
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Store) query(query string) ([]*AnchorStatus, error) {
+	iter, err := s.store.Query(query)
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("query[%s]: %w", query, err))
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Warnf("Error closing iterator: %s", errClose)
+		}
+	}()
+
+	var statuses []*AnchorStatus
+
+	for {
+		ok, errNext := iter.Next()
+		if errNext != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("iterator error: %w", errNext))
+		}
+
+		if !ok {
+			return statuses, nil
+		}
+
+		status, errStatus := s.getAnchorStatus(iter)
+		if errStatus != nil {
+			return nil, errStatus
+		}
+
+		statuses = append(statuses, status)
+	}
+}
+
+func (s *Store) getAnchorStatus(iter storage.Iterator) (*AnchorStatus, error) {
+	value, err := iter.Value()
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("failed to get iterator value: %w", err))
+	}
+
+	tags, err := iter.Tags()
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("failed to get iterator tags: %w", err))
+	}
+
+	status := &AnchorStatus{}
+
+	err = s.unmarshal(value, &status.Status)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal status: %w", err)
+	}
+
+	for _, tag := range tags {
+		switch tag.Name {
+		case index:
+			anchorIDBytes, errDecode := base64.RawURLEncoding.DecodeString(tag.Value)
+			if errDecode != nil {
+				return nil, fmt.Errorf("failed to decode encoded anchorID[%s]: %w", tag.Value, errDecode)
+			}
+
+			status.AnchorID = string(anchorIDBytes)
+		case statusTimeTagName:
+			t, errParse := strconv.ParseInt(tag.Value, 10, 64)
+			if errParse != nil {
+				return nil, fmt.Errorf("invalid value for tag[%s]: %w", statusTimeTagName, errParse)
+			}
+
+			status.Time = time.Unix(t, 0)
+		}
+	}
+
+	return status, nil
+}
+
 // CheckInProcessAnchors will be invoked to check for in-complete (not processed) anchors.
 func (s *Store) CheckInProcessAnchors() {
 	query := fmt.Sprintf("%s<=%d", statusCheckTimeTagName, time.Now().Unix())
@@ -352,13 +517,25 @@ func (s *Store) processIndex(encodedAnchorID string) error {
 
 	logger.Debugf("Processing anchor event ID[%s]", anchorID)
 
-	status, err := s.GetStatus(anchorID)
+	status, err := s.GetStatusInfo(anchorID)
 	if err != nil {
 		return fmt.Errorf("failed to get status for anchorID[%s]: %w", anchorID, err)
 	}
 
-	if status == proof.AnchorIndexStatusCompleted {
-		// already completed - nothing to do
+	if isTerminal(status.Status) {
+		// already completed or failed - nothing to do
+		return nil
+	}
+
+	if !status.Time.IsZero() && !time.Now().Before(status.Time.Add(s.maxWitnessDelay)) {
+		logger.Warnf("Witness policy for anchorID[%s] was not satisfied within the maximum witness delay [%s]",
+			anchorID, s.maxWitnessDelay)
+
+		err = s.AddStatus(anchorID, proof.AnchorIndexStatusFailed)
+		if err != nil {
+			return fmt.Errorf("failed to set failed status for anchorID[%s]: %w", anchorID, err)
+		}
+
 		return nil
 	}
 
@@ -371,3 +548,8 @@ func (s *Store) processIndex(encodedAnchorID string) error {
 
 	return nil
 }
+
+// isTerminal returns true if the status is final, i.e. proofs are no longer being collected.
+func isTerminal(status proof.AnchorIndexStatus) bool {
+	return status == proof.AnchorIndexStatusCompleted || status == proof.AnchorIndexStatusFailed
+}
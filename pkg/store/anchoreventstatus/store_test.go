@@ -226,6 +226,152 @@ func TestStore_Get(t *testing.T) {
 	})
 }
 
+func TestStore_GetStatusInfo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), maxWitnessDelayTime)
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusInProcess))
+		require.NoError(t, s.AddStatus("vcID2", proof.AnchorIndexStatusInProcess))
+
+		status, err := s.GetStatusInfo(vcID)
+		require.NoError(t, err)
+		require.Equal(t, vcID, status.AnchorID)
+		require.Equal(t, proof.AnchorIndexStatusInProcess, status.Status)
+		require.False(t, status.Time.Before(now.Truncate(time.Second)))
+
+		inProcess, err := s.GetInProcess()
+		require.NoError(t, err)
+		require.Len(t, inProcess, 2)
+
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusCompleted))
+
+		status, err = s.GetStatusInfo(vcID)
+		require.NoError(t, err)
+		require.Equal(t, proof.AnchorIndexStatusCompleted, status.Status)
+
+		inProcess, err = s.GetInProcess()
+		require.NoError(t, err)
+		require.Len(t, inProcess, 1)
+		require.Equal(t, "vcID2", inProcess[0].AnchorID)
+	})
+
+	t.Run("completed takes precedence over failed", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), maxWitnessDelayTime)
+		require.NoError(t, err)
+
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusInProcess))
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusFailed))
+
+		status, err := s.GetStatusInfo(vcID)
+		require.NoError(t, err)
+		require.Equal(t, proof.AnchorIndexStatusFailed, status.Status)
+
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusCompleted))
+
+		status, err = s.GetStatusInfo(vcID)
+		require.NoError(t, err)
+		require.Equal(t, proof.AnchorIndexStatusCompleted, status.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), maxWitnessDelayTime)
+		require.NoError(t, err)
+
+		_, err = s.GetStatusInfo(vcID)
+		require.ErrorIs(t, err, orberrors.ErrContentNotFound)
+	})
+
+	t.Run("error - query error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.QueryReturns(nil, fmt.Errorf("query error"))
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider, testutil.GetExpiryService(t), maxWitnessDelayTime)
+		require.NoError(t, err)
+
+		_, err = s.GetStatusInfo(vcID)
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+
+		_, err = s.GetInProcess()
+		require.Error(t, err)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("error - iterator errors", func(t *testing.T) {
+		for _, test := range []struct {
+			name  string
+			setup func(iterator *mocks.Iterator)
+			err   string
+		}{
+			{
+				name:  "next",
+				setup: func(iterator *mocks.Iterator) { iterator.NextReturns(false, fmt.Errorf("next error")) },
+				err:   "next error",
+			},
+			{
+				name:  "value",
+				setup: func(iterator *mocks.Iterator) { iterator.ValueReturns(nil, fmt.Errorf("value error")) },
+				err:   "value error",
+			},
+			{
+				name: "tags",
+				setup: func(iterator *mocks.Iterator) {
+					iterator.ValueReturns([]byte(`"in-process"`), nil)
+					iterator.TagsReturns(nil, fmt.Errorf("tags error"))
+				},
+				err: "tags error",
+			},
+			{
+				name:  "unmarshal",
+				setup: func(iterator *mocks.Iterator) { iterator.ValueReturns([]byte("{"), nil) },
+				err:   "unmarshal status",
+			},
+			{
+				name: "anchor ID",
+				setup: func(iterator *mocks.Iterator) {
+					iterator.ValueReturns([]byte(`"in-process"`), nil)
+					iterator.TagsReturns([]storage.Tag{{Name: index, Value: "="}}, nil)
+				},
+				err: "failed to decode encoded anchorID",
+			},
+			{
+				name: "time",
+				setup: func(iterator *mocks.Iterator) {
+					iterator.ValueReturns([]byte(`"in-process"`), nil)
+					iterator.TagsReturns([]storage.Tag{{Name: statusTimeTagName, Value: "x"}}, nil)
+				},
+				err: "invalid value for tag[StatusTime]",
+			},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				iterator := &mocks.Iterator{}
+				iterator.NextReturns(true, nil)
+
+				test.setup(iterator)
+
+				store := &mocks.Store{}
+				store.QueryReturns(iterator, nil)
+
+				provider := &mocks.Provider{}
+				provider.OpenStoreReturns(store, nil)
+
+				s, err := New(provider, testutil.GetExpiryService(t), maxWitnessDelayTime)
+				require.NoError(t, err)
+
+				_, err = s.GetInProcess()
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.err)
+			})
+		}
+	})
+}
+
 func TestStore_CheckInProcessAnchors(t *testing.T) {
 	t.Run("success - in process(time not past status check time)", func(t *testing.T) {
 		mongoDBConnString, stopMongo := mongodbtestutil.StartMongoDB(t)
@@ -588,6 +734,32 @@ func TestStore_processIndex(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("success - maximum witness delay exceeded", func(t *testing.T) {
+		// The policy shouldn't be re-evaluated after the maximum witness delay.
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), 0,
+			WithPolicyHandler(&mockPolicyHandler{Err: fmt.Errorf("policy error")}))
+		require.NoError(t, err)
+
+		require.NoError(t, s.AddStatus(vcID, proof.AnchorIndexStatusInProcess))
+
+		require.NoError(t, s.processIndex(encoder.EncodeToString([]byte(vcID))))
+
+		status, err := s.GetStatusInfo(vcID)
+		require.NoError(t, err)
+		require.Equal(t, proof.AnchorIndexStatusFailed, status.Status)
+
+		st, err := s.GetStatus(vcID)
+		require.NoError(t, err)
+		require.Equal(t, proof.AnchorIndexStatusFailed, st)
+
+		inProcess, err := s.GetInProcess()
+		require.NoError(t, err)
+		require.Empty(t, inProcess)
+
+		// Nothing to do for an anchor event that has failed.
+		require.NoError(t, s.processIndex(encoder.EncodeToString([]byte(vcID))))
+	})
+
 	t.Run("error - anchor ID not encoded", func(t *testing.T) {
 		mongoDBConnString, stopMongo := mongodbtestutil.StartMongoDB(t)
 		defer stopMongo()
@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+)
+
+var logger = log.New("embedded-store")
+
+const (
+	dirPermissions  = 0o700
+	filePermissions = 0o600
+
+	fileExtension = ".db"
+
+	defaultCompactionThreshold = 10000
+)
+
+// Option is a provider option.
+type Option func(p *Provider)
+
+// WithDBPrefix sets a prefix for the names of the files that hold the data for each store.
+func WithDBPrefix(prefix string) Option {
+	return func(p *Provider) {
+		p.prefix = prefix
+	}
+}
+
+// WithSyncWrites indicates whether or not each write should be synced to disk before returning.
+// Syncing writes guards against data loss on a power failure at the cost of write performance.
+// (A process crash does not lose acknowledged writes in either case.)
+func WithSyncWrites(sync bool) Option {
+	return func(p *Provider) {
+		p.syncWrites = sync
+	}
+}
+
+// WithCompactionThreshold sets the number of log records after which a store's log file is compacted,
+// provided that the log holds at least twice as many records as there are live keys.
+func WithCompactionThreshold(threshold int) Option {
+	return func(p *Provider) {
+		p.compactionThreshold = threshold
+	}
+}
+
+// Provider implements an embedded, single-node storage provider that persists each store to a file in
+// the given directory. All data is also held in memory so that queries (including multiple tags joined
+// with && or ||, numeric comparisons, sorting and paging) may be evaluated without an external database.
+// Each write is appended to the store's log file which is replayed when the store is opened and compacted
+// periodically.
+type Provider struct {
+	dir                 string
+	prefix              string
+	syncWrites          bool
+	compactionThreshold int
+
+	mutex  sync.RWMutex
+	stores map[string]*store
+}
+
+// NewProvider returns a new embedded storage provider that stores data in the given directory.
+// The directory is created if it doesn't exist.
+func NewProvider(dir string, opts ...Option) (*Provider, error) {
+	if dir == "" {
+		return nil, errors.New("data directory is required")
+	}
+
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return nil, fmt.Errorf("create data directory [%s]: %w", dir, err)
+	}
+
+	p := &Provider{
+		dir:                 dir,
+		compactionThreshold: defaultCompactionThreshold,
+		stores:              make(map[string]*store),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	logger.Infof("Created embedded storage provider - Directory: %s, Prefix: %s, SyncWrites: %t",
+		dir, p.prefix, p.syncWrites)
+
+	return p, nil
+}
+
+// OpenStore opens the store with the given name. If the store doesn't exist then it is created.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errors.New("store name cannot be empty")
+	}
+
+	storeName := strings.ToLower(name)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if s, ok := p.stores[storeName]; ok {
+		return s, nil
+	}
+
+	s, err := openStore(storeName, p.fileName(storeName), p.syncWrites, p.compactionThreshold, p.removeStore)
+	if err != nil {
+		return nil, fmt.Errorf("open store [%s]: %w", storeName, err)
+	}
+
+	p.stores[storeName] = s
+
+	return s, nil
+}
+
+// SetStoreConfig sets the configuration on a store. Since all data is indexed in memory, the configuration
+// is only validated and retained. The store must be opened prior to calling this function.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	for _, tagName := range config.TagNames {
+		if strings.Contains(tagName, ":") {
+			return fmt.Errorf("%q is an invalid tag name since it contains one or more ':' characters", tagName)
+		}
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	s, ok := p.stores[strings.ToLower(name)]
+	if !ok {
+		return storage.ErrStoreNotFound
+	}
+
+	s.setConfig(config)
+
+	return nil
+}
+
+// GetStoreConfig returns the configuration of the given store. The store must be opened prior to calling
+// this function.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	s, ok := p.stores[strings.ToLower(name)]
+	if !ok {
+		return storage.StoreConfiguration{}, storage.ErrStoreNotFound
+	}
+
+	return s.getConfig(), nil
+}
+
+// GetOpenStores returns all open stores.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stores := make([]storage.Store, 0, len(p.stores))
+
+	for _, s := range p.stores {
+		stores = append(stores, s)
+	}
+
+	return stores
+}
+
+// Close closes all open stores.
+func (p *Provider) Close() error {
+	p.mutex.RLock()
+
+	stores := make([]*store, 0, len(p.stores))
+
+	for _, s := range p.stores {
+		stores = append(stores, s)
+	}
+
+	p.mutex.RUnlock()
+
+	for _, s := range stores {
+		if err := s.Close(); err != nil {
+			return fmt.Errorf("close store [%s]: %w", s.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.stores, name)
+}
+
+func (p *Provider) fileName(storeName string) string {
+	// Store names may contain characters (such as '/') that aren't allowed in file names.
+	r := strings.NewReplacer("/", "_", "\\", "_", ":", "_")
+
+	return filepath.Join(p.dir, r.Replace(p.prefix+storeName)+fileExtension)
+}
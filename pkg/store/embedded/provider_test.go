@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package embedded
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "data")
+
+		p, err := NewProvider(dir, WithDBPrefix("orb_"), WithSyncWrites(true), WithCompactionThreshold(10))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+		require.Equal(t, "orb_", p.prefix)
+		require.True(t, p.syncWrites)
+		require.Equal(t, 10, p.compactionThreshold)
+
+		_, err = os.Stat(dir)
+		require.NoError(t, err)
+	})
+
+	t.Run("No directory", func(t *testing.T) {
+		_, err := NewProvider("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "data directory is required")
+	})
+}
+
+func TestProvider_OpenStore(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewProvider(dir, WithDBPrefix("orb_"))
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		s, err := p.OpenStore("/services/orb/Activity")
+		require.NoError(t, err)
+		require.NotNil(t, s)
+
+		s2, err := p.OpenStore("/services/orb/activity")
+		require.NoError(t, err)
+		require.True(t, s == s2)
+
+		require.Len(t, p.GetOpenStores(), 1)
+
+		_, err = os.Stat(filepath.Join(dir, "orb__services_orb_activity.db"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty name", func(t *testing.T) {
+		_, err := p.OpenStore("")
+		require.Error(t, err)
+	})
+
+	t.Run("Corrupt file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "orb_corrupt.db"), []byte("{invalid\n"), filePermissions))
+
+		_, err := p.OpenStore("corrupt")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid record at offset 0")
+	})
+}
+
+func TestProvider_StoreConfig(t *testing.T) {
+	p, err := NewProvider(t.TempDir())
+	require.NoError(t, err)
+
+	config := storage.StoreConfiguration{TagNames: []string{"tag1", "tag2"}}
+
+	err = p.SetStoreConfig("store1", config)
+	require.True(t, errors.Is(err, storage.ErrStoreNotFound))
+
+	_, err = p.GetStoreConfig("store1")
+	require.True(t, errors.Is(err, storage.ErrStoreNotFound))
+
+	_, err = p.OpenStore("store1")
+	require.NoError(t, err)
+
+	require.NoError(t, p.SetStoreConfig("store1", config))
+
+	c, err := p.GetStoreConfig("store1")
+	require.NoError(t, err)
+	require.Equal(t, config, c)
+
+	err = p.SetStoreConfig("store1", storage.StoreConfiguration{TagNames: []string{"invalid:tag"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid tag name")
+}
+
+func TestProvider_Close(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewProvider(dir)
+	require.NoError(t, err)
+
+	s1, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	s2, err := p.OpenStore("store2")
+	require.NoError(t, err)
+
+	require.NoError(t, s1.Put("key1", []byte("value1")))
+	require.NoError(t, s2.Put("key2", []byte("value2")))
+
+	require.NoError(t, p.Close())
+	require.Empty(t, p.GetOpenStores())
+
+	// Closing again should be a no-op.
+	require.NoError(t, s1.Close())
+
+	require.Error(t, s1.Put("key3", []byte("value3")))
+
+	// The data should be reloaded from disk by a new provider.
+	p2, err := NewProvider(dir)
+	require.NoError(t, err)
+
+	s1, err = p2.OpenStore("store1")
+	require.NoError(t, err)
+
+	value, err := s1.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	s2, err = p2.OpenStore("store2")
+	require.NoError(t, err)
+
+	value, err = s2.Get("key2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+}
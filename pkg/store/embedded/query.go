@@ -0,0 +1,342 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	andOperator = "&&"
+	orOperator  = "||"
+
+	defaultPageSize = 25
+)
+
+type operator int
+
+const (
+	opExists operator = iota
+	opEquals
+	opLessThan
+	opLessThanOrEqual
+	opGreaterThan
+	opGreaterThanOrEqual
+)
+
+// Comparison operators in the order in which they are matched (two-character operators first).
+var comparisonOperators = []struct {
+	symbol string
+	op     operator
+}{
+	{"<=", opLessThanOrEqual},
+	{">=", opGreaterThanOrEqual},
+	{"<", opLessThan},
+	{">", opGreaterThan},
+}
+
+type term struct {
+	tagName  string
+	op       operator
+	value    string
+	numValue int64
+}
+
+// query is a disjunction of conjunctions of terms.
+type query [][]*term
+
+func parseQuery(expression string) (query, error) {
+	if expression == "" {
+		return nil, errors.New("invalid expression format: expression cannot be empty")
+	}
+
+	var q query
+
+	for _, andExpr := range strings.Split(expression, orOperator) {
+		var terms []*term
+
+		for _, termExpr := range strings.Split(andExpr, andOperator) {
+			t, err := parseTerm(termExpr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expression format [%s]: %w", expression, err)
+			}
+
+			terms = append(terms, t)
+		}
+
+		q = append(q, terms)
+	}
+
+	return q, nil
+}
+
+func parseTerm(expression string) (*term, error) {
+	if expression == "" {
+		return nil, errors.New("empty term")
+	}
+
+	if i := strings.Index(expression, ":"); i >= 0 {
+		t := &term{tagName: expression[:i], op: opEquals, value: expression[i+1:]}
+
+		if t.tagName == "" || strings.Contains(t.value, ":") {
+			return nil, fmt.Errorf("invalid term [%s]", expression)
+		}
+
+		return t, nil
+	}
+
+	for _, c := range comparisonOperators {
+		i := strings.Index(expression, c.symbol)
+		if i < 0 {
+			continue
+		}
+
+		t := &term{tagName: expression[:i], op: c.op, value: expression[i+len(c.symbol):]}
+
+		if t.tagName == "" {
+			return nil, fmt.Errorf("invalid term [%s]", expression)
+		}
+
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value in term [%s] must be an integer: %w", expression, err)
+		}
+
+		t.numValue = n
+
+		return t, nil
+	}
+
+	return &term{tagName: expression, op: opExists}, nil
+}
+
+func (q query) matches(tags []storage.Tag) bool {
+	for _, terms := range q {
+		if allMatch(terms, tags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allMatch(terms []*term, tags []storage.Tag) bool {
+	for _, t := range terms {
+		if !t.matches(tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *term) matches(tags []storage.Tag) bool {
+	for _, tag := range tags {
+		if tag.Name != t.tagName {
+			continue
+		}
+
+		if t.matchesValue(tag.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *term) matchesValue(value string) bool {
+	switch t.op {
+	case opExists:
+		return true
+	case opEquals:
+		return value == t.value
+	default:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		return t.compare(n)
+	}
+}
+
+func (t *term) compare(n int64) bool {
+	switch t.op {
+	case opLessThan:
+		return n < t.numValue
+	case opLessThanOrEqual:
+		return n <= t.numValue
+	case opGreaterThan:
+		return n > t.numValue
+	case opGreaterThanOrEqual:
+		return n >= t.numValue
+	default:
+		return false
+	}
+}
+
+func getQueryOptions(options []storage.QueryOption) *storage.QueryOptions {
+	queryOptions := &storage.QueryOptions{}
+
+	for _, opt := range options {
+		if opt != nil {
+			opt(queryOptions)
+		}
+	}
+
+	if queryOptions.PageSize <= 0 {
+		queryOptions.PageSize = defaultPageSize
+	}
+
+	return queryOptions
+}
+
+type result struct {
+	key   string
+	value []byte
+	tags  []storage.Tag
+}
+
+// sortResults sorts the results by the value of the tag in the sort options. Tag values that are decimal
+// integers are compared numerically. Results without the tag are placed at the end. If no sort options are
+// provided then the results are sorted by key so that paging is deterministic.
+func sortResults(results []*result, sortOptions *storage.SortOptions) {
+	if sortOptions == nil || sortOptions.TagName == "" {
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].key < results[j].key
+		})
+
+		return
+	}
+
+	descending := sortOptions.Order == storage.SortDescending
+
+	sort.SliceStable(results, func(i, j int) bool {
+		vi, oki := tagValue(results[i].tags, sortOptions.TagName)
+		vj, okj := tagValue(results[j].tags, sortOptions.TagName)
+
+		switch {
+		case !oki || !okj:
+			return oki && !okj
+		case vi == vj:
+			return results[i].key < results[j].key
+		case descending:
+			return lessThan(vj, vi)
+		default:
+			return lessThan(vi, vj)
+		}
+	})
+}
+
+func tagValue(tags []storage.Tag, name string) (string, bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func lessThan(v1, v2 string) bool {
+	n1, err1 := strconv.ParseInt(v1, 10, 64)
+	n2, err2 := strconv.ParseInt(v2, 10, 64)
+
+	if err1 == nil && err2 == nil {
+		return n1 < n2
+	}
+
+	return v1 < v2
+}
+
+type iterator struct {
+	results    []*result
+	totalItems int
+	current    int
+}
+
+func newIterator(results []*result, options *storage.QueryOptions) *iterator {
+	totalItems := len(results)
+
+	start := options.InitialPageNum * options.PageSize
+	if start > totalItems {
+		start = totalItems
+	}
+
+	return &iterator{
+		results:    results[start:],
+		totalItems: totalItems,
+		current:    -1,
+	}
+}
+
+// Next moves the iterator to the next result and returns false if there are no more results.
+func (it *iterator) Next() (bool, error) {
+	if it.current+1 >= len(it.results) {
+		it.current = len(it.results)
+
+		return false, nil
+	}
+
+	it.current++
+
+	return true, nil
+}
+
+// Key returns the key of the current result.
+func (it *iterator) Key() (string, error) {
+	r, err := it.result()
+	if err != nil {
+		return "", err
+	}
+
+	return r.key, nil
+}
+
+// Value returns the value of the current result.
+func (it *iterator) Value() ([]byte, error) {
+	r, err := it.result()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.value, nil
+}
+
+// Tags returns the tags of the current result.
+func (it *iterator) Tags() ([]storage.Tag, error) {
+	r, err := it.result()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.tags, nil
+}
+
+// TotalItems returns the total number of results that matched the query, regardless of the initial page.
+func (it *iterator) TotalItems() (int, error) {
+	return it.totalItems, nil
+}
+
+// Close is a no-op since all results are held in memory.
+func (it *iterator) Close() error {
+	return nil
+}
+
+func (it *iterator) result() (*result, error) {
+	if it.current < 0 || it.current >= len(it.results) {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return it.results[it.current], nil
+}
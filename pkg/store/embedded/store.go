@@ -0,0 +1,519 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package embedded
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	opPut    = "p"
+	opDelete = "d"
+)
+
+var errEmptyKey = errors.New("key cannot be empty")
+
+// record is a single entry in a store's log file.
+type record struct {
+	Op    string        `json:"o"`
+	Key   string        `json:"k"`
+	Value []byte        `json:"v,omitempty"`
+	Tags  []storage.Tag `json:"t,omitempty"`
+}
+
+type entry struct {
+	value []byte
+	tags  []storage.Tag
+}
+
+type store struct {
+	name                string
+	fileName            string
+	syncWrites          bool
+	compactionThreshold int
+	close               func(name string)
+
+	mutex      sync.RWMutex
+	entries    map[string]*entry
+	config     storage.StoreConfiguration
+	file       *os.File
+	numRecords int
+	rename     func(oldpath, newpath string) error
+}
+
+func openStore(name, fileName string, syncWrites bool, compactionThreshold int,
+	closeFunc func(name string)) (*store, error) {
+	s := &store{
+		name:                name,
+		fileName:            fileName,
+		syncWrites:          syncWrites,
+		compactionThreshold: compactionThreshold,
+		close:               closeFunc,
+		entries:             make(map[string]*entry),
+		rename:              os.Rename,
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("open file [%s]: %w", fileName, err)
+	}
+
+	s.file = f
+
+	return s, nil
+}
+
+// Put stores the given key-value pair along with the optional tags.
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	if key == "" {
+		return errEmptyKey
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r := &record{Op: opPut, Key: key, Value: value, Tags: tags}
+
+	if err := s.write(r); err != nil {
+		return err
+	}
+
+	s.apply(r)
+
+	s.compactIfNeeded()
+
+	return nil
+}
+
+// Get returns the value for the given key. If the key isn't found then an error wrapping
+// storage.ErrDataNotFound is returned.
+func (s *store) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, errEmptyKey
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("get [%s]: %w", key, storage.ErrDataNotFound)
+	}
+
+	return e.value, nil
+}
+
+// GetTags returns the tags for the given key. If the key isn't found then an error wrapping
+// storage.ErrDataNotFound is returned.
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	if key == "" {
+		return nil, errEmptyKey
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("get tags [%s]: %w", key, storage.ErrDataNotFound)
+	}
+
+	return e.tags, nil
+}
+
+// GetBulk returns the values for the given keys. A nil value is returned for a key that isn't found.
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		if key == "" {
+			return nil, errEmptyKey
+		}
+
+		if e, ok := s.entries[key]; ok {
+			values[i] = e.value
+		}
+	}
+
+	return values, nil
+}
+
+// Query returns the entries that match the given expression. An expression consists of one or more
+// terms joined with && (AND) or || (OR), where && takes precedence. A term is in one of the formats
+// TagName, TagName:TagValue, or TagName<op>Number where <op> is one of <, <=, > or >=.
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	q, err := parseQuery(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	queryOptions := getQueryOptions(options)
+
+	s.mutex.RLock()
+
+	var results []*result
+
+	for key, e := range s.entries {
+		if q.matches(e.tags) {
+			results = append(results, &result{key: key, value: e.value, tags: e.tags})
+		}
+	}
+
+	s.mutex.RUnlock()
+
+	sortResults(results, queryOptions.SortOptions)
+
+	return newIterator(results, queryOptions), nil
+}
+
+// Delete deletes the given key.
+func (s *store) Delete(key string) error {
+	if key == "" {
+		return errEmptyKey
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.entries[key]; !ok {
+		return nil
+	}
+
+	r := &record{Op: opDelete, Key: key}
+
+	if err := s.write(r); err != nil {
+		return err
+	}
+
+	s.apply(r)
+
+	s.compactIfNeeded()
+
+	return nil
+}
+
+// Batch performs the given put and delete operations. All operations are written to the log file in a
+// single write so either all or none of the operations are persisted.
+func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return errors.New("batch requires at least one operation")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records := make([]*record, len(operations))
+
+	for i, op := range operations {
+		if op.Key == "" {
+			return errEmptyKey
+		}
+
+		if op.Value == nil {
+			records[i] = &record{Op: opDelete, Key: op.Key}
+
+			continue
+		}
+
+		if err := validateTags(op.Tags); err != nil {
+			return err
+		}
+
+		if op.PutOptions != nil && op.PutOptions.IsNewKey {
+			if _, ok := s.entries[op.Key]; ok {
+				return fmt.Errorf("key [%s]: %w", op.Key, storage.ErrDuplicateKey)
+			}
+		}
+
+		records[i] = &record{Op: opPut, Key: op.Key, Value: op.Value, Tags: op.Tags}
+	}
+
+	if err := s.write(records...); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		s.apply(r)
+	}
+
+	s.compactIfNeeded()
+
+	return nil
+}
+
+// Flush syncs the log file to disk.
+func (s *store) Flush() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("sync file [%s]: %w", s.fileName, err)
+	}
+
+	return nil
+}
+
+// Close closes the log file. The data remains on disk and is loaded the next time the store is opened.
+func (s *store) Close() error {
+	s.mutex.Lock()
+
+	if s.file == nil {
+		s.mutex.Unlock()
+
+		return nil
+	}
+
+	err := s.file.Close()
+
+	s.file = nil
+	s.entries = make(map[string]*entry)
+
+	s.mutex.Unlock()
+
+	s.close(s.name)
+
+	if err != nil {
+		return fmt.Errorf("close file [%s]: %w", s.fileName, err)
+	}
+
+	return nil
+}
+
+func (s *store) setConfig(config storage.StoreConfiguration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.config = config
+}
+
+func (s *store) getConfig() storage.StoreConfiguration {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.config
+}
+
+func (s *store) apply(r *record) {
+	s.numRecords++
+
+	if r.Op == opDelete {
+		delete(s.entries, r.Key)
+
+		return
+	}
+
+	s.entries[r.Key] = &entry{value: r.Value, tags: r.Tags}
+}
+
+func (s *store) write(records ...*record) error {
+	if s.file == nil {
+		return fmt.Errorf("store [%s] is closed", s.name)
+	}
+
+	buf := &bytes.Buffer{}
+
+	for _, r := range records {
+		recordBytes, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("marshal record: %w", err)
+		}
+
+		buf.Write(recordBytes)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := s.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write to file [%s]: %w", s.fileName, err)
+	}
+
+	if s.syncWrites {
+		if err := s.file.Sync(); err != nil {
+			return fmt.Errorf("sync file [%s]: %w", s.fileName, err)
+		}
+	}
+
+	return nil
+}
+
+// load replays the log file into memory. A partially written record at the end of the file (which
+// may be left behind by a crash in the middle of a write) is discarded.
+func (s *store) load() error {
+	f, err := os.Open(s.fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("open file [%s]: %w", s.fileName, err)
+	}
+
+	defer func() {
+		if e := f.Close(); e != nil {
+			logger.Warnf("Error closing file [%s]: %s", s.fileName, e)
+		}
+	}()
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("read file [%s]: %w", s.fileName, err)
+		}
+
+		complete := len(line) > 0 && line[len(line)-1] == '\n'
+
+		if complete {
+			r := &record{}
+
+			if e := json.Unmarshal(line, r); e != nil {
+				return fmt.Errorf("invalid record at offset %d in file [%s]: %w", offset, s.fileName, e)
+			}
+
+			s.apply(r)
+
+			offset += int64(len(line))
+		}
+
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 && !complete {
+				logger.Warnf("Discarding partially written record at offset %d in file [%s]", offset, s.fileName)
+
+				return os.Truncate(s.fileName, offset)
+			}
+
+			break
+		}
+	}
+
+	logger.Debugf("Loaded %d entries from %d records in file [%s]", len(s.entries), s.numRecords, s.fileName)
+
+	return nil
+}
+
+// compactIfNeeded rewrites the log file with only the live entries once the number of records exceeds
+// the compaction threshold and at least half of the records are stale. The caller's write has already
+// been persisted at this point, so a compaction error is only logged and compaction is retried on a
+// subsequent write.
+func (s *store) compactIfNeeded() {
+	if s.numRecords < s.compactionThreshold || s.numRecords < 2*len(s.entries) {
+		return
+	}
+
+	if err := s.compact(); err != nil {
+		logger.Warnf("Error compacting file [%s]: %s", s.fileName, err)
+	}
+}
+
+// compact writes the live entries to a temporary file and then renames the temporary file over the
+// log file. The handle to the temporary file becomes the new log file handle, so the current handle
+// remains usable if anything fails before the swap.
+func (s *store) compact() error {
+	tmpFileName := s.fileName + ".tmp"
+
+	tmpFile, err := os.OpenFile(tmpFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, filePermissions)
+	if err != nil {
+		return fmt.Errorf("create file [%s]: %w", tmpFileName, err)
+	}
+
+	w := bufio.NewWriter(tmpFile)
+
+	for key, ent := range s.entries {
+		recordBytes, e := json.Marshal(&record{Op: opPut, Key: key, Value: ent.value, Tags: ent.tags})
+		if e != nil {
+			return compactError(tmpFile, fmt.Errorf("marshal record: %w", e))
+		}
+
+		if _, e := w.Write(append(recordBytes, '\n')); e != nil {
+			return compactError(tmpFile, fmt.Errorf("write to file [%s]: %w", tmpFileName, e))
+		}
+	}
+
+	if e := w.Flush(); e != nil {
+		return compactError(tmpFile, fmt.Errorf("flush file [%s]: %w", tmpFileName, e))
+	}
+
+	if e := tmpFile.Sync(); e != nil {
+		return compactError(tmpFile, fmt.Errorf("sync file [%s]: %w", tmpFileName, e))
+	}
+
+	if e := s.rename(tmpFileName, s.fileName); e != nil {
+		return compactError(tmpFile, fmt.Errorf("rename file [%s]: %w", tmpFileName, e))
+	}
+
+	if e := s.file.Close(); e != nil {
+		logger.Warnf("Error closing file [%s]: %s", s.fileName, e)
+	}
+
+	logger.Debugf("Compacted file [%s] from %d records to %d records", s.fileName, s.numRecords, len(s.entries))
+
+	s.file = tmpFile
+	s.numRecords = len(s.entries)
+
+	return nil
+}
+
+func compactError(f *os.File, err error) error {
+	if e := f.Close(); e != nil {
+		logger.Warnf("Error closing file [%s]: %s", f.Name(), e)
+	}
+
+	if e := os.Remove(f.Name()); e != nil {
+		logger.Warnf("Error removing file [%s]: %s", f.Name(), e)
+	}
+
+	return err
+}
+
+func validateTags(tags []storage.Tag) error {
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf("%q is an invalid tag name since it contains one or more ':' characters", tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf("%q is an invalid tag value since it contains one or more ':' characters", tag.Value)
+		}
+	}
+
+	return nil
+}
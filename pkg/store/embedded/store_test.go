@@ -0,0 +1,357 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutGetDelete(t *testing.T) {
+	p, err := NewProvider(t.TempDir())
+	require.NoError(t, err)
+
+	s, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	tags := []storage.Tag{{Name: "tag1", Value: "value1"}, {Name: "tag2"}}
+
+	require.NoError(t, s.Put("key1", []byte("value1"), tags...))
+	require.NoError(t, s.Put("key2", []byte("value2")))
+
+	value, err := s.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	tgs, err := s.GetTags("key1")
+	require.NoError(t, err)
+	require.Equal(t, tags, tgs)
+
+	values, err := s.GetBulk("key1", "key3", "key2")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("value1"), nil, []byte("value2")}, values)
+
+	require.NoError(t, s.Delete("key1"))
+	require.NoError(t, s.Delete("key1"))
+
+	_, err = s.Get("key1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = s.GetTags("key1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	require.NoError(t, s.Flush())
+
+	t.Run("Invalid args", func(t *testing.T) {
+		require.Error(t, s.Put("", []byte("value")))
+		require.Error(t, s.Put("key", nil))
+		require.Error(t, s.Put("key", []byte("value"), storage.Tag{Name: "tag:1"}))
+		require.Error(t, s.Put("key", []byte("value"), storage.Tag{Name: "tag1", Value: "a:b"}))
+
+		_, err := s.Get("")
+		require.Error(t, err)
+
+		_, err = s.GetTags("")
+		require.Error(t, err)
+
+		_, err = s.GetBulk()
+		require.Error(t, err)
+
+		_, err = s.GetBulk("key1", "")
+		require.Error(t, err)
+
+		require.Error(t, s.Delete(""))
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	p, err := NewProvider(t.TempDir())
+	require.NoError(t, err)
+
+	s, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put("key1", []byte("value1")))
+
+	require.NoError(t, s.Batch([]storage.Operation{
+		{Key: "key2", Value: []byte("value2"), Tags: []storage.Tag{{Name: "tag1"}}},
+		{Key: "key3", Value: []byte("value3"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+		{Key: "key1"},
+	}))
+
+	_, err = s.Get("key1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	value, err := s.Get("key2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+
+	t.Run("Duplicate key", func(t *testing.T) {
+		err := s.Batch([]storage.Operation{
+			{Key: "key4", Value: []byte("value4")},
+			{Key: "key3", Value: []byte("value3"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+		})
+		require.True(t, errors.Is(err, storage.ErrDuplicateKey))
+
+		// None of the operations should have been applied.
+		_, err = s.Get("key4")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("Invalid args", func(t *testing.T) {
+		require.Error(t, s.Batch(nil))
+		require.Error(t, s.Batch([]storage.Operation{{Key: ""}}))
+		require.Error(t, s.Batch([]storage.Operation{
+			{Key: "key5", Value: []byte("value5"), Tags: []storage.Tag{{Name: "tag:1"}}},
+		}))
+	})
+}
+
+func TestStore_Query(t *testing.T) {
+	p, err := NewProvider(t.TempDir())
+	require.NoError(t, err)
+
+	s, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		tags := []storage.Tag{
+			{Name: "Type", Value: "even"},
+			{Name: "Time", Value: strconv.Itoa(100 - i*10)},
+		}
+
+		if i%2 == 1 {
+			tags[0].Value = "odd"
+		}
+
+		require.NoError(t, s.Put(fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)), tags...))
+	}
+
+	require.NoError(t, s.Put("untagged", []byte("value")))
+
+	t.Run("Tag name only", func(t *testing.T) {
+		keys, total := queryKeys(t, s, "Type")
+		require.Equal(t, 10, total)
+		require.Len(t, keys, 10)
+		require.Equal(t, "key0", keys[0])
+	})
+
+	t.Run("Tag name and value", func(t *testing.T) {
+		keys, total := queryKeys(t, s, "Type:odd")
+		require.Equal(t, 5, total)
+		require.Equal(t, []string{"key1", "key3", "key5", "key7", "key9"}, keys)
+	})
+
+	t.Run("AND and comparison", func(t *testing.T) {
+		keys, _ := queryKeys(t, s, "Type:even&&Time<=60&&Time>20")
+		require.Equal(t, []string{"key4", "key6"}, keys)
+
+		keys, _ = queryKeys(t, s, "Time>=90")
+		require.Equal(t, []string{"key0", "key1"}, keys)
+
+		keys, _ = queryKeys(t, s, "Time<20")
+		require.Equal(t, []string{"key9"}, keys)
+	})
+
+	t.Run("OR", func(t *testing.T) {
+		keys, _ := queryKeys(t, s, "Time>90||Time<20")
+		require.Equal(t, []string{"key0", "key9"}, keys)
+	})
+
+	t.Run("Sort ascending", func(t *testing.T) {
+		keys, _ := queryKeys(t, s, "Type:even",
+			storage.WithSortOrder(&storage.SortOptions{Order: storage.SortAscending, TagName: "Time"}))
+		require.Equal(t, []string{"key8", "key6", "key4", "key2", "key0"}, keys)
+	})
+
+	t.Run("Sort descending with paging", func(t *testing.T) {
+		keys, total := queryKeys(t, s, "Type",
+			storage.WithSortOrder(&storage.SortOptions{Order: storage.SortDescending, TagName: "Time"}),
+			storage.WithPageSize(4), storage.WithInitialPageNum(2))
+		require.Equal(t, 10, total)
+		require.Equal(t, []string{"key8", "key9"}, keys)
+
+		keys, total = queryKeys(t, s, "Type",
+			storage.WithPageSize(4), storage.WithInitialPageNum(5))
+		require.Equal(t, 10, total)
+		require.Empty(t, keys)
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		it, err := s.Query("Type:odd")
+		require.NoError(t, err)
+
+		_, err = it.Key()
+		require.Error(t, err)
+
+		ok, err := it.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		value, err := it.Value()
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), value)
+
+		tags, err := it.Tags()
+		require.NoError(t, err)
+		require.Len(t, tags, 2)
+
+		require.NoError(t, it.Close())
+	})
+
+	t.Run("Invalid expression", func(t *testing.T) {
+		for _, expr := range []string{"", ":value", "Type&&", "Time<abc", "<10", "Type:a:b"} {
+			_, err := s.Query(expr)
+			require.Errorf(t, err, "expecting error for expression [%s]", expr)
+		}
+	})
+}
+
+func TestStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewProvider(dir, WithCompactionThreshold(5))
+	require.NoError(t, err)
+
+	s, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, s.Put("key1", []byte(fmt.Sprintf("value%d", i)), storage.Tag{Name: "tag1"}))
+	}
+
+	require.NoError(t, s.Put("key2", []byte("value2")))
+	require.NoError(t, s.Delete("key2"))
+
+	st, ok := s.(*store)
+	require.True(t, ok)
+	require.Less(t, st.numRecords, 5)
+
+	fileName := p.fileName("store1")
+
+	require.NoError(t, p.Close())
+
+	// Simulate a crash in the middle of writing a record.
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND, filePermissions)
+	require.NoError(t, err)
+
+	_, err = f.WriteString(`{"o":"p","k":"key3"`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	p, err = NewProvider(dir)
+	require.NoError(t, err)
+
+	s, err = p.OpenStore("store1")
+	require.NoError(t, err)
+
+	value, err := s.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value9"), value)
+
+	_, err = s.Get("key2")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = s.Get("key3")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	keys, _ := queryKeys(t, s, "tag1")
+	require.Equal(t, []string{"key1"}, keys)
+
+	// The store should still be writable after the partial record was discarded.
+	require.NoError(t, s.Put("key3", []byte("value3")))
+	require.NoError(t, p.Close())
+
+	p, err = NewProvider(dir)
+	require.NoError(t, err)
+
+	s, err = p.OpenStore("store1")
+	require.NoError(t, err)
+
+	value, err = s.Get("key3")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), value)
+}
+
+func TestStore_CompactionError(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewProvider(dir, WithCompactionThreshold(5))
+	require.NoError(t, err)
+
+	s, err := p.OpenStore("store1")
+	require.NoError(t, err)
+
+	st, ok := s.(*store)
+	require.True(t, ok)
+
+	st.rename = func(string, string) error { return errors.New("injected rename error") }
+
+	// The writes succeed even though compaction fails and the original log file remains usable.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, s.Put("key1", []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	require.Equal(t, 10, st.numRecords)
+
+	_, err = os.Stat(p.fileName("store1") + ".tmp")
+	require.True(t, os.IsNotExist(err))
+
+	st.rename = os.Rename
+
+	require.NoError(t, s.Put("key2", []byte("value2")))
+	require.Less(t, st.numRecords, 5)
+	require.NoError(t, s.Put("key3", []byte("value3")))
+
+	require.NoError(t, p.Close())
+
+	p, err = NewProvider(dir)
+	require.NoError(t, err)
+
+	s, err = p.OpenStore("store1")
+	require.NoError(t, err)
+
+	for key, expected := range map[string]string{"key1": "value9", "key2": "value2", "key3": "value3"} {
+		value, err := s.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(expected), value)
+	}
+}
+
+func queryKeys(t *testing.T, s storage.Store, expression string, opts ...storage.QueryOption) ([]string, int) {
+	t.Helper()
+
+	it, err := s.Query(expression, opts...)
+	require.NoError(t, err)
+
+	total, err := it.TotalItems()
+	require.NoError(t, err)
+
+	var keys []string
+
+	for {
+		ok, err := it.Next()
+		require.NoError(t, err)
+
+		if !ok {
+			break
+		}
+
+		key, err := it.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+
+	return keys, total
+}
@@ -0,0 +1,416 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationstatus
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/hashlink"
+	"github.com/trustbloc/orb/pkg/store/expiry"
+)
+
+const (
+	namespace = "operation-status"
+
+	suffixTagName     = "Suffix"
+	anchorTagName     = "AnchorID"
+	anchorHashTagName = "AnchorHash"
+	expiryTagName     = "ExpiryTime"
+
+	sha2_256 = 18
+)
+
+var logger = log.New("operation-status-store")
+
+// State is the lifecycle state of a Sidetree operation.
+type State string
+
+const (
+	// StateQueued indicates that the operation was added to the operation queue.
+	StateQueued State = "queued"
+	// StateBatched indicates that the operation was removed from the operation queue and cut into a batch.
+	StateBatched State = "batched"
+	// StateAnchored indicates that an anchor event was created for the batch and offered to witnesses.
+	StateAnchored State = "anchored"
+	// StateWitnessed indicates that the required proofs were collected for the anchor event.
+	StateWitnessed State = "witnessed"
+	// StatePublished indicates that the witnessed anchor event was added to the anchor graph and published.
+	StatePublished State = "published"
+	// StateObserved indicates that the anchor event was processed by the observer and the operation
+	// is reflected in the resolved document.
+	StateObserved State = "observed"
+	// StateFailed indicates that the operation will not be anchored.
+	StateFailed State = "failed"
+)
+
+// Status contains the lifecycle status of a Sidetree operation.
+type Status struct {
+	// ID is the tracking ID of the operation.
+	ID string `json:"id"`
+	// Suffix is the unique suffix of the DID.
+	Suffix string `json:"suffix"`
+	// State is the current lifecycle state of the operation.
+	State State `json:"state"`
+	// Timestamps contains the time at which the operation entered each state.
+	Timestamps map[State]time.Time `json:"timestamps"`
+	// AnchorID is the ID (index) of the anchor event that contains the operation.
+	AnchorID string `json:"anchorId,omitempty"`
+	// AnchorHashlink is the hashlink of the published anchor event.
+	AnchorHashlink string `json:"anchorHashlink,omitempty"`
+	// Error is the reason of the last failure. The operation may still be anchored if the state is not 'failed'
+	// since failed batches are retried.
+	Error string `json:"error,omitempty"`
+}
+
+// TrackingID returns the tracking ID of the given operation request.
+func TrackingID(operationRequest []byte) (string, error) {
+	id, err := hashing.CalculateModelMultihash(operationRequest, sha2_256)
+	if err != nil {
+		return "", fmt.Errorf("calculate tracking ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// Store tracks the lifecycle of Sidetree operations from the time that they're queued until they're observed.
+// Updates are not transactional so the status is a best-effort report.
+type Store struct {
+	store    storage.Store
+	lifespan time.Duration
+	now      func() time.Time
+}
+
+// New creates a new operation status store. The status of an operation is deleted after the given lifespan.
+func New(provider storage.Provider, expiryService *expiry.Service, lifespan time.Duration) (*Store, error) {
+	store, err := provider.OpenStore(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operation status store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(namespace,
+		storage.StoreConfiguration{TagNames: []string{suffixTagName, anchorTagName, anchorHashTagName, expiryTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	expiryService.Register(store, expiryTagName, namespace)
+
+	return &Store{
+		store:    store,
+		lifespan: lifespan,
+		now:      time.Now,
+	}, nil
+}
+
+// Queued records that the operation with the given tracking ID was added to the operation queue. The operation
+// may be queued more than once if its batch is rolled back.
+func (s *Store) Queued(id, suffix string) error {
+	status, err := s.get(id)
+	if err != nil {
+		if !errors.Is(err, orberrors.ErrContentNotFound) {
+			return err
+		}
+
+		status = &Status{
+			ID:         id,
+			Suffix:     suffix,
+			Timestamps: make(map[State]time.Time),
+		}
+	}
+
+	return s.put(s.setState(status, StateQueued))
+}
+
+// Batched records that the operations with the given tracking IDs were cut into a batch.
+func (s *Store) Batched(ids ...string) error {
+	for _, id := range ids {
+		status, err := s.get(id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.put(s.setState(status, StateBatched)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Failed records that the operation with the given tracking ID will not be anchored. The reason
+// is added to the last recorded error, if any.
+func (s *Store) Failed(id, reason string) error {
+	status, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	if status.Error != "" {
+		reason = fmt.Sprintf("%s: %s", reason, status.Error)
+	}
+
+	status.Error = reason
+
+	return s.put(s.setState(status, StateFailed))
+}
+
+// Anchored records that an anchor event with the given ID was created for the batched operations
+// of the given suffixes.
+func (s *Store) Anchored(anchorID string, suffixes ...string) error {
+	for _, suffix := range suffixes {
+		err := s.update(fmt.Sprintf("%s:%s", suffixTagName, suffix), func(status *Status) bool {
+			if status.State != StateBatched {
+				return false
+			}
+
+			status.AnchorID = anchorID
+			status.Error = ""
+
+			s.setState(status, StateAnchored)
+
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AnchorFailed records the reason that the anchor event could not be written for the operations of the given
+// suffixes. The anchor ID is empty if the failure occurred before the anchor event was created.
+func (s *Store) AnchorFailed(anchorID, reason string, suffixes ...string) error {
+	for _, suffix := range suffixes {
+		err := s.update(fmt.Sprintf("%s:%s", suffixTagName, suffix), func(status *Status) bool {
+			if status.State != StateBatched && (status.State != StateAnchored || status.AnchorID != anchorID) {
+				return false
+			}
+
+			status.Error = reason
+
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Published records that the anchor event with the given ID was witnessed and published with the given hashlink.
+func (s *Store) Published(anchorID, anchorHashlink string) error {
+	return s.update(
+		fmt.Sprintf("%s:%s", anchorTagName, base64.RawURLEncoding.EncodeToString([]byte(anchorID))),
+		func(status *Status) bool {
+			if status.State == StateObserved {
+				// The anchor event was published again.
+				return false
+			}
+
+			status.AnchorHashlink = anchorHashlink
+
+			s.setState(status, StatePublished)
+
+			return true
+		},
+	)
+}
+
+// Observed records that the anchor event with the given hashlink was processed by the observer.
+func (s *Store) Observed(anchorHashlink string) error {
+	return s.updateByHashlink(anchorHashlink, func(status *Status) bool {
+		if status.State == StateObserved {
+			return false
+		}
+
+		status.Error = ""
+
+		s.setState(status, StateObserved)
+
+		return true
+	})
+}
+
+// ObserveFailed records the reason that the anchor event with the given hashlink could not be processed
+// by the observer.
+func (s *Store) ObserveFailed(anchorHashlink, reason string) error {
+	return s.updateByHashlink(anchorHashlink, func(status *Status) bool {
+		if status.State == StateObserved {
+			return false
+		}
+
+		status.Error = reason
+
+		return true
+	})
+}
+
+// Get returns the status of the operation with the given tracking ID. orberrors.ErrContentNotFound
+// is returned if the status is not found.
+func (s *Store) Get(id string) (*Status, error) {
+	return s.get(id)
+}
+
+func (s *Store) updateByHashlink(anchorHashlink string, updateFunc func(status *Status) bool) error {
+	hash, err := hashlink.GetResourceHashFromHashLink(anchorHashlink)
+	if err != nil {
+		return fmt.Errorf("parse hashlink [%s]: %w", anchorHashlink, err)
+	}
+
+	return s.update(fmt.Sprintf("%s:%s", anchorHashTagName, hash), updateFunc)
+}
+
+// update applies the given function to the statuses that match the query. A status is only stored
+// if the function returns true.
+func (s *Store) update(query string, updateFunc func(status *Status) bool) error {
+	statuses, err := s.query(query)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if !updateFunc(status) {
+			continue
+		}
+
+		if err := s.put(status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) setState(status *Status, state State) *Status {
+	status.State = state
+	status.Timestamps[state] = s.now()
+
+	logger.Debugf("Operation [%s] for suffix [%s] is %s", status.ID, status.Suffix, state)
+
+	return status
+}
+
+func (s *Store) get(id string) (*Status, error) {
+	statusBytes, err := s.store.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("operation status [%s]: %w", id, orberrors.ErrContentNotFound)
+		}
+
+		return nil, orberrors.NewTransient(fmt.Errorf("get operation status [%s]: %w", id, err))
+	}
+
+	return unmarshalStatus(statusBytes)
+}
+
+func (s *Store) query(query string) ([]*Status, error) {
+	it, err := s.store.Query(query)
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("query operation status [%s]: %w", query, err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("Failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var statuses []*Status
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("query operation status [%s]: %w", query, err))
+		}
+
+		if !ok {
+			break
+		}
+
+		statusBytes, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("get operation status value: %w", err))
+		}
+
+		status, err := unmarshalStatus(statusBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *Store) put(status *Status) error {
+	statusBytes, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal operation status [%s]: %w", status.ID, err)
+	}
+
+	tags := []storage.Tag{
+		{
+			Name:  suffixTagName,
+			Value: status.Suffix,
+		},
+		{
+			Name:  expiryTagName,
+			Value: fmt.Sprintf("%d", status.Timestamps[StateQueued].Add(s.lifespan).Unix()),
+		},
+	}
+
+	if status.AnchorID != "" {
+		tags = append(tags, storage.Tag{
+			Name:  anchorTagName,
+			Value: base64.RawURLEncoding.EncodeToString([]byte(status.AnchorID)),
+		})
+	}
+
+	if status.AnchorHashlink != "" {
+		hash, err := hashlink.GetResourceHashFromHashLink(status.AnchorHashlink)
+		if err != nil {
+			return fmt.Errorf("parse hashlink [%s]: %w", status.AnchorHashlink, err)
+		}
+
+		tags = append(tags, storage.Tag{
+			Name:  anchorHashTagName,
+			Value: hash,
+		})
+	}
+
+	if err := s.store.Put(status.ID, statusBytes, tags...); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store operation status [%s]: %w", status.ID, err))
+	}
+
+	return nil
+}
+
+func unmarshalStatus(statusBytes []byte) (*Status, error) {
+	status := &Status{}
+
+	if err := json.Unmarshal(statusBytes, status); err != nil {
+		return nil, fmt.Errorf("unmarshal operation status: %w", err)
+	}
+
+	if status.Timestamps == nil {
+		status.Timestamps = make(map[State]time.Time)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/mocks"
+)
+
+const (
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+
+	anchorID1       = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+	anchorID2       = "hl:uEiAk0CUuIIVOxlalYH6JU7gsIwvo5zGNcM_zYo2jXwzBzw"
+	anchorHashlink1 = "hl:uEiBK0VEgbhUHNkMbbV6dzJSymWBJU2oVzwHoqYlh9TVpbA:uoQ-BeEJpcGZzOi8vYmFma3JlaWNrMmZpc"
+
+	lifespan = time.Hour
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), lifespan)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("error - open store fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(nil, errors.New("open store error"))
+
+		s, err := New(provider, testutil.GetExpiryService(t), lifespan)
+		require.EqualError(t, err, "failed to open operation status store: open store error")
+		require.Nil(t, s)
+	})
+
+	t.Run("error - set store config fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.SetStoreConfigReturns(errors.New("set store config error"))
+
+		s, err := New(provider, testutil.GetExpiryService(t), lifespan)
+		require.EqualError(t, err, "failed to set store configuration: set store config error")
+		require.Nil(t, s)
+	})
+}
+
+func TestTrackingID(t *testing.T) {
+	id1, err := TrackingID([]byte(`{"type":"update","didSuffix":"` + suffix1 + `"}`))
+	require.NoError(t, err)
+	require.NotEmpty(t, id1)
+
+	id2, err := TrackingID([]byte(`{"type":"update","didSuffix":"` + suffix1 + `"}`))
+	require.NoError(t, err)
+	require.Equal(t, id1, id2)
+
+	id3, err := TrackingID([]byte(`{"type":"update","didSuffix":"` + suffix2 + `"}`))
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id3)
+}
+
+func TestStore_Lifecycle(t *testing.T) {
+	s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), lifespan)
+	require.NoError(t, err)
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	_, err = s.Get("op1")
+	require.ErrorIs(t, err, orberrors.ErrContentNotFound)
+
+	require.NoError(t, s.Queued("op1", suffix1))
+	require.NoError(t, s.Queued("op2", suffix2))
+
+	status, err := s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, "op1", status.ID)
+	require.Equal(t, suffix1, status.Suffix)
+	require.Equal(t, StateQueued, status.State)
+	require.Equal(t, now.Unix(), status.Timestamps[StateQueued].Unix())
+
+	require.NoError(t, s.Batched("op1", "op2"))
+
+	// An error before the anchor event is created applies to the batched operations.
+	require.NoError(t, s.AnchorFailed("", "injected anchor error", suffix1, suffix2))
+
+	status, err = s.Get("op2")
+	require.NoError(t, err)
+	require.Equal(t, StateBatched, status.State)
+	require.Equal(t, "injected anchor error", status.Error)
+
+	// The batch is rolled back and op2 reaches the retry limit.
+	require.NoError(t, s.Queued("op1", suffix1))
+	require.NoError(t, s.Failed("op2", "retry count [10] has reached the limit"))
+
+	status, err = s.Get("op2")
+	require.NoError(t, err)
+	require.Equal(t, StateFailed, status.State)
+	require.Equal(t, "retry count [10] has reached the limit: injected anchor error", status.Error)
+
+	s.now = func() time.Time { return now.Add(time.Minute) }
+
+	require.NoError(t, s.Batched("op1"))
+	require.NoError(t, s.Anchored(anchorID1, suffix1, suffix2))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, StateAnchored, status.State)
+	require.Equal(t, anchorID1, status.AnchorID)
+	require.Empty(t, status.Error)
+	require.Equal(t, now.Add(time.Minute).Unix(), status.Timestamps[StateBatched].Unix())
+
+	// A failed operation isn't anchored.
+	status, err = s.Get("op2")
+	require.NoError(t, err)
+	require.Equal(t, StateFailed, status.State)
+
+	// An error for another anchor event doesn't apply to anchored operations.
+	require.NoError(t, s.AnchorFailed(anchorID2, "injected anchor error", suffix1))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Empty(t, status.Error)
+
+	require.NoError(t, s.Published(anchorID1, anchorHashlink1))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, StatePublished, status.State)
+	require.Equal(t, anchorHashlink1, status.AnchorHashlink)
+
+	require.NoError(t, s.ObserveFailed(anchorHashlink1, "injected observer error"))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, StatePublished, status.State)
+	require.Equal(t, "injected observer error", status.Error)
+
+	require.NoError(t, s.Observed(anchorHashlink1))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, StateObserved, status.State)
+	require.Empty(t, status.Error)
+	require.Len(t, status.Timestamps, 5)
+
+	// Publishing the anchor event again doesn't change the state.
+	require.NoError(t, s.Published(anchorID1, anchorHashlink1))
+
+	status, err = s.Get("op1")
+	require.NoError(t, err)
+	require.Equal(t, StateObserved, status.State)
+
+	// Anchors that don't contain tracked operations are ignored.
+	require.NoError(t, s.Published(anchorID2, anchorHashlink1))
+	require.NoError(t, s.Observed(anchorID2))
+
+	t.Run("invalid hashlink", func(t *testing.T) {
+		require.Error(t, s.Observed("https://example.com"))
+		require.Error(t, s.ObserveFailed("https://example.com", "injected observer error"))
+	})
+}
+
+func TestStore_Error(t *testing.T) {
+	errExpected := errors.New("injected store error")
+
+	store := &mocks.Store{}
+	store.GetReturns(nil, errExpected)
+	store.QueryReturns(nil, errExpected)
+
+	provider := &mocks.Provider{}
+	provider.OpenStoreReturns(store, nil)
+
+	s, err := New(provider, testutil.GetExpiryService(t), lifespan)
+	require.NoError(t, err)
+
+	_, err = s.Get("op1")
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+
+	err = s.Queued("op1", suffix1)
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Batched("op1")
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Failed("op1", "failed")
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Anchored(anchorID1, suffix1)
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+
+	err = s.AnchorFailed(anchorID1, "failed", suffix1)
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Published(anchorID1, anchorHashlink1)
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Observed(anchorHashlink1)
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.ObserveFailed(anchorHashlink1, "failed")
+	require.ErrorIs(t, err, errExpected)
+
+	t.Run("put error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.GetReturns(nil, storage.ErrDataNotFound)
+		store.PutReturns(errExpected)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider, testutil.GetExpiryService(t), lifespan)
+		require.NoError(t, err)
+
+		err = s.Queued("op1", suffix1)
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.GetReturns([]byte("{"), nil)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider, testutil.GetExpiryService(t), lifespan)
+		require.NoError(t, err)
+
+		_, err = s.Get("op1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal operation status")
+	})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooksubscription
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/webhook"
+)
+
+const (
+	namespace = "webhook-subscription"
+
+	subscriptionTagName = "Subscription"
+)
+
+var logger = log.New("webhook-subscription-store")
+
+// Store manages webhook subscriptions.
+type Store struct {
+	store storage.Store
+}
+
+// New creates a new webhook subscription store.
+func New(provider storage.Provider) (*Store, error) {
+	store, err := provider.OpenStore(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open webhook subscription store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(namespace, storage.StoreConfiguration{TagNames: []string{subscriptionTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	return &Store{store: store}, nil
+}
+
+// Put stores the given subscription.
+func (s *Store) Put(subscription *webhook.Subscription) error {
+	subscriptionBytes, err := json.Marshal(subscription)
+	if err != nil {
+		return fmt.Errorf("marshal subscription [%s]: %w", subscription.ID, err)
+	}
+
+	err = s.store.Put(subscription.ID, subscriptionBytes, storage.Tag{Name: subscriptionTagName})
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store subscription [%s]: %w", subscription.ID, err))
+	}
+
+	logger.Debugf("Stored webhook subscription [%s] for callback URL [%s]", subscription.ID, subscription.CallbackURL)
+
+	return nil
+}
+
+// Get returns the subscription for the given ID. orberrors.ErrContentNotFound is returned if the
+// subscription is not found.
+func (s *Store) Get(id string) (*webhook.Subscription, error) {
+	subscriptionBytes, err := s.store.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("subscription [%s]: %w", id, orberrors.ErrContentNotFound)
+		}
+
+		return nil, orberrors.NewTransient(fmt.Errorf("get subscription [%s]: %w", id, err))
+	}
+
+	return unmarshalSubscription(subscriptionBytes)
+}
+
+// GetAll returns all subscriptions.
+func (s *Store) GetAll() ([]*webhook.Subscription, error) {
+	it, err := s.store.Query(subscriptionTagName)
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("query subscriptions: %w", err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("Failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var subscriptions []*webhook.Subscription
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("query subscriptions: %w", err))
+		}
+
+		if !ok {
+			break
+		}
+
+		subscriptionBytes, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("get subscription value: %w", err))
+		}
+
+		subscription, err := unmarshalSubscription(subscriptionBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// Delete deletes the subscription for the given ID. orberrors.ErrContentNotFound is returned if the
+// subscription is not found.
+func (s *Store) Delete(id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(id); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("delete subscription [%s]: %w", id, err))
+	}
+
+	logger.Debugf("Deleted webhook subscription [%s]", id)
+
+	return nil
+}
+
+func unmarshalSubscription(subscriptionBytes []byte) (*webhook.Subscription, error) {
+	subscription := &webhook.Subscription{}
+
+	if err := json.Unmarshal(subscriptionBytes, subscription); err != nil {
+		return nil, fmt.Errorf("unmarshal subscription: %w", err)
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooksubscription
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/store/mocks"
+	"github.com/trustbloc/orb/pkg/webhook"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := New(mem.NewProvider())
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	})
+
+	t.Run("error - open store fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(nil, errors.New("open store error"))
+
+		s, err := New(provider)
+		require.EqualError(t, err, "failed to open webhook subscription store: open store error")
+		require.Nil(t, s)
+	})
+
+	t.Run("error - set store config fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.SetStoreConfigReturns(errors.New("set store config error"))
+
+		s, err := New(provider)
+		require.EqualError(t, err, "failed to set store configuration: set store config error")
+		require.Nil(t, s)
+	})
+}
+
+func TestStore(t *testing.T) {
+	s, err := New(mem.NewProvider())
+	require.NoError(t, err)
+
+	subscriptions, err := s.GetAll()
+	require.NoError(t, err)
+	require.Empty(t, subscriptions)
+
+	s1 := &webhook.Subscription{
+		ID:          "s1",
+		CallbackURL: "https://example.com/callback1",
+		Filter:      &webhook.Filter{OperationTypes: []operation.Type{operation.TypeCreate}},
+		Created:     time.Now(),
+	}
+
+	s2 := &webhook.Subscription{
+		ID:          "s2",
+		CallbackURL: "https://example.com/callback2",
+		Created:     time.Now(),
+	}
+
+	require.NoError(t, s.Put(s1))
+	require.NoError(t, s.Put(s2))
+
+	subscription, err := s.Get("s1")
+	require.NoError(t, err)
+	require.Equal(t, s1.CallbackURL, subscription.CallbackURL)
+	require.Equal(t, s1.Filter, subscription.Filter)
+
+	subscriptions, err = s.GetAll()
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+
+	require.NoError(t, s.Delete("s1"))
+
+	_, err = s.Get("s1")
+	require.ErrorIs(t, err, orberrors.ErrContentNotFound)
+
+	require.ErrorIs(t, s.Delete("s1"), orberrors.ErrContentNotFound)
+
+	subscriptions, err = s.GetAll()
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	require.Equal(t, "s2", subscriptions[0].ID)
+}
+
+func TestStore_Error(t *testing.T) {
+	errExpected := errors.New("injected store error")
+
+	t.Run("get error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.GetReturns(nil, errExpected)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.Get("s1")
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+
+		require.ErrorIs(t, s.Delete("s1"), errExpected)
+	})
+
+	t.Run("put error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.PutReturns(errExpected)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		err = s.Put(&webhook.Subscription{ID: "s1"})
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.GetReturns([]byte(`{"id":"s1"}`), nil)
+		store.DeleteReturns(errExpected)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		err = s.Delete("s1")
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.QueryReturns(nil, errExpected)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.GetAll()
+		require.ErrorIs(t, err, errExpected)
+		require.True(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("iterator error", func(t *testing.T) {
+		it := &mocks.Iterator{}
+		it.NextReturns(false, errExpected)
+
+		store := &mocks.Store{}
+		store.QueryReturns(it, nil)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.GetAll()
+		require.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("iterator value error", func(t *testing.T) {
+		it := &mocks.Iterator{}
+		it.NextReturns(true, nil)
+		it.ValueReturns(nil, errExpected)
+
+		store := &mocks.Store{}
+		store.QueryReturns(it, nil)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.GetAll()
+		require.ErrorIs(t, err, errExpected)
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		store := &mocks.Store{}
+		store.GetReturns([]byte("{"), nil)
+
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(store, nil)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.Get("s1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal subscription")
+	})
+}
@@ -33,16 +33,48 @@ var logger = log.New("witness-store")
 
 type updateWitnessProofFnc func(wf *proof.WitnessProof)
 
+// Option is an option for the anchor witness store.
+type Option func(opts *Store)
+
+// WithStatsRecorder sets an optional recorder that's notified when witnesses are selected
+// and when witnesses provide proofs.
+func WithStatsRecorder(recorder statsRecorder) Option {
+	return func(opts *Store) {
+		opts.statsRecorder = recorder
+	}
+}
+
+type statsRecorder interface {
+	OffersSent(anchorID string, witnesses []*url.URL) error
+	ProofReceived(anchorID string, witness *url.URL) error
+}
+
+type noopStatsRecorder struct{}
+
+func (r *noopStatsRecorder) OffersSent(string, []*url.URL) error {
+	return nil
+}
+
+func (r *noopStatsRecorder) ProofReceived(string, *url.URL) error {
+	return nil
+}
+
 // New creates new anchor witness store.
-func New(provider storage.Provider, expiryService *expiry.Service, expiryPeriod time.Duration) (*Store, error) {
+func New(provider storage.Provider, expiryService *expiry.Service, expiryPeriod time.Duration,
+	opts ...Option) (*Store, error) {
 	store, err := provider.OpenStore(namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open anchor witness store: %w", err)
 	}
 
 	s := &Store{
-		store:        store,
-		expiryPeriod: expiryPeriod,
+		store:         store,
+		expiryPeriod:  expiryPeriod,
+		statsRecorder: &noopStatsRecorder{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	err = provider.SetStoreConfig(namespace, storage.StoreConfiguration{TagNames: []string{anchorIndex, expiryTagName}})
@@ -57,8 +89,9 @@ func New(provider storage.Provider, expiryService *expiry.Service, expiryPeriod
 
 // Store is db implementation of anchor witness store.
 type Store struct {
-	store        storage.Store
-	expiryPeriod time.Duration
+	store         storage.Store
+	expiryPeriod  time.Duration
+	statsRecorder statsRecorder
 }
 
 // Put saves witnesses into anchor witness store.
@@ -103,6 +136,16 @@ func (s *Store) Put(anchorID string, witnesses []*proof.Witness) error {
 
 	logger.Debugf("stored %d witnesses for anchorID[%s]", len(witnesses), anchorID)
 
+	var selected []*url.URL
+
+	for _, w := range witnesses {
+		if w.Selected {
+			selected = append(selected, w.URI)
+		}
+	}
+
+	s.recordOffersSent(anchorID, selected)
+
 	return nil
 }
 
@@ -225,7 +268,7 @@ func (s *Store) Get(anchorID string) ([]*proof.WitnessProof, error) {
 	logger.Debugf("retrieved %d witnesses for anchorID[%s]", len(witnesses), anchorID)
 
 	if len(witnesses) == 0 {
-		return nil, fmt.Errorf("anchorID[%s] not found in the store", anchorID)
+		return nil, fmt.Errorf("anchorID[%s] not found in the store: %w", anchorID, orberrors.ErrContentNotFound)
 	}
 
 	return witnesses, nil
@@ -233,9 +276,18 @@ func (s *Store) Get(anchorID string) ([]*proof.WitnessProof, error) {
 
 // AddProof adds proof for anchor id and witness.
 func (s *Store) AddProof(anchorID string, witness *url.URL, p []byte) error {
-	return s.updateWitnessProof(anchorID, []*url.URL{witness}, func(wf *proof.WitnessProof) {
+	err := s.updateWitnessProof(anchorID, []*url.URL{witness}, func(wf *proof.WitnessProof) {
 		wf.Proof = p
 	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.statsRecorder.ProofReceived(anchorID, witness); err != nil {
+		logger.Warnf("Failed to record proof for anchorID[%s] from witness[%s]: %s", anchorID, witness, err)
+	}
+
+	return nil
 }
 
 func (s *Store) updateWitnessProof(anchorID string, witnesses []*url.URL, updateFnc updateWitnessProofFnc) error { //nolint:funlen,gocyclo,cyclop,lll
@@ -322,9 +374,30 @@ func (s *Store) updateWitnessProof(anchorID string, witnesses []*url.URL, update
 
 // UpdateWitnessSelection updates witness selection flag.
 func (s *Store) UpdateWitnessSelection(anchorID string, witnesses []*url.URL, selected bool) error {
-	return s.updateWitnessProof(anchorID, witnesses, func(wf *proof.WitnessProof) {
+	err := s.updateWitnessProof(anchorID, witnesses, func(wf *proof.WitnessProof) {
 		wf.Selected = selected
 	})
+	if err != nil {
+		return err
+	}
+
+	if selected {
+		s.recordOffersSent(anchorID, witnesses)
+	}
+
+	return nil
+}
+
+// recordOffersSent notifies the stats recorder that offers will be sent to the given (selected) witnesses.
+// Statistics are best-effort so an error is only logged.
+func (s *Store) recordOffersSent(anchorID string, witnesses []*url.URL) {
+	if len(witnesses) == 0 {
+		return
+	}
+
+	if err := s.statsRecorder.OffersSent(anchorID, witnesses); err != nil {
+		logger.Warnf("Failed to record offers for anchorID[%s] to witnesses%s: %s", anchorID, witnesses, err)
+	}
 }
 
 // HandleExpiredKeys is expired keys inspector/handler.
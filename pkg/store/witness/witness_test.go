@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/orb/pkg/anchor/witness/proof"
+	orberrors "github.com/trustbloc/orb/pkg/errors"
 	"github.com/trustbloc/orb/pkg/internal/testutil"
 	"github.com/trustbloc/orb/pkg/internal/testutil/mongodbtestutil"
 	"github.com/trustbloc/orb/pkg/store/expiry"
@@ -226,6 +227,7 @@ func TestStore_Delete(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, ops)
 		require.Contains(t, err.Error(), "anchorID[id] not found in the store")
+		require.ErrorIs(t, err, orberrors.ErrContentNotFound)
 	})
 
 	t.Run("success - no witnesses found for anchor ID", func(t *testing.T) {
@@ -716,6 +718,59 @@ func TestStore_HandleExpiryKeys(t *testing.T) {
 	})
 }
 
+func TestStore_StatsRecorder(t *testing.T) {
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	recorder := &mockStatsRecorder{err: fmt.Errorf("injected recorder error")}
+
+	s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), expiryTime, WithStatsRecorder(recorder))
+	require.NoError(t, err)
+
+	selectedWitness := getTestWitness(witness1)
+	selectedWitness.Selected = true
+
+	require.NoError(t, s.Put(anchorID, []*proof.Witness{selectedWitness, getTestWitness(witness2)}))
+	require.Equal(t, []string{witness1.String()}, recorder.offers[anchorID])
+
+	require.NoError(t, s.UpdateWitnessSelection(anchorID, []*url.URL{witness2}, true))
+	require.Equal(t, []string{witness1.String(), witness2.String()}, recorder.offers[anchorID])
+
+	require.NoError(t, s.UpdateWitnessSelection(anchorID, []*url.URL{witness2}, false))
+	require.Len(t, recorder.offers[anchorID], 2)
+
+	require.NoError(t, s.AddProof(anchorID, witness1, []byte(witnessProof)))
+	require.Equal(t, []string{witness1.String()}, recorder.proofs[anchorID])
+}
+
+type mockStatsRecorder struct {
+	offers map[string][]string
+	proofs map[string][]string
+	err    error
+}
+
+func (m *mockStatsRecorder) OffersSent(anchorID string, witnesses []*url.URL) error {
+	if m.offers == nil {
+		m.offers = make(map[string][]string)
+	}
+
+	for _, w := range witnesses {
+		m.offers[anchorID] = append(m.offers[anchorID], w.String())
+	}
+
+	return m.err
+}
+
+func (m *mockStatsRecorder) ProofReceived(anchorID string, witness *url.URL) error {
+	if m.proofs == nil {
+		m.proofs = make(map[string][]string)
+	}
+
+	m.proofs[anchorID] = append(m.proofs[anchorID], witness.String())
+
+	return m.err
+}
+
 func getTestWitness(witnessURI *url.URL) *proof.Witness {
 	return &proof.Witness{
 		Type: proof.WitnessTypeBatch,
@@ -0,0 +1,780 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package witnessstats
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/store/expiry"
+)
+
+const (
+	namespace = "witness-stats"
+
+	witnessTagName = "witness"
+	statsTagName   = "stats"
+	expiryTagName  = "ExpiryTime"
+
+	statsKeyPrefix = "stats-"
+	offerKeyPrefix = "offer-"
+	roundRobinKey  = "round-robin"
+
+	// latencyWeight is the weight of a new latency sample in the moving average of the latency.
+	latencyWeight = 0.2
+	// failureWeight is the weight of a new response in the moving average of the failure rate.
+	failureWeight = 0.2
+
+	defaultHealthThreshold = 0.5
+	defaultInitialBackoff  = time.Minute
+	defaultMaxBackoff      = time.Hour
+
+	// statsLifespan is the period after the last update after which the statistics recorded by a server
+	// instance expire. This removes the statistics of instances that are no longer running.
+	statsLifespan = 7 * 24 * time.Hour
+)
+
+// Outcome is the outcome of an offer that was sent to a witness.
+type Outcome string
+
+const (
+	// OutcomeSuccess indicates that the witness provided a valid proof.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeTimeout indicates that the witness did not provide a proof in time.
+	OutcomeTimeout Outcome = "timeout"
+	// OutcomeInvalidProof indicates that the witness provided an invalid proof.
+	OutcomeInvalidProof Outcome = "invalid-proof"
+)
+
+var logger = log.New("witness-stats-store")
+
+// Stats contains the statistics of a witness which are used when selecting witnesses.
+type Stats struct {
+	// LastSelected is the time that an offer was last sent to the witness.
+	LastSelected time.Time `json:"lastSelected"`
+	// Latency is the moving average of the time between sending an offer and receiving a proof from the witness.
+	// Latency is zero if a proof was never received from the witness.
+	Latency time.Duration `json:"latency"`
+	// OutstandingOffers is the number of offers that were sent to the witness but for which
+	// a proof was not yet received. Offers that timed out are not outstanding.
+	OutstandingOffers int `json:"-"`
+	// Successes is the number of valid proofs received from the witness.
+	Successes int `json:"successes,omitempty"`
+	// Timeouts is the number of offers for which the witness did not provide a proof in time.
+	Timeouts int `json:"timeouts,omitempty"`
+	// InvalidProofs is the number of invalid proofs received from the witness.
+	InvalidProofs int `json:"invalidProofs,omitempty"`
+	// FailureRate is the moving average of failed responses, from 0 (no failures) to 1 (all responses failed).
+	FailureRate float64 `json:"failureRate,omitempty"`
+	// Backoff is the period for which the witness is skipped during selection after its last failure. The
+	// backoff is doubled on each failure for as long as the witness remains unhealthy.
+	Backoff time.Duration `json:"backoff,omitempty"`
+	// RetryAfter is the time after which an unhealthy witness may be selected again. It's nil if the witness
+	// isn't backed off.
+	RetryAfter *time.Time `json:"retryAfter,omitempty"`
+}
+
+// Score returns the health score of the witness, from 0 (unhealthy) to 1 (healthy).
+func (s *Stats) Score() float64 {
+	return 1 - s.FailureRate
+}
+
+type offer struct {
+	Sent     time.Time `json:"sent"`
+	TimedOut bool      `json:"timedOut,omitempty"`
+}
+
+// Option is an option for the witness stats store.
+type Option func(opts *Store)
+
+// WithHealthThreshold sets the health score below which a witness is skipped during selection. A threshold
+// of zero disables the exclusion of unhealthy witnesses.
+func WithHealthThreshold(threshold float64) Option {
+	return func(opts *Store) {
+		opts.healthThreshold = threshold
+	}
+}
+
+// WithBackoff sets the initial and maximum period for which an unhealthy witness is skipped during selection.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(opts *Store) {
+		opts.initialBackoff = initial
+		opts.maxBackoff = max
+	}
+}
+
+// WithInstanceID sets the ID of this server instance, which identifies the statistics recorded by the instance.
+// The ID should be stable across restarts of the instance so that a restarted instance continues to update its
+// previous statistics rather than leaving them in the store until they expire. The host name is used by default.
+func WithInstanceID(id string) Option {
+	return func(opts *Store) {
+		opts.instanceID = id
+	}
+}
+
+// WithMetrics sets the metrics provider which is notified of witness responses and health scores.
+func WithMetrics(metrics metricsProvider) Option {
+	return func(opts *Store) {
+		opts.metrics = metrics
+	}
+}
+
+type metricsProvider interface {
+	WitnessResponse(witness, outcome string)
+	WitnessHealthScore(witness string, score float64)
+	WitnessLatency(witness string, value time.Duration)
+}
+
+type noopMetricsProvider struct{}
+
+func (m *noopMetricsProvider) WitnessResponse(string, string) {}
+
+func (m *noopMetricsProvider) WitnessHealthScore(string, float64) {}
+
+func (m *noopMetricsProvider) WitnessLatency(string, time.Duration) {}
+
+// Store maintains witness statistics in a store that's shared across all instances of the server. The
+// underlying storage doesn't support atomic updates, so each server instance only updates its own statistics
+// for a witness (serialized by a mutex) and the statistics of all instances are aggregated when they're read.
+// Offers and the round-robin position are shared since a proof may be received by a different instance than
+// the one that sent the offer.
+type Store struct {
+	store           storage.Store
+	instanceID      string
+	offerLifespan   time.Duration
+	healthThreshold float64
+	initialBackoff  time.Duration
+	maxBackoff      time.Duration
+	metrics         metricsProvider
+	now             func() time.Time
+
+	mutex sync.Mutex
+}
+
+// New creates a new witness stats store. Offers for which a proof is not received within the given
+// lifespan are no longer considered outstanding.
+func New(provider storage.Provider, expiryService *expiry.Service, offerLifespan time.Duration,
+	opts ...Option) (*Store, error) {
+	store, err := provider.OpenStore(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open witness stats store: %w", err)
+	}
+
+	err = provider.SetStoreConfig(namespace,
+		storage.StoreConfiguration{TagNames: []string{witnessTagName, statsTagName, expiryTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	expiryService.Register(store, expiryTagName, namespace)
+
+	s := &Store{
+		store:           store,
+		instanceID:      defaultInstanceID(),
+		offerLifespan:   offerLifespan,
+		healthThreshold: defaultHealthThreshold,
+		initialBackoff:  defaultInitialBackoff,
+		maxBackoff:      defaultMaxBackoff,
+		metrics:         &noopMetricsProvider{},
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// OffersSent records that an offer for the given anchor was sent to the given witnesses.
+func (s *Store) OffersSent(anchorID string, witnesses []*url.URL) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+
+	var operations []storage.Operation
+
+	for _, w := range witnesses {
+		stats, err := s.getStats(w.String())
+		if err != nil {
+			return err
+		}
+
+		stats.LastSelected = now
+
+		statsOp, err := s.statsOperation(w.String(), stats)
+		if err != nil {
+			return err
+		}
+
+		offerOp, err := s.offerOperation(anchorID, w.String(), &offer{Sent: now})
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, statsOp, offerOp)
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if err := s.store.Batch(operations); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store offers for anchor [%s]: %w", anchorID, err))
+	}
+
+	logger.Debugf("Recorded offers for anchor [%s] to witnesses %s", anchorID, witnesses)
+
+	return nil
+}
+
+// ProofReceived records that a proof for the given anchor was received from the given witness. The latency
+// of the witness is updated if an outstanding offer was sent to the witness. A proof that's received after
+// the offer timed out updates the latency but doesn't count as a successful response.
+func (s *Store) ProofReceived(anchorID string, witness *url.URL) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	o, err := s.getOffer(anchorID, witness.String())
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.getStats(witness.String())
+	if err != nil {
+		return err
+	}
+
+	var operations []storage.Operation
+
+	if o != nil {
+		latency := s.now().Sub(o.Sent)
+
+		if stats.Latency == 0 {
+			stats.Latency = latency
+		} else {
+			stats.Latency = time.Duration((1-latencyWeight)*float64(stats.Latency) + latencyWeight*float64(latency))
+		}
+
+		operations = append(operations, storage.Operation{Key: offerKey(anchorID, witness.String())})
+
+		logger.Debugf("Proof for anchor [%s] from witness [%s] received after %s. Average latency: %s",
+			anchorID, witness, latency, stats.Latency)
+	} else {
+		logger.Debugf("No outstanding offer for anchor [%s] to witness [%s]", anchorID, witness)
+	}
+
+	var outcome Outcome
+
+	if o == nil || !o.TimedOut {
+		outcome = OutcomeSuccess
+
+		s.updateHealth(stats, outcome)
+	}
+
+	return s.storeStats(witness.String(), stats, outcome, operations...)
+}
+
+// InvalidProof records that an invalid proof for the given anchor was received from the given witness.
+func (s *Store) InvalidProof(anchorID string, witness *url.URL) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	o, err := s.getOffer(anchorID, witness.String())
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.getStats(witness.String())
+	if err != nil {
+		return err
+	}
+
+	var operations []storage.Operation
+
+	if o != nil {
+		operations = append(operations, storage.Operation{Key: offerKey(anchorID, witness.String())})
+	}
+
+	s.updateHealth(stats, OutcomeInvalidProof)
+
+	return s.storeStats(witness.String(), stats, OutcomeInvalidProof, operations...)
+}
+
+// Timeout records that the given witness did not provide a proof for the given anchor in time. The timeout is
+// only recorded once per offer and only if the offer is still outstanding.
+func (s *Store) Timeout(anchorID string, witness *url.URL) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	o, err := s.getOffer(anchorID, witness.String())
+	if err != nil {
+		return err
+	}
+
+	if o == nil || o.TimedOut {
+		logger.Debugf("No outstanding offer for anchor [%s] to witness [%s]", anchorID, witness)
+
+		return nil
+	}
+
+	stats, err := s.getStats(witness.String())
+	if err != nil {
+		return err
+	}
+
+	// Keep the offer so that the latency is still updated if the proof arrives late.
+	o.TimedOut = true
+
+	offerOp, err := s.offerOperation(anchorID, witness.String(), o)
+	if err != nil {
+		return err
+	}
+
+	s.updateHealth(stats, OutcomeTimeout)
+
+	return s.storeStats(witness.String(), stats, OutcomeTimeout, offerOp)
+}
+
+// Get returns the statistics of the given witnesses.
+func (s *Store) Get(witnesses ...string) (map[string]*Stats, error) {
+	result := make(map[string]*Stats, len(witnesses))
+
+	for _, w := range witnesses {
+		stats, err := s.getAggregateStats(w)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.OutstandingOffers, err = s.countOutstandingOffers(w)
+		if err != nil {
+			return nil, err
+		}
+
+		result[w] = stats
+	}
+
+	return result, nil
+}
+
+// GetAll returns the statistics of all witnesses for which statistics were recorded.
+func (s *Store) GetAll() (map[string]*Stats, error) {
+	it, err := s.store.Query(statsTagName)
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("query witness stats: %w", err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("Failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var witnesses []string
+
+	included := make(map[string]bool)
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("query witness stats: %w", err))
+		}
+
+		if !ok {
+			break
+		}
+
+		tags, err := it.Tags()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("get witness stats tags: %w", err))
+		}
+
+		for _, tag := range tags {
+			if tag.Name != statsTagName {
+				continue
+			}
+
+			witness, err := base64.RawURLEncoding.DecodeString(tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("decode witness [%s]: %w", tag.Value, err)
+			}
+
+			// There's a record for each server instance that recorded statistics for the witness.
+			if !included[string(witness)] {
+				included[string(witness)] = true
+
+				witnesses = append(witnesses, string(witness))
+			}
+		}
+	}
+
+	return s.Get(witnesses...)
+}
+
+// Unhealthy returns the given witnesses which are unhealthy and should be skipped during selection
+// until their backoff period expires. A witness is unhealthy if any server instance is backing off from it.
+func (s *Store) Unhealthy(witnesses ...string) ([]string, error) {
+	now := s.now()
+
+	var unhealthy []string
+
+	for _, w := range witnesses {
+		stats, err := s.getAggregateStats(w)
+		if err != nil {
+			return nil, err
+		}
+
+		if stats.RetryAfter != nil && now.Before(*stats.RetryAfter) {
+			unhealthy = append(unhealthy, w)
+		}
+	}
+
+	return unhealthy, nil
+}
+
+// NextRoundRobin returns the current round-robin position and advances the position by n. The position is kept
+// in the shared store so that all server instances take turns from the same position. Since the store doesn't
+// support atomic updates, instances that select witnesses at the same time may occasionally get the same position.
+func (s *Store) NextRoundRobin(n int) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var position int
+
+	positionBytes, err := s.store.Get(roundRobinKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return 0, orberrors.NewTransient(fmt.Errorf("get round-robin position: %w", err))
+		}
+	} else if err := json.Unmarshal(positionBytes, &position); err != nil {
+		return 0, fmt.Errorf("unmarshal round-robin position: %w", err)
+	}
+
+	positionBytes, err = json.Marshal(position + n)
+	if err != nil {
+		return 0, fmt.Errorf("marshal round-robin position: %w", err)
+	}
+
+	if err := s.store.Put(roundRobinKey, positionBytes); err != nil {
+		return 0, orberrors.NewTransient(fmt.Errorf("store round-robin position: %w", err))
+	}
+
+	return position, nil
+}
+
+// getStats returns the statistics of the given witness that were recorded by this server instance.
+func (s *Store) getStats(witness string) (*Stats, error) {
+	statsBytes, err := s.store.Get(s.statsKey(witness))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return &Stats{}, nil
+		}
+
+		return nil, orberrors.NewTransient(fmt.Errorf("get stats for witness [%s]: %w", witness, err))
+	}
+
+	stats := &Stats{}
+
+	if err := json.Unmarshal(statsBytes, stats); err != nil {
+		return nil, fmt.Errorf("unmarshal stats for witness [%s]: %w", witness, err)
+	}
+
+	return stats, nil
+}
+
+// getAggregateStats returns the statistics of the given witness that were recorded by all server instances.
+func (s *Store) getAggregateStats(witness string) (*Stats, error) {
+	it, err := s.store.Query(fmt.Sprintf("%s:%s", statsTagName, encode(witness)))
+	if err != nil {
+		return nil, orberrors.NewTransient(fmt.Errorf("query stats for witness [%s]: %w", witness, err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("Failed to close iterator: %s", errClose)
+		}
+	}()
+
+	var records []*Stats
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("query stats for witness [%s]: %w", witness, err))
+		}
+
+		if !ok {
+			break
+		}
+
+		statsBytes, err := it.Value()
+		if err != nil {
+			return nil, orberrors.NewTransient(fmt.Errorf("get stats for witness [%s]: %w", witness, err))
+		}
+
+		stats := &Stats{}
+
+		if err := json.Unmarshal(statsBytes, stats); err != nil {
+			return nil, fmt.Errorf("unmarshal stats for witness [%s]: %w", witness, err)
+		}
+
+		records = append(records, stats)
+	}
+
+	return aggregate(records), nil
+}
+
+// aggregate combines the statistics that were recorded by each server instance. Counts are summed, the
+// latency and failure rate are averaged (weighted by the number of responses) and the latest selection
+// and backoff times are used.
+func aggregate(records []*Stats) *Stats {
+	result := &Stats{}
+
+	var latencySum, latencySamples, failureSum, responses float64
+
+	for _, r := range records {
+		if r.LastSelected.After(result.LastSelected) {
+			result.LastSelected = r.LastSelected
+		}
+
+		if r.RetryAfter != nil && (result.RetryAfter == nil || r.RetryAfter.After(*result.RetryAfter)) {
+			retryAfter := *r.RetryAfter
+			result.RetryAfter = &retryAfter
+		}
+
+		if r.Backoff > result.Backoff {
+			result.Backoff = r.Backoff
+		}
+
+		result.Successes += r.Successes
+		result.Timeouts += r.Timeouts
+		result.InvalidProofs += r.InvalidProofs
+
+		if r.Latency > 0 {
+			// The latency is also updated by late proofs, which aren't counted as successes.
+			samples := math.Max(float64(r.Successes), 1)
+
+			latencySum += samples * float64(r.Latency)
+			latencySamples += samples
+		}
+
+		n := float64(r.Successes + r.Timeouts + r.InvalidProofs)
+
+		failureSum += n * r.FailureRate
+		responses += n
+	}
+
+	if latencySamples > 0 {
+		result.Latency = time.Duration(math.Round(latencySum / latencySamples))
+	}
+
+	if responses > 0 {
+		result.FailureRate = failureSum / responses
+	}
+
+	return result
+}
+
+func (s *Store) getOffer(anchorID, witness string) (*offer, error) {
+	offerBytes, err := s.store.Get(offerKey(anchorID, witness))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, nil
+		}
+
+		return nil, orberrors.NewTransient(fmt.Errorf("get offer for anchor [%s]: %w", anchorID, err))
+	}
+
+	o := &offer{}
+
+	if err := json.Unmarshal(offerBytes, o); err != nil {
+		return nil, fmt.Errorf("unmarshal offer for anchor [%s]: %w", anchorID, err)
+	}
+
+	return o, nil
+}
+
+// updateHealth updates the failure rate of the witness with the given outcome. An unhealthy witness is
+// skipped during selection for a backoff period which is doubled on each failure.
+func (s *Store) updateHealth(stats *Stats, outcome Outcome) {
+	switch outcome {
+	case OutcomeSuccess:
+		stats.Successes++
+		stats.FailureRate = (1 - failureWeight) * stats.FailureRate
+		stats.Backoff = 0
+		stats.RetryAfter = nil
+
+		return
+	case OutcomeTimeout:
+		stats.Timeouts++
+	case OutcomeInvalidProof:
+		stats.InvalidProofs++
+	}
+
+	stats.FailureRate = (1-failureWeight)*stats.FailureRate + failureWeight
+
+	if stats.Score() >= s.healthThreshold {
+		return
+	}
+
+	if stats.Backoff == 0 {
+		stats.Backoff = s.initialBackoff
+	} else {
+		stats.Backoff *= 2
+	}
+
+	if stats.Backoff > s.maxBackoff {
+		stats.Backoff = s.maxBackoff
+	}
+
+	retryAfter := s.now().Add(stats.Backoff)
+	stats.RetryAfter = &retryAfter
+}
+
+func (s *Store) storeStats(witness string, stats *Stats, outcome Outcome, operations ...storage.Operation) error {
+	statsOp, err := s.statsOperation(witness, stats)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Batch(append(operations, statsOp)); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("store stats for witness [%s]: %w", witness, err))
+	}
+
+	if outcome != "" {
+		logger.Debugf("Recorded response [%s] from witness [%s]. Health score: %.2f, retry after: %s",
+			outcome, witness, stats.Score(), stats.RetryAfter)
+
+		s.metrics.WitnessResponse(witness, string(outcome))
+		s.metrics.WitnessHealthScore(witness, stats.Score())
+	}
+
+	if stats.Latency > 0 {
+		s.metrics.WitnessLatency(witness, stats.Latency)
+	}
+
+	return nil
+}
+
+func (s *Store) offerOperation(anchorID, witness string, o *offer) (storage.Operation, error) {
+	offerBytes, err := json.Marshal(o)
+	if err != nil {
+		return storage.Operation{}, fmt.Errorf("marshal offer: %w", err)
+	}
+
+	return storage.Operation{
+		Key:   offerKey(anchorID, witness),
+		Value: offerBytes,
+		Tags: []storage.Tag{
+			{Name: witnessTagName, Value: encode(witness)},
+			{Name: expiryTagName, Value: strconv.FormatInt(o.Sent.Add(s.offerLifespan).Unix(), 10)},
+		},
+	}, nil
+}
+
+func (s *Store) statsOperation(witness string, stats *Stats) (storage.Operation, error) {
+	statsBytes, err := json.Marshal(stats)
+	if err != nil {
+		return storage.Operation{}, fmt.Errorf("marshal witness stats: %w", err)
+	}
+
+	return storage.Operation{
+		Key:   s.statsKey(witness),
+		Value: statsBytes,
+		Tags: []storage.Tag{
+			{Name: statsTagName, Value: encode(witness)},
+			{Name: expiryTagName, Value: strconv.FormatInt(s.now().Add(statsLifespan).Unix(), 10)},
+		},
+	}, nil
+}
+
+// countOutstandingOffers returns the number of offers to the given witness which haven't timed out. Offers are
+// kept after they time out so that a late proof still updates the latency, and they remain in the store until
+// they're removed by the expiry service.
+func (s *Store) countOutstandingOffers(witness string) (int, error) {
+	it, err := s.store.Query(fmt.Sprintf("%s:%s", witnessTagName, encode(witness)))
+	if err != nil {
+		return 0, orberrors.NewTransient(fmt.Errorf("query offers for witness [%s]: %w", witness, err))
+	}
+
+	defer func() {
+		if errClose := it.Close(); errClose != nil {
+			logger.Warnf("Failed to close iterator: %s", errClose)
+		}
+	}()
+
+	now := s.now()
+
+	count := 0
+
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			return 0, orberrors.NewTransient(fmt.Errorf("query offers for witness [%s]: %w", witness, err))
+		}
+
+		if !ok {
+			return count, nil
+		}
+
+		offerBytes, err := it.Value()
+		if err != nil {
+			return 0, orberrors.NewTransient(fmt.Errorf("get offer for witness [%s]: %w", witness, err))
+		}
+
+		o := &offer{}
+
+		if err := json.Unmarshal(offerBytes, o); err != nil {
+			return 0, fmt.Errorf("unmarshal offer for witness [%s]: %w", witness, err)
+		}
+
+		if o.TimedOut || !now.Before(o.Sent.Add(s.offerLifespan)) {
+			continue
+		}
+
+		count++
+	}
+}
+
+func (s *Store) statsKey(witness string) string {
+	return statsKeyPrefix + encode(witness) + "-" + encode(s.instanceID)
+}
+
+func offerKey(anchorID, witness string) string {
+	return offerKeyPrefix + encode(anchorID) + "-" + encode(witness)
+}
+
+// defaultInstanceID returns the host name, which is stable across restarts of the server instance. A random ID
+// is returned if the host name can't be determined.
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		logger.Warnf("Unable to determine the host name for the witness stats instance ID. Using a random ID: %v", err)
+
+		return uuid.New().String()
+	}
+
+	return hostname
+}
+
+func encode(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
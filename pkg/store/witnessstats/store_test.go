@@ -0,0 +1,431 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package witnessstats
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	"github.com/stretchr/testify/require"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/store/mocks"
+)
+
+const offerLifespan = time.Minute
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), offerLifespan)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+
+		hostname, err := os.Hostname()
+		require.NoError(t, err)
+		require.Equal(t, hostname, s.instanceID)
+
+		s, err = New(mem.NewProvider(), testutil.GetExpiryService(t), offerLifespan, WithInstanceID("instance1"))
+		require.NoError(t, err)
+		require.Equal(t, "instance1", s.instanceID)
+	})
+
+	t.Run("error - open store fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.OpenStoreReturns(nil, errors.New("open store error"))
+
+		s, err := New(provider, testutil.GetExpiryService(t), offerLifespan)
+		require.EqualError(t, err, "failed to open witness stats store: open store error")
+		require.Nil(t, s)
+	})
+
+	t.Run("error - set store config fails", func(t *testing.T) {
+		provider := &mocks.Provider{}
+		provider.SetStoreConfigReturns(errors.New("set store config error"))
+
+		s, err := New(provider, testutil.GetExpiryService(t), offerLifespan)
+		require.EqualError(t, err, "failed to set store configuration: set store config error")
+		require.Nil(t, s)
+	})
+}
+
+func TestStore_Stats(t *testing.T) {
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), offerLifespan)
+	require.NoError(t, err)
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	stats, err := s.Get(witness1.String())
+	require.NoError(t, err)
+	require.True(t, stats[witness1.String()].LastSelected.IsZero())
+	require.Zero(t, stats[witness1.String()].Latency)
+	require.Zero(t, stats[witness1.String()].OutstandingOffers)
+
+	require.NoError(t, s.OffersSent("anchor1", []*url.URL{witness1, witness2}))
+	require.NoError(t, s.OffersSent("anchor2", []*url.URL{witness1}))
+	require.NoError(t, s.OffersSent("anchor3", nil))
+
+	stats, err = s.Get(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, now.Unix(), stats[witness1.String()].LastSelected.Unix())
+	require.Equal(t, 2, stats[witness1.String()].OutstandingOffers)
+	require.Equal(t, 1, stats[witness2.String()].OutstandingOffers)
+
+	s.now = func() time.Time { return now.Add(10 * time.Second) }
+
+	require.NoError(t, s.ProofReceived("anchor1", witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, stats[witness1.String()].Latency)
+	require.Equal(t, 1, stats[witness1.String()].OutstandingOffers)
+
+	s.now = func() time.Time { return now.Add(20 * time.Second) }
+
+	require.NoError(t, s.ProofReceived("anchor2", witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 12*time.Second, stats[witness1.String()].Latency)
+	require.Zero(t, stats[witness1.String()].OutstandingOffers)
+
+	// A proof without an outstanding offer is ignored.
+	require.NoError(t, s.ProofReceived("anchor2", witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 12*time.Second, stats[witness1.String()].Latency)
+
+	// Offers that timed out (or whose lifespan has passed) aren't outstanding.
+	require.NoError(t, s.OffersSent("anchor4", []*url.URL{witness1, witness2}))
+	require.NoError(t, s.Timeout("anchor4", witness2))
+
+	stats, err = s.Get(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Equal(t, 1, stats[witness1.String()].OutstandingOffers)
+	require.Equal(t, 1, stats[witness2.String()].OutstandingOffers)
+
+	s.now = func() time.Time { return now.Add(20*time.Second + offerLifespan) }
+
+	stats, err = s.Get(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Zero(t, stats[witness1.String()].OutstandingOffers)
+	require.Zero(t, stats[witness2.String()].OutstandingOffers)
+}
+
+func TestStore_Health(t *testing.T) {
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	metrics := &mockMetrics{responses: make(map[string]int)}
+
+	s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), offerLifespan,
+		WithHealthThreshold(0.5), WithBackoff(time.Minute, 3*time.Minute), WithMetrics(metrics))
+	require.NoError(t, err)
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	anchors := []string{"anchor1", "anchor2", "anchor3", "anchor4", "anchor5", "anchor6"}
+
+	for _, anchorID := range anchors {
+		require.NoError(t, s.OffersSent(anchorID, []*url.URL{witness1, witness2}))
+	}
+
+	// The first three timeouts don't bring the score below the threshold.
+	for _, anchorID := range anchors[:3] {
+		require.NoError(t, s.Timeout(anchorID, witness1))
+		require.NoError(t, s.ProofReceived(anchorID, witness2))
+	}
+
+	unhealthy, err := s.Unhealthy(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Empty(t, unhealthy)
+
+	// A timeout is only recorded once per offer.
+	require.NoError(t, s.Timeout(anchors[0], witness1))
+
+	stats, err := s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 3, stats[witness1.String()].Timeouts)
+
+	require.NoError(t, s.InvalidProof(anchors[3], witness1))
+
+	unhealthy, err = s.Unhealthy(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Equal(t, []string{witness1.String()}, unhealthy)
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 1, stats[witness1.String()].InvalidProofs)
+	require.Less(t, stats[witness1.String()].Score(), 0.5)
+	require.Equal(t, time.Minute, stats[witness1.String()].Backoff)
+	require.Equal(t, now.Add(time.Minute).Unix(), stats[witness1.String()].RetryAfter.Unix())
+
+	// The backoff is doubled on each failure up to the maximum.
+	require.NoError(t, s.Timeout(anchors[4], witness1))
+	require.NoError(t, s.Timeout(anchors[5], witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 3*time.Minute, stats[witness1.String()].Backoff)
+
+	// The witness may be retried after the backoff period.
+	s.now = func() time.Time { return now.Add(4 * time.Minute) }
+
+	unhealthy, err = s.Unhealthy(witness1.String())
+	require.NoError(t, err)
+	require.Empty(t, unhealthy)
+
+	// A proof that arrives after the timeout doesn't count as a success.
+	require.NoError(t, s.ProofReceived(anchors[5], witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Zero(t, stats[witness1.String()].Successes)
+	require.NotZero(t, stats[witness1.String()].Latency)
+
+	require.NoError(t, s.OffersSent("anchor7", []*url.URL{witness1}))
+	require.NoError(t, s.ProofReceived("anchor7", witness1))
+
+	stats, err = s.Get(witness1.String())
+	require.NoError(t, err)
+	require.Equal(t, 1, stats[witness1.String()].Successes)
+	require.Zero(t, stats[witness1.String()].Backoff)
+	require.Nil(t, stats[witness1.String()].RetryAfter)
+
+	all, err := s.GetAll()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, 1, all[witness1.String()].Successes)
+	require.Equal(t, 3, all[witness2.String()].Successes)
+	// The offers to witness2 were sent more than the offer lifespan ago.
+	require.Zero(t, all[witness2.String()].OutstandingOffers)
+
+	require.Equal(t, 5, metrics.responses[string(OutcomeTimeout)])
+	require.Equal(t, 1, metrics.responses[string(OutcomeInvalidProof)])
+	require.Equal(t, 4, metrics.responses[string(OutcomeSuccess)])
+
+	t.Run("health threshold disabled", func(t *testing.T) {
+		s, err := New(mem.NewProvider(), testutil.GetExpiryService(t), offerLifespan, WithHealthThreshold(0))
+		require.NoError(t, err)
+
+		for _, anchorID := range anchors {
+			require.NoError(t, s.InvalidProof(anchorID, witness1))
+		}
+
+		unhealthy, err := s.Unhealthy(witness1.String())
+		require.NoError(t, err)
+		require.Empty(t, unhealthy)
+	})
+}
+
+func TestStore_NextRoundRobin(t *testing.T) {
+	provider := mem.NewProvider()
+	expiryService := testutil.GetExpiryService(t)
+
+	s1, err := New(provider, expiryService, offerLifespan, WithInstanceID("instance1"))
+	require.NoError(t, err)
+
+	s2, err := New(provider, expiryService, offerLifespan, WithInstanceID("instance2"))
+	require.NoError(t, err)
+
+	// The position is shared by all instances.
+	position, err := s1.NextRoundRobin(2)
+	require.NoError(t, err)
+	require.Zero(t, position)
+
+	position, err = s2.NextRoundRobin(3)
+	require.NoError(t, err)
+	require.Equal(t, 2, position)
+
+	position, err = s1.NextRoundRobin(1)
+	require.NoError(t, err)
+	require.Equal(t, 5, position)
+
+	t.Run("invalid position", func(t *testing.T) {
+		store, err := provider.OpenStore(namespace)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(roundRobinKey, []byte("{")))
+
+		_, err = s1.NextRoundRobin(1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal round-robin position")
+	})
+}
+
+func TestStore_MultipleInstances(t *testing.T) {
+	const (
+		numInstances  = 3
+		numGoroutines = 5
+		numAnchors    = 20
+	)
+
+	witness1 := testutil.MustParseURL("https://domain1.com/services/orb")
+	witness2 := testutil.MustParseURL("https://domain2.com/services/orb")
+
+	provider := mem.NewProvider()
+	expiryService := testutil.GetExpiryService(t)
+
+	instances := make([]*Store, numInstances)
+
+	for i := range instances {
+		s, err := New(provider, expiryService, offerLifespan, WithInstanceID(fmt.Sprintf("instance%d", i)))
+		require.NoError(t, err)
+
+		instances[i] = s
+	}
+
+	var wg sync.WaitGroup
+
+	for i, s := range instances {
+		for g := 0; g < numGoroutines; g++ {
+			wg.Add(1)
+
+			go func(s *Store, prefix string) {
+				defer wg.Done()
+
+				for a := 0; a < numAnchors; a++ {
+					anchorID := fmt.Sprintf("%s-anchor%d", prefix, a)
+
+					require.NoError(t, s.OffersSent(anchorID, []*url.URL{witness1, witness2}))
+					require.NoError(t, s.ProofReceived(anchorID, witness1))
+					require.NoError(t, s.Timeout(anchorID, witness2))
+				}
+			}(s, fmt.Sprintf("instance%d-goroutine%d", i, g))
+		}
+	}
+
+	wg.Wait()
+
+	const expected = numInstances * numGoroutines * numAnchors
+
+	// Every instance returns the statistics of all instances and no updates are lost.
+	for _, s := range instances {
+		all, err := s.GetAll()
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		require.Equal(t, expected, all[witness1.String()].Successes)
+		require.Zero(t, all[witness1.String()].OutstandingOffers)
+		require.Zero(t, all[witness1.String()].FailureRate)
+
+		require.Equal(t, expected, all[witness2.String()].Timeouts)
+		require.Zero(t, all[witness2.String()].OutstandingOffers)
+		require.Greater(t, all[witness2.String()].FailureRate, 0.99)
+	}
+
+	// A witness that's unhealthy for one instance is skipped by all instances.
+	unhealthy, err := instances[1].Unhealthy(witness1.String(), witness2.String())
+	require.NoError(t, err)
+	require.Equal(t, []string{witness2.String()}, unhealthy)
+}
+
+func TestAggregate(t *testing.T) {
+	now := time.Now()
+	retryAfter1 := now.Add(time.Minute)
+	retryAfter2 := now.Add(time.Second)
+
+	stats := aggregate([]*Stats{
+		{
+			LastSelected: now.Add(-time.Minute), Latency: 2 * time.Second, Successes: 3, Timeouts: 1,
+			FailureRate: 0.2, Backoff: time.Minute, RetryAfter: &retryAfter1,
+		},
+		{
+			LastSelected: now, Latency: 6 * time.Second, Successes: 1, InvalidProofs: 1,
+			FailureRate: 0.6, RetryAfter: &retryAfter2,
+		},
+		{},
+	})
+
+	require.Equal(t, now, stats.LastSelected)
+	require.Equal(t, 3*time.Second, stats.Latency)
+	require.Equal(t, 4, stats.Successes)
+	require.Equal(t, 1, stats.Timeouts)
+	require.Equal(t, 1, stats.InvalidProofs)
+	require.InDelta(t, (4*0.2+2*0.6)/6, stats.FailureRate, 0.0001)
+	require.Equal(t, time.Minute, stats.Backoff)
+	require.Equal(t, retryAfter1, *stats.RetryAfter)
+
+	require.Nil(t, aggregate([]*Stats{{}, {}}).RetryAfter)
+
+	require.Equal(t, &Stats{}, aggregate(nil))
+}
+
+func TestStore_Error(t *testing.T) {
+	errExpected := errors.New("injected store error")
+
+	witness := testutil.MustParseURL("https://domain1.com/services/orb")
+
+	store := &mocks.Store{}
+	store.GetReturns(nil, errExpected)
+	store.QueryReturns(nil, errExpected)
+
+	provider := &mocks.Provider{}
+	provider.OpenStoreReturns(store, nil)
+
+	s, err := New(provider, testutil.GetExpiryService(t), offerLifespan)
+	require.NoError(t, err)
+
+	err = s.OffersSent("anchor1", []*url.URL{witness})
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+
+	err = s.ProofReceived("anchor1", witness)
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+
+	_, err = s.Get(witness.String())
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.InvalidProof("anchor1", witness)
+	require.ErrorIs(t, err, errExpected)
+
+	err = s.Timeout("anchor1", witness)
+	require.ErrorIs(t, err, errExpected)
+
+	_, err = s.Unhealthy(witness.String())
+	require.ErrorIs(t, err, errExpected)
+
+	_, err = s.GetAll()
+	require.ErrorIs(t, err, errExpected)
+
+	_, err = s.NextRoundRobin(1)
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+
+	store.GetReturns(nil, storage.ErrDataNotFound)
+	store.PutReturns(errExpected)
+
+	_, err = s.NextRoundRobin(1)
+	require.ErrorIs(t, err, errExpected)
+	require.True(t, orberrors.IsTransient(err))
+}
+
+type mockMetrics struct {
+	responses map[string]int
+}
+
+func (m *mockMetrics) WitnessResponse(_, outcome string) {
+	m.responses[outcome]++
+}
+
+func (m *mockMetrics) WitnessHealthScore(string, float64) {}
+
+func (m *mockMetrics) WitnessLatency(string, time.Duration) {}
@@ -69,18 +69,34 @@ func WithUnpublishedOperationStore(store unpublishedOperationStore, opTypes []op
 
 // Process persists all of the operations for the given anchor.
 func (p *TxnProcessor) Process(sidetreeTxn txn.SidetreeTxn, suffixes ...string) (int, error) { //nolint:gocritic
+	_, numProcessed, err := p.ProcessOperations(sidetreeTxn, suffixes...)
+
+	return numProcessed, err
+}
+
+// ProcessOperations persists all of the operations for the given anchor and returns the operations of the
+// anchor (for the given suffixes, if any) along with the number of operations that were persisted. Operations
+// that were previously persisted are also returned.
+func (p *TxnProcessor) ProcessOperations(sidetreeTxn txn.SidetreeTxn, //nolint:gocritic
+	suffixes ...string) ([]*operation.AnchoredOperation, int, error) {
 	logger.Debugf("processing sidetree txn:%+v", sidetreeTxn)
 
 	txnOps, err := p.OperationProtocolProvider.GetTxnOperations(&sidetreeTxn)
 	if err != nil {
-		return 0, fmt.Errorf("failed to retrieve operations for anchor string[%s]: %w", sidetreeTxn.AnchorString, err)
+		return nil, 0, fmt.Errorf("failed to retrieve operations for anchor string[%s]: %w",
+			sidetreeTxn.AnchorString, err)
 	}
 
 	if len(suffixes) > 0 {
 		txnOps = filterOps(txnOps, suffixes)
 	}
 
-	return p.processTxnOperations(txnOps, &sidetreeTxn)
+	numProcessed, err := p.processTxnOperations(txnOps, &sidetreeTxn)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return txnOps, numProcessed, nil
 }
 
 func filterOps(txnOps []*operation.AnchoredOperation, suffixes []string) []*operation.AnchoredOperation {
@@ -74,6 +74,58 @@ func TestTxnProcessor_Process(t *testing.T) {
 	})
 }
 
+func TestTxnProcessor_ProcessOperations(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		opStore := &mockOperationStore{}
+
+		providers := &Providers{
+			OpStore:                   opStore,
+			OperationProtocolProvider: &mockTxnOpsProvider{},
+		}
+
+		p := New(providers)
+
+		ops, numProcessed, err := p.ProcessOperations(txn.SidetreeTxn{AnchorString: anchorString}, suffix)
+		require.NoError(t, err)
+		require.Equal(t, 1, numProcessed)
+		require.Len(t, ops, 1)
+		require.Equal(t, suffix, ops[0].UniqueSuffix)
+
+		// The operations are returned even if they were already processed.
+		opStore.getFunc = func(string) ([]*operation.AnchoredOperation, error) {
+			return []*operation.AnchoredOperation{{CanonicalReference: canonicalRef}}, nil
+		}
+
+		ops, numProcessed, err = p.ProcessOperations(
+			txn.SidetreeTxn{AnchorString: anchorString, CanonicalReference: canonicalRef})
+		require.NoError(t, err)
+		require.Zero(t, numProcessed)
+		require.Len(t, ops, 1)
+
+		ops, numProcessed, err = p.ProcessOperations(txn.SidetreeTxn{AnchorString: anchorString}, "different")
+		require.NoError(t, err)
+		require.Zero(t, numProcessed)
+		require.Empty(t, ops)
+	})
+
+	t.Run("error - error from operation store", func(t *testing.T) {
+		errExpected := fmt.Errorf("put error")
+
+		providers := &Providers{
+			OpStore: &mockOperationStore{
+				putFunc: func([]*operation.AnchoredOperation) error { return errExpected },
+			},
+			OperationProtocolProvider: &mockTxnOpsProvider{},
+		}
+
+		p := New(providers)
+
+		ops, _, err := p.ProcessOperations(txn.SidetreeTxn{})
+		require.ErrorIs(t, err, errExpected)
+		require.Empty(t, ops)
+	})
+}
+
 func TestProcessTxnOperations(t *testing.T) {
 	t.Run("test error from operationStore Put", func(t *testing.T) {
 		providers := &Providers{
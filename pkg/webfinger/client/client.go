@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/bluele/gcache"
@@ -206,6 +207,39 @@ func (c *Client) GetWebCASURL(domainWithScheme, cid string) (*url.URL, error) {
 	return webCASURL, nil
 }
 
+// ResolveActor resolves the given 'acct' URI (e.g. acct:orb@orb.domain1.com) or fediverse handle
+// (e.g. orb@orb.domain1.com) to the IRI of an ActivityPub actor. The WebFinger query is sent to the
+// host in the handle using HTTPS.
+func (c *Client) ResolveActor(acct string) (*url.URL, error) {
+	user, host, err := model.ParseAcct(acct)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := model.NewAcct(user, host)
+
+	jrd, err := c.ResolveWebFingerResource("https://"+host, resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve WebFinger resource [%s]: %w", resource, err)
+	}
+
+	for _, link := range jrd.Links {
+		if link.Rel != "self" || !isActivityPubType(link.Type) {
+			continue
+		}
+
+		actorIRI, err := url.Parse(link.Href)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse actor IRI [%s] for resource [%s]: %w", link.Href, resource, err)
+		}
+
+		return actorIRI, nil
+	}
+
+	return nil, fmt.Errorf("actor not found in WebFinger response for resource [%s]: %w",
+		resource, model.ErrResourceNotFound)
+}
+
 // Option is a webfinger client instance option.
 type Option func(opts *Client)
 
@@ -232,6 +266,21 @@ func WithCacheSize(size int) Option {
 	}
 }
 
+// isActivityPubType returns true if the given media type refers to an ActivityPub object, i.e. either
+// application/activity+json or application/ld+json with the ActivityStreams profile.
+func isActivityPubType(mediaType string) bool {
+	const activityStreamsProfile = "https://www.w3.org/ns/activitystreams"
+
+	mediaType = strings.TrimSpace(mediaType)
+
+	if mediaType == restapi.ActivityJSONType {
+		return true
+	}
+
+	return strings.HasPrefix(mediaType, "application/ld+json") &&
+		strings.Contains(mediaType, activityStreamsProfile)
+}
+
 func contains(l []string, e string) bool {
 	for _, s := range l {
 		if s == e {
@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/trustbloc/orb/pkg/cas/resolver/mocks"
 	discoveryrest "github.com/trustbloc/orb/pkg/discovery/endpoint/restapi"
 	orbmocks "github.com/trustbloc/orb/pkg/mocks"
+	"github.com/trustbloc/orb/pkg/webfinger/model"
 )
 
 func TestNew(t *testing.T) {
@@ -378,6 +380,97 @@ func TestGetWebCASURL(t *testing.T) {
 	})
 }
 
+func TestResolveActor(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		router := mux.NewRouter()
+
+		testServer := httptest.NewTLSServer(router)
+		defer testServer.Close()
+
+		operations, err := discoveryrest.New(
+			&discoveryrest.Config{BaseURL: testServer.URL, WebCASPath: "/cas"},
+			&discoveryrest.Providers{},
+		)
+		require.NoError(t, err)
+
+		router.HandleFunc(operations.GetRESTHandlers()[1].Path(), operations.GetRESTHandlers()[1].Handler())
+
+		webFingerClient := New(WithHTTPClient(testServer.Client()))
+
+		host := strings.TrimPrefix(testServer.URL, "https://")
+
+		for _, acct := range []string{"acct:orb@" + host, "orb@" + host, "@orb@" + host} {
+			actorIRI, err := webFingerClient.ResolveActor(acct)
+			require.NoError(t, err)
+			require.Equal(t, testServer.URL+"/services/orb", actorIRI.String())
+		}
+
+		_, err = webFingerClient.ResolveActor("acct:alice@" + host)
+		require.ErrorIs(t, err, model.ErrResourceNotFound)
+	})
+
+	t.Run("ActivityStreams profile", func(t *testing.T) {
+		httpClient := httpMock(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "https://orb.domain1.com/.well-known/webfinger?resource=acct:orb@orb.domain1.com",
+				req.URL.String())
+
+			return &http.Response{
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"links":[` +
+					`{"rel":"http://webfinger.net/rel/profile-page","type":"text/html","href":"https://orb.domain1.com"},` +
+					`{"rel":"self","type":"application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",` +
+					`"href":"https://orb.domain1.com/services/orb"}]}`)),
+				StatusCode: http.StatusOK,
+			}, nil
+		})
+
+		actorIRI, err := New(WithHTTPClient(httpClient)).ResolveActor("acct:orb@orb.domain1.com")
+		require.NoError(t, err)
+		require.Equal(t, "https://orb.domain1.com/services/orb", actorIRI.String())
+	})
+
+	t.Run("Invalid acct", func(t *testing.T) {
+		_, err := New().ResolveActor("acct:orb")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid acct URI")
+	})
+
+	t.Run("No actor link", func(t *testing.T) {
+		httpClient := httpMock(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"links":[{"rel":"self","href":"https://x"}]}`)),
+				StatusCode: http.StatusOK,
+			}, nil
+		})
+
+		_, err := New(WithHTTPClient(httpClient)).ResolveActor("acct:orb@orb.domain1.com")
+		require.ErrorIs(t, err, model.ErrResourceNotFound)
+	})
+
+	t.Run("Invalid actor IRI", func(t *testing.T) {
+		httpClient := httpMock(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				Body: ioutil.NopCloser(bytes.NewBufferString(
+					`{"links":[{"rel":"self","type":"application/activity+json","href":"%"}]}`)),
+				StatusCode: http.StatusOK,
+			}, nil
+		})
+
+		_, err := New(WithHTTPClient(httpClient)).ResolveActor("acct:orb@orb.domain1.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse actor IRI")
+	})
+
+	t.Run("HTTP error", func(t *testing.T) {
+		httpClient := httpMock(func(req *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("injected HTTP error")
+		})
+
+		_, err := New(WithHTTPClient(httpClient)).ResolveActor("acct:orb@orb.domain1.com")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "injected HTTP error")
+	})
+}
+
 type httpMock func(req *http.Request) (*http.Response, error)
 
 func (m httpMock) Do(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AcctScheme is the prefix of an 'acct' URI as defined in https://datatracker.ietf.org/doc/html/rfc7565.
+const AcctScheme = "acct:"
+
+// IsAcct returns true if the given value is an 'acct' URI (e.g. acct:orb@orb.domain1.com) or a
+// fediverse handle (e.g. orb@orb.domain1.com or @orb@orb.domain1.com) rather than an IRI.
+func IsAcct(value string) bool {
+	if strings.HasPrefix(value, AcctScheme) {
+		return true
+	}
+
+	return !strings.Contains(value, "://") && strings.Contains(value, "@")
+}
+
+// ParseAcct parses the given 'acct' URI or fediverse handle and returns the user and host.
+func ParseAcct(value string) (user, host string, err error) {
+	acct := strings.TrimPrefix(strings.TrimPrefix(value, AcctScheme), "@")
+
+	i := strings.LastIndex(acct, "@")
+	if i <= 0 || i == len(acct)-1 {
+		return "", "", fmt.Errorf("invalid acct URI [%s]: expecting user@host", value)
+	}
+
+	user, host = acct[:i], acct[i+1:]
+
+	if strings.ContainsAny(user, "/@") || strings.ContainsAny(host, "/?#@") {
+		return "", "", fmt.Errorf("invalid acct URI [%s]: expecting user@host", value)
+	}
+
+	return user, strings.ToLower(host), nil
+}
+
+// NewAcct returns an 'acct' URI for the given user and host.
+func NewAcct(user, host string) string {
+	return fmt.Sprintf("%s%s@%s", AcctScheme, user, strings.ToLower(host))
+}
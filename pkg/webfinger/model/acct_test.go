@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAcct(t *testing.T) {
+	require.True(t, IsAcct("acct:orb@orb.domain1.com"))
+	require.True(t, IsAcct("orb@orb.domain1.com"))
+	require.True(t, IsAcct("@orb@orb.domain1.com"))
+	require.False(t, IsAcct("https://orb.domain1.com/services/orb"))
+	require.False(t, IsAcct("https://user@orb.domain1.com/services/orb"))
+}
+
+func TestParseAcct(t *testing.T) {
+	for _, value := range []string{"acct:orb@orb.domain1.com", "orb@Orb.Domain1.com", "@orb@orb.domain1.com"} {
+		user, host, err := ParseAcct(value)
+		require.NoError(t, err)
+		require.Equal(t, "orb", user)
+		require.Equal(t, "orb.domain1.com", host)
+	}
+
+	for _, value := range []string{
+		"acct:orb.domain1.com", "acct:@orb.domain1.com", "acct:orb@", "orb@orb.domain1.com/services",
+		"a@b@orb.domain1.com",
+	} {
+		_, _, err := ParseAcct(value)
+		require.Errorf(t, err, "expecting error for %s", value)
+		require.Contains(t, err.Error(), "invalid acct URI")
+	}
+
+	require.Equal(t, "acct:orb@orb.domain1.com", NewAcct("orb", "ORB.domain1.com"))
+}
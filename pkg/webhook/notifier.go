@@ -0,0 +1,303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/lifecycle"
+	"github.com/trustbloc/orb/pkg/pubsub/spi"
+)
+
+var logger = log.New("orb-webhook")
+
+const (
+	topic = "orb.webhook"
+
+	metadataSendTo         = "send_to"
+	metadataSubscriptionID = "subscription_id"
+
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+
+	defaultSubscriberPoolSize = 5
+	defaultRequestTimeout     = 10 * time.Second
+)
+
+type pubSub interface {
+	SubscribeWithOpts(ctx context.Context, topic string, opts ...spi.Option) (<-chan *message.Message, error)
+	Publish(topic string, messages ...*message.Message) error
+}
+
+type subscriptionStore interface {
+	Get(id string) (*Subscription, error)
+	GetAll() ([]*Subscription, error)
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type signer interface {
+	SignRequest(pubKeyID string, req *http.Request) error
+}
+
+// Option is a notifier option.
+type Option func(n *Notifier)
+
+// WithSubscriberPoolSize sets the size of the message queue subscriber pool.
+func WithSubscriberPoolSize(value int) Option {
+	return func(n *Notifier) {
+		n.poolSize = value
+	}
+}
+
+// WithRequestTimeout sets the timeout of an HTTP request to a subscriber's callback URL.
+func WithRequestTimeout(value time.Duration) Option {
+	return func(n *Notifier) {
+		n.requestTimeout = value
+	}
+}
+
+// Notifier sends notifications to the callback URLs of webhook subscriptions when DID operations are anchored.
+// A notification is first published to a message queue and then posted (with an HTTP signature) to the callback
+// URL by a subscriber of the queue. If the callback URL can't be reached or the server responds with a 5xx or 429
+// status code then the message is nacked so that delivery is retried by the message queue.
+type Notifier struct {
+	*lifecycle.Lifecycle
+
+	publisher      pubSub
+	msgChan        <-chan *message.Message
+	store          subscriptionStore
+	client         httpClient
+	signer         signer
+	publicKeyID    string
+	poolSize       int
+	requestTimeout time.Duration
+	jsonMarshal    func(v interface{}) ([]byte, error)
+	now            func() time.Time
+}
+
+// New returns a new webhook notifier. The notifications are signed using the given signer and public key ID.
+func New(pubSub pubSub, store subscriptionStore, client httpClient, signer signer, publicKeyID *url.URL,
+	opts ...Option) (*Notifier, error) {
+	n := &Notifier{
+		publisher:      pubSub,
+		store:          store,
+		client:         client,
+		signer:         signer,
+		publicKeyID:    publicKeyID.String(),
+		poolSize:       defaultSubscriberPoolSize,
+		requestTimeout: defaultRequestTimeout,
+		jsonMarshal:    json.Marshal,
+		now:            time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.Lifecycle = lifecycle.New("webhook-notifier",
+		lifecycle.WithStart(n.start),
+	)
+
+	logger.Infof("Subscribing to topic [%s]", topic)
+
+	msgChan, err := pubSub.SubscribeWithOpts(context.Background(), topic, spi.WithPool(n.poolSize))
+	if err != nil {
+		return nil, fmt.Errorf("subscribe to topic [%s]: %w", topic, err)
+	}
+
+	n.msgChan = msgChan
+
+	return n, nil
+}
+
+// Notify publishes a notification to the message queue for each subscription that matches the given
+// anchored operations. A notification is published for every matching subscription even if a previous
+// publish failed, and the errors are returned together. The returned error is transient if any of the
+// errors is transient.
+func (n *Notifier) Notify(anchorHashlink, anchorOrigin string, ops []*operation.AnchoredOperation) error {
+	if n.State() != lifecycle.StateStarted {
+		return lifecycle.ErrNotStarted
+	}
+
+	subscriptions, err := n.store.GetAll()
+	if err != nil {
+		return fmt.Errorf("get webhook subscriptions: %w", err)
+	}
+
+	var isTransient bool
+
+	var errMsgs []string
+
+	for _, s := range subscriptions {
+		matched := s.Match(anchorOrigin, ops)
+		if len(matched) == 0 {
+			continue
+		}
+
+		if err := n.publish(s, anchorHashlink, anchorOrigin, matched); err != nil {
+			logger.Warnf("Error publishing notification for subscription [%s]: %s", s.ID, err)
+
+			errMsgs = append(errMsgs, fmt.Sprintf("subscription[%s]: %s", s.ID, err))
+			isTransient = isTransient || orberrors.IsTransient(err)
+		}
+	}
+
+	if len(errMsgs) == 0 {
+		return nil
+	}
+
+	err = fmt.Errorf("publish notifications: %s", errMsgs)
+
+	if isTransient {
+		return orberrors.NewTransient(err)
+	}
+
+	return err
+}
+
+func (n *Notifier) publish(s *Subscription, anchorHashlink, anchorOrigin string, ops []*Operation) error {
+	notification := &Notification{
+		ID:             notificationID(anchorHashlink, s.ID),
+		SubscriptionID: s.ID,
+		Anchor:         anchorHashlink,
+		AnchorOrigin:   anchorOrigin,
+		Operations:     ops,
+		Created:        n.now(),
+	}
+
+	payload, err := n.jsonMarshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	msg := message.NewMessage(notification.ID, payload)
+	msg.Metadata.Set(metadataSendTo, s.CallbackURL)
+	msg.Metadata.Set(metadataSubscriptionID, s.ID)
+
+	logger.Debugf("Publishing notification [%s] for subscription [%s] to topic [%s]: %s",
+		msg.UUID, s.ID, topic, msg.Payload)
+
+	if err := n.publisher.Publish(topic, msg); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("publish notification [%s]: %w", msg.UUID, err))
+	}
+
+	return nil
+}
+
+// notificationID returns an ID that's derived from the anchor and the subscription so that a notification
+// that's published again (when the anchor is redelivered) has the same ID as the original notification.
+func notificationID(anchorHashlink, subscriptionID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(anchorHashlink+"#"+subscriptionID)).String()
+}
+
+func (n *Notifier) start() {
+	go n.listen()
+}
+
+func (n *Notifier) listen() {
+	logger.Debugf("Starting message listener")
+
+	for msg := range n.msgChan {
+		logger.Debugf("Got new notification message [%s]: %s", msg.UUID, msg.Payload)
+
+		n.handleMessage(msg)
+	}
+
+	logger.Debugf("Message listener stopped")
+}
+
+func (n *Notifier) handleMessage(msg *message.Message) {
+	err := n.deliver(msg)
+
+	switch {
+	case err == nil:
+		logger.Debugf("Acking notification message [%s]", msg.UUID)
+
+		msg.Ack()
+	case orberrors.IsTransient(err):
+		// The message should be redelivered to (potentially) another server instance.
+		logger.Warnf("Nacking notification message [%s] since it could not be delivered due to a transient error: %s",
+			msg.UUID, err)
+
+		msg.Nack()
+	default:
+		logger.Warnf("Acking notification message [%s] since it could not be delivered due to a persistent error: %s",
+			msg.UUID, err)
+
+		msg.Ack()
+	}
+}
+
+func (n *Notifier) deliver(msg *message.Message) error {
+	subscriptionID := msg.Metadata[metadataSubscriptionID]
+
+	_, err := n.store.Get(subscriptionID)
+	if err != nil {
+		if errors.Is(err, orberrors.ErrContentNotFound) {
+			logger.Infof("Not delivering notification [%s] since subscription [%s] was deleted",
+				msg.UUID, subscriptionID)
+
+			return nil
+		}
+
+		return fmt.Errorf("get subscription [%s]: %w", subscriptionID, err)
+	}
+
+	callbackURL := msg.Metadata[metadataSendTo]
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewBuffer(msg.Payload))
+	if err != nil {
+		return fmt.Errorf("new request to [%s]: %w", callbackURL, err)
+	}
+
+	req.Header.Set(contentTypeHeader, jsonContentType)
+
+	if err := n.signer.SignRequest(n.publicKeyID, req); err != nil {
+		return orberrors.NewTransient(fmt.Errorf("sign request to [%s]: %w", callbackURL, err))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return orberrors.NewTransient(fmt.Errorf("post notification to [%s]: %w", callbackURL, err))
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		logger.Warnf("Error closing response body: %s", err)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return orberrors.NewTransient(fmt.Errorf("server at [%s] responded with error %d - %s",
+			callbackURL, resp.StatusCode, resp.Status))
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("server at [%s] responded with error %d - %s", callbackURL, resp.StatusCode, resp.Status)
+	}
+
+	logger.Debugf("Delivered notification [%s] to [%s]", msg.UUID, callbackURL)
+
+	return nil
+}
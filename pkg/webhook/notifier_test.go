@@ -0,0 +1,324 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/internal/testutil"
+	"github.com/trustbloc/orb/pkg/lifecycle"
+	orbmocks "github.com/trustbloc/orb/pkg/mocks"
+	"github.com/trustbloc/orb/pkg/pubsub/mempubsub"
+)
+
+const anchorHashlink = "hl:uEiB5sZH1-ZEY0QDRbFgOrGQZqb95A95q5VWNVBBzxAJMCA"
+
+var publicKeyID = testutil.MustParseURL("https://orb.domain1.com/services/orb/keys/main-key")
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		n, err := New(mempubsub.New(mempubsub.DefaultConfig()), &mockSubscriptionStore{}, http.DefaultClient,
+			&mockSigner{}, publicKeyID, WithSubscriberPoolSize(2), WithRequestTimeout(time.Second))
+		require.NoError(t, err)
+		require.NotNil(t, n)
+		require.Equal(t, 2, n.poolSize)
+		require.Equal(t, time.Second, n.requestTimeout)
+	})
+
+	t.Run("subscribe error", func(t *testing.T) {
+		errExpected := errors.New("injected subscribe error")
+
+		ps := &orbmocks.PubSub{}
+		ps.SubscribeWithOptsReturns(nil, errExpected)
+
+		n, err := New(ps, &mockSubscriptionStore{}, http.DefaultClient, &mockSigner{}, publicKeyID)
+		require.ErrorIs(t, err, errExpected)
+		require.Nil(t, n)
+	})
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	ops := []*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: suffix1},
+		{Type: operation.TypeUpdate, UniqueSuffix: suffix2},
+	}
+
+	notificationChan := make(chan *Notification, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, jsonContentType, r.Header.Get(contentTypeHeader))
+		require.Equal(t, publicKeyID.String(), r.Header.Get("Signature"))
+
+		notification := &Notification{}
+		require.NoError(t, json.Unmarshal(body, notification))
+
+		notificationChan <- notification
+	}))
+	defer server.Close()
+
+	store := &mockSubscriptionStore{
+		subscriptions: map[string]*Subscription{
+			"s1": {
+				ID:          "s1",
+				CallbackURL: server.URL,
+				Filter:      &Filter{OperationTypes: []operation.Type{operation.TypeUpdate}},
+			},
+			"s2": {
+				ID:          "s2",
+				CallbackURL: server.URL,
+				Filter:      &Filter{AnchorOrigins: []string{origin2}},
+			},
+		},
+	}
+
+	ps := mempubsub.New(mempubsub.DefaultConfig())
+	defer ps.Stop()
+
+	n, err := New(ps, store, http.DefaultClient, &mockSigner{}, publicKeyID)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, n.Notify(anchorHashlink, origin1, ops), lifecycle.ErrNotStarted)
+
+	n.Start()
+	defer n.Stop()
+
+	require.NoError(t, n.Notify(anchorHashlink, origin1, ops))
+
+	var id string
+
+	select {
+	case notification := <-notificationChan:
+		require.NotEmpty(t, notification.ID)
+		require.Equal(t, "s1", notification.SubscriptionID)
+		require.Equal(t, anchorHashlink, notification.Anchor)
+		require.Equal(t, origin1, notification.AnchorOrigin)
+		require.Len(t, notification.Operations, 1)
+		require.Equal(t, suffix2, notification.Operations[0].Suffix)
+
+		id = notification.ID
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+
+	// A notification that's published again for the same anchor has the same ID.
+	require.NoError(t, n.Notify(anchorHashlink, origin1, ops))
+
+	select {
+	case notification := <-notificationChan:
+		require.Equal(t, id, notification.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+
+	require.NotEqual(t, id, notificationID(anchorHashlink, "s2"))
+
+	select {
+	case notification := <-notificationChan:
+		t.Fatalf("unexpected notification for subscription [%s]", notification.SubscriptionID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	t.Run("store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		n.store = &mockSubscriptionStore{err: errExpected}
+		defer func() { n.store = store }()
+
+		require.ErrorIs(t, n.Notify(anchorHashlink, origin1, ops), errExpected)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		errExpected := errors.New("injected marshal error")
+
+		n.jsonMarshal = func(v interface{}) ([]byte, error) { return nil, errExpected }
+		defer func() { n.jsonMarshal = json.Marshal }()
+
+		err := n.Notify(anchorHashlink, origin1, ops)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errExpected.Error())
+		require.False(t, orberrors.IsTransient(err))
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		errExpected := errors.New("injected publish error")
+
+		ps := &orbmocks.PubSub{}
+		ps.PublishReturns(errExpected)
+
+		n.publisher = ps
+
+		// Both subscriptions match the operations from origin2 so a publish should be attempted for each of them.
+		err := n.Notify(anchorHashlink, origin2, ops)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "subscription[s1]: publish notification")
+		require.Contains(t, err.Error(), "subscription[s2]: publish notification")
+		require.Contains(t, err.Error(), errExpected.Error())
+		require.True(t, orberrors.IsTransient(err))
+		require.Equal(t, 2, ps.PublishCallCount())
+	})
+}
+
+func TestNotifier_HandleMessage(t *testing.T) {
+	var statusCode int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	store := &mockSubscriptionStore{
+		subscriptions: map[string]*Subscription{
+			"s1": {ID: "s1", CallbackURL: server.URL},
+		},
+	}
+
+	n, err := New(mempubsub.New(mempubsub.DefaultConfig()), store, http.DefaultClient, &mockSigner{}, publicKeyID)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		statusCode = http.StatusOK
+
+		requireAcked(t, n, newMessage("s1", server.URL))
+	})
+
+	t.Run("subscription deleted", func(t *testing.T) {
+		requireAcked(t, n, newMessage("s2", server.URL))
+	})
+
+	t.Run("server error -> nack", func(t *testing.T) {
+		statusCode = http.StatusServiceUnavailable
+
+		requireNacked(t, n, newMessage("s1", server.URL))
+	})
+
+	t.Run("too many requests -> nack", func(t *testing.T) {
+		statusCode = http.StatusTooManyRequests
+
+		requireNacked(t, n, newMessage("s1", server.URL))
+	})
+
+	t.Run("client error -> ack", func(t *testing.T) {
+		statusCode = http.StatusBadRequest
+
+		requireAcked(t, n, newMessage("s1", server.URL))
+	})
+
+	t.Run("connection error -> nack", func(t *testing.T) {
+		requireNacked(t, n, newMessage("s1", "http://localhost:1"))
+	})
+
+	t.Run("invalid callback URL -> ack", func(t *testing.T) {
+		requireAcked(t, n, newMessage("s1", "http://[::1]:namedport"))
+	})
+
+	t.Run("sign error -> nack", func(t *testing.T) {
+		n.signer = &mockSigner{err: errors.New("injected sign error")}
+		defer func() { n.signer = &mockSigner{} }()
+
+		requireNacked(t, n, newMessage("s1", server.URL))
+	})
+
+	t.Run("store error -> nack", func(t *testing.T) {
+		n.store = &mockSubscriptionStore{err: orberrors.NewTransient(errors.New("injected store error"))}
+		defer func() { n.store = store }()
+
+		requireNacked(t, n, newMessage("s1", server.URL))
+	})
+}
+
+func newMessage(subscriptionID, callbackURL string) *message.Message {
+	msg := message.NewMessage("msg1", []byte(`{}`))
+	msg.Metadata.Set(metadataSubscriptionID, subscriptionID)
+	msg.Metadata.Set(metadataSendTo, callbackURL)
+
+	return msg
+}
+
+func requireAcked(t *testing.T, n *Notifier, msg *message.Message) {
+	t.Helper()
+
+	n.handleMessage(msg)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Fatal("expecting message to be acked")
+	}
+}
+
+func requireNacked(t *testing.T, n *Notifier, msg *message.Message) {
+	t.Helper()
+
+	n.handleMessage(msg)
+
+	select {
+	case <-msg.Nacked():
+	default:
+		t.Fatal("expecting message to be nacked")
+	}
+}
+
+type mockSubscriptionStore struct {
+	subscriptions map[string]*Subscription
+	err           error
+}
+
+func (m *mockSubscriptionStore) Get(id string) (*Subscription, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	s, ok := m.subscriptions[id]
+	if !ok {
+		return nil, fmt.Errorf("subscription [%s]: %w", id, orberrors.ErrContentNotFound)
+	}
+
+	return s, nil
+}
+
+func (m *mockSubscriptionStore) GetAll() ([]*Subscription, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var subscriptions []*Subscription
+
+	for _, s := range m.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+
+	return subscriptions, nil
+}
+
+type mockSigner struct {
+	err error
+}
+
+func (m *mockSigner) SignRequest(pubKeyID string, req *http.Request) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	req.Header.Set("Signature", pubKeyID)
+
+	return nil
+}
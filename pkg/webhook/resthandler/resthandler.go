@@ -0,0 +1,290 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/gorilla/mux"
+	"github.com/trustbloc/edge-core/pkg/log"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+
+	orberrors "github.com/trustbloc/orb/pkg/errors"
+	"github.com/trustbloc/orb/pkg/webhook"
+)
+
+const (
+	endpoint       = "/webhook/subscriptions"
+	idPathVariable = "id"
+)
+
+const (
+	notFoundResponse            = "Content Not Found."
+	internalServerErrorResponse = "Internal Server Error."
+)
+
+var logger = log.New("webhook-rest-handler")
+
+type subscriptionStore interface {
+	Put(subscription *webhook.Subscription) error
+	GetAll() ([]*webhook.Subscription, error)
+	Delete(id string) error
+}
+
+// SubscriptionCreator implements a REST handler that registers a webhook subscription. The request body
+// contains the callback URL and an optional filter, e.g.
+// {"callbackUrl":"https://example.com/callback","filter":{"operationTypes":["create","update"]}}.
+// The response contains the stored subscription, including its generated ID.
+type SubscriptionCreator struct {
+	store   subscriptionStore
+	readAll func(r io.Reader) ([]byte, error)
+	marshal func(interface{}) ([]byte, error)
+	newID   func() string
+	now     func() time.Time
+}
+
+// NewSubscriptionCreator returns a new SubscriptionCreator.
+func NewSubscriptionCreator(store subscriptionStore) *SubscriptionCreator {
+	return &SubscriptionCreator{
+		store:   store,
+		readAll: ioutil.ReadAll,
+		marshal: json.Marshal,
+		newID:   watermill.NewUUID,
+		now:     time.Now,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the SubscriptionCreator service.
+func (h *SubscriptionCreator) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the SubscriptionCreator service.
+func (h *SubscriptionCreator) Method() string {
+	return http.MethodPost
+}
+
+// Handler returns the HTTP REST handle for the SubscriptionCreator service.
+func (h *SubscriptionCreator) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *SubscriptionCreator) handle(w http.ResponseWriter, req *http.Request) {
+	reqBytes, err := h.readAll(req.Body)
+	if err != nil {
+		logger.Errorf("[%s] Error reading request body: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	subscription := &webhook.Subscription{}
+
+	if err := json.Unmarshal(reqBytes, subscription); err != nil {
+		logger.Infof("[%s] Invalid subscription request: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("invalid subscription request: %s", err)))
+
+		return
+	}
+
+	if err := validate(subscription); err != nil {
+		logger.Infof("[%s] Invalid subscription request: %s", endpoint, err)
+
+		writeResponse(w, http.StatusBadRequest, []byte(err.Error()))
+
+		return
+	}
+
+	subscription.ID = h.newID()
+	subscription.Created = h.now()
+
+	respBytes, err := h.marshal(subscription)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling subscription: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	if err := h.store.Put(subscription); err != nil {
+		logger.Errorf("[%s] Error storing subscription: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Infof("[%s] Created webhook subscription [%s] for callback URL [%s]",
+		endpoint, subscription.ID, subscription.CallbackURL)
+
+	writeResponse(w, http.StatusOK, respBytes)
+}
+
+// SubscriptionsRetriever implements a REST handler that returns all webhook subscriptions.
+type SubscriptionsRetriever struct {
+	store   subscriptionStore
+	marshal func(interface{}) ([]byte, error)
+}
+
+// NewSubscriptionsRetriever returns a new SubscriptionsRetriever.
+func NewSubscriptionsRetriever(store subscriptionStore) *SubscriptionsRetriever {
+	return &SubscriptionsRetriever{
+		store:   store,
+		marshal: json.Marshal,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the SubscriptionsRetriever service.
+func (h *SubscriptionsRetriever) Path() string {
+	return endpoint
+}
+
+// Method returns the HTTP REST method for the SubscriptionsRetriever service.
+func (h *SubscriptionsRetriever) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the HTTP REST handle for the SubscriptionsRetriever service.
+func (h *SubscriptionsRetriever) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *SubscriptionsRetriever) handle(w http.ResponseWriter, _ *http.Request) {
+	subscriptions, err := h.store.GetAll()
+	if err != nil {
+		logger.Errorf("[%s] Error retrieving subscriptions: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	if subscriptions == nil {
+		subscriptions = []*webhook.Subscription{}
+	}
+
+	respBytes, err := h.marshal(subscriptions)
+	if err != nil {
+		logger.Errorf("[%s] Error marshalling subscriptions: %s", endpoint, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	writeResponse(w, http.StatusOK, respBytes)
+}
+
+// SubscriptionDeleter implements a REST handler that deletes a webhook subscription.
+type SubscriptionDeleter struct {
+	store subscriptionStore
+}
+
+// NewSubscriptionDeleter returns a new SubscriptionDeleter.
+func NewSubscriptionDeleter(store subscriptionStore) *SubscriptionDeleter {
+	return &SubscriptionDeleter{
+		store: store,
+	}
+}
+
+// Path returns the HTTP REST endpoint for the SubscriptionDeleter service.
+func (h *SubscriptionDeleter) Path() string {
+	return fmt.Sprintf("%s/{%s}", endpoint, idPathVariable)
+}
+
+// Method returns the HTTP REST method for the SubscriptionDeleter service.
+func (h *SubscriptionDeleter) Method() string {
+	return http.MethodDelete
+}
+
+// Handler returns the HTTP REST handle for the SubscriptionDeleter service.
+func (h *SubscriptionDeleter) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *SubscriptionDeleter) handle(w http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)[idPathVariable]
+
+	if err := h.store.Delete(id); err != nil {
+		if errors.Is(err, orberrors.ErrContentNotFound) {
+			logger.Debugf("[%s] Subscription not found [%s]", endpoint, id)
+
+			writeResponse(w, http.StatusNotFound, []byte(notFoundResponse))
+
+			return
+		}
+
+		logger.Errorf("[%s] Error deleting subscription [%s]: %s", endpoint, id, err)
+
+		writeResponse(w, http.StatusInternalServerError, []byte(internalServerErrorResponse))
+
+		return
+	}
+
+	logger.Infof("[%s] Deleted webhook subscription [%s]", endpoint, id)
+
+	writeResponse(w, http.StatusOK, nil)
+}
+
+func validate(subscription *webhook.Subscription) error {
+	u, err := url.Parse(subscription.CallbackURL)
+	if err != nil {
+		return fmt.Errorf("invalid callback URL [%s]: %w", subscription.CallbackURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("callback URL [%s] must be an absolute HTTP(S) URL", subscription.CallbackURL)
+	}
+
+	if subscription.Filter == nil {
+		return nil
+	}
+
+	for _, t := range subscription.Filter.OperationTypes {
+		switch t {
+		case operation.TypeCreate, operation.TypeUpdate, operation.TypeRecover, operation.TypeDeactivate:
+		default:
+			return fmt.Errorf("invalid operation type [%s]", t)
+		}
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, body []byte) {
+	if len(body) > 0 {
+		if status == http.StatusOK {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/plain")
+		}
+	}
+
+	w.WriteHeader(status)
+
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			logger.Warnf("[%s] Unable to write response: %s", endpoint, err)
+
+			return
+		}
+
+		logger.Debugf("[%s] Wrote response: %s", endpoint, body)
+	}
+}
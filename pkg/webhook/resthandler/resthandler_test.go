@@ -0,0 +1,230 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resthandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+
+	"github.com/trustbloc/orb/pkg/store/webhooksubscription"
+	"github.com/trustbloc/orb/pkg/webhook"
+)
+
+const (
+	callbackURL = "https://example.com/callback"
+	suffix1     = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+)
+
+func TestSubscriptionCreator(t *testing.T) {
+	store, err := webhooksubscription.New(mem.NewProvider())
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		h := NewSubscriptionCreator(store)
+		require.Equal(t, endpoint, h.Path())
+		require.Equal(t, http.MethodPost, h.Method())
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString(
+			`{"callbackUrl":"`+callbackURL+`","filter":{"suffixes":["`+suffix1+`"],"operationTypes":["update"]}}`,
+		)))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		subscription := &webhook.Subscription{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), subscription))
+		require.NotEmpty(t, subscription.ID)
+		require.False(t, subscription.Created.IsZero())
+		require.Equal(t, callbackURL, subscription.CallbackURL)
+		require.Equal(t, []string{suffix1}, subscription.Filter.Suffixes)
+		require.Equal(t, []operation.Type{operation.TypeUpdate}, subscription.Filter.OperationTypes)
+
+		stored, err := store.Get(subscription.ID)
+		require.NoError(t, err)
+		require.Equal(t, callbackURL, stored.CallbackURL)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		h := NewSubscriptionCreator(store)
+
+		for _, body := range []string{
+			`{`,
+			`{}`,
+			`{"callbackUrl":"/callback"}`,
+			`{"callbackUrl":"ftp://example.com/callback"}`,
+			`{"callbackUrl":"https://example.com/callback","filter":{"operationTypes":["transfer"]}}`,
+		} {
+			rw := httptest.NewRecorder()
+
+			h.handle(rw, httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString(body)))
+
+			result := rw.Result()
+			require.Equalf(t, http.StatusBadRequest, result.StatusCode, "body: %s", body)
+			require.NoError(t, result.Body.Close())
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		h := NewSubscriptionCreator(store)
+		h.readAll = func(io.Reader) ([]byte, error) { return nil, errors.New("injected read error") }
+
+		requireStatus(t, h.handle, http.MethodPost, http.StatusInternalServerError)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := NewSubscriptionCreator(store)
+		h.readAll = func(io.Reader) ([]byte, error) { return []byte(`{"callbackUrl":"` + callbackURL + `"}`), nil }
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		requireStatus(t, h.handle, http.MethodPost, http.StatusInternalServerError)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := NewSubscriptionCreator(&mockStore{err: errors.New("injected store error")})
+		h.readAll = func(io.Reader) ([]byte, error) { return []byte(`{"callbackUrl":"` + callbackURL + `"}`), nil }
+
+		requireStatus(t, h.handle, http.MethodPost, http.StatusInternalServerError)
+	})
+}
+
+func TestSubscriptionsRetriever(t *testing.T) {
+	store, err := webhooksubscription.New(mem.NewProvider())
+	require.NoError(t, err)
+
+	t.Run("success - empty", func(t *testing.T) {
+		h := NewSubscriptionsRetriever(store)
+		require.Equal(t, endpoint, h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+		require.NotNil(t, h.Handler())
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+		require.Equal(t, "[]", rw.Body.String())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, store.Put(&webhook.Subscription{ID: "s1", CallbackURL: callbackURL}))
+
+		h := NewSubscriptionsRetriever(store)
+
+		rw := httptest.NewRecorder()
+
+		h.handle(rw, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		result := rw.Result()
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.NoError(t, result.Body.Close())
+
+		var subscriptions []*webhook.Subscription
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &subscriptions))
+		require.Len(t, subscriptions, 1)
+		require.Equal(t, "s1", subscriptions[0].ID)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := NewSubscriptionsRetriever(&mockStore{err: errors.New("injected store error")})
+
+		requireStatus(t, h.handle, http.MethodGet, http.StatusInternalServerError)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		h := NewSubscriptionsRetriever(store)
+		h.marshal = func(interface{}) ([]byte, error) { return nil, errors.New("injected marshal error") }
+
+		requireStatus(t, h.handle, http.MethodGet, http.StatusInternalServerError)
+	})
+}
+
+func TestSubscriptionDeleter(t *testing.T) {
+	store, err := webhooksubscription.New(mem.NewProvider())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(&webhook.Subscription{ID: "s1", CallbackURL: callbackURL}))
+
+	h := NewSubscriptionDeleter(store)
+	require.Equal(t, "/webhook/subscriptions/{id}", h.Path())
+	require.Equal(t, http.MethodDelete, h.Method())
+	require.NotNil(t, h.Handler())
+
+	t.Run("success", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, deleteSubscription(t, h, "s1"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, deleteSubscription(t, h, "s1"))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := NewSubscriptionDeleter(&mockStore{err: errors.New("injected store error")})
+
+		require.Equal(t, http.StatusInternalServerError, deleteSubscription(t, h, "s1"))
+	})
+}
+
+func deleteSubscription(t *testing.T, h *SubscriptionDeleter, id string) int {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, endpoint+"/"+id, nil), map[string]string{
+		idPathVariable: id,
+	})
+
+	h.handle(rw, req)
+
+	result := rw.Result()
+	require.NoError(t, result.Body.Close())
+
+	return result.StatusCode
+}
+
+func requireStatus(t *testing.T, handle http.HandlerFunc, method string, expectedStatus int) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+
+	handle(rw, httptest.NewRequest(method, endpoint, nil))
+
+	result := rw.Result()
+	require.Equal(t, expectedStatus, result.StatusCode)
+	require.NoError(t, result.Body.Close())
+}
+
+type mockStore struct {
+	err error
+}
+
+func (m *mockStore) Put(*webhook.Subscription) error {
+	return m.err
+}
+
+func (m *mockStore) GetAll() ([]*webhook.Subscription, error) {
+	return nil, m.err
+}
+
+func (m *mockStore) Delete(string) error {
+	return m.err
+}
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+// Subscription is a client's registration to be notified at the callback URL when DID operations
+// that match the filter are anchored and processed by the observer.
+type Subscription struct {
+	ID          string    `json:"id"`
+	CallbackURL string    `json:"callbackUrl"`
+	Filter      *Filter   `json:"filter,omitempty"`
+	Created     time.Time `json:"created"`
+}
+
+// Filter restricts the operations for which notifications are sent. An empty field matches any value.
+type Filter struct {
+	// Suffixes contains the unique suffixes of the DIDs.
+	Suffixes []string `json:"suffixes,omitempty"`
+	// OperationTypes contains the operation types (create, update, recover or deactivate).
+	OperationTypes []operation.Type `json:"operationTypes,omitempty"`
+	// AnchorOrigins contains the origins of the anchor events (e.g. the IRI of the Orb service that anchored
+	// the operations).
+	AnchorOrigins []string `json:"anchorOrigins,omitempty"`
+}
+
+// Notification is the JSON payload that is posted to a subscriber's callback URL. The ID is the same for
+// all notifications of an anchor to a subscription so that a subscriber may discard duplicate notifications.
+type Notification struct {
+	ID             string       `json:"id"`
+	SubscriptionID string       `json:"subscriptionId"`
+	Anchor         string       `json:"anchor"`
+	AnchorOrigin   string       `json:"anchorOrigin,omitempty"`
+	Operations     []*Operation `json:"operations"`
+	Created        time.Time    `json:"created"`
+}
+
+// Operation contains the details of an anchored DID operation.
+type Operation struct {
+	Type               operation.Type `json:"type"`
+	Suffix             string         `json:"didSuffix"`
+	CanonicalReference string         `json:"canonicalReference,omitempty"`
+	TransactionTime    uint64         `json:"transactionTime"`
+	ProtocolVersion    uint64         `json:"protocolVersion"`
+}
+
+// Match returns the operations that match the subscription's filter. Nil is returned if the
+// anchor origin does not match.
+func (s *Subscription) Match(anchorOrigin string, ops []*operation.AnchoredOperation) []*Operation {
+	if s.Filter != nil && !containsOrEmpty(s.Filter.AnchorOrigins, anchorOrigin) {
+		return nil
+	}
+
+	var matched []*Operation
+
+	for _, op := range ops {
+		if s.Filter != nil && (!containsOrEmpty(s.Filter.Suffixes, op.UniqueSuffix) ||
+			!containsTypeOrEmpty(s.Filter.OperationTypes, op.Type)) {
+			continue
+		}
+
+		matched = append(matched, &Operation{
+			Type:               op.Type,
+			Suffix:             op.UniqueSuffix,
+			CanonicalReference: op.CanonicalReference,
+			TransactionTime:    op.TransactionTime,
+			ProtocolVersion:    op.ProtocolVersion,
+		})
+	}
+
+	return matched
+}
+
+func containsOrEmpty(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsTypeOrEmpty(values []operation.Type, value operation.Type) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
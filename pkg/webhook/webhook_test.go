@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+const (
+	suffix1 = "EiA329wd6Aj36YRmp7NGkeB5ADnVt8ARdMZMPzfXsjwTJA"
+	suffix2 = "EiDJpL-xeSE4kVwoGJBYFS9xr3SdJb0sMp4W9BeUDcnW0A"
+
+	origin1 = "https://orb.domain1.com/services/orb"
+	origin2 = "https://orb.domain2.com/services/orb"
+)
+
+func TestSubscription_Match(t *testing.T) {
+	ops := []*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: suffix1, TransactionTime: 100, CanonicalReference: "ref"},
+		{Type: operation.TypeUpdate, UniqueSuffix: suffix2, TransactionTime: 100, CanonicalReference: "ref"},
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		s := &Subscription{}
+
+		matched := s.Match(origin1, ops)
+		require.Len(t, matched, 2)
+		require.Equal(t, operation.TypeCreate, matched[0].Type)
+		require.Equal(t, suffix1, matched[0].Suffix)
+		require.Equal(t, uint64(100), matched[0].TransactionTime)
+		require.Equal(t, "ref", matched[0].CanonicalReference)
+	})
+
+	t.Run("suffix filter", func(t *testing.T) {
+		s := &Subscription{Filter: &Filter{Suffixes: []string{suffix2}}}
+
+		matched := s.Match(origin1, ops)
+		require.Len(t, matched, 1)
+		require.Equal(t, suffix2, matched[0].Suffix)
+	})
+
+	t.Run("operation type filter", func(t *testing.T) {
+		s := &Subscription{Filter: &Filter{OperationTypes: []operation.Type{operation.TypeCreate}}}
+
+		matched := s.Match(origin1, ops)
+		require.Len(t, matched, 1)
+		require.Equal(t, suffix1, matched[0].Suffix)
+
+		s = &Subscription{Filter: &Filter{
+			Suffixes:       []string{suffix2},
+			OperationTypes: []operation.Type{operation.TypeCreate},
+		}}
+
+		require.Empty(t, s.Match(origin1, ops))
+	})
+
+	t.Run("anchor origin filter", func(t *testing.T) {
+		s := &Subscription{Filter: &Filter{AnchorOrigins: []string{origin1}}}
+
+		require.Len(t, s.Match(origin1, ops), 2)
+		require.Empty(t, s.Match(origin2, ops))
+	})
+}